@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/chess10kp/locus/internal/config"
+	"github.com/chess10kp/locus/internal/statusbar"
+	_ "github.com/chess10kp/locus/internal/statusbar/modules"
 )
 
 func main() {
@@ -15,10 +18,59 @@ func main() {
 
 	fmt.Printf("Validating config: %s\n", configPath)
 
-	if err := config.ValidateConfig(configPath); err != nil {
-		fmt.Printf("❌ Config validation failed: %v\n", err)
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		fmt.Printf("❌ Config validation failed: failed to load config: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("✅ Config is valid!")
+	problems := append(problemsOf(cfg.Validate()), moduleProblems(cfg)...)
+	switch len(problems) {
+	case 0:
+		fmt.Println("✅ Config is valid!")
+		return
+	case 1:
+		fmt.Printf("❌ Config validation failed: %v\n", problems[0])
+	default:
+		fmt.Printf("❌ Config validation failed with %d problems:\n", len(problems))
+		for _, problem := range problems {
+			fmt.Printf("  - %v\n", problem)
+		}
+	}
+	os.Exit(1)
+}
+
+// problemsOf splits a validation error into its problems
+func problemsOf(err error) []error {
+	if err == nil {
+		return nil
+	}
+	var verr *config.ValidationError
+	if errors.As(err, &verr) {
+		return verr.Problems
+	}
+	return []error{err}
+}
+
+// moduleProblems checks the properties of every status bar module in the
+// layout with its factory, as the bar does when it loads them
+func moduleProblems(cfg *config.Config) []error {
+	layout := cfg.StatusBar.Layout
+	names := append(append(append([]string{}, layout.Left...), layout.Middle...), layout.Right...)
+
+	var problems []error
+	checked := make(map[string]bool, len(names))
+	for _, name := range names {
+		if checked[name] {
+			continue
+		}
+		checked[name] = true
+
+		moduleConfig := cfg.StatusBar.ModuleConfigs[name]
+		err := statusbar.DefaultRegistry().ValidateModuleConfig(name, moduleConfig.ToMap())
+		for _, problem := range problemsOf(err) {
+			problems = append(problems, fmt.Errorf("status bar module '%s': %w", name, problem))
+		}
+	}
+	return problems
 }
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Exit codes of dmenu mode: a cancelled pick exits 1 as dmenu does
+const (
+	exitSelected  = 0
+	exitCancelled = 1
+	exitFailed    = 2
+)
+
+// dmenuOptions are the dmenu flags locus understands
+type dmenuOptions struct {
+	prompt          string
+	lines           int
+	caseInsensitive bool
+}
+
+// dmenuIgnoredFlags are dmenu's appearance flags that take a value; they
+// are accepted so scripts written for dmenu keep working, and ignored
+var dmenuIgnoredFlags = map[string]bool{
+	"-fn": true, "-m": true, "-nb": true, "-nf": true, "-sb": true, "-sf": true, "-w": true,
+}
+
+// parseDmenuArgs parses dmenu-style flags: -p <prompt>, -l <lines> and -i.
+// -dmenu is accepted for rofi compatibility.
+func parseDmenuArgs(args []string) (dmenuOptions, error) {
+	var opts dmenuOptions
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-i":
+			opts.caseInsensitive = true
+		case arg == "-dmenu", arg == "-b", arg == "-f", arg == "-F":
+		case arg == "-p", arg == "-l", dmenuIgnoredFlags[arg]:
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("%s needs a value", arg)
+			}
+			i++
+			switch arg {
+			case "-p":
+				opts.prompt = args[i]
+			case "-l":
+				lines, err := strconv.Atoi(args[i])
+				if err != nil || lines < 0 {
+					return opts, fmt.Errorf("invalid -l value: %s", args[i])
+				}
+				opts.lines = lines
+			}
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+// dmenuRequest is the JSON launcher request the daemon serves
+type dmenuRequest struct {
+	Command string      `json:"command"`
+	Params  dmenuParams `json:"params"`
+}
+
+type dmenuParams struct {
+	Prompt        string   `json:"prompt,omitempty"`
+	CaseSensitive bool     `json:"case_sensitive"`
+	Lines         int      `json:"lines,omitempty"`
+	Dmenu         bool     `json:"dmenu"`
+	Options       []string `json:"options"`
+}
+
+// dmenuReply is the daemon's answer once a line is picked or the launcher
+// is dismissed
+type dmenuReply struct {
+	Selection string `json:"selection"`
+	Cancelled bool   `json:"cancelled,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// readOptions reads the lines to pick from, skipping empty ones
+func readOptions(r io.Reader) ([]string, error) {
+	var options []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			options = append(options, line)
+		}
+	}
+	return options, scanner.Err()
+}
+
+// runDmenu reads options from stdin, lets the user pick through the
+// launcher and prints the picked lines to stdout. It returns the exit code.
+func runDmenu(args []string, stdin io.Reader, stdout, stderr io.Writer, dial func() (net.Conn, error)) int {
+	opts, err := parseDmenuArgs(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		fmt.Fprintf(stderr, "Usage: locus-client dmenu [-p prompt] [-l lines] [-i] < options\n")
+		return exitFailed
+	}
+
+	options, err := readOptions(stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to read options: %v\n", err)
+		return exitFailed
+	}
+
+	// dmenu matches case-sensitively unless given -i
+	request, err := json.Marshal(dmenuRequest{
+		Command: "launcher",
+		Params: dmenuParams{
+			Prompt:        opts.prompt,
+			CaseSensitive: !opts.caseInsensitive,
+			Lines:         opts.lines,
+			Dmenu:         true,
+			Options:       options,
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitFailed
+	}
+
+	conn, err := dial()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to connect to locus socket: %v\n", err)
+		return exitFailed
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(append(request, '\n')); err != nil {
+		fmt.Fprintf(stderr, "Error: failed to send message: %v\n", err)
+		return exitFailed
+	}
+
+	var reply dmenuReply
+	if err := json.NewDecoder(conn).Decode(&reply); err != nil {
+		fmt.Fprintf(stderr, "Error: no reply from locus: %v\n", err)
+		return exitFailed
+	}
+	if reply.Error != "" {
+		fmt.Fprintf(stderr, "Error: %s\n", reply.Error)
+		return exitFailed
+	}
+	if reply.Cancelled {
+		return exitCancelled
+	}
+
+	fmt.Fprintln(stdout, strings.TrimSuffix(reply.Selection, "\n"))
+	return exitSelected
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDmenuArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    dmenuOptions
+		wantErr bool
+	}{
+		{nil, dmenuOptions{}, false},
+		{[]string{"-p", "Run:", "-l", "10", "-i"}, dmenuOptions{prompt: "Run:", lines: 10, caseInsensitive: true}, false},
+		{[]string{"-dmenu", "-i", "-p", "Pick"}, dmenuOptions{prompt: "Pick", caseInsensitive: true}, false},
+		{[]string{"-b", "-fn", "monospace:size=10", "-nb", "#000000", "-p", "x"}, dmenuOptions{prompt: "x"}, false},
+		{[]string{"-p"}, dmenuOptions{}, true},
+		{[]string{"-l", "many"}, dmenuOptions{}, true},
+		{[]string{"-l", "-3"}, dmenuOptions{}, true},
+		{[]string{"--bogus"}, dmenuOptions{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDmenuArgs(tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDmenuArgs(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseDmenuArgs(%q) = %+v, want %+v", tt.args, got, tt.want)
+		}
+	}
+}
+
+// fakeDaemon answers one launcher request with reply, passing the request
+// it received on the returned channel
+func fakeDaemon(t *testing.T, reply dmenuReply) (func() (net.Conn, error), <-chan dmenuRequest) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "locus.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	requests := make(chan dmenuRequest, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var req dmenuRequest
+		if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+			close(requests)
+			return
+		}
+		requests <- req
+		data, _ := json.Marshal(reply)
+		conn.Write(append(data, '\n'))
+	}()
+
+	return func() (net.Conn, error) { return net.Dial("unix", path) }, requests
+}
+
+func TestRunDmenuPrintsSelection(t *testing.T) {
+	dial, requests := fakeDaemon(t, dmenuReply{Selection: "notes.txt"})
+
+	var stdout, stderr bytes.Buffer
+	stdin := strings.NewReader("todo.txt\n\nnotes.txt\n")
+	code := runDmenu([]string{"-p", "Open:", "-l", "5"}, stdin, &stdout, &stderr, dial)
+	if code != exitSelected {
+		t.Fatalf("expected exit %d, got %d (stderr %q)", exitSelected, code, stderr.String())
+	}
+	if stdout.String() != "notes.txt\n" {
+		t.Errorf("expected the selection on stdout, got %q", stdout.String())
+	}
+
+	req := <-requests
+	if req.Command != "launcher" || req.Params.Prompt != "Open:" || req.Params.Lines != 5 || !req.Params.Dmenu {
+		t.Errorf("unexpected request %+v", req)
+	}
+	if !req.Params.CaseSensitive {
+		t.Error("expected case-sensitive matching without -i, as dmenu does")
+	}
+	if !reflect.DeepEqual(req.Params.Options, []string{"todo.txt", "notes.txt"}) {
+		t.Errorf("expected stdin lines as options, got %q", req.Params.Options)
+	}
+}
+
+func TestRunDmenuMultipleLines(t *testing.T) {
+	dial, requests := fakeDaemon(t, dmenuReply{Selection: "a\nb"})
+
+	var stdout, stderr bytes.Buffer
+	if code := runDmenu([]string{"-i"}, strings.NewReader("a\nb\nc\n"), &stdout, &stderr, dial); code != exitSelected {
+		t.Fatalf("expected exit %d, got %d", exitSelected, code)
+	}
+	if stdout.String() != "a\nb\n" {
+		t.Errorf("expected one picked line per output line, got %q", stdout.String())
+	}
+	if req := <-requests; req.Params.CaseSensitive {
+		t.Error("expected -i to request case-insensitive matching")
+	}
+}
+
+func TestRunDmenuExitCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply dmenuReply
+		want  int
+	}{
+		{"cancelled", dmenuReply{Cancelled: true}, exitCancelled},
+		{"daemon error", dmenuReply{Cancelled: true, Error: "launcher unavailable"}, exitFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dial, _ := fakeDaemon(t, tt.reply)
+			var stdout, stderr bytes.Buffer
+			if code := runDmenu(nil, strings.NewReader("a\n"), &stdout, &stderr, dial); code != tt.want {
+				t.Errorf("expected exit %d, got %d", tt.want, code)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("expected nothing on stdout, got %q", stdout.String())
+			}
+		})
+	}
+
+	noDaemon := func() (net.Conn, error) {
+		return net.Dial("unix", filepath.Join(t.TempDir(), "missing.sock"))
+	}
+	var stdout, stderr bytes.Buffer
+	if code := runDmenu(nil, strings.NewReader("a\n"), &stdout, &stderr, noDaemon); code != exitFailed {
+		t.Errorf("expected exit %d without a daemon, got %d", exitFailed, code)
+	}
+	if stderr.Len() == 0 {
+		t.Error("expected an error message without a daemon")
+	}
+
+	if code := runDmenu([]string{"-l"}, strings.NewReader(""), &stdout, &stderr, noDaemon); code != exitFailed {
+		t.Errorf("expected exit %d for bad flags, got %d", exitFailed, code)
+	}
+}
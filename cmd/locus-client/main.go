@@ -1,13 +1,18 @@
 package main
 
 import (
-	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/chess10kp/locus/internal/command"
+	"github.com/chess10kp/locus/internal/version"
 )
 
 const defaultSocketPath = "/tmp/locus_socket"
@@ -23,21 +28,27 @@ func runCommand(cmd string) string {
 		return ""
 	}
 
-	command := exec.Command(parts[0], parts[1:]...)
-	output, err := command.Output()
+	runner := command.Runner{Timeout: command.DefaultTimeout}
+	output, err := runner.Output(context.Background(), parts[0], parts[1:]...)
 	if err != nil {
 		return ""
 	}
 	return strings.TrimSpace(string(output))
 }
 
-func sendMessage(message string) error {
-	socketPath := os.Getenv("LOCUS_SOCKET")
-	if socketPath == "" {
-		socketPath = defaultSocketPath
+func socketPath() string {
+	if path := os.Getenv("LOCUS_SOCKET"); path != "" {
+		return path
 	}
+	return defaultSocketPath
+}
 
-	conn, err := net.Dial("unix", socketPath)
+func dialSocket() (net.Conn, error) {
+	return net.Dial("unix", socketPath())
+}
+
+func sendMessage(message string) error {
+	conn, err := dialSocket()
 	if err != nil {
 		return fmt.Errorf("failed to connect to locus socket: %w", err)
 	}
@@ -51,6 +62,23 @@ func sendMessage(message string) error {
 	return nil
 }
 
+// queryStatus asks the daemon for its status and copies the JSON reply to w
+func queryStatus(w io.Writer) error {
+	conn, err := dialSocket()
+	if err != nil {
+		return fmt.Errorf("failed to connect to locus socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("status")); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if _, err := io.Copy(w, conn); err != nil {
+		return fmt.Errorf("failed to read status: %w", err)
+	}
+	return nil
+}
+
 func handleVolume(action string) {
 	var getVolumeCmd string
 
@@ -135,14 +163,31 @@ func handleBrightness(action string) {
 }
 
 func main() {
+	// Installed as "dmenu", the client is a drop-in replacement
+	if filepath.Base(os.Args[0]) == "dmenu" {
+		os.Exit(runDmenu(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, dialSocket))
+	}
+
 	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: locus-client volume up|down|mute | brightness up|down | launcher [resume|fresh] [app] | <message>\n")
+		fmt.Fprintf(os.Stderr, "Usage: locus-client volume up|down|mute | brightness up|down | launcher [resume|fresh] [app] | dmenu [-p prompt] [-l lines] [-i] | status | version | <message>\n")
 		os.Exit(1)
 	}
 
 	args := os.Args[1:]
 
 	switch args[0] {
+	case "version", "--version":
+		fmt.Println(version.Get())
+
+	case "status":
+		if err := queryStatus(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "dmenu", "-dmenu":
+		os.Exit(runDmenu(args[1:], os.Stdin, os.Stdout, os.Stderr, dialSocket))
+
 	case "volume":
 		if len(args) < 2 {
 			fmt.Fprintf(os.Stderr, "Usage: locus-client volume up|down|mute\n")
@@ -173,14 +218,7 @@ func main() {
 					sendMessage(fmt.Sprintf("launcher:%s", subcmd))
 				}
 			} else if subcmd == "dmenu" {
-				// Read options from stdin
-				scanner := bufio.NewScanner(os.Stdin)
-				var options strings.Builder
-				for scanner.Scan() {
-					options.WriteString(scanner.Text())
-					options.WriteString("\n")
-				}
-				sendMessage(fmt.Sprintf("launcher dmenu:%s", options.String()))
+				os.Exit(runDmenu(args[2:], os.Stdin, os.Stdout, os.Stderr, dialSocket))
 			} else {
 				// Regular launcher with app name
 				appName := strings.Join(args[1:], " ")
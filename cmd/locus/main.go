@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/chess10kp/locus/internal/config"
 	"github.com/chess10kp/locus/internal/core"
+	"github.com/chess10kp/locus/internal/version"
 )
 
 const pidFile = "/tmp/locus.pid"
@@ -36,6 +38,12 @@ func cleanup() {
 }
 
 func main() {
+	// Answer before taking over from a running instance
+	if len(os.Args) > 1 && (os.Args[1] == "version" || os.Args[1] == "--version") {
+		fmt.Println(version.Get())
+		return
+	}
+
 	// Set up logging to file
 	logFile, err := os.OpenFile("locus.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err == nil {
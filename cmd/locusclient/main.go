@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 
 	"github.com/chess10kp/locus/internal/config"
+	"github.com/chess10kp/locus/internal/version"
 )
 
 var (
@@ -42,6 +43,8 @@ func main() {
 			os.Exit(1)
 		}
 		sendMessage("statusbar:" + os.Args[2])
+	case "version", "--version":
+		fmt.Println(version.Get())
 	case "help", "-h", "--help":
 		printUsage()
 	default:
@@ -74,6 +77,7 @@ func printUsage() {
 	fmt.Println("  launcher    Show the application launcher")
 	fmt.Println("  hide        Hide the application launcher")
 	fmt.Println("  statusbar <msg>  Send message to status bar")
+	fmt.Println("  version     Show the version locusclient was built from")
 	fmt.Println("  help        Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
@@ -3,6 +3,7 @@ module github.com/chess10kp/locus
 go 1.21
 
 require (
+	github.com/godbus/dbus/v5 v5.2.1
 	github.com/gotk3/gotk3 v0.6.5-0.20251124190141-e7a9e823ca35
 	github.com/hashicorp/golang-lru/v2 v2.0.7
 	github.com/joshuarubin/go-sway v1.2.0
@@ -11,7 +12,6 @@ require (
 )
 
 require (
-	github.com/godbus/dbus/v5 v5.2.1 // indirect
 	github.com/joshuarubin/lifecycle v1.0.0 // indirect
 	github.com/kylelemons/godebug v1.1.0 // indirect
 	go.uber.org/atomic v1.3.2 // indirect
@@ -2,6 +2,8 @@ package apps
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -16,6 +18,7 @@ import (
 	"time"
 
 	"github.com/chess10kp/locus/internal/config"
+	"github.com/chess10kp/locus/internal/version"
 )
 
 // expandPath expands ~ to home directory
@@ -38,7 +41,11 @@ type App struct {
 	Category    string `json:"category"`
 	Keywords    string `json:"keywords"`
 	Description string `json:"description"`
-	NoDisplay   bool   `json:"no_display"`
+	// NoDisplay marks NoDisplay or Hidden entries, which stay indexed but
+	// are only listed with launcher.search.show_hidden_apps
+	NoDisplay bool `json:"no_display"`
+	// StartupWMClass is the window class the app's windows are expected to use
+	StartupWMClass string `json:"startup_wm_class,omitempty"`
 }
 
 // AppLoader loads and caches desktop applications
@@ -49,17 +56,41 @@ type AppLoader struct {
 	cacheValid bool
 	mu         sync.RWMutex
 	cfg        *config.Config
+	onParsed   func(App) // called for each app parsed during a system scan
+}
+
+// resolveCacheDir picks the apps cache directory: launcher.cache.cache_dir,
+// then the top-level cache_dir, then $XDG_CACHE_HOME/locus, then
+// ~/.cache/locus. A directory the user set is always used as given.
+func resolveCacheDir(cfg *config.Config) string {
+	for _, dir := range []string{cfg.Launcher.Cache.CacheDir, cfg.CacheDir} {
+		if dir != "" {
+			return expandPath(dir)
+		}
+	}
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "locus")
+	}
+	return expandPath("~/.cache/locus")
+}
+
+// appsCacheFormat is bumped whenever the cache layout changes
+const appsCacheFormat = 4
+
+// appsCacheVersion identifies caches written by this build; caches from
+// other formats or locus versions are rebuilt
+func appsCacheVersion() string {
+	return fmt.Sprintf("%d/%s", appsCacheFormat, version.Version)
 }
 
 // NewAppLoader creates a new app loader
 func NewAppLoader(cfg *config.Config) *AppLoader {
-	cacheDir := expandPath(cfg.Launcher.Cache.CacheDir)
-	if cacheDir == "" || cacheDir == "~/.cache/locus" {
-		home, _ := user.Current()
-		cacheDir = filepath.Join(home.HomeDir, ".cache", "locus")
+	cacheDir := resolveCacheDir(cfg)
+	cacheFileName := cfg.Launcher.Cache.AppsCacheFile
+	if cacheFileName == "" {
+		cacheFileName = "apps.json"
 	}
-
-	cacheFile := filepath.Join(cacheDir, cfg.Launcher.Cache.AppsCacheFile)
+	cacheFile := filepath.Join(cacheDir, cacheFileName)
 
 	return &AppLoader{
 		apps:       []App{},
@@ -70,6 +101,14 @@ func NewAppLoader(cfg *config.Config) *AppLoader {
 	}
 }
 
+// SetOnAppParsed registers a callback invoked, from the loading goroutine,
+// for each app as it is parsed during a system scan
+func (l *AppLoader) SetOnAppParsed(fn func(App)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onParsed = fn
+}
+
 // LoadApps loads applications from cache or system
 func (l *AppLoader) LoadApps(forceReload bool) ([]App, error) {
 	loadStart := time.Now()
@@ -103,6 +142,41 @@ func (l *AppLoader) LoadApps(forceReload bool) ([]App, error) {
 	return l.apps, nil
 }
 
+// appsCache is the on-disk apps cache. Count and Checksum guard against a
+// damaged file that still parses.
+type appsCache struct {
+	Apps      []App  `json:"apps"`
+	Count     int    `json:"count"`
+	Checksum  string `json:"checksum"`
+	Timestamp string `json:"timestamp"`
+	Version   string `json:"version"`
+}
+
+// appsChecksum is the hex SHA-256 of apps' compact JSON encoding
+func appsChecksum(apps []App) (string, error) {
+	data, err := json.Marshal(apps)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// verify checks the cache's app count and checksum
+func (c *appsCache) verify() error {
+	if len(c.Apps) != c.Count {
+		return fmt.Errorf("app count %d does not match %d", len(c.Apps), c.Count)
+	}
+	checksum, err := appsChecksum(c.Apps)
+	if err != nil {
+		return err
+	}
+	if checksum != c.Checksum {
+		return fmt.Errorf("checksum mismatch")
+	}
+	return nil
+}
+
 // loadFromCache loads apps from cache file
 func (l *AppLoader) loadFromCache() bool {
 	loadStart := time.Now()
@@ -112,17 +186,22 @@ func (l *AppLoader) loadFromCache() bool {
 		return false
 	}
 
-	var cache struct {
-		Apps      []App  `json:"apps"`
-		Timestamp string `json:"timestamp"`
-		Version   string `json:"version"`
-	}
-
+	var cache appsCache
 	if err := json.Unmarshal(data, &cache); err != nil {
 		fmt.Printf("[APPS-CACHE] Cache miss: failed to unmarshal cache file: %v\n", err)
 		return false
 	}
 
+	if cache.Version != appsCacheVersion() {
+		fmt.Printf("[APPS-CACHE] Cache miss: version %q does not match %q\n", cache.Version, appsCacheVersion())
+		return false
+	}
+
+	if err := cache.verify(); err != nil {
+		fmt.Printf("[APPS-CACHE] Cache miss: %v\n", err)
+		return false
+	}
+
 	// Check cache age
 	cacheTime, _ := time.Parse(time.RFC3339, cache.Timestamp)
 	age := time.Since(cacheTime)
@@ -150,14 +229,16 @@ func (l *AppLoader) saveToCache() error {
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
-	cache := struct {
-		Apps      []App  `json:"apps"`
-		Timestamp string `json:"timestamp"`
-		Version   string `json:"version"`
-	}{
+	checksum, err := appsChecksum(l.apps)
+	if err != nil {
+		return fmt.Errorf("failed to checksum cache data: %w", err)
+	}
+	cache := appsCache{
 		Apps:      l.apps,
+		Count:     len(l.apps),
+		Checksum:  checksum,
 		Timestamp: time.Now().Format(time.RFC3339),
-		Version:   "1.0",
+		Version:   appsCacheVersion(),
 	}
 
 	data, err := json.MarshalIndent(cache, "", "  ")
@@ -241,7 +322,7 @@ func (l *AppLoader) loadFromSystem() error {
 			defer func() { <-semaphore }()
 
 			app, err := l.parseDesktopFile(fp)
-			if err == nil && !app.NoDisplay {
+			if err == nil {
 				appChan <- app
 			}
 		}(filePath)
@@ -256,6 +337,9 @@ func (l *AppLoader) loadFromSystem() error {
 	// Collect results
 	for app := range appChan {
 		apps = append(apps, app)
+		if l.onParsed != nil {
+			l.onParsed(app)
+		}
 	}
 
 	// Sort apps by name
@@ -283,6 +367,7 @@ func (l *AppLoader) parseDesktopFile(path string) (App, error) {
 	app := App{
 		File: path,
 	}
+	appType := ""
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
@@ -314,17 +399,19 @@ func (l *AppLoader) parseDesktopFile(path string) (App, error) {
 			case "Icon":
 				app.Icon = value
 			case "Type":
-				if value != "Application" {
+				appType = value
+			case "NoDisplay":
+				if strings.ToLower(value) == "true" {
 					app.NoDisplay = true
 				}
-			case "NoDisplay":
-				app.NoDisplay = strings.ToLower(value) == "true"
 			case "Hidden":
 				if strings.ToLower(value) == "true" {
 					app.NoDisplay = true
 				}
 			case "Keywords":
 				app.Keywords = value
+			case "StartupWMClass":
+				app.StartupWMClass = value
 			case "Comment":
 				if app.Description == "" {
 					app.Description = value
@@ -333,13 +420,17 @@ func (l *AppLoader) parseDesktopFile(path string) (App, error) {
 		}
 	}
 
+	if appType != "" && appType != "Application" {
+		return App{}, fmt.Errorf("not an application: Type=%s", appType)
+	}
+
 	// Validate app has required fields
 	if app.Name == "" || app.Exec == "" {
 		return App{}, fmt.Errorf("invalid desktop file: missing Name or Exec")
 	}
 
 	// Strip field codes and check if executable exists
-	cleanExec := stripFieldCodes(app.Exec)
+	cleanExec := StripFieldCodes(app.Exec)
 	parts := strings.Fields(cleanExec)
 	if len(parts) > 0 {
 		execPath := parts[0]
@@ -371,18 +462,19 @@ func (l *AppLoader) Search(query string, maxResults int) []App {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 
+	list := VisibleApps(l.apps, l.cfg.Launcher.Search.ShowHiddenApps)
 	if query == "" {
 		// Return first maxResults apps
-		if len(l.apps) > maxResults {
-			return l.apps[:maxResults]
+		if len(list) > maxResults {
+			return list[:maxResults]
 		}
-		return l.apps
+		return list
 	}
 
 	query = strings.ToLower(query)
 
 	var results []App
-	for _, app := range l.apps {
+	for _, app := range list {
 		// Simple substring match for now
 		// TODO: Implement fuzzy search
 		name := strings.ToLower(app.Name)
@@ -400,7 +492,22 @@ func (l *AppLoader) Search(query string, maxResults int) []App {
 	return results
 }
 
-// GetApps returns all loaded applications
+// VisibleApps returns the apps to list: all of them when showHidden is
+// set, otherwise those without NoDisplay
+func VisibleApps(list []App, showHidden bool) []App {
+	if showHidden {
+		return list
+	}
+	visible := make([]App, 0, len(list))
+	for _, app := range list {
+		if !app.NoDisplay {
+			visible = append(visible, app)
+		}
+	}
+	return visible
+}
+
+// GetApps returns all loaded applications, hidden ones included
 func (l *AppLoader) GetApps() []App {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
@@ -418,8 +525,10 @@ func (l *AppLoader) InvalidateCache() {
 	l.cacheValid = false
 }
 
-// stripFieldCodes removes desktop entry field codes like %f, %u, etc.
-func stripFieldCodes(cmd string) string {
-	re := regexp.MustCompile(`%[uUfFdDnNickvm]`)
-	return strings.TrimSpace(re.ReplaceAllString(cmd, ""))
+// fieldCodePattern matches desktop entry field codes like %f, %u, etc.
+var fieldCodePattern = regexp.MustCompile(`%[uUfFdDnNickvm]`)
+
+// StripFieldCodes removes desktop entry field codes like %f, %u, etc.
+func StripFieldCodes(cmd string) string {
+	return strings.TrimSpace(fieldCodePattern.ReplaceAllString(cmd, ""))
 }
@@ -0,0 +1,176 @@
+package apps
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+	"github.com/chess10kp/locus/internal/version"
+)
+
+func TestResolveCacheDir(t *testing.T) {
+	home := expandPath("~")
+
+	tests := []struct {
+		name     string
+		launcher string
+		global   string
+		xdg      string
+		want     string
+	}{
+		{"launcher cache dir", "/custom/apps", "/global", "/xdg/cache", "/custom/apps"},
+		{"default string is honored", "~/.cache/locus", "/global", "/xdg/cache", filepath.Join(home, ".cache", "locus")},
+		{"top-level cache dir", "", "/global", "/xdg/cache", "/global"},
+		{"xdg cache home", "", "", "/xdg/cache", "/xdg/cache/locus"},
+		{"home fallback", "", "", "", filepath.Join(home, ".cache", "locus")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("XDG_CACHE_HOME", tc.xdg)
+			cfg := &config.Config{CacheDir: tc.global}
+			cfg.Launcher.Cache.CacheDir = tc.launcher
+			if got := resolveCacheDir(cfg); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func newTestLoader(t *testing.T) *AppLoader {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Launcher.Cache.CacheDir = t.TempDir()
+	cfg.Launcher.Performance.CacheMaxAgeHours = 24
+	return NewAppLoader(cfg)
+}
+
+func TestAppsCacheVersionInvalidation(t *testing.T) {
+	defer func(v string) { version.Version = v }(version.Version)
+	version.Version = "1.0.0"
+
+	l := newTestLoader(t)
+	l.apps = []App{{Name: "Firefox", Exec: "firefox"}}
+	if err := l.saveToCache(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := NewAppLoader(l.cfg)
+	if !reloaded.loadFromCache() {
+		t.Fatal("Expected a cache from the same version to load")
+	}
+	if len(reloaded.apps) != 1 || reloaded.apps[0].Name != "Firefox" {
+		t.Errorf("Expected the cached app, got %v", reloaded.apps)
+	}
+
+	version.Version = "1.1.0"
+	upgraded := NewAppLoader(l.cfg)
+	if upgraded.loadFromCache() {
+		t.Error("Expected a cache from another version to be rejected")
+	}
+}
+
+func TestAppsCacheRejectsCorruption(t *testing.T) {
+	l := newTestLoader(t)
+	l.apps = []App{{Name: "Firefox", Exec: "firefox"}, {Name: "Files", Exec: "nautilus"}}
+	if err := l.saveToCache(); err != nil {
+		t.Fatal(err)
+	}
+	clean, err := os.ReadFile(l.cacheFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"truncated", clean[:len(clean)/2]},
+		{"edited app", bytes.Replace(clean, []byte(`"nautilus"`), []byte(`"rm -rf"`), 1)},
+		{"count mismatch", bytes.Replace(clean, []byte(`"count": 2`), []byte(`"count": 3`), 1)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if bytes.Equal(tc.data, clean) {
+				t.Fatal("test data was not modified")
+			}
+			if err := os.WriteFile(l.cacheFile, tc.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if NewAppLoader(l.cfg).loadFromCache() {
+				t.Error("Expected a damaged cache to be rejected")
+			}
+		})
+	}
+
+	if err := os.WriteFile(l.cacheFile, clean, 0644); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := NewAppLoader(l.cfg)
+	if !reloaded.loadFromCache() || len(reloaded.apps) != 2 {
+		t.Errorf("Expected the clean cache to load, got %v", reloaded.apps)
+	}
+}
+
+func TestParseDesktopFileKeepsHiddenApps(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	l := newTestLoader(t)
+
+	visible, err := l.parseDesktopFile(write("shell.desktop", "[Desktop Entry]\nType=Application\nName=Shell\nExec=sh\n"))
+	if err != nil || visible.NoDisplay {
+		t.Errorf("Expected a visible app, got %+v (%v)", visible, err)
+	}
+
+	for _, key := range []string{"NoDisplay", "Hidden"} {
+		app, err := l.parseDesktopFile(write(key+".desktop", "[Desktop Entry]\nType=Application\nName="+key+"\nExec=sh\n"+key+"=true\n"))
+		if err != nil {
+			t.Fatalf("%s: expected the app to parse, got %v", key, err)
+		}
+		if !app.NoDisplay {
+			t.Errorf("%s: expected the app to be flagged hidden", key)
+		}
+	}
+
+	if _, err := l.parseDesktopFile(write("link.desktop", "[Desktop Entry]\nType=Link\nName=Link\nExec=sh\n")); err == nil {
+		t.Error("Expected a non-application entry to be rejected")
+	}
+}
+
+func TestParseDesktopFileComment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "firefox.desktop")
+	content := "[Desktop Entry]\nType=Application\nName=Firefox\nComment=Browse the World Wide Web\nExec=sh\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app, err := newTestLoader(t).parseDesktopFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Description != "Browse the World Wide Web" {
+		t.Errorf("Expected the Comment as the description, got %q", app.Description)
+	}
+}
+
+func TestVisibleApps(t *testing.T) {
+	list := []App{{Name: "Shell"}, {Name: "Settings Daemon", NoDisplay: true}, {Name: "Files"}}
+
+	visible := VisibleApps(list, false)
+	if len(visible) != 2 || visible[0].Name != "Shell" || visible[1].Name != "Files" {
+		t.Errorf("Expected hidden apps to be excluded by default, got %v", visible)
+	}
+	if all := VisibleApps(list, true); len(all) != 3 {
+		t.Errorf("Expected hidden apps to be included when enabled, got %v", all)
+	}
+}
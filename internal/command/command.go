@@ -0,0 +1,87 @@
+// Package command runs external commands with a deadline, so a hung
+// process can't freeze a launcher search or the status bar
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// DefaultTimeout bounds commands run without a configured timeout
+const DefaultTimeout = 5 * time.Second
+
+// waitDelay is how long a killed command may keep its output open, e.g.
+// through children of sh -c, before Wait gives up on it
+const waitDelay = time.Second
+
+// ErrTimeout is wrapped by the error of a command killed at its deadline
+var ErrTimeout = errors.New("command timed out")
+
+// Runner runs external commands, killing any that outlive Timeout. A zero
+// Timeout lets commands run until done.
+type Runner struct {
+	Timeout time.Duration
+}
+
+// Output runs name with args and returns its standard output. The command
+// is also killed when ctx is done.
+func (r Runner) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return r.run(ctx, nil, true, name, args...)
+}
+
+// RunWithTimeout runs name with args, killing it after timeout or the
+// runner's own timeout, whichever comes first. A zero timeout leaves only
+// the runner's.
+func (r Runner) RunWithTimeout(timeout time.Duration, name string, args ...string) ([]byte, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return r.Output(ctx, name, args...)
+}
+
+// OutputEnv is like Output but runs the command with env as its environment
+func (r Runner) OutputEnv(env []string, name string, args ...string) ([]byte, error) {
+	return r.run(context.Background(), env, true, name, args...)
+}
+
+// Shell runs command with sh -c and returns its standard output
+func (r Runner) Shell(command string) ([]byte, error) {
+	return r.Output(context.Background(), "sh", "-c", command)
+}
+
+// Run runs name with args without capturing its output, so a command that
+// leaves a child running in the background (like wl-copy) returns at once
+func (r Runner) Run(name string, args ...string) error {
+	_, err := r.run(context.Background(), nil, false, name, args...)
+	return err
+}
+
+func (r Runner) run(ctx context.Context, env []string, capture bool, name string, args ...string) ([]byte, error) {
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	cmd.WaitDelay = waitDelay
+
+	var out []byte
+	var err error
+	if capture {
+		out, err = cmd.Output()
+	} else {
+		err = cmd.Run()
+	}
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return out, fmt.Errorf("%s: %w", name, ErrTimeout)
+	}
+	return out, err
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOutput(t *testing.T) {
+	r := Runner{Timeout: 5 * time.Second}
+
+	out, err := r.Output(context.Background(), "echo", "hello")
+	if err != nil || string(out) != "hello\n" {
+		t.Errorf("Output(echo) = %q, %v", out, err)
+	}
+
+	out, err = r.Shell("printf '%s' \"$0\"")
+	if err != nil || string(out) != "sh" {
+		t.Errorf("Shell() = %q, %v", out, err)
+	}
+
+	if err := r.Run("false"); err == nil || errors.Is(err, ErrTimeout) {
+		t.Errorf("Run(false) = %v, want a plain exit error", err)
+	}
+}
+
+func TestOutputKilledAtDeadline(t *testing.T) {
+	r := Runner{Timeout: 50 * time.Millisecond}
+
+	start := time.Now()
+	_, err := r.Output(context.Background(), "sleep", "5")
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("Output(sleep) = %v, want ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the command killed at the deadline, took %v", elapsed)
+	}
+}
+
+func TestShellKilledWithChildren(t *testing.T) {
+	r := Runner{Timeout: 50 * time.Millisecond}
+
+	// The backgrounded sleep keeps stdout open after sh is killed
+	start := time.Now()
+	if _, err := r.Shell("sleep 5 & sleep 5"); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Shell() = %v, want ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Expected Shell to give up on open output, took %v", elapsed)
+	}
+}
+
+func TestOutputContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	if _, err := (Runner{}).Output(ctx, "sleep", "5"); err == nil || errors.Is(err, ErrTimeout) {
+		t.Errorf("Output() = %v, want the cancelled command's error", err)
+	}
+}
+
+func TestRunWithTimeout(t *testing.T) {
+	output, err := Runner{}.RunWithTimeout(time.Second, "echo", "hello")
+	if err != nil || string(output) != "hello\n" {
+		t.Fatalf("RunWithTimeout(echo) = %q, %v", output, err)
+	}
+
+	// The shorter of the two limits applies
+	for _, r := range []struct {
+		runner  Runner
+		timeout time.Duration
+	}{
+		{Runner{}, 50 * time.Millisecond},
+		{Runner{Timeout: 50 * time.Millisecond}, 10 * time.Second},
+		{Runner{Timeout: 50 * time.Millisecond}, 0},
+	} {
+		start := time.Now()
+		if _, err := r.runner.RunWithTimeout(r.timeout, "sleep", "5"); !errors.Is(err, ErrTimeout) {
+			t.Errorf("%+v.RunWithTimeout(%v) = %v, want ErrTimeout", r.runner, r.timeout, err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("Expected the command killed at the shorter limit, took %v", elapsed)
+		}
+	}
+}
+
+func TestRunDoesNotWaitForBackgroundChildren(t *testing.T) {
+	r := Runner{Timeout: 5 * time.Second}
+
+	start := time.Now()
+	if err := r.Run("sh", "-c", "sleep 3 &"); err != nil {
+		t.Errorf("Run() = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Run to return when sh exits, took %v", elapsed)
+	}
+}
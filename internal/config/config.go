@@ -1,12 +1,16 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/chess10kp/locus/internal/command"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -22,6 +26,23 @@ type Config struct {
 	FileSearch   FileSearchConfig   `toml:"file_search"`
 	LockScreen   LockScreenConfig   `toml:"lock_screen"`
 	Color        ColorConfig        `toml:"color"`
+
+	// CommandTimeout bounds each external command locus waits on, such as
+	// status bar module commands and WM queries; 0 uses command.DefaultTimeout
+	// and a negative value disables it
+	CommandTimeout int `toml:"command_timeout"` // milliseconds
+}
+
+// CommandRunner returns a runner that kills commands outliving
+// command_timeout
+func (c *Config) CommandRunner() command.Runner {
+	switch {
+	case c.CommandTimeout < 0:
+		return command.Runner{}
+	case c.CommandTimeout == 0:
+		return command.Runner{Timeout: command.DefaultTimeout}
+	}
+	return command.Runner{Timeout: time.Duration(c.CommandTimeout) * time.Millisecond}
 }
 
 type StatusBarLayout struct {
@@ -32,9 +53,39 @@ type StatusBarLayout struct {
 
 type StatusBarConfig struct {
 	Height        int                     `toml:"height"`
+	Position      string                  `toml:"position"` // "top" or "bottom"
+	MarginTop     int                     `toml:"margin_top"`
+	MarginBottom  int                     `toml:"margin_bottom"`
+	MarginLeft    int                     `toml:"margin_left"`
+	MarginRight   int                     `toml:"margin_right"`
+	Gap           int                     `toml:"gap"` // space kept free between the bar and windows
 	Layout        StatusBarLayout         `toml:"layout"`
+	Separator     string                  `toml:"separator"`  // shown between modules, "" for none
+	Separators    map[string]string       `toml:"separators"` // per-section overrides: left, middle, right
 	ModuleConfigs map[string]ModuleConfig `toml:"module_configs"`
 	Colors        ColorsConfig            `toml:"colors"`
+	// MissingDependencies is what to do with modules whose commands are not
+	// installed: "placeholder" (default), "skip" or "load"
+	MissingDependencies string `toml:"missing_dependencies"`
+	// ReconnectBackoff paces event listeners reconnecting to their socket
+	ReconnectBackoff ReconnectBackoffConfig `toml:"reconnect_backoff"`
+}
+
+// ReconnectBackoffConfig is an exponential reconnect backoff. Fields left
+// at 0 use the status bar's built-in defaults.
+type ReconnectBackoffConfig struct {
+	Base   int     `toml:"base"`   // ms before the first retry
+	Max    int     `toml:"max"`    // ms the delay grows to at most
+	Factor float64 `toml:"factor"` // growth per attempt
+}
+
+// SectionSeparator returns the separator used between modules of a layout
+// section ("left", "middle" or "right")
+func (c *StatusBarConfig) SectionSeparator(section string) string {
+	if sep, ok := c.Separators[section]; ok {
+		return sep
+	}
+	return c.Separator
 }
 
 type ModuleConfig struct {
@@ -90,17 +141,69 @@ type LauncherConfig struct {
 	Styling          StylingConfig     `toml:"styling"`
 	LauncherPrefixes map[string]string `toml:"launcher_prefixes"`
 	Wallpaper        WallpaperConfig   `toml:"wallpaper"`
+	Screenshot       ScreenshotConfig  `toml:"screenshot"`
+	Define           DefineConfig      `toml:"define"`
+	Bookmarks        BookmarksConfig   `toml:"bookmarks"`
+	Snippets         SnippetsConfig    `toml:"snippets"`
+	WebSearch        WebSearchConfig   `toml:"web_search"`
+	Clipboard        ClipboardConfig   `toml:"clipboard"`
+	Preview          PreviewConfig     `toml:"preview"`
+	// RowTemplates lays out list rows per launcher name from item metadata
+	RowTemplates map[string]RowTemplateConfig `toml:"row_templates"`
+	// Enabled turns built-in launchers on or off by name; launchers not
+	// listed are enabled
+	Enabled map[string]bool `toml:"enabled"`
+}
+
+// RowTemplateConfig lays out a launcher's list rows. Fields are text with
+// {key} placeholders for item metadata, plus {title} and {subtitle}; empty
+// title and subtitle keep the item's own, and an empty badge hides it.
+type RowTemplateConfig struct {
+	Title    string `toml:"title"`
+	Subtitle string `toml:"subtitle"`
+	Badge    string `toml:"badge"`
+}
+
+// LauncherEnabled reports whether the named built-in launcher is enabled
+func intPtr(v int) *int {
+	return &v
+}
+
+// ClosesOnActivate reports whether the launcher hides after running a
+// result; it does unless close_on_activate is explicitly false
+func (c BehaviorConfig) ClosesOnActivate() bool {
+	return c.CloseOnActivate == nil || *c.CloseOnActivate
+}
+
+// SaveEvery returns how long history writes are batched for; an unset
+// save_interval uses the default
+func (h NotificationHistoryConfig) SaveEvery() time.Duration {
+	if h.SaveInterval <= 0 {
+		return time.Duration(DefaultConfig.Notification.History.SaveInterval) * time.Millisecond
+	}
+	return time.Duration(h.SaveInterval) * time.Millisecond
+}
+
+func (c *LauncherConfig) LauncherEnabled(name string) bool {
+	enabled, ok := c.Enabled[name]
+	return !ok || enabled
 }
 
 type WindowConfig struct {
-	Width             int  `toml:"width"`
-	Height            int  `toml:"height"`
-	Resizable         bool `toml:"resizable"`
-	Modal             bool `toml:"modal"`
-	Decorated         bool `toml:"decorated"`
-	ShowMenubar       bool `toml:"show_menubar"`
-	DestroyWithParent bool `toml:"destroy_with_parent"`
-	HideOnClose       bool `toml:"hide_on_close"`
+	Width             int    `toml:"width"`
+	Height            int    `toml:"height"`
+	Resizable         bool   `toml:"resizable"`
+	Modal             bool   `toml:"modal"`
+	Decorated         bool   `toml:"decorated"`
+	ShowMenubar       bool   `toml:"show_menubar"`
+	DestroyWithParent bool   `toml:"destroy_with_parent"`
+	HideOnClose       bool   `toml:"hide_on_close"`
+	Anchor            string `toml:"anchor"`       // "top" or "center"
+	OffsetX           int    `toml:"offset_x"`     // >0: px from left edge, <0: px from right edge, 0: centered
+	OffsetY           int    `toml:"offset_y"`     // "top": added to the top margin; "center": >0 from top, <0 from bottom
+	RowHeight         int    `toml:"row_height"`   // minimum px per result row, 0 uses the default
+	RowPadding        int    `toml:"row_padding"`  // px around a row's content, 0 uses the default
+	VisibleRows       int    `toml:"visible_rows"` // rows the result list is sized for, 0 uses the default
 }
 
 type AnimationConfig struct {
@@ -122,12 +225,33 @@ type AnimationConfig struct {
 }
 
 type SearchConfig struct {
-	MaxResults        int  `toml:"max_results"`
-	MaxCommandResults int  `toml:"max_command_results"`
-	DebounceDelay     int  `toml:"debounce_delay"` // milliseconds
-	FuzzySearch       bool `toml:"fuzzy_search"`
-	CaseSensitive     bool `toml:"case_sensitive"`
-	ShowHiddenApps    bool `toml:"show_hidden_apps"`
+	MaxResults        int `toml:"max_results"`
+	MaxCommandResults int `toml:"max_command_results"`
+	DebounceDelay     int `toml:"debounce_delay"` // milliseconds
+	// AdaptiveDelays holds per-query-length debounce delays in milliseconds,
+	// indexed by query length; longer queries use DebounceDelay
+	AdaptiveDelays []int `toml:"adaptive_delays"`
+	Instant        bool  `toml:"instant"` // skip debouncing entirely
+	FuzzySearch    bool  `toml:"fuzzy_search"`
+	CaseSensitive  bool  `toml:"case_sensitive"`
+	ShowHiddenApps bool  `toml:"show_hidden_apps"`
+	// Scope lists launchers searched alongside apps for unprefixed queries;
+	// empty searches apps only
+	Scope []string `toml:"scope"`
+	// ScopeMaxResults caps the items each scope launcher contributes
+	ScopeMaxResults int `toml:"scope_max_results"`
+	// MinQueryLength holds per-launcher minimum query lengths; shorter
+	// non-empty queries skip the launcher's search
+	MinQueryLength map[string]int `toml:"min_query_length"`
+	// NoResultsAction is what the placeholder shown for searches that find
+	// nothing does: "shell" runs the query, "web" searches for it, "none"
+	// (the default) shows no placeholder
+	NoResultsAction string `toml:"no_results_action"`
+	// Sort orders the matched results: "relevance" (match quality),
+	// "alpha", "frecency" or "recent" (last launched first)
+	Sort string `toml:"sort"`
+	// Metrics aggregates search timings, readable over IPC with "metrics"
+	Metrics bool `toml:"metrics"`
 }
 
 type PerformanceConfig struct {
@@ -136,6 +260,11 @@ type PerformanceConfig struct {
 	SearchCacheSize         int  `toml:"search_cache_size"`
 	EnableBackgroundLoading bool `toml:"enable_background_loading"`
 	MaxVisibleResults       int  `toml:"max_visible_results"`
+	// WarmupPrefixes are searched in the background after startup, along
+	// with the empty query, so the first keystrokes hit the search cache
+	WarmupPrefixes []string `toml:"warmup_prefixes"`
+	// HookTimeout bounds each synchronous launcher hook call; 0 disables it
+	HookTimeout int `toml:"hook_timeout"` // milliseconds
 }
 
 type IconsConfig struct {
@@ -145,15 +274,20 @@ type IconsConfig struct {
 	CacheSize         int      `toml:"cache_size"`
 	FallbackIcon      string   `toml:"fallback_icon"`
 	IconsForLaunchers []string `toml:"icons_for_launchers"`
+	Theme             string   `toml:"theme"`          // icon theme name; empty uses the GTK default
+	FallbackChain     []string `toml:"fallback_chain"` // names tried before FallbackIcon
 }
 
 type BehaviorConfig struct {
-	ActivateOnHover         bool `toml:"activate_on_hover"`
-	ClearSearchOnActivate   bool `toml:"clear_search_on_activate"`
-	CloseOnActivate         bool `toml:"close_on_activate"`
-	ShowRecentApps          bool `toml:"show_recent_apps"`
-	MaxRecentApps           int  `toml:"max_recent_apps"`
-	DesktopLauncherFastPath bool `toml:"desktop_launcher_fast_path"`
+	ActivateOnHover         bool  `toml:"activate_on_hover"`
+	HoverDwell              int   `toml:"hover_dwell"` // ms resting on a hovered result before activating it, 0 only selects
+	ClearSearchOnActivate   bool  `toml:"clear_search_on_activate"`
+	CloseOnActivate         *bool `toml:"close_on_activate"` // unset closes, see ClosesOnActivate
+	ShowRecentApps          bool  `toml:"show_recent_apps"`
+	MaxRecentApps           int   `toml:"max_recent_apps"`
+	DesktopLauncherFastPath bool  `toml:"desktop_launcher_fast_path"`
+	QueryHistorySize        int   `toml:"query_history_size"` // past queries kept for recall, 0 disables
+	HideOnFocusLoss         bool  `toml:"hide_on_focus_loss"` // hide when the compositor moves keyboard focus away
 }
 
 type KeysConfig struct {
@@ -163,6 +297,15 @@ type KeysConfig struct {
 	Close       []string `toml:"close"`
 	TabComplete []string `toml:"tab_complete"`
 	QuickSelect []string `toml:"quick_select"`
+	// NumberAction keys run the launcher-specific action for their number
+	NumberAction []string `toml:"number_action"`
+	// HistoryPrevious and HistoryNext step through past queries even when
+	// there are results to navigate
+	HistoryPrevious []string `toml:"history_previous"`
+	HistoryNext     []string `toml:"history_next"`
+	HintCount       int      `toml:"hint_count"` // results showing their quick-select number, -1 hides the hints
+	// TogglePreview shows or hides the preview pane
+	TogglePreview []string `toml:"toggle_preview"`
 }
 
 type DesktopAppsConfig struct {
@@ -195,6 +338,8 @@ type StylingConfig struct {
 	FontFamily        string `toml:"font_family"`
 	FontSize          int    `toml:"font_size"`
 	FontWeight        string `toml:"font_weight"`
+	SubtitleMaxLength int    `toml:"subtitle_max_length"` // runes, 0 for no limit
+	SubtitleLines     int    `toml:"subtitle_lines"`      // lines a subtitle may wrap onto before ellipsizing
 }
 
 type WallpaperConfig struct {
@@ -202,6 +347,69 @@ type WallpaperConfig struct {
 	PreviewOnNav  bool   `toml:"preview_on_navigation"`
 }
 
+type ScreenshotConfig struct {
+	SaveDir string `toml:"save_dir"`
+}
+
+type DefineConfig struct {
+	Backend    string `toml:"backend"` // "auto", "dict", "wn" or "api"
+	APIURL     string `toml:"api_url"` // %s is replaced by the word
+	MaxResults int    `toml:"max_results"`
+}
+
+type BookmarksConfig struct {
+	File    string `toml:"file"`    // TOML, or JSON when it ends in .json
+	Browser string `toml:"browser"` // command used to open URLs; empty uses xdg-open
+}
+
+type SnippetsConfig struct {
+	File   string `toml:"file"`
+	Action string `toml:"action"` // "copy" or "type"
+}
+
+type ClipboardConfig struct {
+	MaxEntries   int `toml:"max_entries"`
+	MaxImageSize int `toml:"max_image_size"` // KB; larger images are left out of history, 0 uses the default
+}
+
+// PreviewConfig controls the pane beside the results that shows details
+// of the selected item
+type PreviewConfig struct {
+	Enabled  bool `toml:"enabled"`
+	Width    int  `toml:"width"`     // 0 uses the default
+	MaxLines int  `toml:"max_lines"` // lines of a text file shown, 0 uses the default
+}
+
+type WebSearchConfig struct {
+	DefaultEngine string `toml:"default_engine"` // keyword used when the query names no engine
+	Browser       string `toml:"browser"`        // command used to open URLs; empty uses xdg-open
+	// Engines maps keywords to URL templates, %s being the query. They add
+	// to (or replace) DefaultWebSearchEngines.
+	Engines map[string]string `toml:"engines"`
+}
+
+// DefaultWebSearchEngines are the search engines available without
+// configuration, by keyword
+func DefaultWebSearchEngines() map[string]string {
+	return map[string]string{
+		"ddg": "https://duckduckgo.com/?q=%s",
+		"g":   "https://www.google.com/search?q=%s",
+		"w":   "https://en.wikipedia.org/w/index.php?search=%s",
+		"gh":  "https://github.com/search?q=%s",
+		"yt":  "https://www.youtube.com/results?search_query=%s",
+	}
+}
+
+// WebSearchEngines returns the default engines with the configured ones
+// added over them
+func (w *WebSearchConfig) WebSearchEngines() map[string]string {
+	engines := DefaultWebSearchEngines()
+	for keyword, template := range w.Engines {
+		engines[keyword] = template
+	}
+	return engines
+}
+
 type NotificationConfig struct {
 	History  NotificationHistoryConfig  `toml:"history"`
 	UI       NotificationUIConfig       `toml:"ui"`
@@ -210,9 +418,11 @@ type NotificationConfig struct {
 }
 
 type NotificationHistoryConfig struct {
-	MaxHistory  int    `toml:"max_history"`
-	MaxAgeDays  int    `toml:"max_age_days"`
-	PersistPath string `toml:"persist_path"`
+	MaxHistory    int    `toml:"max_history"`
+	MaxAgeDays    int    `toml:"max_age_days"`
+	PersistPath   string `toml:"persist_path"`
+	SaveInterval  int    `toml:"save_interval"`  // ms between history writes; 0 uses the default
+	KeepImportant bool   `toml:"keep_important"` // past max_history, evict read, non-critical notifications first
 }
 
 type NotificationUIConfig struct {
@@ -224,13 +434,40 @@ type NotificationUIConfig struct {
 }
 
 type NotificationDaemonConfig struct {
-	Enabled           bool   `toml:"enabled"`
-	Position          string `toml:"position"`
-	MaxBanners        int    `toml:"max_banners"`
-	BannerGap         int    `toml:"banner_gap"`
-	BannerWidth       int    `toml:"banner_width"`
-	BannerHeight      int    `toml:"banner_height"`
-	AnimationDuration int    `toml:"animation_duration"`
+	Enabled             bool   `toml:"enabled"`
+	Position            string `toml:"position"`
+	MaxBanners          int    `toml:"max_banners"`
+	BannerGap           int    `toml:"banner_gap"`
+	BannerWidth         int    `toml:"banner_width"`
+	BannerHeight        int    `toml:"banner_height"`
+	AnimationDuration   int    `toml:"animation_duration"`
+	Animation           string `toml:"animation"`             // "slide", "fade" or "scale"
+	OverflowPolicy      string `toml:"overflow_policy"`       // "drop_oldest", "drop_newest" or "queue"
+	MaxLifetime         int    `toml:"max_lifetime"`          // ms, caps non-critical banners; 0 disables
+	RateLimitBurst      int    `toml:"rate_limit_burst"`      // banners an app may show at once; 0 disables
+	RateLimitInterval   int    `toml:"rate_limit_interval"`   // ms to regain one banner
+	IdleInhibitCritical bool   `toml:"idle_inhibit_critical"` // keep the screen awake while a critical banner is up
+	IconSize            int    `toml:"icon_size"`             // px, 0 uses the default
+	Padding             *int   `toml:"padding"`               // px around and between the banner's parts, unset uses the default
+
+	Style BannerStyleConfig `toml:"style"`
+}
+
+// BannerStyleConfig styles notification banners. Urgency colors left empty
+// use the built-in accent for that urgency; other colors left empty and
+// sizes left at 0 use the default style.
+type BannerStyleConfig struct {
+	Background    string `toml:"background"`
+	Foreground    string `toml:"foreground"`
+	AppColor      string `toml:"app_color"`
+	BorderRadius  int    `toml:"border_radius"`
+	BorderWidth   int    `toml:"border_width"` // urgency accent on the left edge, -1 hides it
+	LowColor      string `toml:"low_color"`
+	NormalColor   string `toml:"normal_color"`
+	CriticalColor string `toml:"critical_color"`
+	TitleFontSize int    `toml:"title_font_size"`
+	BodyFontSize  int    `toml:"body_font_size"`
+	AppFontSize   int    `toml:"app_font_size"`
 }
 
 type NotificationTimeoutsConfig struct {
@@ -244,6 +481,9 @@ type FileSearchConfig struct {
 	Exclusions  []string `toml:"exclusions"`
 	MaxResults  int      `toml:"max_results"`
 	FileOpener  string   `toml:"file_opener"`
+	// FileOpeners maps lowercase extensions (".pdf") to opener commands,
+	// falling back to FileOpener
+	FileOpeners map[string]string `toml:"file_openers"`
 }
 
 type ColorsConfig struct {
@@ -258,6 +498,11 @@ type LockScreenConfig struct {
 	MaxAttempts  int    `toml:"max_attempts"`
 	Enabled      bool   `toml:"enabled"`
 	CSS          string `toml:"css"`
+	CSSFile      string `toml:"css_file"`   // loaded after css, so its rules win
+	Background   string `toml:"background"` // "color" or "screenshot"
+	Blur         int    `toml:"blur"`       // screenshot blur strength; below 2 leaves it sharp
+	OnLock       string `toml:"on_lock"`    // shell command run when the screen locks
+	OnUnlock     string `toml:"on_unlock"`  // shell command run when the screen unlocks
 }
 
 type ColorConfig struct {
@@ -272,7 +517,15 @@ var DefaultConfig = Config{
 	CacheDir:   "~/.cache/locus",
 	ConfigDir:  "~/.config/locus",
 	StatusBar: StatusBarConfig{
-		Height: 40,
+		Height:              40,
+		Position:            "top",
+		Separator:           " | ",
+		MissingDependencies: "placeholder",
+		ReconnectBackoff: ReconnectBackoffConfig{
+			Base:   1000,
+			Max:    60000,
+			Factor: 2,
+		},
 		Layout: StatusBarLayout{
 			Left: []string{
 				"launcher",
@@ -324,6 +577,10 @@ var DefaultConfig = Config{
 			ShowMenubar:       false,
 			DestroyWithParent: true,
 			HideOnClose:       true,
+			Anchor:            "top",
+			RowHeight:         44,
+			RowPadding:        8,
+			VisibleRows:       5,
 		},
 		Animation: AnimationConfig{
 			Enabled:         true,
@@ -342,10 +599,15 @@ var DefaultConfig = Config{
 		Search: SearchConfig{
 			MaxResults:        10, // Reduced for better performance
 			MaxCommandResults: 10,
+			ScopeMaxResults:   3,
 			DebounceDelay:     100, // Faster response
+			AdaptiveDelays:    []int{0, 50, 100, 100},
 			FuzzySearch:       true,
 			CaseSensitive:     false,
 			ShowHiddenApps:    false,
+			MinQueryLength:    map[string]int{"file": 3, "kill": 2},
+			NoResultsAction:   "none",
+			Sort:              "relevance",
 		},
 		Performance: PerformanceConfig{
 			EnableCache:             true,
@@ -353,6 +615,8 @@ var DefaultConfig = Config{
 			SearchCacheSize:         200, // Larger cache
 			EnableBackgroundLoading: true,
 			MaxVisibleResults:       10, // Fewer widgets
+			WarmupPrefixes:          []string{"a", "c", "f", "s", "t"},
+			HookTimeout:             2000,
 		},
 		Icons: IconsConfig{
 			EnableIcons:       true,
@@ -361,22 +625,29 @@ var DefaultConfig = Config{
 			CacheSize:         500, // Larger icon cache
 			FallbackIcon:      "image-missing",
 			IconsForLaunchers: []string{}, // Empty means all launchers show icons
+			FallbackChain:     []string{"application-x-executable"},
 		},
 		Behavior: BehaviorConfig{
 			ActivateOnHover:         false,
+			HoverDwell:              0,
 			ClearSearchOnActivate:   true,
-			CloseOnActivate:         true,
 			ShowRecentApps:          false,
 			MaxRecentApps:           5,
 			DesktopLauncherFastPath: true,
+			QueryHistorySize:        100,
 		},
 		Keys: KeysConfig{
-			Up:          []string{"Up", "Ctrl+P", "Ctrl+K"},
-			Down:        []string{"Down", "Ctrl+N", "Ctrl+J"},
-			Activate:    []string{"Return", "KP_Enter"},
-			Close:       []string{"Escape"},
-			TabComplete: []string{"Tab", "Ctrl+L"},
-			QuickSelect: []string{"Alt+1", "Alt+2", "Alt+3", "Alt+4", "Alt+5", "Alt+6", "Alt+7", "Alt+8", "Alt+9"},
+			Up:              []string{"Up", "Ctrl+P", "Ctrl+K"},
+			Down:            []string{"Down", "Ctrl+N", "Ctrl+J"},
+			Activate:        []string{"Return", "KP_Enter"},
+			Close:           []string{"Escape"},
+			TabComplete:     []string{"Tab", "Ctrl+L"},
+			QuickSelect:     []string{"Alt+1", "Alt+2", "Alt+3", "Alt+4", "Alt+5", "Alt+6", "Alt+7", "Alt+8", "Alt+9"},
+			NumberAction:    []string{"Ctrl+1", "Ctrl+2", "Ctrl+3", "Ctrl+4", "Ctrl+5", "Ctrl+6", "Ctrl+7", "Ctrl+8", "Ctrl+9"},
+			HistoryPrevious: []string{"Alt+Up"},
+			HistoryNext:     []string{"Alt+Down"},
+			HintCount:       9,
+			TogglePreview:   []string{"Ctrl+O"},
 		},
 		DesktopApps: DesktopAppsConfig{
 			ScanUserDir:    true,
@@ -406,6 +677,8 @@ var DefaultConfig = Config{
 			FontFamily:        "Victor Mono, monospace",
 			FontSize:          16,
 			FontWeight:        "bold",
+			SubtitleMaxLength: 50,
+			SubtitleLines:     1,
 		},
 		LauncherPrefixes: map[string]string{
 			"timer": "%",
@@ -414,12 +687,40 @@ var DefaultConfig = Config{
 			SetterCommand: "swww img",
 			PreviewOnNav:  true,
 		},
+		Screenshot: ScreenshotConfig{
+			SaveDir: "~/Pictures/Screenshots",
+		},
+		Define: DefineConfig{
+			Backend:    "auto",
+			APIURL:     "https://api.dictionaryapi.dev/api/v2/entries/en/%s",
+			MaxResults: 10,
+		},
+		Bookmarks: BookmarksConfig{
+			File: "~/.config/locus/bookmarks.toml",
+		},
+		Snippets: SnippetsConfig{
+			File:   "~/.config/locus/snippets.toml",
+			Action: "copy",
+		},
+		WebSearch: WebSearchConfig{
+			DefaultEngine: "ddg",
+		},
+		Clipboard: ClipboardConfig{
+			MaxEntries:   50,
+			MaxImageSize: 5120,
+		},
+		Preview: PreviewConfig{
+			Width:    320,
+			MaxLines: 40,
+		},
 	},
 	Notification: NotificationConfig{
 		History: NotificationHistoryConfig{
-			MaxHistory:  500,
-			MaxAgeDays:  30,
-			PersistPath: "~/.cache/locus/notifications.json",
+			MaxHistory:    500,
+			MaxAgeDays:    30,
+			PersistPath:   "~/.cache/locus/notifications.json",
+			SaveInterval:  2000,
+			KeepImportant: true,
 		},
 		UI: NotificationUIConfig{
 			Icon:            "notifications:",
@@ -429,13 +730,31 @@ var DefaultConfig = Config{
 			TimestampFormat: "%H:%M",
 		},
 		Daemon: NotificationDaemonConfig{
-			Enabled:           true,
-			Position:          "top-right",
-			MaxBanners:        5,
-			BannerGap:         10,
-			BannerWidth:       400,
-			BannerHeight:      100,
-			AnimationDuration: 200,
+			Enabled:             true,
+			Position:            "top-right",
+			MaxBanners:          5,
+			BannerGap:           10,
+			BannerWidth:         400,
+			BannerHeight:        100,
+			AnimationDuration:   200,
+			Animation:           "slide",
+			OverflowPolicy:      "drop_oldest",
+			MaxLifetime:         0,
+			RateLimitBurst:      5,
+			RateLimitInterval:   2000,
+			IdleInhibitCritical: false,
+			IconSize:            48,
+			Padding:             intPtr(10),
+			Style: BannerStyleConfig{
+				Background:    "rgba(14, 20, 25, 0.95)",
+				Foreground:    "#f8f8f2",
+				AppColor:      "#6272a4",
+				BorderRadius:  0,
+				BorderWidth:   3,
+				TitleFontSize: 16,
+				BodyFontSize:  14,
+				AppFontSize:   12,
+			},
 		},
 		Timeouts: NotificationTimeoutsConfig{
 			Low:      3000,
@@ -463,6 +782,8 @@ var DefaultConfig = Config{
 		PasswordHash: "",
 		MaxAttempts:  3,
 		Enabled:      true,
+		Background:   "color",
+		Blur:         8,
 		CSS: `#lockscreen-window {
 			background-color: #0e1419;
 		}
@@ -492,6 +813,10 @@ var DefaultConfig = Config{
 			font-size: 24px;
 			text-shadow: 0 0 10px rgba(251, 73, 52, 0.5);
 		}
+		#lockscreen-capslock {
+			color: #fabd2f;
+			font-size: 16px;
+		}
 		#lockscreen-label {
 			color: #ebdbb2;
 			font-size: 24px;
@@ -501,6 +826,7 @@ var DefaultConfig = Config{
 		MaxHistory:  50,
 		HistoryPath: "",
 	},
+	CommandTimeout: 5000,
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -531,6 +857,7 @@ func LoadConfig(path string) (*Config, error) {
 	cfg.ConfigDir = expandPath(cfg.ConfigDir)
 	cfg.SocketPath = expandPath(cfg.SocketPath)
 	cfg.Notification.History.PersistPath = expandPath(cfg.Notification.History.PersistPath)
+	cfg.LockScreen.CSSFile = expandPath(cfg.LockScreen.CSSFile)
 
 	return &cfg, nil
 }
@@ -572,85 +899,303 @@ func SaveConfig(cfg *Config, path string) error {
 	return os.WriteFile(expandedPath, data, 0644)
 }
 
-func (c *Config) Validate() error {
-	if err := c.validateWindow(); err != nil {
-		return err
+// ValidationError lists every problem found when validating a config
+type ValidationError struct {
+	Problems []error
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].Error()
 	}
-	if err := c.validateSearch(); err != nil {
-		return err
+	msgs := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		msgs[i] = problem.Error()
 	}
-	if err := c.validateStatusBar(); err != nil {
-		return err
+	return fmt.Sprintf("%d problems: %s", len(e.Problems), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual problems, for errors.Is and errors.As
+func (e *ValidationError) Unwrap() []error {
+	return e.Problems
+}
+
+// problems collects the errors found by the validate* checks
+type problems []error
+
+func (p *problems) addf(format string, args ...interface{}) {
+	*p = append(*p, fmt.Errorf(format, args...))
+}
+
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
 	}
-	if err := c.validateNotification(); err != nil {
-		return err
+	return &ValidationError{Problems: p}
+}
+
+// Validate checks every setting. It returns a *ValidationError listing all
+// the problems found, or nil if there are none.
+func (c *Config) Validate() error {
+	var errs problems
+	c.validateCommandTimeout(&errs)
+	c.validateWindow(&errs)
+	c.validateSearch(&errs)
+	c.validateStatusBar(&errs)
+	c.validateNotification(&errs)
+	c.validateStyling(&errs)
+	c.validateIcons(&errs)
+	c.validatePerformance(&errs)
+	c.validateBehavior(&errs)
+	c.validateAnimation(&errs)
+	c.validateLockScreen(&errs)
+	c.validateDefine(&errs)
+	c.validateSnippets(&errs)
+	c.validateWebSearch(&errs)
+	c.validateClipboard(&errs)
+	c.validatePreview(&errs)
+	c.validateRowTemplates(&errs)
+	c.validateEnabled(&errs)
+	return errs.err()
+}
+
+// ValidateFirst is like Validate but returns only the first problem found
+func (c *Config) ValidateFirst() error {
+	err := c.Validate()
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr.Problems[0]
 	}
-	if err := c.validateIcons(); err != nil {
-		return err
+	return err
+}
+
+func (c *Config) validateCommandTimeout(errs *problems) {
+	if c.CommandTimeout < -1 || c.CommandTimeout > 600000 {
+		errs.addf("invalid command_timeout: %d (must be 0-600000ms, or -1 for no limit)", c.CommandTimeout)
 	}
-	if err := c.validatePerformance(); err != nil {
-		return err
+}
+
+func (c *Config) validateEnabled(errs *problems) {
+	if !c.Launcher.LauncherEnabled("apps") {
+		errs.addf("the apps launcher cannot be disabled")
 	}
-	if err := c.validateBehavior(); err != nil {
-		return err
+}
+
+func (c *Config) validateDefine(errs *problems) {
+	d := c.Launcher.Define
+	if d.Backend != "" {
+		validBackends := map[string]bool{"auto": true, "dict": true, "wn": true, "api": true}
+		if !validBackends[d.Backend] {
+			errs.addf("invalid define backend: %s (must be one of: auto, dict, wn, api)", d.Backend)
+		}
 	}
-	if err := c.validateAnimation(); err != nil {
-		return err
+	if d.MaxResults < 0 || d.MaxResults > 100 {
+		errs.addf("invalid define max_results: %d (must be 0-100)", d.MaxResults)
 	}
-	if err := c.validateLockScreen(); err != nil {
-		return err
+}
+
+func (c *Config) validateRowTemplates(errs *problems) {
+	for name, t := range c.Launcher.RowTemplates {
+		for field, text := range map[string]string{"title": t.Title, "subtitle": t.Subtitle, "badge": t.Badge} {
+			if err := validateRowTemplateField(text); err != nil {
+				errs.addf("invalid row_templates.%s.%s: %w", name, field, err)
+			}
+		}
+	}
+}
+
+// validateRowTemplateField checks that every "{" in text opens a non-empty,
+// closed placeholder
+func validateRowTemplateField(text string) error {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			end := strings.IndexByte(text[i:], '}')
+			if end < 0 {
+				return fmt.Errorf("unclosed placeholder at offset %d", i)
+			}
+			if key := text[i+1 : i+end]; key == "" || strings.ContainsAny(key, "{ ") {
+				return fmt.Errorf("invalid placeholder {%s}", key)
+			}
+			i += end
+		case '}':
+			return fmt.Errorf("unmatched '}' at offset %d", i)
+		}
 	}
 	return nil
 }
 
-func (c *Config) validateWindow() error {
+func (c *Config) validateClipboard(errs *problems) {
+	cb := c.Launcher.Clipboard
+	if cb.MaxEntries < 0 || cb.MaxEntries > 1000 {
+		errs.addf("invalid clipboard max_entries: %d (must be 0-1000)", cb.MaxEntries)
+	}
+	if cb.MaxImageSize < 0 || cb.MaxImageSize > 102400 {
+		errs.addf("invalid clipboard max_image_size: %d (must be 0-102400 KB)", cb.MaxImageSize)
+	}
+}
+
+func (c *Config) validatePreview(errs *problems) {
+	p := c.Launcher.Preview
+	if p.Width != 0 && (p.Width < 100 || p.Width > 2000) {
+		errs.addf("invalid preview width: %d (must be 100-2000)", p.Width)
+	}
+	if p.MaxLines < 0 || p.MaxLines > 1000 {
+		errs.addf("invalid preview max_lines: %d (must be 1-1000)", p.MaxLines)
+	}
+}
+
+func (c *Config) validateWebSearch(errs *problems) {
+	w := c.Launcher.WebSearch
+	for keyword, template := range w.Engines {
+		if keyword == "" || strings.ContainsAny(keyword, " \t") {
+			errs.addf("invalid web_search engine keyword: %q (must be a single word)", keyword)
+		}
+		if !strings.Contains(template, "%s") {
+			errs.addf("invalid web_search engine %s: %s (must contain %%s)", keyword, template)
+		}
+	}
+	if w.DefaultEngine != "" {
+		if _, ok := w.WebSearchEngines()[w.DefaultEngine]; !ok {
+			errs.addf("invalid web_search default_engine: %s (no such engine)", w.DefaultEngine)
+		}
+	}
+}
+
+func (c *Config) validateSnippets(errs *problems) {
+	a := c.Launcher.Snippets.Action
+	if a != "" && a != "copy" && a != "type" {
+		errs.addf("invalid snippets action: %s (must be one of: copy, type)", a)
+	}
+}
+
+func (c *Config) validateWindow(errs *problems) {
 	w := c.Launcher.Window
 	if w.Width < 100 || w.Width > 4000 {
-		return fmt.Errorf("invalid window width: %d (must be 100-4000)", w.Width)
+		errs.addf("invalid window width: %d (must be 100-4000)", w.Width)
 	}
 	if w.Height < 100 || w.Height > 4000 {
-		return fmt.Errorf("invalid window height: %d (must be 100-4000)", w.Height)
+		errs.addf("invalid window height: %d (must be 100-4000)", w.Height)
+	}
+	if w.Anchor != "" && w.Anchor != "top" && w.Anchor != "center" {
+		errs.addf("invalid window anchor: %s (must be one of: top, center)", w.Anchor)
+	}
+	if w.RowHeight != 0 && (w.RowHeight < 16 || w.RowHeight > 200) {
+		errs.addf("invalid window row_height: %d (must be 16-200)", w.RowHeight)
+	}
+	if w.RowPadding < 0 || w.RowPadding > 32 {
+		errs.addf("invalid window row_padding: %d (must be 0-32)", w.RowPadding)
+	}
+	if w.VisibleRows < 0 || w.VisibleRows > 50 {
+		errs.addf("invalid window visible_rows: %d (must be 1-50)", w.VisibleRows)
 	}
-	return nil
 }
 
-func (c *Config) validateSearch() error {
+func (c *Config) validateSearch(errs *problems) {
 	s := c.Launcher.Search
 	if s.MaxResults < 1 || s.MaxResults > 1000 {
-		return fmt.Errorf("invalid max_results: %d (must be 1-1000)", s.MaxResults)
+		errs.addf("invalid max_results: %d (must be 1-1000)", s.MaxResults)
 	}
 	if s.MaxCommandResults < 1 || s.MaxCommandResults > 1000 {
-		return fmt.Errorf("invalid max_command_results: %d (must be 1-1000)", s.MaxCommandResults)
+		errs.addf("invalid max_command_results: %d (must be 1-1000)", s.MaxCommandResults)
 	}
 	if s.DebounceDelay < 0 || s.DebounceDelay > 5000 {
-		return fmt.Errorf("invalid debounce_delay: %d (must be 0-5000ms)", s.DebounceDelay)
+		errs.addf("invalid debounce_delay: %d (must be 0-5000ms)", s.DebounceDelay)
+	}
+	for _, d := range s.AdaptiveDelays {
+		if d < 0 || d > 5000 {
+			errs.addf("invalid adaptive_delays entry: %d (must be 0-5000ms)", d)
+		}
+	}
+	if s.ScopeMaxResults < 0 || s.ScopeMaxResults > 100 {
+		errs.addf("invalid scope_max_results: %d (must be 0-100)", s.ScopeMaxResults)
+	}
+	for name, n := range s.MinQueryLength {
+		if n < 0 || n > 20 {
+			errs.addf("invalid min_query_length for %s: %d (must be 0-20)", name, n)
+		}
+	}
+	switch s.NoResultsAction {
+	case "", "shell", "web", "none":
+	default:
+		errs.addf("invalid no_results_action: %s (must be one of: shell, web, none)", s.NoResultsAction)
+	}
+	switch s.Sort {
+	case "", "relevance", "alpha", "frecency", "recent":
+	default:
+		errs.addf("invalid sort: %s (must be one of: relevance, alpha, frecency, recent)", s.Sort)
 	}
-	return nil
 }
 
-func (c *Config) validateStatusBar() error {
+func (c *Config) validateStatusBar(errs *problems) {
 	if c.StatusBar.Height < 10 || c.StatusBar.Height > 100 {
-		return fmt.Errorf("invalid statusbar height: %d (must be 10-100px)", c.StatusBar.Height)
+		errs.addf("invalid statusbar height: %d (must be 10-100px)", c.StatusBar.Height)
+	}
+	sb := c.StatusBar
+	if sb.Position != "" && sb.Position != "top" && sb.Position != "bottom" {
+		errs.addf("invalid statusbar position: %s (must be one of: top, bottom)", sb.Position)
+	}
+	if sb.MarginTop < 0 || sb.MarginTop > 200 {
+		errs.addf("invalid statusbar margin_top: %d (must be 0-200px)", sb.MarginTop)
+	}
+	if sb.MarginBottom < 0 || sb.MarginBottom > 200 {
+		errs.addf("invalid statusbar margin_bottom: %d (must be 0-200px)", sb.MarginBottom)
+	}
+	if sb.MarginLeft < 0 || sb.MarginLeft > 2000 {
+		errs.addf("invalid statusbar margin_left: %d (must be 0-2000px)", sb.MarginLeft)
+	}
+	if sb.MarginRight < 0 || sb.MarginRight > 2000 {
+		errs.addf("invalid statusbar margin_right: %d (must be 0-2000px)", sb.MarginRight)
+	}
+	if sb.Gap < 0 || sb.Gap > 100 {
+		errs.addf("invalid statusbar gap: %d (must be 0-100px)", sb.Gap)
+	}
+	switch sb.MissingDependencies {
+	case "", "placeholder", "skip", "load":
+	default:
+		errs.addf("invalid statusbar missing_dependencies: %s (must be one of: placeholder, skip, load)", sb.MissingDependencies)
+	}
+	rb := sb.ReconnectBackoff
+	if rb.Base < 0 || rb.Base > 600000 {
+		errs.addf("invalid reconnect_backoff base: %d (must be 0-600000ms)", rb.Base)
+	}
+	if rb.Max < 0 || rb.Max > 3600000 {
+		errs.addf("invalid reconnect_backoff max: %d (must be 0-3600000ms)", rb.Max)
+	} else if rb.Max > 0 && rb.Max < rb.Base {
+		errs.addf("invalid reconnect_backoff max: %d (must be at least base, %dms)", rb.Max, rb.Base)
+	}
+	if rb.Factor != 0 && (rb.Factor < 1 || rb.Factor > 10) {
+		errs.addf("invalid reconnect_backoff factor: %g (must be 1-10)", rb.Factor)
+	}
+	for section := range sb.Separators {
+		if section != "left" && section != "middle" && section != "right" {
+			errs.addf("invalid statusbar separators section: %s (must be one of: left, middle, right)", section)
+		}
 	}
-	return nil
 }
 
-func (c *Config) validateNotification() error {
+func (c *Config) validateNotification(errs *problems) {
 	d := c.Notification.Daemon
 	if d.MaxBanners < 1 || d.MaxBanners > 20 {
-		return fmt.Errorf("invalid max_banners: %d (must be 1-20)", d.MaxBanners)
+		errs.addf("invalid max_banners: %d (must be 1-20)", d.MaxBanners)
 	}
 	if d.BannerGap < 0 || d.BannerGap > 50 {
-		return fmt.Errorf("invalid banner_gap: %d (must be 0-50px)", d.BannerGap)
+		errs.addf("invalid banner_gap: %d (must be 0-50px)", d.BannerGap)
 	}
 	if d.BannerWidth < 100 || d.BannerWidth > 2000 {
-		return fmt.Errorf("invalid banner_width: %d (must be 100-2000)", d.BannerWidth)
+		errs.addf("invalid banner_width: %d (must be 100-2000)", d.BannerWidth)
 	}
 	if d.BannerHeight < 50 || d.BannerHeight > 500 {
-		return fmt.Errorf("invalid banner_height: %d (must be 50-500)", d.BannerHeight)
+		errs.addf("invalid banner_height: %d (must be 50-500)", d.BannerHeight)
 	}
 	if d.AnimationDuration < 0 || d.AnimationDuration > 2000 {
-		return fmt.Errorf("invalid animation_duration: %d (must be 0-2000ms)", d.AnimationDuration)
+		errs.addf("invalid animation_duration: %d (must be 0-2000ms)", d.AnimationDuration)
+	}
+	if d.Animation != "" {
+		validAnimations := map[string]bool{"slide": true, "fade": true, "scale": true}
+		if !validAnimations[d.Animation] {
+			errs.addf("invalid animation: %s (must be one of: slide, fade, scale)", d.Animation)
+		}
 	}
 	if d.Position != "" {
 		validPositions := map[string]bool{
@@ -658,103 +1203,161 @@ func (c *Config) validateNotification() error {
 			"bottom-left": true, "bottom-center": true, "bottom-right": true,
 		}
 		if !validPositions[d.Position] {
-			return fmt.Errorf("invalid daemon position: %s (must be one of: top-left, top-center, top-right, bottom-left, bottom-center, bottom-right)", d.Position)
+			errs.addf("invalid daemon position: %s (must be one of: top-left, top-center, top-right, bottom-left, bottom-center, bottom-right)", d.Position)
+		}
+	}
+	if d.OverflowPolicy != "" {
+		validPolicies := map[string]bool{"drop_oldest": true, "drop_newest": true, "queue": true}
+		if !validPolicies[d.OverflowPolicy] {
+			errs.addf("invalid overflow_policy: %s (must be one of: drop_oldest, drop_newest, queue)", d.OverflowPolicy)
+		}
+	}
+	if d.MaxLifetime < 0 || d.MaxLifetime > 600000 {
+		errs.addf("invalid max_lifetime: %d (must be 0-600000ms)", d.MaxLifetime)
+	}
+	if d.RateLimitBurst < 0 || d.RateLimitBurst > 100 {
+		errs.addf("invalid rate_limit_burst: %d (must be 0-100)", d.RateLimitBurst)
+	}
+	if d.RateLimitInterval < 0 || d.RateLimitInterval > 600000 {
+		errs.addf("invalid rate_limit_interval: %d (must be 0-600000ms)", d.RateLimitInterval)
+	}
+	if d.IconSize != 0 && (d.IconSize < 16 || d.IconSize > 256) {
+		errs.addf("invalid notification icon_size: %d (must be 16-256px)", d.IconSize)
+	}
+	if d.Padding != nil && (*d.Padding < 0 || *d.Padding > 100) {
+		errs.addf("invalid notification padding: %d (must be 0-100px)", *d.Padding)
+	}
+	st := d.Style
+	if st.BorderRadius < 0 || st.BorderRadius > 100 {
+		errs.addf("invalid banner border_radius: %d (must be 0-100px)", st.BorderRadius)
+	}
+	if st.BorderWidth < -1 || st.BorderWidth > 20 {
+		errs.addf("invalid banner border_width: %d (must be 0-20px, or -1 to hide it)", st.BorderWidth)
+	}
+	for name, size := range map[string]int{"title_font_size": st.TitleFontSize, "body_font_size": st.BodyFontSize, "app_font_size": st.AppFontSize} {
+		if size != 0 && (size < 6 || size > 72) {
+			errs.addf("invalid banner %s: %d (must be 6-72px)", name, size)
 		}
 	}
 
 	h := c.Notification.History
 	if h.MaxHistory < 0 || h.MaxHistory > 10000 {
-		return fmt.Errorf("invalid max_history: %d (must be 0-10000)", h.MaxHistory)
+		errs.addf("invalid max_history: %d (must be 0-10000)", h.MaxHistory)
 	}
 	if h.MaxAgeDays < 1 || h.MaxAgeDays > 365 {
-		return fmt.Errorf("invalid max_age_days: %d (must be 1-365)", h.MaxAgeDays)
+		errs.addf("invalid max_age_days: %d (must be 1-365)", h.MaxAgeDays)
+	}
+	if h.SaveInterval < 0 || h.SaveInterval > 600000 {
+		errs.addf("invalid save_interval: %d (must be 0-600000ms)", h.SaveInterval)
 	}
 
 	t := c.Notification.Timeouts
 	if t.Low < 0 || t.Low > 60000 {
-		return fmt.Errorf("invalid low timeout: %d (must be 0-60000ms)", t.Low)
+		errs.addf("invalid low timeout: %d (must be 0-60000ms)", t.Low)
 	}
 	if t.Normal < 0 || t.Normal > 60000 {
-		return fmt.Errorf("invalid normal timeout: %d (must be 0-60000ms)", t.Normal)
+		errs.addf("invalid normal timeout: %d (must be 0-60000ms)", t.Normal)
 	}
 	if t.Critical < -1 || t.Critical > 60000 {
-		return fmt.Errorf("invalid critical timeout: %d (must be -1 for no timeout, or 0-60000ms)", t.Critical)
+		errs.addf("invalid critical timeout: %d (must be -1 for no timeout, or 0-60000ms)", t.Critical)
 	}
 
-	return nil
 }
 
-func (c *Config) validateIcons() error {
+func (c *Config) validateStyling(errs *problems) {
+	s := c.Launcher.Styling
+	if s.SubtitleMaxLength < 0 || s.SubtitleMaxLength > 1000 {
+		errs.addf("invalid subtitle_max_length: %d (must be 0-1000)", s.SubtitleMaxLength)
+	}
+	if s.SubtitleLines < 1 || s.SubtitleLines > 10 {
+		errs.addf("invalid subtitle_lines: %d (must be 1-10)", s.SubtitleLines)
+	}
+}
+
+func (c *Config) validateIcons(errs *problems) {
 	i := c.Launcher.Icons
 	if i.IconSize < 16 || i.IconSize > 256 {
-		return fmt.Errorf("invalid icon_size: %d (must be 16-256)", i.IconSize)
+		errs.addf("invalid icon_size: %d (must be 16-256)", i.IconSize)
 	}
 	if i.CacheSize < 10 || i.CacheSize > 10000 {
-		return fmt.Errorf("invalid cache_size: %d (must be 10-10000)", i.CacheSize)
+		errs.addf("invalid cache_size: %d (must be 10-10000)", i.CacheSize)
 	}
-	return nil
 }
 
-func (c *Config) validatePerformance() error {
+func (c *Config) validatePerformance(errs *problems) {
 	p := c.Launcher.Performance
 	if p.CacheMaxAgeHours < 1 || p.CacheMaxAgeHours > 168 {
-		return fmt.Errorf("invalid cache_max_age_hours: %d (must be 1-168 hours)", p.CacheMaxAgeHours)
+		errs.addf("invalid cache_max_age_hours: %d (must be 1-168 hours)", p.CacheMaxAgeHours)
 	}
 	if p.SearchCacheSize < 10 || p.SearchCacheSize > 10000 {
-		return fmt.Errorf("invalid search_cache_size: %d (must be 10-10000)", p.SearchCacheSize)
+		errs.addf("invalid search_cache_size: %d (must be 10-10000)", p.SearchCacheSize)
 	}
 	if p.MaxVisibleResults < 1 || p.MaxVisibleResults > 100 {
-		return fmt.Errorf("invalid max_visible_results: %d (must be 1-100)", p.MaxVisibleResults)
+		errs.addf("invalid max_visible_results: %d (must be 1-100)", p.MaxVisibleResults)
+	}
+	if p.HookTimeout < 0 || p.HookTimeout > 60000 {
+		errs.addf("invalid hook_timeout: %d (must be 0-60000 ms)", p.HookTimeout)
 	}
-	return nil
 }
 
-func (c *Config) validateBehavior() error {
+func (c *Config) validateBehavior(errs *problems) {
 	b := c.Launcher.Behavior
 	if b.MaxRecentApps < 0 || b.MaxRecentApps > 50 {
-		return fmt.Errorf("invalid max_recent_apps: %d (must be 0-50)", b.MaxRecentApps)
+		errs.addf("invalid max_recent_apps: %d (must be 0-50)", b.MaxRecentApps)
+	}
+	if k := c.Launcher.Keys; k.HintCount < -1 || k.HintCount > 9 {
+		errs.addf("invalid hint_count: %d (must be 1-9, or -1 to hide the hints)", k.HintCount)
+	}
+	if b.QueryHistorySize < 0 || b.QueryHistorySize > 1000 {
+		errs.addf("invalid query_history_size: %d (must be 0-1000)", b.QueryHistorySize)
+	}
+	if b.HoverDwell < 0 || b.HoverDwell > 5000 {
+		errs.addf("invalid hover_dwell: %d (must be 0-5000ms)", b.HoverDwell)
 	}
 	if b.DesktopLauncherFastPath && b.MaxRecentApps == 0 {
-		return fmt.Errorf("desktop_launcher_fast_path requires max_recent_apps > 0")
+		errs.addf("desktop_launcher_fast_path requires max_recent_apps > 0")
 	}
-	return nil
 }
 
-func (c *Config) validateAnimation() error {
+func (c *Config) validateAnimation(errs *problems) {
 	a := c.Launcher.Animation
 	if a.SlideDuration < 0 || a.SlideDuration > 5000 {
-		return fmt.Errorf("invalid slide_duration: %d (must be 0-5000ms)", a.SlideDuration)
+		errs.addf("invalid slide_duration: %d (must be 0-5000ms)", a.SlideDuration)
 	}
 	if a.FadeInDuration < 0 || a.FadeInDuration > 5000 {
-		return fmt.Errorf("invalid fade_in_duration: %d (must be 0-5000ms)", a.FadeInDuration)
+		errs.addf("invalid fade_in_duration: %d (must be 0-5000ms)", a.FadeInDuration)
 	}
 	if a.FadeOutDuration < 0 || a.FadeOutDuration > 5000 {
-		return fmt.Errorf("invalid fade_out_duration: %d (must be 0-5000ms)", a.FadeOutDuration)
+		errs.addf("invalid fade_out_duration: %d (must be 0-5000ms)", a.FadeOutDuration)
 	}
 	if a.ScaleDuration < 0 || a.ScaleDuration > 5000 {
-		return fmt.Errorf("invalid scale_duration: %d (must be 0-5000ms)", a.ScaleDuration)
+		errs.addf("invalid scale_duration: %d (must be 0-5000ms)", a.ScaleDuration)
 	}
 	if a.ScaleStart < 0.0 || a.ScaleStart > 1.0 {
-		return fmt.Errorf("invalid scale_start: %f (must be 0.0-1.0)", a.ScaleStart)
+		errs.addf("invalid scale_start: %f (must be 0.0-1.0)", a.ScaleStart)
 	}
 	validEasings := map[string]bool{
 		"linear": true, "ease-in": true, "ease-out": true, "ease-in-out": true,
 	}
 	if !validEasings[a.Easing] {
-		return fmt.Errorf("invalid easing: %s (must be one of: linear, ease-in, ease-out, ease-in-out)", a.Easing)
+		errs.addf("invalid easing: %s (must be one of: linear, ease-in, ease-out, ease-in-out)", a.Easing)
 	}
-	return nil
 }
 
-func (c *Config) validateLockScreen() error {
+func (c *Config) validateLockScreen(errs *problems) {
 	ls := c.LockScreen
 	if ls.MaxAttempts < 1 || ls.MaxAttempts > 10 {
-		return fmt.Errorf("invalid max_attempts: %d (must be 1-10)", ls.MaxAttempts)
+		errs.addf("invalid max_attempts: %d (must be 1-10)", ls.MaxAttempts)
+	}
+	if ls.Background != "" && ls.Background != "color" && ls.Background != "screenshot" {
+		errs.addf("invalid lock screen background: %s (must be one of: color, screenshot)", ls.Background)
+	}
+	if ls.Blur < 0 || ls.Blur > 50 {
+		errs.addf("invalid lock screen blur: %d (must be 0-50)", ls.Blur)
 	}
 	if ls.Enabled && ls.Password == "" && ls.PasswordHash == "" {
-		return fmt.Errorf("lockscreen enabled but no password or password_hash provided")
+		errs.addf("lockscreen enabled but no password or password_hash provided")
 	}
-	return nil
 }
 
 func ValidateConfig(path string) error {
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chess10kp/locus/internal/command"
+)
+
+// validConfig returns the default config, which enables the lock screen
+// without a password, made valid
+func validConfig() Config {
+	cfg := DefaultConfig
+	cfg.LockScreen.Password = "secret"
+	return cfg
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := validConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected the default config to be valid, got %v", err)
+	}
+
+	cfg.Launcher.Window.Width = 10
+	cfg.Launcher.Search.MaxResults = 0
+	cfg.StatusBar.Height = 500
+
+	err := cfg.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a *ValidationError, got %T: %v", err, err)
+	}
+	if len(verr.Problems) != 3 {
+		t.Fatalf("expected 3 problems, got %d: %v", len(verr.Problems), err)
+	}
+	for _, want := range []string{"window width: 10", "max_results: 0", "statusbar height: 500"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err.Error())
+		}
+	}
+
+	first := cfg.ValidateFirst()
+	if first == nil || first.Error() != verr.Problems[0].Error() {
+		t.Errorf("expected ValidateFirst to return %v, got %v", verr.Problems[0], first)
+	}
+}
+
+func TestValidationErrorSingleProblem(t *testing.T) {
+	cfg := validConfig()
+	cfg.Launcher.Window.Height = 5
+
+	err := cfg.Validate()
+	if err == nil || err.Error() != "invalid window height: 5 (must be 100-4000)" {
+		t.Errorf("expected a lone problem to read as itself, got %v", err)
+	}
+}
+
+func TestCommandRunnerTimeout(t *testing.T) {
+	tests := []struct {
+		timeout int
+		want    time.Duration
+	}{
+		{0, command.DefaultTimeout},
+		{250, 250 * time.Millisecond},
+		{-1, 0},
+	}
+
+	for _, tt := range tests {
+		cfg := Config{CommandTimeout: tt.timeout}
+		if got := cfg.CommandRunner().Timeout; got != tt.want {
+			t.Errorf("command_timeout %d: expected %v, got %v", tt.timeout, tt.want, got)
+		}
+	}
+}
+
+func TestHistorySaveEvery(t *testing.T) {
+	tests := []struct {
+		interval int
+		want     time.Duration
+	}{
+		{0, 2 * time.Second},
+		{-5, 2 * time.Second},
+		{500, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		h := NotificationHistoryConfig{SaveInterval: tt.interval}
+		if got := h.SaveEvery(); got != tt.want {
+			t.Errorf("save_interval %d: expected %v, got %v", tt.interval, tt.want, got)
+		}
+	}
+}
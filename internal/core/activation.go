@@ -0,0 +1,27 @@
+package core
+
+import "github.com/chess10kp/locus/internal/config"
+
+// afterActivation describes what the launcher does once a result has run
+type afterActivation struct {
+	hide       bool // hide the launcher
+	clearQuery bool // clear the search entry, now or before it is next shown
+	refresh    bool // re-run the search so an open launcher shows fresh results
+}
+
+// afterActivationFor applies close_on_activate and clear_search_on_activate
+func afterActivationFor(behavior config.BehaviorConfig) afterActivation {
+	closes := behavior.ClosesOnActivate()
+	return afterActivation{
+		hide:       closes,
+		clearQuery: behavior.ClearSearchOnActivate,
+		refresh:    !closes,
+	}
+}
+
+// hidesOnFocusOut applies hide_on_focus_loss. Focus changes while the
+// launcher slides in or out, or is hidden, come from mapping and unmapping
+// the window, so only a settled, visible launcher hides.
+func hidesOnFocusOut(behavior config.BehaviorConfig, visible, animating bool) bool {
+	return behavior.HideOnFocusLoss && visible && !animating
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestAfterActivationFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		close      bool
+		clear      bool
+		wantHide   bool
+		wantClear  bool
+		wantReload bool
+	}{
+		{"close and clear", true, true, true, true, false},
+		{"close and keep query", true, false, true, false, false},
+		{"stay open and clear", false, true, false, true, true},
+		{"stay open and keep query", false, false, false, false, true},
+	}
+
+	for _, tt := range tests {
+		behavior := config.DefaultConfig.Launcher.Behavior
+		closeOnActivate := tt.close
+		behavior.CloseOnActivate = &closeOnActivate
+		behavior.ClearSearchOnActivate = tt.clear
+
+		got := afterActivationFor(behavior)
+		if got.hide != tt.wantHide || got.clearQuery != tt.wantClear || got.refresh != tt.wantReload {
+			t.Errorf("%s: got %+v, want hide=%v clear=%v refresh=%v", tt.name, got, tt.wantHide, tt.wantClear, tt.wantReload)
+		}
+	}
+}
+
+func TestAfterActivationDefaults(t *testing.T) {
+	got := afterActivationFor(config.DefaultConfig.Launcher.Behavior)
+	if !got.hide || !got.clearQuery {
+		t.Errorf("default behavior should hide and clear the query, got %+v", got)
+	}
+
+	// Configs that don't set close_on_activate keep hiding after activation
+	if got := afterActivationFor(config.BehaviorConfig{}); !got.hide || got.refresh {
+		t.Errorf("unset close_on_activate should hide, got %+v", got)
+	}
+}
+
+func TestHidesOnFocusOut(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		visible   bool
+		animating bool
+		want      bool
+	}{
+		{"enabled and shown", true, true, false, true},
+		{"disabled", false, true, false, false},
+		{"sliding in or out", true, true, true, false},
+		{"already hidden", true, false, false, false},
+	}
+
+	for _, tt := range tests {
+		behavior := config.DefaultConfig.Launcher.Behavior
+		behavior.HideOnFocusLoss = tt.enabled
+		if got := hidesOnFocusOut(behavior, tt.visible, tt.animating); got != tt.want {
+			t.Errorf("%s: hidesOnFocusOut() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if hidesOnFocusOut(config.DefaultConfig.Launcher.Behavior, true, false) {
+		t.Errorf("focus loss should not hide the launcher by default")
+	}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// launcherAnimation is how the launcher window slides and fades as it is
+// shown and hidden, from launcher.animation. Parts with a zero duration are
+// not animated.
+type launcherAnimation struct {
+	slide   time.Duration // slide along the top margin
+	fadeIn  time.Duration
+	fadeOut time.Duration
+	easing  func(float64) float64
+}
+
+// animationFrame is the state of the window part-way through an animation
+type animationFrame struct {
+	slide   float64 // 0 off-screen, 1 at its resting margin
+	opacity float64
+	done    bool
+}
+
+// launcherAnimationFor reads launcher.animation. slide reports whether the
+// placement allows sliding (top-anchored launchers only).
+func launcherAnimationFor(cfg config.AnimationConfig, slide bool) launcherAnimation {
+	a := launcherAnimation{easing: easingFunc(cfg.Easing)}
+	if !cfg.Enabled {
+		return a
+	}
+	if slide && cfg.EnableSlideIn {
+		a.slide = time.Duration(cfg.SlideDuration) * time.Millisecond
+	}
+	if cfg.FadeEnabled {
+		a.fadeIn = time.Duration(cfg.FadeInDuration) * time.Millisecond
+		a.fadeOut = time.Duration(cfg.FadeOutDuration) * time.Millisecond
+	}
+	return a
+}
+
+// duration is how long showing (or hiding) takes, 0 for no animation
+func (a launcherAnimation) duration(showing bool) time.Duration {
+	fade := a.fadeOut
+	if showing {
+		fade = a.fadeIn
+	}
+	if a.slide > fade {
+		return a.slide
+	}
+	return fade
+}
+
+// frame returns the window state elapsed into showing or hiding
+func (a launcherAnimation) frame(elapsed time.Duration, showing bool) animationFrame {
+	fade := a.fadeOut
+	if showing {
+		fade = a.fadeIn
+	}
+
+	f := animationFrame{
+		slide:   a.progress(elapsed, a.slide),
+		opacity: a.progress(elapsed, fade),
+		done:    elapsed >= a.duration(showing),
+	}
+	if !showing {
+		f.slide = 1 - f.slide
+		f.opacity = 1 - f.opacity
+	}
+	return f
+}
+
+// progress is the eased fraction of duration elapsed; parts that are not
+// animated are complete from the start
+func (a launcherAnimation) progress(elapsed, duration time.Duration) float64 {
+	if duration <= 0 || elapsed >= duration {
+		return 1
+	}
+	if elapsed <= 0 {
+		return 0
+	}
+	return a.easing(float64(elapsed) / float64(duration))
+}
+
+// easingFunc returns the easing curve named by launcher.animation.easing
+func easingFunc(name string) func(float64) float64 {
+	switch name {
+	case "linear":
+		return func(t float64) float64 { return t }
+	case "ease-in":
+		return func(t float64) float64 { return t * t * t }
+	case "ease-in-out":
+		return func(t float64) float64 {
+			if t < 0.5 {
+				return 4 * t * t * t
+			}
+			u := -2*t + 2
+			return 1 - u*u*u/2
+		}
+	}
+	return easeOutCubic
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func testAnimationConfig() config.AnimationConfig {
+	return config.AnimationConfig{
+		Enabled:         true,
+		EnableSlideIn:   true,
+		SlideDuration:   100,
+		FadeEnabled:     true,
+		FadeInDuration:  200,
+		FadeOutDuration: 50,
+		Easing:          "linear",
+	}
+}
+
+func TestLauncherAnimationFor(t *testing.T) {
+	cfg := testAnimationConfig()
+
+	a := launcherAnimationFor(cfg, true)
+	if a.duration(true) != 200*time.Millisecond {
+		t.Errorf("show duration = %v, want the longer fade-in", a.duration(true))
+	}
+	if a.duration(false) != 100*time.Millisecond {
+		t.Errorf("hide duration = %v, want the longer slide", a.duration(false))
+	}
+
+	if a := launcherAnimationFor(cfg, false); a.slide != 0 {
+		t.Errorf("slide = %v for a placement that cannot slide, want 0", a.slide)
+	}
+
+	cfg.Enabled = false
+	a = launcherAnimationFor(cfg, true)
+	if a.duration(true) != 0 || a.duration(false) != 0 {
+		t.Errorf("disabled animation takes %v/%v, want 0", a.duration(true), a.duration(false))
+	}
+	if f := a.frame(0, true); !f.done || f.slide != 1 || f.opacity != 1 {
+		t.Errorf("disabled show frame = %+v, want done and fully shown", f)
+	}
+}
+
+func TestLauncherAnimationFrame(t *testing.T) {
+	a := launcherAnimationFor(testAnimationConfig(), true)
+
+	tests := []struct {
+		name        string
+		elapsed     time.Duration
+		showing     bool
+		wantSlide   float64
+		wantOpacity float64
+		wantDone    bool
+	}{
+		{"show start", 0, true, 0, 0, false},
+		{"show midway", 50 * time.Millisecond, true, 0.5, 0.25, false},
+		{"show slide done", 100 * time.Millisecond, true, 1, 0.5, false},
+		{"show end", 200 * time.Millisecond, true, 1, 1, true},
+		{"hide start", 0, false, 1, 1, false},
+		{"hide midway", 25 * time.Millisecond, false, 0.75, 0.5, false},
+		{"hide end", 100 * time.Millisecond, false, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := a.frame(tt.elapsed, tt.showing)
+			if math.Abs(f.slide-tt.wantSlide) > 1e-9 || math.Abs(f.opacity-tt.wantOpacity) > 1e-9 || f.done != tt.wantDone {
+				t.Errorf("frame(%v, %v) = %+v, want slide %v opacity %v done %v",
+					tt.elapsed, tt.showing, f, tt.wantSlide, tt.wantOpacity, tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestEasingFunc(t *testing.T) {
+	for _, name := range []string{"linear", "ease-in", "ease-out", "ease-in-out"} {
+		ease := easingFunc(name)
+		if ease(0) != 0 || ease(1) != 1 {
+			t.Errorf("%s: ease(0), ease(1) = %v, %v, want 0, 1", name, ease(0), ease(1))
+		}
+	}
+	if got := easingFunc("ease-in")(0.5); got >= 0.5 {
+		t.Errorf("ease-in(0.5) = %v, want below linear", got)
+	}
+	if got := easingFunc("ease-out")(0.5); got <= 0.5 {
+		t.Errorf("ease-out(0.5) = %v, want above linear", got)
+	}
+}
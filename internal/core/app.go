@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -27,6 +28,7 @@ type App struct {
 	lockscreen      *lockscreen.LockScreenManager
 	notificationMgr *notification.Manager
 	iconCache       *launcher.IconCache
+	events          *EventBus
 }
 
 // NewApp creates a new application
@@ -35,6 +37,7 @@ func NewApp(cfg *config.Config) (*App, error) {
 		config:  cfg,
 		running: false,
 		sigChan: make(chan os.Signal, 1),
+		events:  NewEventBus(),
 	}, nil
 }
 
@@ -79,6 +82,7 @@ func (a *App) initialize() {
 	go a.monitorGTKMainLoop()
 
 	a.lockscreen = lockscreen.NewLockScreenManager(a.config)
+	a.subscribeLockEvents()
 
 	iconCache, err := launcher.NewIconCache(a.config)
 	if err != nil {
@@ -178,6 +182,25 @@ func (a *App) PresentLauncher() error {
 	return err
 }
 
+// PresentLauncherWithQuery shows the launcher with the search entry prefilled
+func (a *App) PresentLauncherWithQuery(query string) error {
+	if a.launcher == nil {
+		log.Printf("PresentLauncherWithQuery: launcher is nil!")
+		return nil
+	}
+	return a.launcher.ShowWithQuery(query)
+}
+
+// PresentLauncherWithParams shows the launcher with options passed over
+// IPC; done receives the lines chosen in dmenu and multi-select mode
+func (a *App) PresentLauncherWithParams(params *launcher.InvocationParams, done func(lines []string)) error {
+	if a.launcher == nil {
+		log.Printf("PresentLauncherWithParams: launcher is nil!")
+		return fmt.Errorf("launcher is not running")
+	}
+	return a.launcher.ShowWithParams(params, done)
+}
+
 // HideLauncher hides the launcher
 func (a *App) HideLauncher() error {
 	if a.launcher != nil {
@@ -203,6 +226,37 @@ func (a *App) GetConfig() *config.Config {
 	return a.config
 }
 
+// Events returns the application event bus
+func (a *App) Events() *EventBus {
+	return a.events
+}
+
+// subscribeLockEvents connects lock screen requests on the bus to the lock
+// manager and publishes its lock and unlock transitions
+func (a *App) subscribeLockEvents() {
+	a.lockscreen.SetStateCallback(func(locked bool) {
+		topic := TopicUnlocked
+		if locked {
+			topic = TopicLocked
+		}
+		// Publish once the lock manager has finished the transition
+		glib.IdleAdd(func() {
+			a.events.Publish(topic, nil)
+		})
+	})
+
+	a.events.Subscribe(TopicLockRequested, func(interface{}) {
+		if err := a.ShowLockScreen(); err != nil {
+			log.Printf("Failed to show lock screen: %v", err)
+		}
+	})
+	a.events.Subscribe(TopicUnlockRequested, func(interface{}) {
+		if err := a.HideLockScreen(); err != nil {
+			log.Printf("Failed to hide lock screen: %v", err)
+		}
+	})
+}
+
 // ShowLockScreen shows the lock screen
 func (a *App) ShowLockScreen() error {
 	if a.lockscreen == nil {
@@ -211,7 +265,13 @@ func (a *App) ShowLockScreen() error {
 	if a.statusBar != nil {
 		a.statusBar.Hide()
 	}
-	return a.lockscreen.Show()
+	if err := a.lockscreen.Show(); err != nil {
+		if a.statusBar != nil {
+			a.statusBar.Show()
+		}
+		return err
+	}
+	return nil
 }
 
 // HideLockScreen hides the lock screen
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"log"
+	"sync"
+)
+
+// Event bus topics
+const (
+	TopicLockRequested   = "lock_requested"
+	TopicUnlockRequested = "unlock_requested"
+	TopicLocked          = "locked"   // the lock screen was shown
+	TopicUnlocked        = "unlocked" // the lock screen was dismissed
+)
+
+// EventHandler handles an event published on the bus
+type EventHandler func(payload interface{})
+
+type subscription struct {
+	id      int
+	handler EventHandler
+}
+
+// EventBus is a lightweight in-process publish/subscribe bus used to decouple
+// subsystems (launcher, lock screen, status bar) from each other's callbacks
+type EventBus struct {
+	subscribers map[string][]subscription
+	nextID      int
+	mu          sync.RWMutex
+}
+
+// NewEventBus creates a new event bus
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[string][]subscription),
+	}
+}
+
+// Subscribe registers a handler for a topic and returns a function that removes it
+func (b *EventBus) Subscribe(topic string, handler EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscribers[topic] = append(b.subscribers[topic], subscription{id: id, handler: handler})
+
+	return func() {
+		b.unsubscribe(topic, id)
+	}
+}
+
+// unsubscribe removes a single subscription from a topic
+func (b *EventBus) unsubscribe(topic string, id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs := b.subscribers[topic]
+	for i, sub := range subs {
+		if sub.id == id {
+			b.subscribers[topic] = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(b.subscribers[topic]) == 0 {
+		delete(b.subscribers, topic)
+	}
+}
+
+// Publish delivers payload to every handler subscribed to topic, in subscription
+// order, on the calling goroutine. It returns the number of handlers invoked.
+func (b *EventBus) Publish(topic string, payload interface{}) int {
+	b.mu.RLock()
+	subs := make([]subscription, len(b.subscribers[topic]))
+	copy(subs, b.subscribers[topic])
+	b.mu.RUnlock()
+
+	for _, sub := range subs {
+		b.dispatch(topic, sub.handler, payload)
+	}
+
+	return len(subs)
+}
+
+// dispatch invokes a handler, recovering from panics so one bad subscriber
+// cannot break the publisher
+func (b *EventBus) dispatch(topic string, handler EventHandler, payload interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[EVENT-BUS] Handler for '%s' panicked: %v", topic, r)
+		}
+	}()
+	handler(payload)
+}
+
+// HasSubscribers returns whether any handler is subscribed to topic
+func (b *EventBus) HasSubscribers(topic string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers[topic]) > 0
+}
+
+// lockRequestPublisher bridges the launcher's lock screen callback to the bus
+func lockRequestPublisher(bus *EventBus) func() error {
+	return func() error {
+		bus.Publish(TopicLockRequested, nil)
+		return nil
+	}
+}
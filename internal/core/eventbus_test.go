@@ -0,0 +1,62 @@
+package core
+
+import "testing"
+
+func TestEventBusPublishSubscribe(t *testing.T) {
+	bus := NewEventBus()
+
+	var got []interface{}
+	unsubscribe := bus.Subscribe("topic", func(payload interface{}) {
+		got = append(got, payload)
+	})
+
+	if n := bus.Publish("topic", "first"); n != 1 {
+		t.Errorf("Expected 1 handler invoked, got %d", n)
+	}
+	if n := bus.Publish("other", "ignored"); n != 0 {
+		t.Errorf("Expected 0 handlers for unrelated topic, got %d", n)
+	}
+
+	unsubscribe()
+	bus.Publish("topic", "second")
+
+	if len(got) != 1 || got[0] != "first" {
+		t.Errorf("Expected only the first payload, got %v", got)
+	}
+	if bus.HasSubscribers("topic") {
+		t.Error("Expected no subscribers after unsubscribe")
+	}
+}
+
+func TestEventBusOrderAndPanicRecovery(t *testing.T) {
+	bus := NewEventBus()
+
+	var order []int
+	bus.Subscribe("topic", func(interface{}) { order = append(order, 1) })
+	bus.Subscribe("topic", func(interface{}) { panic("boom") })
+	bus.Subscribe("topic", func(interface{}) { order = append(order, 3) })
+
+	bus.Publish("topic", nil)
+
+	if len(order) != 2 || order[0] != 1 || order[1] != 3 {
+		t.Errorf("Expected handlers 1 and 3 to run in order, got %v", order)
+	}
+}
+
+func TestLockRequestPublisher(t *testing.T) {
+	bus := NewEventBus()
+
+	requested := 0
+	bus.Subscribe(TopicLockRequested, func(interface{}) {
+		requested++
+	})
+
+	callback := lockRequestPublisher(bus)
+	if err := callback(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requested != 1 {
+		t.Errorf("Expected lock request to be published once, got %d", requested)
+	}
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// hoverTracker turns pointer motion over the result list into row selection
+// when activate_on_hover is set, and activation once the pointer has rested
+// on a row for the configured dwell
+type hoverTracker struct {
+	enabled    bool
+	dwell      time.Duration
+	index      int
+	generation int
+}
+
+func newHoverTracker(behavior config.BehaviorConfig) *hoverTracker {
+	return &hoverTracker{
+		enabled: behavior.ActivateOnHover,
+		dwell:   time.Duration(behavior.HoverDwell) * time.Millisecond,
+		index:   -1,
+	}
+}
+
+// move records the pointer over row index, -1 meaning no row. It reports
+// whether that row should be selected and, when a dwell should start, a
+// non-zero token to pass to dwellElapsed.
+func (h *hoverTracker) move(index int) (selectRow bool, dwellToken int) {
+	if !h.enabled || index == h.index {
+		return false, 0
+	}
+
+	h.index = index
+	h.generation++
+	if index < 0 {
+		return false, 0
+	}
+	if h.dwell > 0 {
+		return true, h.generation
+	}
+	return true, 0
+}
+
+// leave forgets the hovered row and cancels any pending dwell
+func (h *hoverTracker) leave() {
+	h.move(-1)
+}
+
+// reset forgets the hovered row after the results change, so the row that
+// ends up under a resting pointer isn't selected until it moves
+func (h *hoverTracker) reset() {
+	h.index = -1
+	h.generation++
+}
+
+// dwellElapsed reports whether the pointer stayed on the row since the dwell
+// identified by token started
+func (h *hoverTracker) dwellElapsed(token int) bool {
+	return h.enabled && token != 0 && token == h.generation && h.index >= 0
+}
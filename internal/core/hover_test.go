@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestHoverTrackerDisabled(t *testing.T) {
+	h := newHoverTracker(config.DefaultConfig.Launcher.Behavior)
+
+	if selectRow, token := h.move(2); selectRow || token != 0 {
+		t.Errorf("hover should do nothing by default, got select=%v token=%d", selectRow, token)
+	}
+}
+
+func TestHoverTrackerSelect(t *testing.T) {
+	behavior := config.DefaultConfig.Launcher.Behavior
+	behavior.ActivateOnHover = true
+	h := newHoverTracker(behavior)
+
+	if selectRow, token := h.move(1); !selectRow || token != 0 {
+		t.Errorf("hovering a row should select it without a dwell, got select=%v token=%d", selectRow, token)
+	}
+	if selectRow, _ := h.move(1); selectRow {
+		t.Error("motion within the same row should not reselect it")
+	}
+	if selectRow, _ := h.move(3); !selectRow {
+		t.Error("moving to another row should select it")
+	}
+	if selectRow, _ := h.move(-1); selectRow {
+		t.Error("moving off the rows should not select anything")
+	}
+
+	h.move(2)
+	h.reset()
+	if selectRow, _ := h.move(2); !selectRow {
+		t.Error("after the results change the hovered row should be selectable again")
+	}
+}
+
+func TestHoverTrackerDwell(t *testing.T) {
+	behavior := config.DefaultConfig.Launcher.Behavior
+	behavior.ActivateOnHover = true
+	behavior.HoverDwell = 600
+	h := newHoverTracker(behavior)
+
+	_, token := h.move(0)
+	if token == 0 {
+		t.Fatal("expected a dwell to start")
+	}
+	h.move(0)
+	if !h.dwellElapsed(token) {
+		t.Error("dwell should complete while the pointer stays on the row")
+	}
+
+	_, token = h.move(1)
+	h.move(2)
+	if h.dwellElapsed(token) {
+		t.Error("moving to another row should cancel the dwell")
+	}
+
+	_, token = h.move(3)
+	h.leave()
+	if h.dwellElapsed(token) {
+		t.Error("leaving the list should cancel the dwell")
+	}
+
+	_, token = h.move(4)
+	h.reset()
+	if h.dwellElapsed(token) {
+		t.Error("new results should cancel the dwell")
+	}
+}
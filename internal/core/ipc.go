@@ -1,8 +1,11 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
@@ -11,6 +14,8 @@ import (
 	"time"
 
 	"github.com/chess10kp/locus/internal/config"
+	"github.com/chess10kp/locus/internal/launcher"
+	"github.com/chess10kp/locus/internal/version"
 	"github.com/gotk3/gotk3/glib"
 )
 
@@ -134,14 +139,8 @@ func (s *IPCServer) handleConnection(ctx context.Context, conn *net.UnixConn) {
 
 	// Read message in goroutine with timeout
 	go func() {
-		buf := make([]byte, 1024)
-		n, err := conn.Read(buf)
-		if err != nil {
-			resultCh <- result{err: err}
-			return
-		}
-		message := strings.TrimSpace(string(buf[:n]))
-		resultCh <- result{message: message}
+		message, err := readMessage(conn)
+		resultCh <- result{message: message, err: err}
 	}()
 
 	select {
@@ -151,6 +150,22 @@ func (s *IPCServer) handleConnection(ctx context.Context, conn *net.UnixConn) {
 			return
 		}
 		log.Printf("Received IPC message: %s", res.message)
+		if name, ok := parseModuleQuery(res.message); ok {
+			replyModuleValue(conn, name, s.moduleValue)
+			return
+		}
+		if res.message == "metrics" {
+			s.replyMetrics(conn)
+			return
+		}
+		if res.message == "status" {
+			writeStatusReply(conn, s.status())
+			return
+		}
+		if params, ok := parseLauncherRequest(res.message); ok {
+			s.serveLauncherRequest(ctx, conn, params)
+			return
+		}
 		s.handleMessage(res.message)
 	case <-ctx.Done():
 		log.Printf("IPC connection handling cancelled")
@@ -158,6 +173,33 @@ func (s *IPCServer) handleConnection(ctx context.Context, conn *net.UnixConn) {
 	}
 }
 
+// maxJSONMessageSize bounds a JSON request, which may carry many dmenu
+// options
+const maxJSONMessageSize = 16 << 20
+
+// readMessage reads one IPC message. Plain messages arrive in a single
+// write; a JSON request is read on until the JSON value is complete, since
+// large ones span several reads.
+func readMessage(r io.Reader) (string, error) {
+	buf := make([]byte, 4096)
+	n, err := r.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	data := bytes.TrimSpace(buf[:n])
+
+	if len(data) > 0 && data[0] == '{' && !json.Valid(data) {
+		var raw json.RawMessage
+		rest := io.LimitReader(r, maxJSONMessageSize)
+		if err := json.NewDecoder(io.MultiReader(bytes.NewReader(data), rest)).Decode(&raw); err != nil {
+			return "", fmt.Errorf("incomplete JSON message: %w", err)
+		}
+		data = raw
+	}
+
+	return string(data), nil
+}
+
 func (s *IPCServer) handleMessage(message string) {
 	if message == "launcher" {
 		log.Printf("[IPC] Handling launcher message - app=%v", s.app != nil)
@@ -227,6 +269,17 @@ func (s *IPCServer) handleMessage(message string) {
 				log.Printf("Status message: %s", statusMsg)
 			}
 		})
+	} else if message == "launcher:hooks" {
+		// Debug: report which hooks handled the last launcher events
+		if s.app.launcher != nil && s.app.launcher.registry != nil {
+			log.Printf("[IPC] %s", s.app.launcher.registry.GetHookRegistry().DebugSummary())
+		}
+	} else if query, ok := parseLauncherQuery(message); ok {
+		glib.IdleAdd(func() {
+			if err := s.app.PresentLauncherWithQuery(query); err != nil {
+				log.Printf("Failed to show launcher with query: %v", err)
+			}
+		})
 	} else if strings.HasPrefix(message, "launcher:refresh:") {
 		// Handle launcher refresh requests
 		launcherName := strings.TrimPrefix(message, "launcher:refresh:")
@@ -240,6 +293,200 @@ func (s *IPCServer) handleMessage(message string) {
 	}
 }
 
+// moduleValueReply is the JSON reply to a query_module message
+type moduleValueReply struct {
+	Module string `json:"module"`
+	Value  string `json:"value"`
+	Error  string `json:"error,omitempty"`
+}
+
+// parseModuleQuery extracts the module name from "query_module:<name>"
+func parseModuleQuery(message string) (string, bool) {
+	if !strings.HasPrefix(message, "query_module:") {
+		return "", false
+	}
+	name := strings.TrimSpace(strings.TrimPrefix(message, "query_module:"))
+	return name, name != ""
+}
+
+// replyModuleValue writes the value of module name, read with lookup, to
+// conn as a single line of JSON
+func replyModuleValue(conn net.Conn, name string, lookup func(string) (string, error)) {
+	reply := moduleValueReply{Module: name}
+	if value, err := lookup(name); err != nil {
+		reply.Error = err.Error()
+	} else {
+		reply.Value = value
+	}
+
+	data, err := json.Marshal(reply)
+	if err != nil {
+		log.Printf("Failed to encode module value: %v", err)
+		return
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		log.Printf("Failed to write module value: %v", err)
+	}
+}
+
+func (s *IPCServer) moduleValue(name string) (string, error) {
+	if s.app == nil || s.app.statusBar == nil {
+		return "", fmt.Errorf("status bar is not running")
+	}
+	return s.app.statusBar.ModuleValue(name)
+}
+
+// replyMetrics writes the launcher's search metrics to conn in the
+// Prometheus text format
+func (s *IPCServer) replyMetrics(conn net.Conn) {
+	var metrics *launcher.SearchMetrics
+	if s.app != nil && s.app.launcher != nil && s.app.launcher.registry != nil {
+		metrics = s.app.launcher.registry.Metrics()
+	}
+	if metrics == nil {
+		conn.Write([]byte("# search metrics are disabled, set launcher.search.metrics = true\n"))
+		return
+	}
+	if err := metrics.WritePrometheus(conn); err != nil {
+		log.Printf("Failed to write search metrics: %v", err)
+	}
+}
+
+// statusReply is the JSON reply to a status message: the daemon's build and
+// which of its parts are running
+type statusReply struct {
+	version.Info
+	StatusBar     bool `json:"statusbar"`
+	Launcher      bool `json:"launcher"`
+	Notifications bool `json:"notifications"`
+}
+
+func (s *IPCServer) status() statusReply {
+	reply := statusReply{Info: version.Get()}
+	if s.app != nil {
+		reply.StatusBar = s.app.statusBar != nil
+		reply.Launcher = s.app.launcher != nil
+		reply.Notifications = s.app.notificationMgr != nil
+	}
+	return reply
+}
+
+// writeStatusReply writes reply to conn as a single line of JSON
+func writeStatusReply(conn net.Conn, reply statusReply) {
+	data, err := json.Marshal(reply)
+	if err != nil {
+		log.Printf("Failed to encode status: %v", err)
+		return
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		log.Printf("Failed to write status: %v", err)
+	}
+}
+
+// launcherRequest is the JSON form of the launcher command, which carries
+// options for that showing, e.g.
+//
+//	{"command": "launcher", "params": {"prompt": "Pick a file:", "multi_select": true}}
+type launcherRequest struct {
+	Command string                     `json:"command"`
+	Params  *launcher.InvocationParams `json:"params"`
+}
+
+// launcherReply is the JSON reply to a launcher request: the chosen lines,
+// newline-joined as dmenu prints them, or cancelled if none were chosen
+type launcherReply struct {
+	Selection string `json:"selection"`
+	Cancelled bool   `json:"cancelled,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// launcherReplyFor builds the reply for the lines an invocation returned,
+// nil meaning the launcher was dismissed
+func launcherReplyFor(lines []string) launcherReply {
+	if lines == nil {
+		return launcherReply{Cancelled: true}
+	}
+	return launcherReply{Selection: launcher.SelectionPayload(lines)}
+}
+
+// serveLauncherRequest shows the launcher for a JSON launcher request. The
+// reply is sent once the user picks lines or dismisses the launcher, so
+// dmenu-style callers can block on it.
+func (s *IPCServer) serveLauncherRequest(ctx context.Context, conn net.Conn, params *launcher.InvocationParams) {
+	replies := make(chan launcherReply, 1)
+	glib.IdleAdd(func() {
+		err := s.app.PresentLauncherWithParams(params, func(lines []string) {
+			replies <- launcherReplyFor(lines)
+		})
+		if err != nil {
+			log.Printf("Failed to show launcher with params: %v", err)
+			replies <- launcherReply{Cancelled: true, Error: err.Error()}
+		}
+	})
+
+	select {
+	case reply := <-replies:
+		writeLauncherReply(conn, reply)
+	case <-ctx.Done():
+	}
+}
+
+// writeLauncherReply writes reply to conn as a single line of JSON
+func writeLauncherReply(conn net.Conn, reply launcherReply) {
+	data, err := json.Marshal(reply)
+	if err != nil {
+		log.Printf("Failed to encode launcher reply: %v", err)
+		return
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		log.Printf("Failed to write launcher reply: %v", err)
+	}
+}
+
+// parseLauncherRequest decodes a JSON launcher command, returning its params
+func parseLauncherRequest(message string) (*launcher.InvocationParams, bool) {
+	if !strings.HasPrefix(message, "{") {
+		return nil, false
+	}
+
+	var req launcherRequest
+	if err := json.Unmarshal([]byte(message), &req); err != nil {
+		log.Printf("[IPC] Invalid JSON message: %v", err)
+		return nil, false
+	}
+	if req.Command != "launcher" {
+		return nil, false
+	}
+	if req.Params == nil {
+		req.Params = &launcher.InvocationParams{}
+	}
+	return req.Params, true
+}
+
+// parseLauncherQuery extracts the query to prefill from "launcher:<query>",
+// "launcher <query>" and ">command" messages. Query text keeps its launcher
+// prefix, so "launcher >reboot" opens the launcher in command mode.
+func parseLauncherQuery(message string) (string, bool) {
+	switch {
+	case strings.HasPrefix(message, "launcher:"):
+		query := strings.TrimPrefix(message, "launcher:")
+		if query == "" || query == "resume" || query == "fresh" || query == "hooks" ||
+			strings.HasPrefix(query, "refresh:") || strings.HasPrefix(query, "dmenu") {
+			return "", false
+		}
+		return query, true
+	case strings.HasPrefix(message, "launcher "):
+		query := strings.TrimSpace(strings.TrimPrefix(message, "launcher "))
+		if query == "" || strings.HasPrefix(query, "dmenu:") {
+			return "", false
+		}
+		return query, true
+	case strings.HasPrefix(message, ">"):
+		return message, true
+	}
+	return "", false
+}
+
 func (s *IPCServer) Stop() error {
 	if !s.running {
 		return nil
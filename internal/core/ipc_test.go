@@ -0,0 +1,222 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/chess10kp/locus/internal/version"
+)
+
+func TestParseLauncherQuery(t *testing.T) {
+	tests := []struct {
+		message string
+		query   string
+		ok      bool
+	}{
+		{"launcher:firefox", "firefox", true},
+		{"launcher firefox", "firefox", true},
+		{"launcher >reboot", ">reboot", true},
+		{">reboot", ">reboot", true},
+		{"launcher:calc 2+2", "calc 2+2", true},
+		{"launcher", "", false},
+		{"launcher:", "", false},
+		{"launcher:resume", "", false},
+		{"launcher:fresh", "", false},
+		{"launcher:hooks", "", false},
+		{"launcher:refresh:apps", "", false},
+		{"launcher dmenu:a|b", "", false},
+		{"lock", "", false},
+	}
+
+	for _, tt := range tests {
+		query, ok := parseLauncherQuery(tt.message)
+		if ok != tt.ok || query != tt.query {
+			t.Errorf("parseLauncherQuery(%q) = (%q, %v), want (%q, %v)", tt.message, query, ok, tt.query, tt.ok)
+		}
+	}
+}
+
+func TestParseModuleQuery(t *testing.T) {
+	tests := []struct {
+		message string
+		name    string
+		ok      bool
+	}{
+		{"query_module:battery", "battery", true},
+		{"query_module: workspaces ", "workspaces", true},
+		{"query_module:", "", false},
+		{"statusbar:battery", "", false},
+	}
+
+	for _, tt := range tests {
+		name, ok := parseModuleQuery(tt.message)
+		if ok != tt.ok || name != tt.name {
+			t.Errorf("parseModuleQuery(%q) = (%q, %v), want (%q, %v)", tt.message, name, ok, tt.name, tt.ok)
+		}
+	}
+}
+
+func TestReplyModuleValue(t *testing.T) {
+	values := map[string]string{"battery": "87", "workspaces": "2"}
+	lookup := func(name string) (string, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+		return "", errors.New("module '" + name + "' not found")
+	}
+
+	tests := []struct {
+		name string
+		want moduleValueReply
+	}{
+		{"battery", moduleValueReply{Module: "battery", Value: "87"}},
+		{"workspaces", moduleValueReply{Module: "workspaces", Value: "2"}},
+		{"missing", moduleValueReply{Module: "missing", Error: "module 'missing' not found"}},
+	}
+
+	for _, tt := range tests {
+		server, client := net.Pipe()
+		go func() {
+			defer server.Close()
+			replyModuleValue(server, tt.name, lookup)
+		}()
+
+		line, err := bufio.NewReader(client).ReadBytes('\n')
+		client.Close()
+		if err != nil {
+			t.Fatalf("reading reply for %q: %v", tt.name, err)
+		}
+
+		var got moduleValueReply
+		if err := json.Unmarshal(line, &got); err != nil {
+			t.Fatalf("decoding reply %q: %v", line, err)
+		}
+		if got != tt.want {
+			t.Errorf("reply for %q = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseLauncherRequest(t *testing.T) {
+	params, ok := parseLauncherRequest(`{"command":"launcher","params":{"prompt":"Pick:","initial_query":"doc","case_sensitive":true,"multi_select":true}}`)
+	if !ok {
+		t.Fatal("expected a launcher request")
+	}
+	if params.Prompt != "Pick:" || params.InitialQuery != "doc" || !params.MultiSelect {
+		t.Errorf("unexpected params %+v", params)
+	}
+	if params.CaseSensitive == nil || !*params.CaseSensitive {
+		t.Errorf("expected case_sensitive to be set, got %v", params.CaseSensitive)
+	}
+
+	params, ok = parseLauncherRequest(`{"command":"launcher"}`)
+	if !ok || params == nil || params.CaseSensitive != nil {
+		t.Errorf("expected empty params for a bare request, got %+v, %v", params, ok)
+	}
+
+	for _, message := range []string{`{"command":"lock"}`, `{"command":`, "launcher", "launcher:{}"} {
+		if _, ok := parseLauncherRequest(message); ok {
+			t.Errorf("parseLauncherRequest(%q) should not match", message)
+		}
+	}
+}
+
+func TestLauncherReply(t *testing.T) {
+	tests := []struct {
+		lines []string
+		want  launcherReply
+	}{
+		{[]string{"a.txt", "b.txt"}, launcherReply{Selection: "a.txt\nb.txt"}},
+		{[]string{"a.txt"}, launcherReply{Selection: "a.txt"}},
+		{nil, launcherReply{Cancelled: true}},
+	}
+
+	for _, tt := range tests {
+		server, client := net.Pipe()
+		go func() {
+			defer server.Close()
+			writeLauncherReply(server, launcherReplyFor(tt.lines))
+		}()
+
+		line, err := bufio.NewReader(client).ReadBytes('\n')
+		client.Close()
+		if err != nil {
+			t.Fatalf("reading reply for %v: %v", tt.lines, err)
+		}
+
+		var got launcherReply
+		if err := json.Unmarshal(line, &got); err != nil {
+			t.Fatalf("decoding reply %q: %v", line, err)
+		}
+		if got != tt.want {
+			t.Errorf("reply for %v = %+v, want %+v", tt.lines, got, tt.want)
+		}
+	}
+}
+
+func TestReadMessage(t *testing.T) {
+	message, err := readMessage(strings.NewReader("launcher\n"))
+	if err != nil || message != "launcher" {
+		t.Errorf("expected a plain message, got %q, %v", message, err)
+	}
+
+	// A JSON request larger than one read arrives in pieces
+	options := make([]string, 2000)
+	for i := range options {
+		options[i] = fmt.Sprintf("option %d", i)
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"command": "launcher",
+		"params":  map[string]interface{}{"options": options},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err = readMessage(iotest.HalfReader(bytes.NewReader(append(data, '\n'))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, ok := parseLauncherRequest(message)
+	if !ok || len(params.Options) != len(options) {
+		t.Fatalf("expected all %d options, got %v", len(options), ok)
+	}
+
+	if _, err := readMessage(strings.NewReader(`{"command":"launcher","params":`)); err == nil {
+		t.Error("expected a truncated JSON request to fail")
+	}
+}
+
+func TestStatusReply(t *testing.T) {
+	defer func(v, c string) { version.Version, version.Commit = v, c }(version.Version, version.Commit)
+	version.Version, version.Commit = "2.0.0-test", "feedface"
+
+	server, client := net.Pipe()
+	go func() {
+		defer server.Close()
+		writeStatusReply(server, (&IPCServer{}).status())
+	}()
+
+	line, err := bufio.NewReader(client).ReadBytes('\n')
+	client.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(line, &got); err != nil {
+		t.Fatalf("decoding status %q: %v", line, err)
+	}
+	if got["version"] != "2.0.0-test" || got["commit"] != "feedface" {
+		t.Errorf("expected the build version in the status, got %s", line)
+	}
+	if got["launcher"] != false || got["statusbar"] != false {
+		t.Errorf("expected no running parts without an app, got %s", line)
+	}
+}
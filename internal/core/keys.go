@@ -0,0 +1,309 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// keyModifier is a set of modifier keys held with a key press
+type keyModifier uint
+
+const (
+	modShift keyModifier = 1 << iota
+	modCtrl
+	modAlt
+	modSuper
+)
+
+var modifierNames = map[string]keyModifier{
+	"shift":   modShift,
+	"ctrl":    modCtrl,
+	"control": modCtrl,
+	"alt":     modAlt,
+	"mod1":    modAlt,
+	"super":   modSuper,
+	"mod4":    modSuper,
+	"logo":    modSuper,
+}
+
+// keyBinding is a parsed key string such as "Ctrl+Shift+1"
+type keyBinding struct {
+	mods keyModifier
+	key  string
+}
+
+// parseKeyBinding parses a key string of "+"-separated modifiers followed by
+// a key name. Modifier names are case-insensitive.
+func parseKeyBinding(s string) (keyBinding, error) {
+	parts := strings.Split(strings.TrimSpace(s), "+")
+	key := strings.TrimSpace(parts[len(parts)-1])
+	if key == "" {
+		return keyBinding{}, fmt.Errorf("invalid key binding %q: missing key", s)
+	}
+
+	var mods keyModifier
+	for _, part := range parts[:len(parts)-1] {
+		mod, ok := modifierNames[strings.ToLower(strings.TrimSpace(part))]
+		if !ok {
+			return keyBinding{}, fmt.Errorf("invalid key binding %q: unknown modifier %q", s, part)
+		}
+		mods |= mod
+	}
+
+	return keyBinding{mods: mods, key: normalizeKeyName(key)}, nil
+}
+
+// normalizeKeyName lowercases single-letter keys, since GDK reports the
+// shifted letter when Shift is held
+func normalizeKeyName(key string) string {
+	if len(key) == 1 {
+		return strings.ToLower(key)
+	}
+	return key
+}
+
+// numberKeys maps bindings like "Alt+3" to the number they select
+type numberKeys map[keyBinding]int
+
+// parseNumberKeys parses bindings whose key is a digit from 1 to 9
+func parseNumberKeys(bindings []string) (numberKeys, error) {
+	keys := make(numberKeys, len(bindings))
+	for _, s := range bindings {
+		binding, err := parseKeyBinding(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(binding.key) != 1 || binding.key[0] < '1' || binding.key[0] > '9' {
+			return nil, fmt.Errorf("invalid key binding %q: key must be a digit from 1 to 9", s)
+		}
+		keys[binding] = int(binding.key[0] - '0')
+	}
+	return keys, nil
+}
+
+// lookup returns the number bound to key pressed with exactly mods held
+func (n numberKeys) lookup(mods keyModifier, key string) (int, bool) {
+	number, ok := n[keyBinding{mods: mods, key: key}]
+	return number, ok
+}
+
+// numberKeysOrDefault parses bindings, falling back to fallback when none
+// are set or (reporting the error) when they are invalid
+func numberKeysOrDefault(bindings, fallback []string) (numberKeys, error) {
+	keys, err := parseNumberKeys(bindings)
+	if err == nil && len(keys) > 0 {
+		return keys, nil
+	}
+	defaults, _ := parseNumberKeys(fallback)
+	return defaults, err
+}
+
+// keySet is a set of key bindings that trigger the same command
+type keySet map[keyBinding]bool
+
+// parseKeySet parses bindings for a single command
+func parseKeySet(bindings []string) (keySet, error) {
+	keys := make(keySet, len(bindings))
+	for _, s := range bindings {
+		binding, err := parseKeyBinding(s)
+		if err != nil {
+			return nil, err
+		}
+		keys[binding] = true
+	}
+	return keys, nil
+}
+
+// has reports whether key pressed with exactly mods held is in the set
+func (k keySet) has(mods keyModifier, key string) bool {
+	return k[keyBinding{mods: mods, key: normalizeKeyName(key)}]
+}
+
+// keySetOrDefault parses bindings, falling back to fallback when none are
+// set or (reporting the error) when they are invalid
+func keySetOrDefault(bindings, fallback []string) (keySet, error) {
+	keys, err := parseKeySet(bindings)
+	if err == nil && len(keys) > 0 {
+		return keys, nil
+	}
+	if err == nil {
+		return parseKeySet(fallback)
+	}
+	defaults, _ := parseKeySet(fallback)
+	return defaults, err
+}
+
+// navigationKeys are the configured bindings for moving through and
+// acting on results
+type navigationKeys struct {
+	up, down, activate, close, tabComplete keySet
+}
+
+// parseNavigationKeys parses keys, falling back to the defaults for each
+// command whose bindings are invalid
+func parseNavigationKeys(keys, defaults config.KeysConfig) navigationKeys {
+	parse := func(name string, bindings, fallback []string) keySet {
+		set, err := keySetOrDefault(bindings, fallback)
+		if err != nil {
+			log.Printf("[LAUNCHER] %v, using the default %s keys", err, name)
+		}
+		return set
+	}
+
+	return navigationKeys{
+		up:          parse("up", keys.Up, defaults.Up),
+		down:        parse("down", keys.Down, defaults.Down),
+		activate:    parse("activate", keys.Activate, defaults.Activate),
+		close:       parse("close", keys.Close, defaults.Close),
+		tabComplete: parse("tab_complete", keys.TabComplete, defaults.TabComplete),
+	}
+}
+
+// recallsHistory reports whether an Up/Down press steps through query
+// history rather than the results. Plain arrows only recall when there are
+// no results to navigate and the entry is empty or still shows a recalled
+// query; the dedicated history keys always recall.
+func recallsHistory(dedicated bool, resultCount int, text string, recalling bool) bool {
+	if dedicated {
+		return true
+	}
+	return resultCount == 0 && (text == "" || recalling)
+}
+
+// modifierLabels orders modifiers as they are shown in key labels
+var modifierLabels = []struct {
+	mod   keyModifier
+	label string
+}{
+	{modCtrl, "Ctrl"},
+	{modAlt, "Alt"},
+	{modShift, "Shift"},
+	{modSuper, "Super"},
+}
+
+// keyNameLabels shortens GDK key names for display
+var keyNameLabels = map[string]string{
+	"Up":       "↑",
+	"Down":     "↓",
+	"Left":     "←",
+	"Right":    "→",
+	"KP_Enter": "Enter",
+	"Escape":   "Esc",
+}
+
+// label formats the binding for display, e.g. "Ctrl+J"
+func (b keyBinding) label() string {
+	var parts []string
+	for _, m := range modifierLabels {
+		if b.mods&m.mod != 0 {
+			parts = append(parts, m.label)
+		}
+	}
+
+	key := b.key
+	if short, ok := keyNameLabels[key]; ok {
+		key = short
+	} else if len(key) == 1 {
+		key = strings.ToUpper(key)
+	}
+	return strings.Join(append(parts, key), "+")
+}
+
+// bindingLabels formats bindings for display, skipping invalid ones and
+// those whose key is the arrow a badge is already named after
+func bindingLabels(bindings []string, skipKey string) []string {
+	var labels []string
+	for _, s := range bindings {
+		binding, err := parseKeyBinding(s)
+		if err != nil || (binding.mods == 0 && binding.key == skipKey) {
+			continue
+		}
+		labels = append(labels, binding.label())
+	}
+	return labels
+}
+
+// effectiveBindings returns the bindings keySetOrDefault ends up using:
+// fallback when bindings are unset or invalid
+func effectiveBindings(bindings, fallback []string) []string {
+	if keys, err := parseKeySet(bindings); err != nil || len(keys) == 0 {
+		return fallback
+	}
+	return bindings
+}
+
+// shortcutBadges returns the key hints shown under the search entry, taken
+// from the bindings in effect
+func shortcutBadges(keys config.KeysConfig) []string {
+	defaults := config.DefaultConfig.Launcher.Keys
+	var badges []string
+	if labels := bindingLabels(effectiveBindings(keys.Activate, defaults.Activate), ""); len(labels) > 0 {
+		badges = append(badges, "Select: "+labels[0])
+	}
+	if labels := bindingLabels(effectiveBindings(keys.Down, defaults.Down), "Down"); len(labels) > 0 {
+		badges = append(badges, "↓: "+strings.Join(labels, " / "))
+	}
+	if labels := bindingLabels(effectiveBindings(keys.Up, defaults.Up), "Up"); len(labels) > 0 {
+		badges = append(badges, "↑: "+strings.Join(labels, " / "))
+	}
+	return badges
+}
+
+// hintCount returns how many results show their quick-select number:
+// hint_count, the default when it is unset, or none when it is negative
+func hintCount(keys config.KeysConfig) int {
+	switch {
+	case keys.HintCount < 0:
+		return 0
+	case keys.HintCount == 0:
+		return config.DefaultConfig.Launcher.Keys.HintCount
+	}
+	return keys.HintCount
+}
+
+// hintLabel returns the number hint shown on the result at index: only the
+// first count results that a quick-select key actually reaches get one
+func (n numberKeys) hintLabel(index, count int) (string, bool) {
+	if index < 0 || index >= count {
+		return "", false
+	}
+	number := index + 1
+	for _, bound := range n {
+		if bound == number {
+			return strconv.Itoa(number), true
+		}
+	}
+	return "", false
+}
+
+// actionHints describes an item's number actions for the hint row by the
+// key bound to each number, e.g. "Ctrl+1 Copy  Ctrl+2 Type". Actions with no
+// key bound are left out.
+func (n numberKeys) actionHints(labels map[int]string) string {
+	bound := make(map[int]string, len(n))
+	for binding, number := range n {
+		label := binding.label()
+		if prev, ok := bound[number]; !ok || label < prev {
+			bound[number] = label
+		}
+	}
+
+	numbers := make([]int, 0, len(labels))
+	for number := range labels {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	var hints []string
+	for _, number := range numbers {
+		if key, ok := bound[number]; ok {
+			hints = append(hints, key+" "+labels[number])
+		}
+	}
+	return strings.Join(hints, "  ")
+}
@@ -0,0 +1,232 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestParseKeyBinding(t *testing.T) {
+	tests := []struct {
+		input string
+		want  keyBinding
+	}{
+		{"Alt+1", keyBinding{modAlt, "1"}},
+		{"ctrl+2", keyBinding{modCtrl, "2"}},
+		{"Control+Shift+3", keyBinding{modCtrl | modShift, "3"}},
+		{"Super+4", keyBinding{modSuper, "4"}},
+		{"Mod4+5", keyBinding{modSuper, "5"}},
+		{"Mod1 + 6", keyBinding{modAlt, "6"}},
+		{"Escape", keyBinding{0, "Escape"}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseKeyBinding(tt.input)
+		if err != nil {
+			t.Errorf("parseKeyBinding(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseKeyBinding(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "Alt+", "Hyper+1", "Ctrl++"} {
+		if _, err := parseKeyBinding(input); err == nil {
+			t.Errorf("parseKeyBinding(%q) expected an error", input)
+		}
+	}
+}
+
+func TestParseNumberKeys(t *testing.T) {
+	keys, err := parseNumberKeys(config.DefaultConfig.Launcher.Keys.QuickSelect)
+	if err != nil {
+		t.Fatalf("default quick_select keys failed to parse: %v", err)
+	}
+	if len(keys) != 9 {
+		t.Errorf("expected 9 quick-select keys, got %d", len(keys))
+	}
+
+	for _, bad := range [][]string{{"Alt+0"}, {"Alt+a"}, {"Alt+10"}, {"Meta+1"}} {
+		if _, err := parseNumberKeys(bad); err == nil {
+			t.Errorf("parseNumberKeys(%v) expected an error", bad)
+		}
+	}
+}
+
+func TestNumberKeysDispatch(t *testing.T) {
+	quickSelect, _ := parseNumberKeys([]string{"Super+1", "Super+2"})
+	actions, _ := parseNumberKeys(config.DefaultConfig.Launcher.Keys.NumberAction)
+
+	if number, ok := quickSelect.lookup(modSuper, "2"); !ok || number != 2 {
+		t.Errorf("Super+2 = %d, %v; want quick select 2", number, ok)
+	}
+	if _, ok := quickSelect.lookup(modAlt, "1"); ok {
+		t.Error("Alt+1 should not quick select once the modifier is changed")
+	}
+	if _, ok := quickSelect.lookup(modSuper|modShift, "1"); ok {
+		t.Error("extra modifiers should not match")
+	}
+	if _, ok := quickSelect.lookup(modSuper, "3"); ok {
+		t.Error("unbound number should not match")
+	}
+
+	if number, ok := actions.lookup(modCtrl, "7"); !ok || number != 7 {
+		t.Errorf("Ctrl+7 = %d, %v; want action 7", number, ok)
+	}
+	if _, ok := actions.lookup(modSuper, "1"); ok {
+		t.Error("quick-select key should not run a number action")
+	}
+}
+
+func TestNumberKeysOrDefault(t *testing.T) {
+	defaults := config.DefaultConfig.Launcher.Keys.QuickSelect
+
+	keys, err := numberKeysOrDefault([]string{"Hyper+1"}, defaults)
+	if err == nil {
+		t.Error("expected the invalid binding to be reported")
+	}
+	if _, ok := keys.lookup(modAlt, "1"); !ok {
+		t.Error("invalid bindings should fall back to the defaults")
+	}
+
+	keys, err = numberKeysOrDefault([]string{}, defaults)
+	if err != nil {
+		t.Errorf("an empty list should not be an error, got %v", err)
+	}
+	if _, ok := keys.lookup(modAlt, "1"); !ok {
+		t.Error("an empty list should fall back to the defaults")
+	}
+}
+
+func TestParseKeySet(t *testing.T) {
+	keys, err := parseKeySet(config.DefaultConfig.Launcher.Keys.HistoryPrevious)
+	if err != nil {
+		t.Fatalf("default history_previous keys failed to parse: %v", err)
+	}
+	if !keys.has(modAlt, "Up") {
+		t.Error("Alt+Up should step back through query history")
+	}
+	if keys.has(0, "Up") {
+		t.Error("plain Up should not be a history key")
+	}
+
+	keys, err = keySetOrDefault([]string{"Hyper+Up"}, []string{"Ctrl+Up"})
+	if err == nil {
+		t.Error("expected an error for an unknown modifier")
+	}
+	if !keys.has(modCtrl, "Up") {
+		t.Error("expected the fallback keys after an error")
+	}
+}
+
+func TestRecallsHistory(t *testing.T) {
+	tests := []struct {
+		name      string
+		dedicated bool
+		results   int
+		text      string
+		recalling bool
+		want      bool
+	}{
+		{"empty input and no results", false, 0, "", false, true},
+		{"results to navigate", false, 3, "", false, false},
+		{"typed query without results", false, 0, "fir", false, false},
+		{"recalled query without results", false, 0, "firefox", true, true},
+		{"recalled query with results", false, 2, "firefox", true, false},
+		{"dedicated key with results", true, 5, "fir", false, true},
+	}
+
+	for _, tt := range tests {
+		if got := recallsHistory(tt.dedicated, tt.results, tt.text, tt.recalling); got != tt.want {
+			t.Errorf("%s: recallsHistory() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestShortcutBadges(t *testing.T) {
+	got := shortcutBadges(config.DefaultConfig.Launcher.Keys)
+	want := []string{"Select: Return", "↓: Ctrl+N / Ctrl+J", "↑: Ctrl+P / Ctrl+K"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("default badges = %q, want %q", got, want)
+	}
+
+	remapped := config.KeysConfig{
+		Activate: []string{"KP_Enter", "Return"},
+		Down:     []string{"Down", "alt+shift+j"},
+		Up:       []string{"Up"},
+	}
+	got = shortcutBadges(remapped)
+	want = []string{"Select: Enter", "↓: Alt+Shift+J"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("remapped badges = %q, want %q", got, want)
+	}
+
+	// Unset and invalid bindings show the defaults they fall back to
+	if got := shortcutBadges(config.KeysConfig{Activate: []string{"Hyper+x"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("fallback badges = %q, want %q", got, want)
+	}
+}
+
+func TestHintLabel(t *testing.T) {
+	keys, _ := parseNumberKeys(config.DefaultConfig.Launcher.Keys.QuickSelect)
+	for index, want := range []string{"1", "2", "3"} {
+		if got, ok := keys.hintLabel(index, 3); !ok || got != want {
+			t.Errorf("hintLabel(%d, 3) = %q, %v; want %q", index, got, ok, want)
+		}
+	}
+	if _, ok := keys.hintLabel(3, 3); ok {
+		t.Error("results past hint_count should not be labelled")
+	}
+	if _, ok := keys.hintLabel(0, 0); ok {
+		t.Error("a zero hint_count should hide the hints")
+	}
+
+	if got := hintCount(config.KeysConfig{}); got != config.DefaultConfig.Launcher.Keys.HintCount {
+		t.Errorf("unset hint_count = %d, want the default", got)
+	}
+	if got := hintCount(config.KeysConfig{HintCount: -1}); got != 0 {
+		t.Errorf("negative hint_count = %d, want 0", got)
+	}
+
+	sparse, _ := parseNumberKeys([]string{"Alt+1", "Alt+3"})
+	if _, ok := sparse.hintLabel(1, 9); ok {
+		t.Error("a number without a quick-select key should not be labelled")
+	}
+	if got, ok := sparse.hintLabel(2, 9); !ok || got != "3" {
+		t.Errorf("hintLabel(2, 9) = %q, %v; want 3", got, ok)
+	}
+}
+
+func TestActionHints(t *testing.T) {
+	keys, _ := parseNumberKeys([]string{"Ctrl+1", "Ctrl+2", "Ctrl+3"})
+	labels := map[int]string{2: "Type", 1: "Copy", 4: "Delete"}
+	if got, want := keys.actionHints(labels), "Ctrl+1 Copy  Ctrl+2 Type"; got != want {
+		t.Errorf("actionHints() = %q, want %q", got, want)
+	}
+	if got := keys.actionHints(nil); got != "" {
+		t.Errorf("expected no hint without actions, got %q", got)
+	}
+}
+
+func TestParseNavigationKeys(t *testing.T) {
+	defaults := config.DefaultConfig.Launcher.Keys
+	keys := parseNavigationKeys(config.KeysConfig{Down: []string{"Ctrl+D"}, Up: []string{"Meta+U"}}, defaults)
+
+	if !keys.down.has(modCtrl, "d") || keys.down.has(modCtrl, "j") {
+		t.Error("down should follow the configured keys")
+	}
+	if !keys.up.has(modCtrl, "p") || !keys.up.has(0, "Up") {
+		t.Error("invalid up keys should fall back to the defaults")
+	}
+	if !keys.close.has(0, "Escape") || !keys.activate.has(0, "KP_Enter") {
+		t.Error("unset keys should fall back to the defaults")
+	}
+	if !keys.tabComplete.has(modCtrl, "l") {
+		t.Error("Ctrl+L should match the lowercase key GDK reports")
+	}
+	if keys.tabComplete.has(modCtrl|modShift, "L") {
+		t.Error("Ctrl+Shift+L should not match Ctrl+L")
+	}
+}
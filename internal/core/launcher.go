@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/chess10kp/locus/internal/config"
@@ -24,6 +26,9 @@ import (
 
 var debugLogger = log.New(log.Writer(), "[LAUNCHER-DEBUG] ", log.LstdFlags|log.Lmicroseconds)
 
+// asyncHookTimeout bounds how long async select hooks may run
+const asyncHookTimeout = 5 * time.Second
+
 func easeOutCubic(t float64) float64 {
 	return 1 - (1-t)*(1-t)*(1-t)
 }
@@ -60,15 +65,32 @@ type Launcher struct {
 	currentItems       []*launcher.LauncherItem
 	scrolledWindow     *gtk.ScrolledWindow
 	badgesBox          *gtk.Box
+	actionHintLabel    *gtk.Label
 	footerBox          *gtk.Box
 	footerLabel        *gtk.Label
 	running            bool
 	visible            atomic.Bool
 	searchTimer        *time.Timer
-	searchVersion      int64 // Track search version to prevent race conditions
+	searchVersion      int64              // Track search version to prevent race conditions
+	searchCancel       context.CancelFunc // cancels the in-flight search when searchVersion is bumped
 	gridMode           bool
 	colorPreviewBox    *gtk.Box
 	colorPreviewWidget *gtk.Box
+	preview            *previewPane
+	hover              *hoverTracker
+	keepQuery          bool // keep the search text when next shown
+	quickSelectKeys    numberKeys
+	numberActionKeys   numberKeys
+	navKeys            navigationKeys
+	historyPrevKeys    keySet
+	historyNextKeys    keySet
+	togglePreviewKeys  keySet
+	queryHistory       *launcher.QueryHistory // nil when query_history_size is 0
+	selection          launcher.Selection     // rows picked in multi-select mode
+	invocationDone     func(lines []string)   // answers the IPC invocation; nil lines cancel it
+	animating          bool                   // the window is sliding in or out
+	focusedOutput      *launcher.Output       // the WM's focused output when last asked
+	fetchingOutput     bool                   // a focused output query is running
 
 	mu            sync.RWMutex
 	refreshUIChan chan launcher.RefreshUIRequest
@@ -112,7 +134,7 @@ func NewLauncher(app *App, cfg *config.Config) (*Launcher, error) {
 		return nil, fmt.Errorf("failed to create search entry: %w", err)
 	}
 
-	searchEntry.SetPlaceholderText("Search or type a command...")
+	searchEntry.SetPlaceholderText(launcher.DefaultPrompt)
 	searchEntry.SetName("launcher-entry")
 
 	// Create horizontal box for search entry and buttons
@@ -149,7 +171,7 @@ func NewLauncher(app *App, cfg *config.Config) (*Launcher, error) {
 	scrolledWindow.SetPolicy(gtk.POLICY_AUTOMATIC, gtk.POLICY_AUTOMATIC)
 	scrolledWindow.SetVExpand(true)
 	scrolledWindow.SetHExpand(false)
-	scrolledWindow.SetMinContentHeight(5 * 44) // Minimum height for 5 results
+	scrolledWindow.SetMinContentHeight(resultsMinHeight(cfg.Launcher.Window))
 	scrolledWindow.SetSizeRequest(cfg.Launcher.Window.Width, -1)
 
 	resultList, err := gtk.ListBoxNew()
@@ -163,8 +185,22 @@ func NewLauncher(app *App, cfg *config.Config) (*Launcher, error) {
 	scrolledWindow.Add(resultList)
 	scrolledWindow.ShowAll()
 
-	// Add scrolled window to the main box
-	box.PackStart(scrolledWindow, true, true, 0)
+	// Results and the preview pane share a row
+	resultsBox, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create results box: %w", err)
+	}
+	resultsBox.PackStart(scrolledWindow, true, true, 0)
+
+	preview, err := newPreviewPane(cfg.Launcher.Preview)
+	if err != nil {
+		return nil, err
+	}
+	resultsBox.PackStart(preview.box, false, false, 0)
+	resultsBox.ShowAll()
+	preview.box.Hide()
+
+	box.PackStart(resultsBox, true, true, 0)
 
 	// Create grid flow box for grid mode
 	gridFlowBox, err := gtk.FlowBoxNew()
@@ -192,9 +228,8 @@ func NewLauncher(app *App, cfg *config.Config) (*Launcher, error) {
 	badgesBox.SetHExpand(false)
 	badgesBox.SetSizeRequest(cfg.Launcher.Window.Width, -1)
 
-	// Add keyboard shortcut hints
-	shortcuts := []string{"Select: Return", "↓: Ctrl+J", "↑: Ctrl+K"}
-	for _, shortcut := range shortcuts {
+	// Add keyboard shortcut hints for the configured bindings
+	for _, shortcut := range shortcutBadges(cfg.Launcher.Keys) {
 		label, err := gtk.LabelNew(shortcut)
 		if err != nil {
 			continue
@@ -202,6 +237,15 @@ func NewLauncher(app *App, cfg *config.Config) (*Launcher, error) {
 		label.SetName("badge-label")
 		badgesBox.PackStart(label, false, false, 0)
 	}
+
+	// The selected item's number actions, for launchers that describe them
+	actionHintLabel, err := gtk.LabelNew("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action hint label: %w", err)
+	}
+	actionHintLabel.SetName("badge-label")
+	actionHintLabel.SetEllipsize(pango.ELLIPSIZE_END)
+	badgesBox.PackEnd(actionHintLabel, false, false, 0)
 	box.PackStart(badgesBox, false, false, 4)
 
 	// Create color preview box (hidden by default)
@@ -238,14 +282,13 @@ func NewLauncher(app *App, cfg *config.Config) (*Launcher, error) {
 		iconCache = nil
 	}
 
-	// Create thumbnail cache for grid items - DISABLED to prevent image corruption
-	// thumbnailCache, err := launcher.NewThumbnailCache(100, 100)
-	// if err != nil {
-	// 	log.Printf("Failed to create thumbnail cache: %v", err)
-	// 	// Continue without cache
-	// 	thumbnailCache = nil
-	// }
-	var thumbnailCache *launcher.ThumbnailCache = nil
+	// Create thumbnail cache for grid items (keyed by content hash, stores PNG bytes)
+	thumbnailCache, err := launcher.NewThumbnailCache(100, 100)
+	if err != nil {
+		log.Printf("Failed to create thumbnail cache: %v", err)
+		// Continue without cache
+		thumbnailCache = nil
+	}
 
 	// Create channels for hook context
 	refreshUIChan := make(chan launcher.RefreshUIRequest, 1)
@@ -261,6 +304,7 @@ func NewLauncher(app *App, cfg *config.Config) (*Launcher, error) {
 		gridFlowBox:        gridFlowBox,
 		scrolledWindow:     scrolledWindow,
 		badgesBox:          badgesBox,
+		actionHintLabel:    actionHintLabel,
 		footerBox:          footerBox,
 		footerLabel:        footerLabel,
 		registry:           registry,
@@ -268,12 +312,36 @@ func NewLauncher(app *App, cfg *config.Config) (*Launcher, error) {
 		thumbnailCache:     thumbnailCache,
 		colorPreviewBox:    colorPreviewBox,
 		colorPreviewWidget: colorPreviewWidget,
+		preview:            preview,
+		hover:              newHoverTracker(cfg.Launcher.Behavior),
 		refreshUIChan:      refreshUIChan,
 		statusChan:         statusChan,
 		ctx:                ctx,
 		cancel:             cancel,
 	}
 
+	keys := cfg.Launcher.Keys
+	defaultKeys := config.DefaultConfig.Launcher.Keys
+	if l.quickSelectKeys, err = numberKeysOrDefault(keys.QuickSelect, defaultKeys.QuickSelect); err != nil {
+		log.Printf("[LAUNCHER] %v, using the default quick_select keys", err)
+	}
+	if l.numberActionKeys, err = numberKeysOrDefault(keys.NumberAction, defaultKeys.NumberAction); err != nil {
+		log.Printf("[LAUNCHER] %v, using the default number_action keys", err)
+	}
+	l.navKeys = parseNavigationKeys(keys, defaultKeys)
+	if l.historyPrevKeys, err = keySetOrDefault(keys.HistoryPrevious, defaultKeys.HistoryPrevious); err != nil {
+		log.Printf("[LAUNCHER] %v, using the default history_previous keys", err)
+	}
+	if l.historyNextKeys, err = keySetOrDefault(keys.HistoryNext, defaultKeys.HistoryNext); err != nil {
+		log.Printf("[LAUNCHER] %v, using the default history_next keys", err)
+	}
+	if l.togglePreviewKeys, err = keySetOrDefault(keys.TogglePreview, defaultKeys.TogglePreview); err != nil {
+		log.Printf("[LAUNCHER] %v, using the default toggle_preview keys", err)
+	}
+	if size := cfg.Launcher.Behavior.QueryHistorySize; size > 0 {
+		l.queryHistory = launcher.NewQueryHistory(launcher.DataDir(cfg), size)
+	}
+
 	// Start goroutines to handle channel requests
 	go l.handleRefreshUIRequests(ctx, refreshUIChan)
 	go l.handleStatusRequests(ctx, statusChan)
@@ -339,6 +407,13 @@ func (l *Launcher) setupSignals() {
 		return l.onKeyPress(keyEvent)
 	})
 
+	l.window.Connect("focus-out-event", func(w *gtk.Window, event *gdk.Event) bool {
+		if hidesOnFocusOut(l.config.Launcher.Behavior, l.visible.Load(), l.animating) {
+			l.Hide()
+		}
+		return false
+	})
+
 	l.resultList.Connect("row-activated", func(list *gtk.ListBox, row *gtk.ListBoxRow) {
 		defer func() {
 			if r := recover(); r != nil {
@@ -354,6 +429,29 @@ func (l *Launcher) setupSignals() {
 		l.onRowActivated(row)
 	})
 
+	// Selection changes while results are swapped under l.mu, so the
+	// preview is updated once that is done
+	l.resultList.Connect("row-selected", func(list *gtk.ListBox, row *gtk.ListBoxRow) {
+		glib.IdleAdd(func() bool {
+			l.updatePreview()
+			l.updateActionHint()
+			return false
+		})
+	})
+
+	l.resultList.AddEvents(int(gdk.POINTER_MOTION_MASK | gdk.LEAVE_NOTIFY_MASK))
+	l.resultList.Connect("motion-notify-event", func(list *gtk.ListBox, event *gdk.Event) bool {
+		if motion := gdk.EventMotionNewFromEvent(event); motion != nil {
+			_, y := motion.MotionVal()
+			l.onResultHover(list.GetRowAtY(int(y)))
+		}
+		return false
+	})
+	l.resultList.Connect("leave-notify-event", func(list *gtk.ListBox, event *gdk.Event) bool {
+		l.hover.leave()
+		return false
+	})
+
 	l.gridFlowBox.Connect("child-activated", func(box *gtk.FlowBox, child *gtk.FlowBoxChild) {
 		defer func() {
 			if r := recover(); r != nil {
@@ -378,7 +476,36 @@ func (l *Launcher) setupSignals() {
 		if l == nil {
 			return
 		}
-		l.onGridSelectionChanged()
+		glib.IdleAdd(func() bool {
+			l.updatePreview()
+			l.updateActionHint()
+			return false
+		})
+	})
+}
+
+// onResultHover selects the result row under the pointer when
+// activate_on_hover is set, activating it once the hover dwell elapses
+func (l *Launcher) onResultHover(row *gtk.ListBoxRow) {
+	index := -1
+	if row != nil {
+		index = row.GetIndex()
+	}
+
+	selectRow, dwellToken := l.hover.move(index)
+	if !selectRow {
+		return
+	}
+	l.resultList.SelectRow(row)
+
+	if dwellToken == 0 {
+		return
+	}
+	glib.TimeoutAdd(uint(l.hover.dwell.Milliseconds()), func() bool {
+		if l.visible.Load() && l.hover.dwellElapsed(dwellToken) {
+			l.onRowActivated(row)
+		}
+		return false
 	})
 }
 
@@ -395,6 +522,10 @@ func (l *Launcher) onGridChildActivated(child *gtk.FlowBoxChild) {
 
 	item := l.currentItems[index]
 
+	if l.selectAsync(item) {
+		return
+	}
+
 	// Execute hooks first
 	if l.registry != nil {
 		hookCtx := l.createHookContext(item)
@@ -403,8 +534,8 @@ func (l *Launcher) onGridChildActivated(child *gtk.FlowBoxChild) {
 			if hookRegistry != nil {
 				result := hookRegistry.ExecuteSelectHooks(l.ctx, hookCtx, item.ActionData)
 				if result.Handled {
-					log.Printf("[LAUNCHER] Hook handled action, hiding launcher")
-					l.Hide()
+					log.Printf("[LAUNCHER] Hook handled action")
+					l.finishActivation()
 					return
 				}
 			}
@@ -418,49 +549,7 @@ func (l *Launcher) onGridChildActivated(child *gtk.FlowBoxChild) {
 		}
 	}
 
-	l.Hide()
-}
-
-func (l *Launcher) onGridSelectionChanged() {
-	if l == nil {
-		return
-	}
-
-	// Check if wallpaper preview is enabled
-	if !l.config.Launcher.Wallpaper.PreviewOnNav {
-		return
-	}
-
-	l.mu.RLock()
-	defer l.mu.RUnlock()
-
-	// Get selected child from grid
-	selected := l.gridFlowBox.GetSelectedChildren()
-	if len(selected) == 0 {
-		return
-	}
-
-	// Get the selected item
-	child := selected[0]
-	if child == nil {
-		return
-	}
-
-	index := child.GetIndex()
-	if index < 0 || index >= len(l.currentItems) {
-		return
-	}
-
-	item := l.currentItems[index]
-
-	// Call preview action if available
-	if item.PreviewAction != nil {
-		go func() {
-			if err := item.PreviewAction(); err != nil {
-				log.Printf("[LAUNCHER] Preview action failed: %v", err)
-			}
-		}()
-	}
+	l.finishActivation()
 }
 
 func (l *Launcher) onSearchChanged(text string) {
@@ -477,24 +566,18 @@ func (l *Launcher) onSearchChanged(text string) {
 	// Update color preview if input is a color
 	l.updateColorPreview(text)
 
-	// Increment search version for this request
+	// Increment search version for this request and cancel the search it
+	// supersedes
 	version := atomic.AddInt64(&l.searchVersion, 1)
 	searchVersion := version // Copy for closure
+	if l.searchCancel != nil {
+		l.searchCancel()
+	}
+	searchCtx, searchCancel := context.WithCancel(context.Background())
+	l.searchCancel = searchCancel
 
 	// Calculate adaptive debounce delay
-	baseDelay := l.config.Launcher.Search.DebounceDelay
-	var debounceMs int
-
-	switch {
-	case len(text) == 0:
-		debounceMs = 0 // Immediate for empty
-	case len(text) == 1:
-		debounceMs = 50 // Very fast for single char
-	case len(text) <= 3:
-		debounceMs = 100 // Fast for short queries (user-approved)
-	default:
-		debounceMs = baseDelay // Standard delay (150ms default)
-	}
+	debounce := searchDebounceDelay(l.config.Launcher.Search, utf8.RuneCountInString(text))
 
 	// Cancel previous timer if exists
 	if l.searchTimer != nil {
@@ -502,7 +585,7 @@ func (l *Launcher) onSearchChanged(text string) {
 	}
 
 	// Start new timer with adaptive debounce delay
-	l.searchTimer = time.AfterFunc(time.Duration(debounceMs)*time.Millisecond, func() {
+	l.searchTimer = time.AfterFunc(debounce, func() {
 		// Check if this timer callback is still valid before proceeding
 		currentVersion := atomic.LoadInt64(&l.searchVersion)
 		if version != currentVersion {
@@ -523,7 +606,10 @@ func (l *Launcher) onSearchChanged(text string) {
 				return
 			}
 
-			items, err := l.registry.Search(query)
+			items, err := l.registry.SearchContext(searchCtx, query)
+			if errors.Is(err, context.Canceled) {
+				return
+			}
 			if err != nil {
 				fmt.Printf("Search error: %v\n", err)
 				return
@@ -545,10 +631,23 @@ func (l *Launcher) onSearchChanged(text string) {
 			})
 		}(text, searchVersion, searchStart)
 	})
+}
 
-	// For zero delay (empty string), also trigger immediate update
-	if debounceMs == 0 {
+// refreshGeneralSearch re-runs the current query when it is a general app
+// search, replacing results computed from a partial app index
+func (l *Launcher) refreshGeneralSearch() {
+	if !l.visible.Load() {
+		return
 	}
+
+	l.mu.Lock()
+	input := l.currentInput
+	l.mu.Unlock()
+
+	if _, triggered, _ := l.registry.FindLauncherForInput(input); triggered != nil {
+		return
+	}
+	l.onSearchChanged(input)
 }
 
 func (l *Launcher) updateResults(items []*launcher.LauncherItem, version int64) {
@@ -611,6 +710,8 @@ func (l *Launcher) updateResultsUnsafe(items []*launcher.LauncherItem, version i
 }
 
 func (l *Launcher) updateListResults(items []*launcher.LauncherItem) {
+	l.hover.reset()
+
 	// Remove all rows by repeatedly removing the first row
 	for {
 		row := l.resultList.GetRowAtIndex(0)
@@ -750,6 +851,56 @@ func (l *Launcher) adjustWindowSizeForGrid(gridConfig *launcher.GridConfig, item
 	log.Printf("[GRID] Adjusted window size to %dx%d for grid mode", width, height)
 }
 
+// rowLayout returns w with row sizes left at 0 set to their defaults
+func rowLayout(w config.WindowConfig) config.WindowConfig {
+	defaults := config.DefaultConfig.Launcher.Window
+	if w.RowHeight == 0 {
+		w.RowHeight = defaults.RowHeight
+	}
+	if w.RowPadding == 0 {
+		w.RowPadding = defaults.RowPadding
+	}
+	if w.VisibleRows == 0 {
+		w.VisibleRows = defaults.VisibleRows
+	}
+	return w
+}
+
+// resultsMinHeight is the height of the result list showing the configured
+// number of rows
+func resultsMinHeight(w config.WindowConfig) int {
+	w = rowLayout(w)
+	return w.VisibleRows * w.RowHeight
+}
+
+// setVisibleRows sizes the result list to show rows rows, as dmenu -l does
+func (l *Launcher) setVisibleRows(rows int) {
+	w := rowLayout(l.config.Launcher.Window)
+	configured := w.VisibleRows
+	w.VisibleRows = rows
+	height := resultsMinHeight(w)
+	l.scrolledWindow.SetMinContentHeight(height)
+	if rows != configured {
+		l.scrolledWindow.SetMaxContentHeight(height)
+		l.scrolledWindow.SetVExpand(false)
+	} else {
+		l.scrolledWindow.SetMaxContentHeight(-1)
+		l.scrolledWindow.SetVExpand(true)
+	}
+}
+
+// defaultLauncherHeight is the window height used when none is configured:
+// the result list plus the search entry and padding, at least 500px
+func defaultLauncherHeight(w config.WindowConfig) int {
+	searchEntryHeight := 50
+	extraPadding := 20
+	height := resultsMinHeight(w) + searchEntryHeight + extraPadding
+	if height < 500 {
+		height = 500
+	}
+	return height
+}
+
 func (l *Launcher) restoreDefaultWindowSize() {
 	width := l.config.Launcher.Window.Width
 	height := l.config.Launcher.Window.Height
@@ -758,19 +909,32 @@ func (l *Launcher) restoreDefaultWindowSize() {
 		width = 600
 	}
 	if height <= 0 {
-		minHeightForResults := 5 * 44
-		searchEntryHeight := 50
-		extraPadding := 20
-		height = minHeightForResults + searchEntryHeight + extraPadding
-		if height < 500 {
-			height = 500
-		}
+		height = defaultLauncherHeight(l.config.Launcher.Window)
 	}
 
 	l.window.SetDefaultSize(width, height)
 	log.Printf("[GRID] Restored default window size to %dx%d", width, height)
 }
 
+// newSubtitleLabel creates a label for a result subtitle, cut to the
+// configured length and ellipsized by Pango once it runs out of lines
+func (l *Launcher) newSubtitleLabel(subtitle string) (*gtk.Label, error) {
+	styling := l.config.Launcher.Styling
+
+	label, err := gtk.LabelNew(launcher.TruncateRunes(subtitle, styling.SubtitleMaxLength))
+	if err != nil {
+		return nil, err
+	}
+
+	label.SetEllipsize(pango.ELLIPSIZE_END)
+	if styling.SubtitleLines > 1 {
+		label.SetLineWrap(true)
+		label.SetLineWrapMode(pango.WRAP_WORD_CHAR)
+		label.SetLines(styling.SubtitleLines)
+	}
+	return label, nil
+}
+
 func (l *Launcher) createResultRow(item *launcher.LauncherItem, index int) (*gtk.ListBoxRow, error) {
 	row, err := gtk.ListBoxRowNew()
 	if err != nil {
@@ -790,10 +954,11 @@ func (l *Launcher) createResultRow(item *launcher.LauncherItem, index int) (*gtk
 		return nil, err
 	}
 
-	box.SetMarginStart(8)
-	box.SetMarginEnd(8)
-	box.SetMarginTop(8)
-	box.SetMarginBottom(8)
+	padding := rowLayout(l.config.Launcher.Window).RowPadding
+	box.SetMarginStart(padding)
+	box.SetMarginEnd(padding)
+	box.SetMarginTop(padding)
+	box.SetMarginBottom(padding)
 	box.SetHExpand(true) // Allow content to expand horizontally
 
 	// Create a horizontal box for icon and text
@@ -844,23 +1009,33 @@ func (l *Launcher) createResultRow(item *launcher.LauncherItem, index int) (*gtk
 			var pixbuf *gdk.Pixbuf
 			var loadErr error
 
-			if l.iconCache != nil {
-				// Use cache if available (includes fallback handling)
-				pixbuf, loadErr = l.iconCache.GetIcon(item.Icon, iconSize)
-			} else {
-				// Load directly from theme at custom size with fallback
-				theme, themeErr := gtk.IconThemeGetDefault()
-				if themeErr == nil {
-					// Try the requested icon first
-					pixbuf, loadErr = theme.LoadIcon(item.Icon, iconSize, gtk.ICON_LOOKUP_USE_BUILTIN)
-					if loadErr != nil || pixbuf == nil {
-						// Try fallback icon
-						fallback := l.config.Launcher.Icons.FallbackIcon
+			// Items backed by an image (clipboard images) preview it,
+			// keeping its aspect ratio
+			preview := false
+			if item.ImagePath != "" {
+				pixbuf, loadErr = gdk.PixbufNewFromFileAtScale(item.ImagePath, iconSize, iconSize, true)
+				preview = loadErr == nil && pixbuf != nil
+			}
+
+			if !preview {
+				if l.iconCache != nil {
+					// Use cache if available (includes fallback handling)
+					pixbuf, loadErr = l.iconCache.GetIcon(item.Icon, iconSize)
+				} else {
+					// Load directly from theme at custom size with fallback
+					icons := l.config.Launcher.Icons
+					theme, themeErr := launcher.IconTheme(icons.Theme)
+					if themeErr == nil {
+						fallback := icons.FallbackIcon
 						if fallback == "" {
 							fallback = "image-missing"
 						}
-						if item.Icon != fallback {
-							pixbuf, loadErr = theme.LoadIcon(fallback, iconSize, gtk.ICON_LOOKUP_USE_BUILTIN)
+						loadErr = fmt.Errorf("icon '%s' not found in theme", item.Icon)
+						for _, name := range launcher.IconCandidates(item.Icon, icons.FallbackChain, fallback) {
+							pixbuf, loadErr = theme.LoadIcon(name, iconSize, gtk.ICON_LOOKUP_USE_BUILTIN)
+							if loadErr == nil && pixbuf != nil {
+								break
+							}
 						}
 					}
 				}
@@ -868,7 +1043,7 @@ func (l *Launcher) createResultRow(item *launcher.LauncherItem, index int) (*gtk
 
 			if loadErr == nil && pixbuf != nil {
 				// Ensure pixbuf is exactly the right size
-				if pixbuf.GetWidth() != iconSize || pixbuf.GetHeight() != iconSize {
+				if !preview && (pixbuf.GetWidth() != iconSize || pixbuf.GetHeight() != iconSize) {
 					// Scale to exact size if needed
 					scaled, scaleErr := pixbuf.ScaleSimple(iconSize, iconSize, gdk.INTERP_BILINEAR)
 					if scaleErr == nil && scaled != nil {
@@ -906,13 +1081,32 @@ func (l *Launcher) createResultRow(item *launcher.LauncherItem, index int) (*gtk
 	textBox.SetHExpand(false)
 	iconTextBox.PackStart(textBox, true, false, 0)
 
+	if l.registry.InvocationParams().MultiSelectEnabled() {
+		mark, err := gtk.LabelNew(launcher.SelectionMark(l.selection.Selected(item)))
+		if err != nil {
+			return nil, err
+		}
+		mark.SetName("result-mark")
+		mark.SetVAlign(gtk.ALIGN_START)
+		iconTextBox.PackStart(mark, false, false, 0)
+		iconTextBox.ReorderChild(mark, 0)
+		mark.Show()
+	}
+
 	box.PackStart(iconTextBox, false, false, 0)
 
-	label, err := gtk.LabelNew(item.Title)
+	rowText := launcher.ResolveRow(launcher.RowTemplateFor(l.config, item.Launcher), item)
+
+	label, err := gtk.LabelNew(rowText.Title)
 	if err != nil {
 		return nil, err
 	}
 
+	// Match positions index the item's own title
+	if len(item.MatchedIndexes) > 0 && rowText.Title == item.Title {
+		label.SetMarkup(launcher.HighlightMarkup(item.Title, item.MatchedIndexes, l.config.Launcher.Styling.AccentColor))
+	}
+
 	label.SetHAlign(gtk.ALIGN_START)
 	label.SetHExpand(false)
 	label.SetMaxWidthChars(30)
@@ -921,19 +1115,14 @@ func (l *Launcher) createResultRow(item *launcher.LauncherItem, index int) (*gtk
 	textBox.PackStart(label, false, false, 0)
 	label.Show()
 
-	if item.Subtitle != "" {
-		subtitle := item.Subtitle
-		if len(subtitle) > 50 {
-			subtitle = subtitle[:50]
-		}
-		subLabel, err := gtk.LabelNew(subtitle)
+	if rowText.Subtitle != "" {
+		subLabel, err := l.newSubtitleLabel(rowText.Subtitle)
 		if err != nil {
 			return nil, err
 		}
 
 		subLabel.SetHAlign(gtk.ALIGN_START)
 		subLabel.SetMaxWidthChars(30)
-		subLabel.SetEllipsize(pango.ELLIPSIZE_END)
 		subLabel.SetOpacity(0.6)
 		subLabel.SetName("result-subtitle")
 		textBox.PackStart(subLabel, false, false, 0)
@@ -946,8 +1135,8 @@ func (l *Launcher) createResultRow(item *launcher.LauncherItem, index int) (*gtk
 	iconTextBox.SetHExpand(false)
 	iconTextBox.Show()
 
-	if index < 9 {
-		hintLabel, err := gtk.LabelNew(fmt.Sprintf("%d", index+1))
+	if hint, ok := l.quickSelectKeys.hintLabel(index, hintCount(l.config.Launcher.Keys)); ok {
+		hintLabel, err := gtk.LabelNew(hint)
 		if err != nil {
 			return nil, err
 		}
@@ -958,11 +1147,103 @@ func (l *Launcher) createResultRow(item *launcher.LauncherItem, index int) (*gtk
 		hintLabel.Show()
 	}
 
+	if rowText.Badge != "" {
+		badgeLabel, err := gtk.LabelNew(rowText.Badge)
+		if err != nil {
+			return nil, err
+		}
+
+		badgeLabel.SetHAlign(gtk.ALIGN_END)
+		badgeLabel.SetMarginStart(8)
+		badgeLabel.SetOpacity(0.6)
+		badgeLabel.SetName("result-badge")
+		box.PackEnd(badgeLabel, false, false, 0)
+		badgeLabel.Show()
+	}
+
 	row.Add(box)
 	row.ShowAll()
 	return row, nil
 }
 
+// loadGridThumbnail fills image with the thumbnail of the image at path.
+// With a thumbnail cache, hashing the file for its key and reading the
+// cached bytes happen off the GTK thread.
+func (l *Launcher) loadGridThumbnail(image *gtk.Image, path string, width, height int) {
+	if l.thumbnailCache == nil {
+		if pixbuf := l.gridThumbnail(path, width, height, "", nil); pixbuf != nil {
+			image.SetFromPixbuf(pixbuf)
+		}
+		return
+	}
+
+	go func() {
+		cacheKey, err := l.thumbnailCache.ContentKey(path, width, height)
+		if err != nil {
+			log.Printf("[GRID] Failed to compute cache key for %s: %v", path, err)
+			cacheKey = ""
+		}
+
+		// Try memory cache, then disk cache
+		var cachedData []byte
+		if cacheKey != "" {
+			var found bool
+			cachedData, found = l.thumbnailCache.Get(cacheKey)
+			if !found {
+				cachedData, _ = l.thumbnailCache.GetFromCacheDir(cacheKey)
+			}
+		}
+
+		glib.IdleAdd(func() {
+			if pixbuf := l.gridThumbnail(path, width, height, cacheKey, cachedData); pixbuf != nil {
+				image.SetFromPixbuf(pixbuf)
+			}
+		})
+	}()
+}
+
+// gridThumbnail decodes cachedData, or loads the image at path and caches it
+// under cacheKey when one is given. It must run on the GTK thread.
+func (l *Launcher) gridThumbnail(path string, width, height int, cacheKey string, cachedData []byte) *gdk.Pixbuf {
+	if cachedData != nil {
+		pixbuf, err := gdk.PixbufNewFromDataOnly(cachedData)
+		if err == nil {
+			return pixbuf
+		}
+		log.Printf("[GRID] Failed to load pixbuf from cache: %v", err)
+	}
+
+	pixbuf, err := gdk.PixbufNewFromFileAtScale(path, width, height, false)
+	if err != nil {
+		log.Printf("[GRID] Failed to load image %s: %v", path, err)
+		// Create a placeholder
+		pixbuf, err = gdk.PixbufNew(gdk.COLORSPACE_RGB, true, 8, width, height)
+		if err != nil {
+			return nil
+		}
+		pixbuf.Fill(0x22222222) // Dark gray placeholder
+		return pixbuf
+	}
+
+	if cacheKey != "" {
+		// Cache the encoded thumbnail rather than raw pixels so rowstride
+		// and alpha differences can't corrupt it on reload
+		var buf bytes.Buffer
+		if err := pixbuf.WritePNG(&buf, 0); err == nil {
+			data := buf.Bytes()
+			go func() {
+				l.thumbnailCache.Put(cacheKey, data)
+				if err := l.thumbnailCache.SaveToCacheDir(cacheKey, data); err != nil {
+					log.Printf("[GRID] %v", err)
+				}
+			}()
+		} else {
+			log.Printf("[GRID] Failed to encode thumbnail for %s: %v", path, err)
+		}
+	}
+	return pixbuf
+}
+
 func (l *Launcher) createGridItem(item *launcher.LauncherItem, index int) (gtk.IWidget, error) {
 	// Get grid config from launcher
 	var gridConfig *launcher.GridConfig
@@ -997,46 +1278,7 @@ func (l *Launcher) createGridItem(item *launcher.LauncherItem, index int) (gtk.I
 			return nil, err
 		}
 
-		// Check cache first
-		cacheKey := fmt.Sprintf("%s_%dx%d", item.ImagePath, gridConfig.ItemWidth)
-		var pixbuf *gdk.Pixbuf
-
-		if l.thumbnailCache != nil {
-			// Try memory cache
-			if cachedData, found := l.thumbnailCache.Get(cacheKey); found {
-				pixbuf, err = gdk.PixbufNewFromData(cachedData, gdk.COLORSPACE_RGB, true, 8, gridConfig.ItemWidth, gridConfig.ItemHeight, gridConfig.ItemWidth*4)
-				if err != nil {
-					log.Printf("[GRID] Failed to load pixbuf from cache: %v", err)
-				}
-			}
-		}
-
-		// Load from file if not in cache
-		if pixbuf == nil {
-			pixbuf, err = gdk.PixbufNewFromFileAtScale(item.ImagePath, gridConfig.ItemWidth, gridConfig.ItemHeight, false)
-			if err != nil {
-				log.Printf("[GRID] Failed to load image %s: %v", item.ImagePath, err)
-				// Create a placeholder
-				pixbuf, err = gdk.PixbufNew(gdk.COLORSPACE_RGB, true, 8, gridConfig.ItemWidth, gridConfig.ItemHeight)
-				if err == nil {
-					pixbuf.Fill(0x22222222) // Dark gray placeholder
-				}
-			} else {
-				// Cache the loaded pixbuf
-				if l.thumbnailCache != nil {
-					pixels := pixbuf.GetPixels()
-					if len(pixels) > 0 {
-						data := make([]byte, len(pixels))
-						copy(data, pixels)
-						l.thumbnailCache.Put(cacheKey, data)
-					}
-				}
-			}
-		}
-
-		if pixbuf != nil {
-			image.SetFromPixbuf(pixbuf)
-		}
+		l.loadGridThumbnail(image, item.ImagePath, gridConfig.ItemWidth, gridConfig.ItemHeight)
 		container.PackStart(image, true, true, 0)
 		image.Show()
 	}
@@ -1066,18 +1308,13 @@ func (l *Launcher) createGridItem(item *launcher.LauncherItem, index int) (gtk.I
 		}
 
 		if item.Subtitle != "" && gridConfig.MetadataPosition == launcher.MetadataPositionBottom {
-			subtitle := item.Subtitle
-			if len(subtitle) > 50 {
-				subtitle = subtitle[:50]
-			}
-			subLabel, err := gtk.LabelNew(subtitle)
+			subLabel, err := l.newSubtitleLabel(item.Subtitle)
 			if err != nil {
 				return nil, err
 			}
 			subLabel.SetName("grid-item-subtitle")
 			subLabel.SetHAlign(gtk.ALIGN_START)
 			subLabel.SetMaxWidthChars(20)
-			subLabel.SetEllipsize(pango.ELLIPSIZE_END)
 			metaBox.PackStart(subLabel, false, false, 0)
 			subLabel.Show()
 		}
@@ -1087,7 +1324,7 @@ func (l *Launcher) createGridItem(item *launcher.LauncherItem, index int) (gtk.I
 	}
 
 	// Add keyboard shortcut hint
-	if index < 9 {
+	if hint, ok := l.quickSelectKeys.hintLabel(index, hintCount(l.config.Launcher.Keys)); ok {
 		hintBox, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
 		if err != nil {
 			return nil, err
@@ -1097,7 +1334,7 @@ func (l *Launcher) createGridItem(item *launcher.LauncherItem, index int) (gtk.I
 		hintBox.SetMarginEnd(4)
 		hintBox.SetMarginTop(4)
 
-		hintLabel, err := gtk.LabelNew(fmt.Sprintf("%d", index+1))
+		hintLabel, err := gtk.LabelNew(hint)
 		if err != nil {
 			return nil, err
 		}
@@ -1123,14 +1360,18 @@ func (l *Launcher) createGridItem(item *launcher.LauncherItem, index int) (gtk.I
 }
 
 func (l *Launcher) onActivate() {
+	if l.finishSelection(l.activationTarget()) {
+		return
+	}
+
 	text, _ := l.searchEntry.GetText()
 
 	// Execute enter hooks first
-	hookCtx := l.createHookContext(nil)
+	hookCtx := l.createInputHookContext(text)
 	result := l.registry.GetHookRegistry().ExecuteEnterHooks(l.ctx, hookCtx, text)
 
 	if result.Handled {
-		l.Hide()
+		l.finishActivation()
 		return
 	}
 
@@ -1141,11 +1382,15 @@ func (l *Launcher) onActivate() {
 	} else if len(l.currentItems) > 0 {
 		item := l.currentItems[0]
 
+		if l.applyPrefill(item) || l.selectAsync(item) {
+			return
+		}
+
 		// Execute hooks first
 		hookCtx := l.createHookContext(item)
 		result := l.registry.GetHookRegistry().ExecuteSelectHooks(l.ctx, hookCtx, item.ActionData)
 		if result.Handled {
-			l.Hide()
+			l.finishActivation()
 			return
 		}
 
@@ -1156,7 +1401,74 @@ func (l *Launcher) onActivate() {
 			}
 		}
 
-		l.Hide()
+		l.finishActivation()
+	}
+}
+
+// activationTarget is the item Enter acts on: the selected row, or the
+// first result if none is selected
+func (l *Launcher) activationTarget() *launcher.LauncherItem {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	index := 0
+	if selected := l.resultList.GetSelectedRow(); selected != nil {
+		index = selected.GetIndex()
+	}
+	if index < 0 || index >= len(l.currentItems) {
+		return nil
+	}
+	return l.currentItems[index]
+}
+
+// selectedItem is the item of the selected row or grid child, if any
+func (l *Launcher) selectedItem() *launcher.LauncherItem {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	index := -1
+	if l.gridMode {
+		if selected := l.gridFlowBox.GetSelectedChildren(); len(selected) > 0 && selected[0] != nil {
+			index = selected[0].GetIndex()
+		}
+	} else if row := l.resultList.GetSelectedRow(); row != nil {
+		index = row.GetIndex()
+	}
+	if index < 0 || index >= len(l.currentItems) {
+		return nil
+	}
+	return l.currentItems[index]
+}
+
+// updateActionHint shows the number actions of the selected item in the
+// hint row
+func (l *Launcher) updateActionHint() {
+	text := ""
+	if item := l.selectedItem(); item != nil {
+		if actions, ok := item.Launcher.(launcher.CtrlNumberActionMap); ok {
+			text = l.numberActionKeys.actionHints(actions.CtrlNumberActions(item))
+		}
+	}
+	l.actionHintLabel.SetText(text)
+}
+
+// updatePreview shows the selected item in the preview pane and, when
+// preview_on_nav is set, runs its PreviewAction. The pane shows an item's
+// image itself, so the action (e.g. setting the wallpaper) is opt-in.
+func (l *Launcher) updatePreview() {
+	item := l.selectedItem()
+	if l.preview != nil {
+		l.preview.show(item)
+	}
+	if item == nil || item.PreviewAction == nil {
+		return
+	}
+	if l.config.Launcher.Wallpaper.PreviewOnNav {
+		go func() {
+			if err := item.PreviewAction(); err != nil {
+				log.Printf("[LAUNCHER] Preview action failed: %v", err)
+			}
+		}()
 	}
 }
 
@@ -1173,6 +1485,19 @@ func (l *Launcher) onRowActivated(row *gtk.ListBoxRow) {
 	item := l.currentItems[index]
 	l.mu.RUnlock()
 
+	if l.finishSelection(item) || l.applyPrefill(item) {
+		return
+	}
+
+	if l.queryHistory != nil {
+		text, _ := l.searchEntry.GetText()
+		l.queryHistory.Add(text)
+	}
+
+	if l.selectAsync(item) {
+		return
+	}
+
 	// Execute hooks first
 	if l.registry != nil {
 		hookCtx := l.createHookContext(item)
@@ -1181,8 +1506,8 @@ func (l *Launcher) onRowActivated(row *gtk.ListBoxRow) {
 			if hookRegistry != nil {
 				result := hookRegistry.ExecuteSelectHooks(l.ctx, hookCtx, item.ActionData)
 				if result.Handled {
-					log.Printf("[LAUNCHER] Hook handled action, hiding launcher")
-					l.Hide()
+					log.Printf("[LAUNCHER] Hook handled action")
+					l.finishActivation()
 					return
 				}
 			}
@@ -1196,7 +1521,59 @@ func (l *Launcher) onRowActivated(row *gtk.ListBoxRow) {
 		}
 	}
 
-	l.Hide()
+	l.finishActivation()
+}
+
+// selectAsync runs the select hooks of launchers with async hooks off the
+// GTK main loop, so slow commands don't freeze the window. The launcher is
+// hidden (or refreshed) right away; unhandled items fall back to default
+// execution.
+func (l *Launcher) selectAsync(item *launcher.LauncherItem) bool {
+	if l.registry == nil || item.Launcher == nil {
+		return false
+	}
+	hookRegistry := l.registry.GetHookRegistry()
+	if hookRegistry == nil || !hookRegistry.HasAsyncHooks(item.Launcher.Name()) {
+		return false
+	}
+
+	hookCtx := l.createHookContext(item)
+	if hookCtx == nil {
+		return false
+	}
+
+	l.finishActivation()
+	go func() {
+		result := hookRegistry.ExecuteSelectHooksAsync(hookCtx, item.ActionData, asyncHookTimeout)
+		if result.Error != nil {
+			log.Printf("[LAUNCHER] Async hook failed: %v", result.Error)
+		}
+		if result.Handled || result.Error != nil {
+			return
+		}
+
+		glib.IdleAdd(func() bool {
+			if err := l.registry.Execute(item); err != nil {
+				log.Printf("[LAUNCHER] Failed to execute item: %v\n", err)
+			}
+			return false
+		})
+	}()
+	return true
+}
+
+// applyPrefill replaces the search text for items with a PrefillAction,
+// keeping the launcher open
+func (l *Launcher) applyPrefill(item *launcher.LauncherItem) bool {
+	prefill, ok := item.ActionData.(*launcher.PrefillAction)
+	if !ok {
+		return false
+	}
+
+	l.searchEntry.SetText(prefill.Text)
+	l.searchEntry.GrabFocusWithoutSelecting()
+	l.searchEntry.SetPosition(-1)
+	return true
 }
 
 func (l *Launcher) onKeyPress(event *gdk.EventKey) bool {
@@ -1210,58 +1587,41 @@ func (l *Launcher) onKeyPress(event *gdk.EventKey) bool {
 		return false
 	}
 
-	switch key {
-	case gdk.KEY_Escape:
+	mods := eventModifiers(state)
+	keyName := gdk.KeyValName(key)
+
+	if l.recallQuery(key, mods, keyName) {
+		return true
+	}
+
+	if mods == 0 && keyName == "space" && l.toggleSelection() {
+		return true
+	}
+
+	switch {
+	case l.navKeys.close.has(mods, keyName):
 		l.Hide()
 		return true
-	case gdk.KEY_Down:
+	case l.navKeys.down.has(mods, keyName):
 		l.navigateResult(1)
 		return true
-	case gdk.KEY_Up:
+	case l.navKeys.up.has(mods, keyName):
 		l.navigateResult(-1)
 		return true
-	case gdk.KEY_Tab:
+	case l.navKeys.activate.has(mods, keyName):
+		l.onActivate()
+		return true
+	case l.navKeys.tabComplete.has(mods, keyName):
 		return l.onTabPressed()
-	case gdk.KEY_n, gdk.KEY_j:
-		if state&uint(gdk.CONTROL_MASK) != 0 {
-			l.navigateResult(1)
-			return true
-		}
-		return false
-	case gdk.KEY_p, gdk.KEY_k: // TODO: add to config file;
-		if state&uint(gdk.CONTROL_MASK) != 0 {
-			l.navigateResult(-1)
-			return true
-		}
-		return false
+	case l.togglePreviewKeys.has(mods, keyName):
+		l.preview.toggle()
+		l.updatePreview()
+		return true
 	}
 
-	// Check for Alt+number (1-9) to directly activate corresponding entry
-	if state&uint(gdk.MOD1_MASK) != 0 {
-		var index int
-		switch key {
-		case gdk.KEY_1:
-			index = 0
-		case gdk.KEY_2:
-			index = 1
-		case gdk.KEY_3:
-			index = 2
-		case gdk.KEY_4:
-			index = 3
-		case gdk.KEY_5:
-			index = 4
-		case gdk.KEY_6:
-			index = 5
-		case gdk.KEY_7:
-			index = 6
-		case gdk.KEY_8:
-			index = 7
-		case gdk.KEY_9:
-			index = 8
-		default:
-			return false
-		}
-
+	// Quick-select keys (Alt+1-9 by default) activate the corresponding entry
+	if number, ok := l.quickSelectKeys.lookup(mods, keyName); ok {
+		index := number - 1
 		l.mu.RLock()
 		if index < len(l.currentItems) {
 			row := l.resultList.GetRowAtIndex(index)
@@ -1272,46 +1632,24 @@ func (l *Launcher) onKeyPress(event *gdk.EventKey) bool {
 			}
 		}
 		l.mu.RUnlock()
+		return false
 	}
 
-	// Check for Ctrl+number (1-9) to execute launcher-specific action on corresponding entry
-	if state&uint(gdk.CONTROL_MASK) != 0 {
-		var number int
-		switch key {
-		case gdk.KEY_1:
-			number = 1
-		case gdk.KEY_2:
-			number = 2
-		case gdk.KEY_3:
-			number = 3
-		case gdk.KEY_4:
-			number = 4
-		case gdk.KEY_5:
-			number = 5
-		case gdk.KEY_6:
-			number = 6
-		case gdk.KEY_7:
-			number = 7
-		case gdk.KEY_8:
-			number = 8
-		case gdk.KEY_9:
-			number = 9
-		default:
-			return false
-		}
-
+	// Number action keys (Ctrl+1-9 by default) run a launcher-specific action
+	if number, ok := l.numberActionKeys.lookup(mods, keyName); ok {
 		l.mu.RLock()
-		index := number - 1
-		if index < len(l.currentItems) {
-			item := l.currentItems[index]
+		if item := l.ctrlNumberTarget(number); item != nil {
 			if item.Launcher != nil {
 				action, exists := item.Launcher.GetCtrlNumberAction(number)
 				if exists && action != nil {
 					l.mu.RUnlock()
-					if err := action(item); err != nil {
-						fmt.Printf("Ctrl+%d action failed: %v\n", number, err)
+					var prompt *launcher.ConfirmPrompt
+					if err := action(item); errors.As(err, &prompt) {
+						l.showFooterNotice(prompt.Prompt)
+					} else if err != nil {
+						fmt.Printf("Number action %d failed: %v\n", number, err)
 					} else {
-						l.Hide()
+						l.finishActivation()
 					}
 					return true
 				}
@@ -1323,13 +1661,103 @@ func (l *Launcher) onKeyPress(event *gdk.EventKey) bool {
 	return false
 }
 
+// recallQuery replaces the search text with a past query for the history
+// keys, or for Up/Down when recallsHistory allows it
+func (l *Launcher) recallQuery(key uint, mods keyModifier, keyName string) bool {
+	if l.queryHistory == nil {
+		return false
+	}
+
+	var dedicated, older bool
+	switch {
+	case l.historyPrevKeys.has(mods, keyName):
+		dedicated, older = true, true
+	case l.historyNextKeys.has(mods, keyName):
+		dedicated, older = true, false
+	case key == gdk.KEY_Up && mods == 0:
+		older = true
+	case key == gdk.KEY_Down && mods == 0:
+		older = false
+	default:
+		return false
+	}
+
+	text, _ := l.searchEntry.GetText()
+	l.mu.RLock()
+	resultCount := len(l.currentItems)
+	l.mu.RUnlock()
+	if !recallsHistory(dedicated, resultCount, text, l.queryHistory.Recalling(text)) {
+		return false
+	}
+
+	var query string
+	var ok bool
+	if older {
+		query, ok = l.queryHistory.Previous(text)
+	} else {
+		query, ok = l.queryHistory.Next(text)
+	}
+	if !ok {
+		// Nothing further to recall; plain arrows fall back to navigation
+		return dedicated
+	}
+
+	l.searchEntry.SetText(query)
+	l.searchEntry.SetPosition(-1)
+	return true
+}
+
+// eventModifiers converts a GDK modifier state to key binding modifiers
+func eventModifiers(state uint) keyModifier {
+	var mods keyModifier
+	if state&uint(gdk.SHIFT_MASK) != 0 {
+		mods |= modShift
+	}
+	if state&uint(gdk.CONTROL_MASK) != 0 {
+		mods |= modCtrl
+	}
+	if state&uint(gdk.MOD1_MASK) != 0 {
+		mods |= modAlt
+	}
+	if state&uint(gdk.SUPER_MASK|gdk.MOD4_MASK) != 0 {
+		mods |= modSuper
+	}
+	return mods
+}
+
+// ctrlNumberTarget returns the item Ctrl+number acts on: the selected (or
+// first) item for launchers with per-item action maps, otherwise the item at
+// that position. Callers must hold l.mu.
+func (l *Launcher) ctrlNumberTarget(number int) *launcher.LauncherItem {
+	if len(l.currentItems) == 0 {
+		return nil
+	}
+
+	if _, ok := l.currentItems[0].Launcher.(launcher.CtrlNumberActionMap); ok {
+		index := 0
+		if row := l.resultList.GetSelectedRow(); row != nil {
+			index = row.GetIndex()
+		}
+		if index >= 0 && index < len(l.currentItems) {
+			return l.currentItems[index]
+		}
+		return nil
+	}
+
+	if number-1 < len(l.currentItems) {
+		return l.currentItems[number-1]
+	}
+	return nil
+}
+
 func (l *Launcher) onTabPressed() bool {
 	text, _ := l.searchEntry.GetText()
-	hookCtx := l.createHookContext(nil)
+	hookCtx := l.createInputHookContext(text)
 	result := l.registry.GetHookRegistry().ExecuteTabHooks(l.ctx, hookCtx, text)
 
 	if result.Handled {
 		l.searchEntry.SetText(result.NewText)
+		l.searchEntry.SetPosition(-1)
 		return true
 	}
 
@@ -1360,6 +1788,18 @@ func (l *Launcher) shouldShowIcon(item *launcher.LauncherItem) bool {
 	return false
 }
 
+// createInputHookContext creates a hook context for enter and tab events,
+// addressed to the launcher whose trigger starts text
+func (l *Launcher) createInputHookContext(text string) *launcher.HookContext {
+	hookCtx := l.createHookContext(nil)
+	if hookCtx != nil && l.registry != nil {
+		if _, active, _ := l.registry.FindLauncherForInput(text); active != nil {
+			hookCtx.LauncherName = active.Name()
+		}
+	}
+	return hookCtx
+}
+
 func (l *Launcher) createHookContext(item *launcher.LauncherItem) *launcher.HookContext {
 	if l == nil {
 		return nil
@@ -1537,82 +1977,247 @@ func (l *Launcher) Show() error {
 	}
 	l.mu.Unlock()
 
-	cfg := l.config.Launcher.Animation
-	startY := -400
-	targetY := cfg.TargetMargin
-	distance := targetY - startY
+	placement := launcherPlacementFor(l.config.Launcher)
+	anim := launcherAnimationFor(l.config.Launcher.Animation, placement.slide)
+	shownY := placement.margins[layer.EdgeTop]
 
-	layer.SetMargin(unsafe.Pointer(l.window.Native()), layer.EdgeTop, startY)
+	l.registry.LauncherShown()
+	l.placeOnFocusedOutput()
+	l.applyAnimationFrame(anim, anim.frame(0, true), shownY)
 	l.window.ShowAll()
 	l.window.Present()
-	l.searchEntry.SetText("")
-
-	if cfg.Enabled && cfg.EnableSlideIn {
-		durationNs := int64(cfg.SlideDuration) * 1_000_000
-		startTime := time.Now().UnixNano()
+	if l.keepQuery {
+		// The query kept from the last activation needs fresh results
+		l.keepQuery = false
+		l.refreshResults()
+	} else {
+		l.searchEntry.SetText("")
+	}
 
+	if anim.duration(true) > 0 {
+		start := time.Now()
+		l.animating = true
 		l.window.AddTickCallback(func(w *gtk.Widget, frameClock *gdk.FrameClock) bool {
-			elapsed := time.Now().UnixNano() - startTime
-			progress := float64(elapsed) / float64(durationNs)
-
-			if progress >= 1.0 {
-				layer.SetMargin(unsafe.Pointer(w.Native()), layer.EdgeTop, targetY)
-				l.searchEntry.GrabFocus()
+			frame := anim.frame(time.Since(start), true)
+			l.applyAnimationFrame(anim, frame, shownY)
+			if frame.done {
+				l.searchEntry.GrabFocusWithoutSelecting()
+				l.animating = false
 				return false
 			}
-
-			easedProgress := easeOutCubic(progress)
-			currentY := startY + int(float64(distance)*easedProgress)
-			layer.SetMargin(unsafe.Pointer(w.Native()), layer.EdgeTop, currentY)
 			return true
 		})
 	} else {
-		layer.SetMargin(unsafe.Pointer(l.window.Native()), layer.EdgeTop, targetY)
-		l.searchEntry.GrabFocus()
+		l.searchEntry.GrabFocusWithoutSelecting()
 	}
 
 	l.visible.Store(true)
 	return nil
 }
 
+// placeOnFocusedOutput moves the launcher to the monitor the WM last
+// reported as focused, then asks the WM again off the main thread and moves
+// it if focus has since changed outputs. If the focused output can't be
+// determined, the compositor's choice is kept.
+func (l *Launcher) placeOnFocusedOutput() {
+	if l.focusedOutput != nil {
+		l.moveToOutput(*l.focusedOutput)
+	}
+	if l.fetchingOutput {
+		return
+	}
+
+	l.fetchingOutput = true
+	go func() {
+		output, err := launcher.FetchFocusedOutput()
+		glib.IdleAdd(func() bool {
+			l.fetchingOutput = false
+			if err != nil {
+				log.Printf("[LAUNCHER] Could not determine focused output: %v", err)
+				return false
+			}
+			moved := l.focusedOutput == nil || l.focusedOutput.Name != output.Name
+			l.focusedOutput = &output
+			if moved && l.visible.Load() {
+				l.moveToOutput(output)
+			}
+			return false
+		})
+	}()
+}
+
+// moveToOutput puts the launcher on the monitor showing output
+func (l *Launcher) moveToOutput(output launcher.Output) {
+	display, err := gdk.DisplayGetDefault()
+	if err != nil {
+		return
+	}
+
+	for i := 0; i < display.GetNMonitors(); i++ {
+		monitor, err := display.GetMonitor(i)
+		if err != nil {
+			continue
+		}
+		geometry := monitor.GetGeometry()
+		if geometry.GetX() == output.Rect.X && geometry.GetY() == output.Rect.Y {
+			layer.SetMonitor(unsafe.Pointer(l.window.Native()), unsafe.Pointer(monitor.Native()))
+			return
+		}
+	}
+
+	log.Printf("[LAUNCHER] No monitor matches focused output %s", output.Name)
+}
+
 func (l *Launcher) Hide() {
+	l.hide(true)
+}
+
+// hide hides the launcher, keeping the query for the next show unless
+// clearQuery is set
+func (l *Launcher) hide(clearQuery bool) {
 	l.mu.Lock()
 	l.stopAndDrainSearchTimer()
 	l.currentItems = nil
 	l.mu.Unlock()
+	l.keepQuery = !clearQuery
+	if l.queryHistory != nil {
+		l.queryHistory.Reset()
+	}
+	l.answerInvocation(nil)
+	l.selection.Clear()
+	if l.registry.InvocationParams() != nil {
+		l.registry.SetInvocationParams(nil)
+		l.searchEntry.SetPlaceholderText(launcher.DefaultPrompt)
+		l.setVisibleRows(rowLayout(l.config.Launcher.Window).VisibleRows)
+	}
 
-	cfg := l.config.Launcher.Animation
-	startY := cfg.TargetMargin
-	targetY := -400
-	distance := startY - targetY
+	placement := launcherPlacementFor(l.config.Launcher)
+	anim := launcherAnimationFor(l.config.Launcher.Animation, placement.slide)
+	shownY := placement.margins[layer.EdgeTop]
 
-	if cfg.Enabled && cfg.EnableSlideIn {
-		durationNs := int64(cfg.SlideDuration) * 1_000_000
-		startTime := time.Now().UnixNano()
+	finish := func() {
+		l.window.Hide()
+		if clearQuery {
+			l.searchEntry.SetText("")
+		}
+		l.visible.Store(false)
+		// Leave the window in place for the next show
+		l.applyAnimationFrame(anim, animationFrame{slide: 1, opacity: 1}, shownY)
+	}
 
+	if anim.duration(false) > 0 {
+		start := time.Now()
+		l.animating = true
 		l.window.AddTickCallback(func(w *gtk.Widget, frameClock *gdk.FrameClock) bool {
-			elapsed := time.Now().UnixNano() - startTime
-			progress := float64(elapsed) / float64(durationNs)
-
-			if progress >= 1.0 {
-				l.window.Hide()
-				l.searchEntry.SetText("")
-				l.visible.Store(false)
-				layer.SetMargin(unsafe.Pointer(l.window.Native()), layer.EdgeTop, cfg.TargetMargin)
+			frame := anim.frame(time.Since(start), false)
+			if frame.done {
+				finish()
+				l.animating = false
 				return false
 			}
-
-			easedProgress := easeOutCubic(progress)
-			currentY := startY - int(float64(distance)*easedProgress)
-			layer.SetMargin(unsafe.Pointer(w.Native()), layer.EdgeTop, currentY)
+			l.applyAnimationFrame(anim, frame, shownY)
 			return true
 		})
 	} else {
-		l.window.Hide()
+		finish()
+	}
+}
+
+// hiddenMarginTop is the top margin that slides the launcher off-screen
+const hiddenMarginTop = -400
+
+// applyAnimationFrame moves and fades the window to frame; shownY is its
+// top margin once fully shown
+func (l *Launcher) applyAnimationFrame(anim launcherAnimation, frame animationFrame, shownY int) {
+	if anim.slide > 0 {
+		y := hiddenMarginTop + int(float64(shownY-hiddenMarginTop)*frame.slide)
+		layer.SetMargin(unsafe.Pointer(l.window.Native()), layer.EdgeTop, y)
+	}
+	if anim.fadeIn > 0 || anim.fadeOut > 0 {
+		l.window.SetOpacity(frame.opacity)
+	}
+}
+
+// finishActivation hides or refreshes the launcher after a result has run,
+// following close_on_activate and clear_search_on_activate
+func (l *Launcher) finishActivation() {
+	after := afterActivationFor(l.config.Launcher.Behavior)
+	if after.hide {
+		l.hide(after.clearQuery)
+		return
+	}
+
+	text, _ := l.searchEntry.GetText()
+	if after.clearQuery && text != "" {
+		// Clearing the entry runs the search through the changed signal
 		l.searchEntry.SetText("")
-		l.visible.Store(false)
-		layer.SetMargin(unsafe.Pointer(l.window.Native()), layer.EdgeTop, cfg.TargetMargin)
+		return
+	}
+	if after.refresh {
+		l.refreshResults()
+	}
+}
+
+// launcherPlacement describes how the launcher surface is anchored
+type launcherPlacement struct {
+	anchors map[layer.Edge]bool
+	margins map[layer.Edge]int
+	slide   bool // whether the slide animation (on the top margin) applies
+}
+
+// launcherPlacementFor computes layer-shell anchors and margins from config.
+// "top" pins the launcher below the top edge at the animation target margin;
+// "center" leaves it unanchored so the compositor centers it. Offsets anchor
+// the matching edge so the margin takes effect, keeping the other axis centered.
+func launcherPlacementFor(cfg config.LauncherConfig) launcherPlacement {
+	p := launcherPlacement{
+		anchors: make(map[layer.Edge]bool),
+		margins: make(map[layer.Edge]int),
+	}
+
+	w := cfg.Window
+	if w.Anchor == "center" {
+		if w.OffsetY > 0 {
+			p.anchors[layer.EdgeTop] = true
+			p.margins[layer.EdgeTop] = w.OffsetY
+		} else if w.OffsetY < 0 {
+			p.anchors[layer.EdgeBottom] = true
+			p.margins[layer.EdgeBottom] = -w.OffsetY
+		}
+	} else {
+		p.anchors[layer.EdgeTop] = true
+		p.margins[layer.EdgeTop] = cfg.Animation.TargetMargin + w.OffsetY
+		p.slide = cfg.Animation.Enabled && cfg.Animation.EnableSlideIn
+	}
+
+	if w.OffsetX > 0 {
+		p.anchors[layer.EdgeLeft] = true
+		p.margins[layer.EdgeLeft] = w.OffsetX
+	} else if w.OffsetX < 0 {
+		p.anchors[layer.EdgeRight] = true
+		p.margins[layer.EdgeRight] = -w.OffsetX
 	}
+
+	return p
+}
+
+// searchDebounceDelay picks the debounce delay for a query of queryLen runes,
+// falling back to the default adaptive_delays when none are configured
+func searchDebounceDelay(cfg config.SearchConfig, queryLen int) time.Duration {
+	if cfg.Instant {
+		return 0
+	}
+
+	delays := cfg.AdaptiveDelays
+	if len(delays) == 0 {
+		delays = config.DefaultConfig.Launcher.Search.AdaptiveDelays
+	}
+
+	ms := cfg.DebounceDelay
+	if queryLen < len(delays) {
+		ms = delays[queryLen]
+	}
+	return time.Duration(ms) * time.Millisecond
 }
 
 func (l *Launcher) stopAndDrainSearchTimer() {
@@ -1643,6 +2248,93 @@ func (l *Launcher) Toggle() error {
 	return nil
 }
 
+// ShowWithParams shows the launcher for an IPC invocation, applying its
+// prompt and initial query. The params last until the launcher is hidden.
+// done receives the chosen lines of dmenu and multi-select invocations, or
+// nil if the launcher is dismissed; it may be nil.
+func (l *Launcher) ShowWithParams(params *launcher.InvocationParams, done func(lines []string)) error {
+	// A new invocation replaces any still waiting for an answer
+	l.answerInvocation(nil)
+	l.selection.Clear()
+	l.invocationDone = done
+	l.registry.SetInvocationParams(params)
+	l.searchEntry.SetPlaceholderText(params.PromptText())
+	l.setVisibleRows(params.VisibleRows(rowLayout(l.config.Launcher.Window).VisibleRows))
+	if err := l.ShowWithQuery(params.Query()); err != nil {
+		// The caller answers failed invocations itself
+		l.invocationDone = nil
+		return err
+	}
+	return nil
+}
+
+// answerInvocation passes lines to the waiting IPC invocation, if any
+func (l *Launcher) answerInvocation(lines []string) {
+	if done := l.invocationDone; done != nil {
+		l.invocationDone = nil
+		done(lines)
+	}
+}
+
+// finishSelection answers a dmenu or multi-select invocation with the
+// picked lines, or item if none were picked, or the typed text if no row
+// matched, instead of running item
+func (l *Launcher) finishSelection(item *launcher.LauncherItem) bool {
+	if !l.registry.InvocationParams().ReturnsSelection() {
+		return false
+	}
+
+	text, _ := l.searchEntry.GetText()
+	lines := l.selection.Lines(item, text)
+	if len(lines) == 0 {
+		return true
+	}
+	l.answerInvocation(lines)
+	l.hide(true)
+	return true
+}
+
+// toggleSelection picks or unpicks the selected row in multi-select mode
+func (l *Launcher) toggleSelection() bool {
+	if !l.registry.InvocationParams().MultiSelectEnabled() {
+		return false
+	}
+
+	row := l.resultList.GetSelectedRow()
+	if row == nil {
+		return true
+	}
+	index := row.GetIndex()
+
+	l.mu.RLock()
+	if index < 0 || index >= len(l.currentItems) {
+		l.mu.RUnlock()
+		return true
+	}
+	items := l.currentItems
+	l.mu.RUnlock()
+
+	l.selection.Toggle(items[index])
+	l.updateListResults(items)
+	if row := l.resultList.GetRowAtIndex(index); row != nil {
+		l.resultList.SelectRow(row)
+	}
+	return true
+}
+
+// ShowWithQuery shows the launcher with the search entry prefilled. Setting the
+// text fires the entry's changed signal, which runs the search as if typed.
+func (l *Launcher) ShowWithQuery(query string) error {
+	if err := l.Show(); err != nil {
+		return err
+	}
+
+	l.searchEntry.SetText(query)
+	l.searchEntry.GrabFocusWithoutSelecting()
+	l.searchEntry.SetPosition(-1)
+	return nil
+}
+
 func (l *Launcher) Start() error {
 	log.Printf("Launcher.Start() - beginning")
 
@@ -1657,11 +2349,29 @@ func (l *Launcher) Start() error {
 		return fmt.Errorf("failed to load launchers: %w", err)
 	}
 
-	// Set up lock screen callback
+	// Set up lock screen callback; requests go through the app event bus.
+	// Hooks run off the GTK main loop, so the request is published from it.
 	if l.app != nil {
-		l.registry.SetLockScreenCallback(l.app.ShowLockScreen)
+		publishLock := lockRequestPublisher(l.app.Events())
+		l.registry.SetLockScreenCallback(func() error {
+			glib.IdleAdd(func() bool {
+				if err := publishLock(); err != nil {
+					log.Printf("[LAUNCHER] Failed to request lock screen: %v", err)
+				}
+				return false
+			})
+			return nil
+		})
 	}
 
+	// Re-run general searches as the app index fills in
+	l.registry.OnAppIndexUpdate(func() {
+		glib.IdleAdd(func() bool {
+			l.refreshGeneralSearch()
+			return false
+		})
+	})
+
 	// Get window dimensions for geometry hints
 	width := l.config.Launcher.Window.Width
 	height := l.config.Launcher.Window.Height
@@ -1669,13 +2379,7 @@ func (l *Launcher) Start() error {
 		width = 600
 	}
 	if height <= 0 {
-		minHeightForResults := 5 * 44
-		searchEntryHeight := 50
-		extraPadding := 20
-		height = minHeightForResults + searchEntryHeight + extraPadding
-		if height < 500 {
-			height = 500
-		}
+		height = defaultLauncherHeight(l.config.Launcher.Window)
 	}
 
 	// Set geometry hints to enforce fixed window size
@@ -1703,11 +2407,11 @@ func (l *Launcher) Start() error {
 	layer.SetLayer(unsafe.Pointer(l.window.Native()), layer.LayerOverlay)
 	layer.SetKeyboardMode(unsafe.Pointer(l.window.Native()), layer.KeyboardModeExclusive)
 	// Explicitly set all anchors
-	layer.SetAnchor(unsafe.Pointer(l.window.Native()), layer.EdgeTop, true)
-	layer.SetAnchor(unsafe.Pointer(l.window.Native()), layer.EdgeBottom, false)
-	layer.SetAnchor(unsafe.Pointer(l.window.Native()), layer.EdgeLeft, false)
-	layer.SetAnchor(unsafe.Pointer(l.window.Native()), layer.EdgeRight, false)
-	layer.SetMargin(unsafe.Pointer(l.window.Native()), layer.EdgeTop, 40)
+	placement := launcherPlacementFor(l.config.Launcher)
+	for _, edge := range []layer.Edge{layer.EdgeTop, layer.EdgeBottom, layer.EdgeLeft, layer.EdgeRight} {
+		layer.SetAnchor(unsafe.Pointer(l.window.Native()), edge, placement.anchors[edge])
+		layer.SetMargin(unsafe.Pointer(l.window.Native()), edge, placement.margins[edge])
+	}
 	layer.SetExclusiveZone(unsafe.Pointer(l.window.Native()), 0)
 
 	l.window.Connect("destroy", func() {
@@ -1814,6 +2518,25 @@ func (l *Launcher) updateFooter(input string) {
 	}
 }
 
+// footerNoticeDuration is how long showFooterNotice replaces the footer
+const footerNoticeDuration = 5 * time.Second
+
+// showFooterNotice shows text in the footer until the search text changes
+// or footerNoticeDuration passes. Call it on the GTK main thread.
+func (l *Launcher) showFooterNotice(text string) {
+	if l.footerLabel == nil {
+		return
+	}
+	l.footerLabel.SetText(text)
+	glib.TimeoutAdd(uint(footerNoticeDuration.Milliseconds()), func() bool {
+		if current, _ := l.footerLabel.GetText(); current == text {
+			query, _ := l.searchEntry.GetText()
+			l.updateFooter(query)
+		}
+		return false
+	})
+}
+
 func (l *Launcher) updateColorPreview(input string) {
 	glib.IdleAdd(func() bool {
 		if l.colorPreviewBox == nil || l.colorPreviewWidget == nil {
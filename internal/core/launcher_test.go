@@ -0,0 +1,126 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+	"github.com/chess10kp/locus/internal/layer"
+)
+
+func TestLauncherPlacementTop(t *testing.T) {
+	cfg := config.DefaultConfig.Launcher
+	cfg.Animation.TargetMargin = 40
+	cfg.Window.Anchor = "top"
+	cfg.Window.OffsetY = 10
+
+	p := launcherPlacementFor(cfg)
+
+	if !p.anchors[layer.EdgeTop] || p.anchors[layer.EdgeBottom] || p.anchors[layer.EdgeLeft] || p.anchors[layer.EdgeRight] {
+		t.Errorf("Expected top-only anchor, got %v", p.anchors)
+	}
+	if p.margins[layer.EdgeTop] != 50 {
+		t.Errorf("Expected top margin 50, got %d", p.margins[layer.EdgeTop])
+	}
+	if p.slide != (cfg.Animation.Enabled && cfg.Animation.EnableSlideIn) {
+		t.Errorf("Expected slide to follow animation config, got %v", p.slide)
+	}
+}
+
+func TestLauncherPlacementCenter(t *testing.T) {
+	cfg := config.DefaultConfig.Launcher
+	cfg.Window.Anchor = "center"
+
+	p := launcherPlacementFor(cfg)
+
+	for edge, anchored := range p.anchors {
+		if anchored {
+			t.Errorf("Expected no anchors in center mode, edge %v anchored", edge)
+		}
+	}
+	if p.slide {
+		t.Error("Expected slide animation to be disabled in center mode")
+	}
+}
+
+func TestLauncherPlacementOffsets(t *testing.T) {
+	cfg := config.DefaultConfig.Launcher
+	cfg.Window.Anchor = "center"
+	cfg.Window.OffsetX = -120
+	cfg.Window.OffsetY = -30
+
+	p := launcherPlacementFor(cfg)
+
+	if !p.anchors[layer.EdgeRight] || p.margins[layer.EdgeRight] != 120 {
+		t.Errorf("Expected right anchor with 120px margin, got anchors=%v margins=%v", p.anchors, p.margins)
+	}
+	if !p.anchors[layer.EdgeBottom] || p.margins[layer.EdgeBottom] != 30 {
+		t.Errorf("Expected bottom anchor with 30px margin, got anchors=%v margins=%v", p.anchors, p.margins)
+	}
+	if p.anchors[layer.EdgeTop] || p.anchors[layer.EdgeLeft] {
+		t.Errorf("Unexpected anchors: %v", p.anchors)
+	}
+}
+
+func TestSearchDebounceDelay(t *testing.T) {
+	base := config.SearchConfig{DebounceDelay: 150}
+
+	tests := []struct {
+		name     string
+		cfg      func(c config.SearchConfig) config.SearchConfig
+		queryLen int
+		expected time.Duration
+	}{
+		{"default empty", func(c config.SearchConfig) config.SearchConfig { return c }, 0, 0},
+		{"default single char", func(c config.SearchConfig) config.SearchConfig { return c }, 1, 50 * time.Millisecond},
+		{"default short", func(c config.SearchConfig) config.SearchConfig { return c }, 3, 100 * time.Millisecond},
+		{"default long", func(c config.SearchConfig) config.SearchConfig { return c }, 4, 150 * time.Millisecond},
+		{"custom ladder", func(c config.SearchConfig) config.SearchConfig {
+			c.AdaptiveDelays = []int{10, 20}
+			return c
+		}, 1, 20 * time.Millisecond},
+		{"custom ladder beyond", func(c config.SearchConfig) config.SearchConfig {
+			c.AdaptiveDelays = []int{10, 20}
+			return c
+		}, 2, 150 * time.Millisecond},
+		{"instant", func(c config.SearchConfig) config.SearchConfig {
+			c.Instant = true
+			return c
+		}, 10, 0},
+	}
+
+	for _, tt := range tests {
+		got := searchDebounceDelay(tt.cfg(base), tt.queryLen)
+		if got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestResultsMinHeight(t *testing.T) {
+	tests := []struct {
+		name        string
+		rowHeight   int
+		visibleRows int
+		wantList    int
+		wantWindow  int
+	}{
+		{"defaults", 44, 5, 220, 500},
+		{"dense", 28, 8, 224, 500},
+		{"roomy", 64, 10, 640, 710},
+		{"unset", 0, 0, 220, 500},
+	}
+
+	for _, tt := range tests {
+		w := config.DefaultConfig.Launcher.Window
+		w.RowHeight = tt.rowHeight
+		w.VisibleRows = tt.visibleRows
+
+		if got := resultsMinHeight(w); got != tt.wantList {
+			t.Errorf("%s: resultsMinHeight() = %d, want %d", tt.name, got, tt.wantList)
+		}
+		if got := defaultLauncherHeight(w); got != tt.wantWindow {
+			t.Errorf("%s: defaultLauncherHeight() = %d, want %d", tt.name, got, tt.wantWindow)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/chess10kp/locus/internal/config"
+	"github.com/chess10kp/locus/internal/launcher"
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+	"github.com/gotk3/gotk3/pango"
+)
+
+// previewPane sits beside the results and shows details of the selected
+// item, as resolved by launcher.PreviewFor
+type previewPane struct {
+	box      *gtk.Box
+	title    *gtk.Label
+	image    *gtk.Image
+	text     *gtk.Label
+	width    int
+	maxLines int
+	enabled  bool // toggled by the toggle_preview keys
+	// generation counts show calls, so a preview loaded after the selection
+	// moved on is dropped. Only touched on the GTK main thread.
+	generation uint64
+}
+
+func newPreviewPane(cfg config.PreviewConfig) (*previewPane, error) {
+	if cfg.Width <= 0 {
+		cfg.Width = config.DefaultConfig.Launcher.Preview.Width
+	}
+	if cfg.MaxLines <= 0 {
+		cfg.MaxLines = config.DefaultConfig.Launcher.Preview.MaxLines
+	}
+
+	box, err := gtk.BoxNew(gtk.ORIENTATION_VERTICAL, 6)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview box: %w", err)
+	}
+	box.SetName("preview-pane")
+	box.SetSizeRequest(cfg.Width, -1)
+
+	title, err := gtk.LabelNew("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview title: %w", err)
+	}
+	title.SetName("preview-title")
+	title.SetXAlign(0)
+	title.SetEllipsize(pango.ELLIPSIZE_END)
+	box.PackStart(title, false, false, 0)
+
+	image, err := gtk.ImageNew()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview image: %w", err)
+	}
+	box.PackStart(image, false, false, 0)
+
+	text, err := gtk.LabelNew("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview text: %w", err)
+	}
+	text.SetName("preview-text")
+	text.SetXAlign(0)
+	text.SetYAlign(0)
+	text.SetLineWrap(true)
+	text.SetLineWrapMode(pango.WRAP_WORD_CHAR)
+
+	scrolled, err := gtk.ScrolledWindowNew(nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview scrolled window: %w", err)
+	}
+	scrolled.SetPolicy(gtk.POLICY_NEVER, gtk.POLICY_AUTOMATIC)
+	scrolled.SetVExpand(true)
+	scrolled.Add(text)
+	box.PackStart(scrolled, true, true, 0)
+
+	return &previewPane{
+		box:      box,
+		title:    title,
+		image:    image,
+		text:     text,
+		width:    cfg.Width,
+		maxLines: cfg.MaxLines,
+		enabled:  cfg.Enabled,
+	}, nil
+}
+
+// show fills the pane with the preview of item, hiding the pane when it is
+// toggled off or there is nothing to preview. The preview, and any image in
+// it, is loaded off the main thread.
+func (p *previewPane) show(item *launcher.LauncherItem) {
+	p.generation++
+	if !p.enabled || item == nil {
+		p.box.Hide()
+		return
+	}
+
+	generation := p.generation
+	go func() {
+		preview, ok := launcher.PreviewFor(item, p.maxLines)
+		var pixbuf *gdk.Pixbuf
+		if ok && preview.ImagePath != "" {
+			var err error
+			pixbuf, err = gdk.PixbufNewFromFileAtScale(preview.ImagePath, p.width, p.width, true)
+			if err != nil {
+				log.Printf("[LAUNCHER] Failed to load preview image %s: %v", preview.ImagePath, err)
+			}
+		}
+		glib.IdleAdd(func() bool {
+			if generation == p.generation {
+				p.apply(preview, ok, pixbuf)
+			}
+			return false
+		})
+	}()
+}
+
+// apply puts a loaded preview into the pane
+func (p *previewPane) apply(preview launcher.Preview, ok bool, pixbuf *gdk.Pixbuf) {
+	if !p.enabled || !ok {
+		p.box.Hide()
+		return
+	}
+
+	p.title.SetText(preview.Title)
+	p.text.SetText(preview.Text)
+	if preview.Monospace {
+		p.text.SetName("preview-text-mono")
+	} else {
+		p.text.SetName("preview-text")
+	}
+
+	p.image.Clear()
+	if pixbuf != nil {
+		p.image.SetFromPixbuf(pixbuf)
+	}
+
+	p.box.ShowAll()
+	if preview.Text == "" {
+		p.text.Hide()
+	}
+}
+
+// toggle turns the pane on or off; the caller shows the selection after
+func (p *previewPane) toggle() {
+	p.enabled = !p.enabled
+}
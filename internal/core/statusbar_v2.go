@@ -6,10 +6,10 @@ import (
 	"log"
 	"net"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/chess10kp/locus/internal/config"
@@ -21,6 +21,9 @@ import (
 	"github.com/gotk3/gotk3/gtk"
 )
 
+// moduleQueryTimeout bounds how long query_module waits for the main loop
+const moduleQueryTimeout = time.Second
+
 var (
 	ErrStatusBarAlreadyRunning = errors.New("status bar is already running")
 )
@@ -116,8 +119,7 @@ func (sb *StatusBar) createStatusBarsForAllMonitors() error {
 	sb.destroyAllStatusBars()
 
 	// Get monitor count using xrandr
-	cmd := exec.Command("sh", "-c", "xrandr --listmonitors 2>/dev/null | grep Monitors: | awk '{print $2}' || echo 1")
-	output, err := cmd.Output()
+	output, err := sb.config.CommandRunner().Shell("xrandr --listmonitors 2>/dev/null | grep Monitors: | awk '{print $2}' || echo 1")
 	monitorCount := 1 // default
 	if err != nil {
 		log.Printf("Warning: failed to get monitor count, assuming 1: %v", err)
@@ -156,12 +158,19 @@ func (sb *StatusBar) createStatusBarsForAllMonitors() error {
 
 		// Initialize layer shell for this monitor
 		layer.InitForWindow(unsafe.Pointer(window.GObject))
-		layer.SetAnchor(unsafe.Pointer(window.GObject), layer.EdgeLeft, true)
-		layer.SetAnchor(unsafe.Pointer(window.GObject), layer.EdgeRight, true)
-		layer.SetAnchor(unsafe.Pointer(window.GObject), layer.EdgeTop, true)
-		layer.SetMargin(unsafe.Pointer(window.GObject), layer.EdgeTop, 0)
+		anchors := statusBarAnchors(sb.config.StatusBar.Position)
+		for _, edge := range []layer.Edge{layer.EdgeLeft, layer.EdgeRight, layer.EdgeTop, layer.EdgeBottom} {
+			layer.SetAnchor(unsafe.Pointer(window.GObject), edge, anchors[edge])
+		}
+		if anchors[layer.EdgeBottom] {
+			layer.SetMargin(unsafe.Pointer(window.GObject), layer.EdgeBottom, sb.config.StatusBar.MarginBottom)
+		} else {
+			layer.SetMargin(unsafe.Pointer(window.GObject), layer.EdgeTop, sb.config.StatusBar.MarginTop)
+		}
+		layer.SetMargin(unsafe.Pointer(window.GObject), layer.EdgeLeft, sb.config.StatusBar.MarginLeft)
+		layer.SetMargin(unsafe.Pointer(window.GObject), layer.EdgeRight, sb.config.StatusBar.MarginRight)
 		layer.SetLayer(unsafe.Pointer(window.GObject), layer.LayerTop)
-		layer.SetExclusiveZone(unsafe.Pointer(window.GObject), height)
+		layer.SetExclusiveZone(unsafe.Pointer(window.GObject), statusBarExclusiveZone(sb.config.StatusBar))
 		layer.SetKeyboardMode(unsafe.Pointer(window.GObject), layer.KeyboardModeNone)
 
 		// Connect destroy signal to quit
@@ -178,6 +187,31 @@ func (sb *StatusBar) createStatusBarsForAllMonitors() error {
 	return nil
 }
 
+// statusBarAnchors returns the edges a bar window is anchored to for the
+// configured position. Anything other than "bottom" anchors to the top.
+func statusBarAnchors(position string) map[layer.Edge]bool {
+	anchors := map[layer.Edge]bool{
+		layer.EdgeLeft:  true,
+		layer.EdgeRight: true,
+	}
+	if position == "bottom" {
+		anchors[layer.EdgeBottom] = true
+	} else {
+		anchors[layer.EdgeTop] = true
+	}
+	return anchors
+}
+
+// statusBarExclusiveZone returns the exclusive zone a bar window should reserve.
+// Compositors already add the anchored-edge margin to the exclusive zone, so only
+// the gap between the bar and tiled windows is added on top of the bar height.
+func statusBarExclusiveZone(cfg config.StatusBarConfig) int {
+	if cfg.Height <= 0 {
+		return 0
+	}
+	return cfg.Height + cfg.Gap
+}
+
 // destroyAllStatusBars destroys all statusbar windows
 func (sb *StatusBar) destroyAllStatusBars() {
 	for _, window := range sb.windows {
@@ -262,11 +296,20 @@ func (sb *StatusBar) HandleIPC(msg string) error {
 }
 
 func (sb *StatusBar) loadModules() error {
+	sb.registry.SetDependencyPolicy(statusbar.DependencyPolicy(sb.config.StatusBar.MissingDependencies))
+	sb.registry.SetCommandTimeout(sb.config.CommandRunner().Timeout)
+	backoff := sb.config.StatusBar.ReconnectBackoff
+	sb.registry.SetReconnectBackoff(time.Duration(backoff.Base)*time.Millisecond, time.Duration(backoff.Max)*time.Millisecond, backoff.Factor)
+
 	// Collect all modules from all sections
 	allModules := append(append(sb.config.StatusBar.Layout.Left, sb.config.StatusBar.Layout.Middle...), sb.config.StatusBar.Layout.Right...)
 	log.Printf("Loading modules, config: %v", allModules)
 
 	for _, moduleName := range allModules {
+		if isLayoutSpacer(moduleName) {
+			continue
+		}
+
 		moduleConfig := sb.config.StatusBar.ModuleConfigs[moduleName]
 		log.Printf("Loading module '%s' with config: %v", moduleName, moduleConfig)
 
@@ -334,28 +377,26 @@ func (sb *StatusBar) createWidgetsForContainer(container *gtk.Box, monitorIndex
 	}
 
 	// Create spacers for centering middle section
-	leftSpacer, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+	leftSpacer, err := newLayoutSpacer()
 	if err != nil {
 		return fmt.Errorf("failed to create left spacer: %w", err)
 	}
-	leftSpacer.SetHExpand(true)
 
-	rightSpacer, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+	rightSpacer, err := newLayoutSpacer()
 	if err != nil {
 		return fmt.Errorf("failed to create right spacer: %w", err)
 	}
-	rightSpacer.SetHExpand(true)
 
 	// Build sections
-	if err := sb.constructSection(sb.config.StatusBar.Layout.Left, leftBox); err != nil {
+	if err := sb.constructSection("left", sb.config.StatusBar.Layout.Left, leftBox); err != nil {
 		return fmt.Errorf("failed to construct left section: %w", err)
 	}
 
-	if err := sb.constructSection(sb.config.StatusBar.Layout.Middle, middleBox); err != nil {
+	if err := sb.constructSection("middle", sb.config.StatusBar.Layout.Middle, middleBox); err != nil {
 		return fmt.Errorf("failed to construct middle section: %w", err)
 	}
 
-	if err := sb.constructSection(sb.config.StatusBar.Layout.Right, rightBox); err != nil {
+	if err := sb.constructSection("right", sb.config.StatusBar.Layout.Right, rightBox); err != nil {
 		return fmt.Errorf("failed to construct right section: %w", err)
 	}
 
@@ -369,21 +410,79 @@ func (sb *StatusBar) createWidgetsForContainer(container *gtk.Box, monitorIndex
 	return nil
 }
 
-func (sb *StatusBar) constructSection(modules []string, box *gtk.Box) error {
-	for i, moduleName := range modules {
-		if i > 0 {
-			// Add separator between modules
-			sep, err := gtk.LabelNew(" | ")
+// sectionEntry is a module, separator or spacer in a status bar section
+type sectionEntry struct {
+	module    string
+	separator bool
+	spacer    bool
+}
+
+// isLayoutSpacer reports whether a layout entry is a "spacer" or "expander"
+// pseudo-module rather than a real module
+func isLayoutSpacer(name string) bool {
+	return name == "spacer" || name == "expander"
+}
+
+// sectionEntries interleaves separators between modules. Spacers take up the
+// free space and are never separated from their neighbours. An empty
+// separator adds none.
+func sectionEntries(modules []string, separator string) []sectionEntry {
+	entries := make([]sectionEntry, 0, 2*len(modules))
+	prevModule := false
+	for _, moduleName := range modules {
+		if isLayoutSpacer(moduleName) {
+			entries = append(entries, sectionEntry{spacer: true})
+			prevModule = false
+			continue
+		}
+		if prevModule && separator != "" {
+			entries = append(entries, sectionEntry{separator: true})
+		}
+		entries = append(entries, sectionEntry{module: moduleName})
+		prevModule = true
+	}
+	return entries
+}
+
+// newLayoutSpacer creates an empty box that expands to fill free space
+func newLayoutSpacer() (*gtk.Box, error) {
+	spacer, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+	if err != nil {
+		return nil, err
+	}
+	spacer.SetHExpand(true)
+	return spacer, nil
+}
+
+func (sb *StatusBar) constructSection(section string, modules []string, box *gtk.Box) error {
+	separator := sb.config.StatusBar.SectionSeparator(section)
+	for _, entry := range sectionEntries(modules, separator) {
+		if entry.spacer {
+			spacer, err := newLayoutSpacer()
+			if err != nil {
+				log.Printf("Failed to create spacer: %v", err)
+				continue
+			}
+			box.PackStart(spacer, true, true, 0)
+			continue
+		}
+
+		if entry.separator {
+			sep, err := gtk.LabelNew(separator)
 			if err != nil {
 				log.Printf("Failed to create separator: %v", err)
 				continue
 			}
 			if ctx, err := sep.GetStyleContext(); err == nil {
 				ctx.AddClass("separator")
+				ctx.AddClass("separator-" + section)
 			}
 			box.PackStart(sep, false, false, 0)
+			continue
 		}
 
+		moduleName := entry.module
+
 		// Check if module was successfully loaded and registered
 		if _, exists := sb.registry.GetModule(moduleName); !exists {
 			log.Printf("Module '%s' was not loaded, creating error widget", moduleName)
@@ -436,6 +535,35 @@ func (sb *StatusBar) GetModuleWidget(name string) (gtk.IWidget, bool) {
 	return sb.scheduler.GetModuleWidget(name)
 }
 
+// ModuleValue returns a module's CurrentValue, falling back to the text of
+// its widget. Modules are read on the GTK main thread, so this must not be
+// called from it.
+func (sb *StatusBar) ModuleValue(name string) (string, error) {
+	module, ok := sb.registry.GetModule(name)
+	if !ok {
+		return "", fmt.Errorf("module '%s' not found", name)
+	}
+
+	valueCh := make(chan string, 1)
+	glib.IdleAdd(func() bool {
+		value := module.CurrentValue()
+		if value == "" {
+			if widget, ok := sb.scheduler.GetModuleWidget(name); ok {
+				value = sb.registry.GetWidgetHelper().WidgetText(widget)
+			}
+		}
+		valueCh <- value
+		return false
+	})
+
+	select {
+	case value := <-valueCh:
+		return value, nil
+	case <-time.After(moduleQueryTimeout):
+		return "", fmt.Errorf("timed out reading module '%s'", name)
+	}
+}
+
 func (sb *StatusBar) IsRunning() bool {
 	sb.mu.RLock()
 	defer sb.mu.RUnlock()
@@ -539,6 +667,11 @@ func (sb *StatusBar) handleIPCConnection(conn net.Conn) {
 
 	log.Printf("Received IPC message: %s", message)
 
+	if name, ok := parseModuleQuery(message); ok {
+		replyModuleValue(conn, name, sb.ModuleValue)
+		return
+	}
+
 	// Handle the message
 	handled := sb.handleIPCMessage(message)
 	if !handled {
@@ -586,6 +719,13 @@ func (sb *StatusBar) handleIPCMessage(message string) bool {
 			})
 			return true
 		}
+		if query, ok := parseLauncherQuery(message); ok {
+			glib.IdleAdd(func() bool {
+				sb.app.PresentLauncherWithQuery(query)
+				return false
+			})
+			return true
+		}
 
 	case strings.HasPrefix(message, "launcher dmenu:"):
 		// Handle dmenu with options - for now just show launcher
@@ -597,10 +737,14 @@ func (sb *StatusBar) handleIPCMessage(message string) bool {
 		return true
 
 	case strings.HasPrefix(message, ">") || strings.HasPrefix(message, "launcher "):
-		// Handle launcher commands - for now just show launcher
-		// TODO: Implement direct command input when launcher supports it
+		// Open the launcher with the command/app prefilled
+		query, ok := parseLauncherQuery(message)
 		glib.IdleAdd(func() bool {
-			sb.app.PresentLauncher()
+			if ok {
+				sb.app.PresentLauncherWithQuery(query)
+			} else {
+				sb.app.PresentLauncher()
+			}
 			return false
 		})
 		return true
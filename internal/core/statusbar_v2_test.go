@@ -0,0 +1,149 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+	"github.com/chess10kp/locus/internal/layer"
+)
+
+func TestStatusBarAnchors(t *testing.T) {
+	tests := []struct {
+		position string
+		top      bool
+		bottom   bool
+	}{
+		{"top", true, false},
+		{"bottom", false, true},
+		{"", true, false},
+	}
+
+	for _, tt := range tests {
+		anchors := statusBarAnchors(tt.position)
+		if !anchors[layer.EdgeLeft] || !anchors[layer.EdgeRight] {
+			t.Errorf("position %q: expected left and right anchors", tt.position)
+		}
+		if anchors[layer.EdgeTop] != tt.top {
+			t.Errorf("position %q: top anchor = %v, want %v", tt.position, anchors[layer.EdgeTop], tt.top)
+		}
+		if anchors[layer.EdgeBottom] != tt.bottom {
+			t.Errorf("position %q: bottom anchor = %v, want %v", tt.position, anchors[layer.EdgeBottom], tt.bottom)
+		}
+	}
+}
+
+func TestStatusBarExclusiveZone(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.StatusBarConfig
+		want int
+	}{
+		{"default", config.StatusBarConfig{Height: 40}, 40},
+		{"gap", config.StatusBarConfig{Height: 40, Gap: 6}, 46},
+		{"margins not counted", config.StatusBarConfig{Height: 30, MarginTop: 8, MarginLeft: 12, MarginRight: 12}, 30},
+		{"floating", config.StatusBarConfig{Height: 30, MarginTop: 8, Gap: 4}, 34},
+		{"no height", config.StatusBarConfig{Height: 0, Gap: 4}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusBarExclusiveZone(tt.cfg); got != tt.want {
+				t.Errorf("statusBarExclusiveZone() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectionEntries(t *testing.T) {
+	cfg := config.StatusBarConfig{
+		Separator:  " | ",
+		Separators: map[string]string{"right": " · ", "middle": ""},
+	}
+	modules := []string{"time", "battery", "volume"}
+
+	tests := []struct {
+		section    string
+		separator  string
+		separators int
+	}{
+		{"left", " | ", 2},
+		{"right", " · ", 2},
+		{"middle", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.section, func(t *testing.T) {
+			separator := cfg.SectionSeparator(tt.section)
+			if separator != tt.separator {
+				t.Fatalf("SectionSeparator(%q) = %q, want %q", tt.section, separator, tt.separator)
+			}
+
+			entries := sectionEntries(modules, separator)
+			separators := 0
+			var names []string
+			for i, entry := range entries {
+				if entry.separator {
+					separators++
+					if i == 0 || i == len(entries)-1 || entries[i-1].separator {
+						t.Errorf("separator at position %d is not between modules", i)
+					}
+					continue
+				}
+				names = append(names, entry.module)
+			}
+			if separators != tt.separators {
+				t.Errorf("got %d separators, want %d", separators, tt.separators)
+			}
+			if len(names) != len(modules) {
+				t.Fatalf("got modules %v, want %v", names, modules)
+			}
+			for i := range modules {
+				if names[i] != modules[i] {
+					t.Errorf("module %d = %q, want %q", i, names[i], modules[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSectionEntriesSpacers(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []string
+		want    []sectionEntry
+	}{
+		{
+			"spacer pushes modules apart",
+			[]string{"workspaces", "spacer", "time"},
+			[]sectionEntry{{module: "workspaces"}, {spacer: true}, {module: "time"}},
+		},
+		{
+			"expander alias",
+			[]string{"expander", "battery"},
+			[]sectionEntry{{spacer: true}, {module: "battery"}},
+		},
+		{
+			"separators only between adjacent modules",
+			[]string{"cpu", "memory", "spacer", "time", "battery"},
+			[]sectionEntry{
+				{module: "cpu"}, {separator: true}, {module: "memory"},
+				{spacer: true},
+				{module: "time"}, {separator: true}, {module: "battery"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sectionEntries(tt.modules, " | ")
+			if len(got) != len(tt.want) {
+				t.Fatalf("sectionEntries(%v) = %+v, want %+v", tt.modules, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("entry %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
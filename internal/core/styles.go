@@ -54,6 +54,11 @@ window {
     color: #ebdbb2;
 }
 
+#statusbar .degraded {
+    color: #fb4934;
+    opacity: 0.6;
+}
+
 #lockscreen-window {
     background: #0e1419;
     color: #ebdbb2;
@@ -147,7 +152,7 @@ background-color: #504945;
 
 var globalStyleProvider *gtk.CssProvider
 
-func generateLauncherCSS(styling *config.StylingConfig, animConfig *config.AnimationConfig) string {
+func generateLauncherCSS(styling *config.StylingConfig, window *config.WindowConfig, animConfig *config.AnimationConfig) string {
 	// Parse background color to add transparency
 	bgColor := styling.BackgroundColor
 	if len(bgColor) == 7 && bgColor[0] == '#' {
@@ -182,9 +187,9 @@ func generateLauncherCSS(styling *config.StylingConfig, animConfig *config.Anima
 }
 
  #list-row {
-     padding: 4px 8px;
+     padding: 0px 8px;
      border-bottom: none;
-     min-height: 32px;
+     min-height: %dpx;
      background-color: %s;
  }
 
@@ -238,6 +243,20 @@ func generateLauncherCSS(styling *config.StylingConfig, animConfig *config.Anima
        text-align: left;
    }
 
+   #preview-pane {
+       padding: 8px;
+       border-left: 1px solid #3c3836;
+   }
+
+   #preview-title {
+       font-weight: bold;
+   }
+
+   #preview-text-mono {
+       font-family: monospace;
+       font-size: 12px;
+   }
+
 
  `,
 		bgColor,
@@ -255,6 +274,7 @@ func generateLauncherCSS(styling *config.StylingConfig, animConfig *config.Anima
 		styling.BorderWidth,
 		styling.EntryFocusColor,
 
+		rowLayout(*window).RowHeight,
 		styling.ListRowBackground,
 
 		styling.ListRowSelected,
@@ -295,7 +315,7 @@ func SetupLauncherStyles(cfg *config.Config) {
 	}
 
 	// Generate CSS from config
-	launcherCSS := generateLauncherCSS(&cfg.Launcher.Styling, &cfg.Launcher.Animation)
+	launcherCSS := generateLauncherCSS(&cfg.Launcher.Styling, &cfg.Launcher.Window, &cfg.Launcher.Animation)
 
 	// Load built-in launcher CSS
 	provider, _ := gtk.CssProviderNew()
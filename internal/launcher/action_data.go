@@ -3,6 +3,7 @@ package launcher
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -241,6 +242,20 @@ func ParseActionData(data []byte) (ActionData, error) {
 		}
 		return &action, nil
 
+	case "prefill":
+		var action PrefillAction
+		if err := json.Unmarshal(data, &action); err != nil {
+			return nil, fmt.Errorf("failed to parse prefill action: %w", err)
+		}
+		return &action, nil
+
+	case "type":
+		var action TypeAction
+		if err := json.Unmarshal(data, &action); err != nil {
+			return nil, fmt.Errorf("failed to parse type action: %w", err)
+		}
+		return &action, nil
+
 	default:
 		// Treat as custom action
 		var action CustomAction
@@ -256,6 +271,13 @@ func NewShellAction(command string) *ShellAction {
 	return &ShellAction{Command: command}
 }
 
+// NewShScriptAction creates a ShellAction that runs script through sh -c, so
+// pipes and command substitutions work
+func NewShScriptAction(script string) *ShellAction {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(script)
+	return &ShellAction{Command: `sh -c "` + escaped + `"`}
+}
+
 // NewDesktopAction creates a new DesktopAction
 func NewDesktopAction(file string) *DesktopAction {
 	return &DesktopAction{File: file}
@@ -406,3 +428,47 @@ func (a *ColorAction) ToJSON() ([]byte, error) {
 func NewColorAction(action, color string) *ColorAction {
 	return &ColorAction{Action: action, Color: color}
 }
+
+// PrefillAction replaces the search text instead of closing the launcher
+type PrefillAction struct {
+	Text string `json:"text"`
+}
+
+func (a *PrefillAction) Type() string {
+	return "prefill"
+}
+
+func (a *PrefillAction) ToJSON() ([]byte, error) {
+	data := map[string]interface{}{
+		"type": a.Type(),
+		"text": a.Text,
+	}
+	return json.Marshal(data)
+}
+
+// NewPrefillAction creates a new PrefillAction
+func NewPrefillAction(text string) *PrefillAction {
+	return &PrefillAction{Text: text}
+}
+
+// TypeAction types text into the focused window
+type TypeAction struct {
+	Text string `json:"text"`
+}
+
+func (a *TypeAction) Type() string {
+	return "type"
+}
+
+func (a *TypeAction) ToJSON() ([]byte, error) {
+	data := map[string]interface{}{
+		"type": a.Type(),
+		"text": a.Text,
+	}
+	return json.Marshal(data)
+}
+
+// NewTypeAction creates a new TypeAction
+func NewTypeAction(text string) *TypeAction {
+	return &TypeAction{Text: text}
+}
@@ -32,6 +32,22 @@ func TestShellAction(t *testing.T) {
 	}
 }
 
+func TestShScriptAction(t *testing.T) {
+	script := `grim -g "$(slurp)" - | wl-copy -t image/png && echo 'done' \ ok`
+	action := NewShScriptAction(script)
+
+	parts, err := (&LauncherRegistry{}).splitCommand(action.Command)
+	if err != nil {
+		t.Fatalf("Failed to split command: %v", err)
+	}
+	if len(parts) != 3 || parts[0] != "sh" || parts[1] != "-c" {
+		t.Fatalf("Expected sh -c <script>, got %q", parts)
+	}
+	if parts[2] != script {
+		t.Errorf("Expected script %q, got %q", script, parts[2])
+	}
+}
+
 func TestClipboardAction(t *testing.T) {
 	action := NewClipboardAction("hello world", "copy")
 
@@ -132,6 +148,10 @@ func TestParseActionData(t *testing.T) {
 			name:   "rebuild launcher action",
 			action: NewRebuildLauncherAction("timer"),
 		},
+		{
+			name:   "prefill action",
+			action: NewPrefillAction(">file "),
+		},
 		{
 			name:   "custom action",
 			action: NewCustomAction("mytype", "payload"),
@@ -23,8 +23,18 @@ type AppLauncher struct {
 	nameToApp       map[string]apps.App
 	initialized     bool
 	frecencyTracker *FrecencyTracker
+	loaded          chan struct{} // closed once the background load finishes
+	loadedOnce      sync.Once
+	// partialApps holds apps parsed so far while a system scan is running,
+	// so early searches return something instead of nothing
+	partialApps       []apps.App
+	lastPartialNotify time.Time
+	onIndexUpdate     func()
 }
 
+// partialNotifyInterval throttles index update callbacks during a scan
+const partialNotifyInterval = 100 * time.Millisecond
+
 type AppLauncherFactory struct{}
 
 func (f *AppLauncherFactory) Name() string {
@@ -40,11 +50,14 @@ func init() {
 }
 
 func NewAppLauncher(cfg *config.Config) *AppLauncher {
-	return &AppLauncher{
+	l := &AppLauncher{
 		config:    cfg,
 		appLoader: apps.NewAppLoader(cfg),
 		apps:      []apps.App{},
+		loaded:    make(chan struct{}),
 	}
+	l.appLoader.SetOnAppParsed(l.addPartialApp)
+	return l
 }
 
 func (l *AppLauncher) SetFrecencyTracker(tracker *FrecencyTracker) {
@@ -65,23 +78,86 @@ func (l *AppLauncher) StartBackgroundLoad() {
 		log.Printf("[APP-LAUNCHER] Starting background app loading")
 		loadStart := time.Now()
 
+		defer l.loadedOnce.Do(func() { close(l.loaded) })
+
 		if _, err := l.appLoader.LoadApps(false); err != nil {
 			log.Printf("[APP-LAUNCHER] Background app load failed: %v", err)
 			return
 		}
 
-		l.mu.Lock()
-		l.apps = l.appLoader.GetApps()
-		l.appsLoaded = true
+		l.finishLoad(l.appLoader.GetApps())
+
+		log.Printf("[APP-LAUNCHER] Background load completed in %v, loaded %d apps", time.Since(loadStart), len(l.apps))
+	}()
+}
+
+// SetIndexUpdateCallback registers fn to be called (from a background
+// goroutine) as the partial index grows and once loading completes
+func (l *AppLauncher) SetIndexUpdateCallback(fn func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onIndexUpdate = fn
+}
 
-		// Pre-compute search data for performance
-		l.precomputeSearchData()
-		l.initialized = true
+// IndexComplete reports whether the full app index has loaded; results
+// returned before that come from the partial index
+func (l *AppLauncher) IndexComplete() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.appsLoaded
+}
 
+// addPartialApp adds an app parsed mid-scan to the partial index
+func (l *AppLauncher) addPartialApp(app apps.App) {
+	l.mu.Lock()
+	if l.appsLoaded || (app.NoDisplay && !l.config.Launcher.Search.ShowHiddenApps) {
 		l.mu.Unlock()
+		return
+	}
+	l.partialApps = append(l.partialApps, app)
 
-		log.Printf("[APP-LAUNCHER] Background load completed in %v, loaded %d apps", time.Since(loadStart), len(l.apps))
-	}()
+	var notify func()
+	if l.onIndexUpdate != nil && time.Since(l.lastPartialNotify) >= partialNotifyInterval {
+		notify = l.onIndexUpdate
+		l.lastPartialNotify = time.Now()
+	}
+	l.mu.Unlock()
+
+	if notify != nil {
+		notify()
+	}
+}
+
+// finishLoad installs the full app index, replacing the partial one
+func (l *AppLauncher) finishLoad(loaded []apps.App) {
+	l.mu.Lock()
+	l.apps = apps.VisibleApps(loaded, l.config.Launcher.Search.ShowHiddenApps)
+	l.appsLoaded = true
+	l.partialApps = nil
+
+	// Pre-compute search data for performance
+	l.precomputeSearchData()
+	l.initialized = true
+
+	notify := l.onIndexUpdate
+	l.mu.Unlock()
+
+	if notify != nil {
+		notify()
+	}
+}
+
+// WaitLoaded blocks until the background load finishes or timeout elapses,
+// reporting whether apps are loaded
+func (l *AppLauncher) WaitLoaded(timeout time.Duration) bool {
+	select {
+	case <-l.loaded:
+	case <-time.After(timeout):
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.appsLoaded
 }
 
 // precomputeSearchData creates optimized data structures for fast searching
@@ -125,6 +201,10 @@ func (l *AppLauncher) Populate(query string, ctx *LauncherContext) []*LauncherIt
 	defer l.mu.Unlock()
 
 	query = strings.TrimSpace(query)
+	if !l.appsLoaded && len(l.partialApps) > 0 {
+		return l.searchPartial(query, l.config.Launcher.Search.MaxResults, ctx.CaseSensitive())
+	}
+
 	if query == "" {
 		// Return top apps by frecency score
 		maxResults := l.config.Launcher.Search.MaxResults
@@ -138,22 +218,27 @@ func (l *AppLauncher) Populate(query string, ctx *LauncherContext) []*LauncherIt
 
 	// Use fuzzy search with frecency ranking
 	maxResults := l.config.Launcher.Search.MaxResults
-	results := l.fuzzySearch(query, maxResults)
+	results := l.fuzzySearch(query, maxResults, ctx)
 	log.Printf("[APP-LAUNCHER] Fuzzy search completed in %v, returned %d results", time.Since(populateStart), len(results))
 	return results
 }
 
-func (l *AppLauncher) fuzzySearch(query string, maxResults int) []*LauncherItem {
+func (l *AppLauncher) fuzzySearch(query string, maxResults int, ctx *LauncherContext) []*LauncherItem {
 	fuzzyStart := time.Now()
 	log.Printf("[APP-LAUNCHER] Fuzzy search started for query='%s' against %d apps", query, len(l.apps))
 
 	findStart := time.Now()
-	matches := fuzzy.Find(query, l.appNames)
+	matches := fuzzyFind(query, l.appNames, ctx.CaseSensitive())
 	log.Printf("[APP-LAUNCHER] Fuzzy find completed in %v, found %d raw matches", time.Since(findStart), len(matches))
 
+	if ctx.Cancelled() {
+		return nil
+	}
+
 	type scoredMatch struct {
-		match fuzzy.Match
-		score float64
+		match    fuzzy.Match
+		frecency float64
+		score    float64
 	}
 
 	scoredMatches := make([]scoredMatch, 0, len(matches))
@@ -165,8 +250,9 @@ func (l *AppLauncher) fuzzySearch(query string, maxResults int) []*LauncherItem
 
 		weightedScore := float64(match.Score) + (frecencyScore * 2.0)
 		scoredMatches = append(scoredMatches, scoredMatch{
-			match: match,
-			score: weightedScore,
+			match:    match,
+			frecency: frecencyScore,
+			score:    weightedScore,
 		})
 	}
 
@@ -183,8 +269,9 @@ func (l *AppLauncher) fuzzySearch(query string, maxResults int) []*LauncherItem
 		scored := scoredMatches[i]
 		if app, ok := l.nameToApp[scored.match.Str]; ok {
 			item := l.appToItem(app)
+			item.MatchedIndexes = scored.match.MatchedIndexes
 			log.Printf("[APP-LAUNCHER] App '%s' - fuzzy_score=%d, frecency=%.2f, total=%.2f",
-				app.Name, scored.match.Score, l.frecencyTracker.GetFrecencyScore(app.Name), scored.score)
+				app.Name, scored.match.Score, scored.frecency, scored.score)
 			items = append(items, item)
 		}
 	}
@@ -193,6 +280,33 @@ func (l *AppLauncher) fuzzySearch(query string, maxResults int) []*LauncherItem
 	return items
 }
 
+// searchPartial searches the apps parsed so far during a scan
+func (l *AppLauncher) searchPartial(query string, maxResults int, caseSensitive bool) []*LauncherItem {
+	if query == "" {
+		partial := l.partialApps
+		if len(partial) > maxResults {
+			partial = partial[:maxResults]
+		}
+		return l.appsToItems(partial)
+	}
+
+	names := make([]string, len(l.partialApps))
+	for i, app := range l.partialApps {
+		names[i] = app.Name
+	}
+
+	matches := fuzzyFind(query, names, caseSensitive)
+	items := make([]*LauncherItem, 0, min(len(matches), maxResults))
+	for i := 0; i < len(matches) && i < maxResults; i++ {
+		item := l.appToItem(l.partialApps[matches[i].Index])
+		item.MatchedIndexes = matches[i].MatchedIndexes
+		items = append(items, item)
+	}
+
+	log.Printf("[APP-LAUNCHER] Partial search over %d apps returned %d results", len(l.partialApps), len(items))
+	return items
+}
+
 func (l *AppLauncher) getAppsSortedByFrecency() []apps.App {
 	if l.frecencyTracker == nil {
 		return l.apps
@@ -232,9 +346,16 @@ func (l *AppLauncher) appToItem(app apps.App) *LauncherItem {
 		icon = l.config.Launcher.Icons.FallbackIcon
 	}
 
+	// The desktop file's Comment describes the app; without one, show the
+	// command it runs
+	subtitle := app.Description
+	if subtitle == "" {
+		subtitle = apps.StripFieldCodes(app.Exec)
+	}
+
 	return &LauncherItem{
 		Title:      app.Name,
-		Subtitle:   app.Description,
+		Subtitle:   subtitle,
 		Icon:       icon,
 		ActionData: NewDesktopAction(app.File),
 		Launcher:   l,
@@ -249,6 +370,38 @@ func (l *AppLauncher) appsToItems(apps []apps.App) []*LauncherItem {
 	return items
 }
 
+// Preview describes the app behind item: its description, command and
+// category
+func (l *AppLauncher) Preview(item *LauncherItem) (Preview, bool) {
+	action, ok := item.ActionData.(*DesktopAction)
+	if !ok {
+		return Preview{}, false
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, list := range [][]apps.App{l.apps, l.partialApps} {
+		for _, app := range list {
+			if app.File == action.File {
+				return appPreview(app), true
+			}
+		}
+	}
+	return Preview{}, false
+}
+
+func appPreview(app apps.App) Preview {
+	var lines []string
+	if app.Description != "" {
+		lines = append(lines, app.Description, "")
+	}
+	lines = append(lines, "Command: "+app.Exec)
+	if app.Category != "" {
+		lines = append(lines, "Category: "+app.Category)
+	}
+	return Preview{Title: app.Name, Text: strings.Join(lines, "\n")}
+}
+
 func (l *AppLauncher) HandlesEnter() bool {
 	return false
 }
@@ -293,15 +446,15 @@ func (l *AppLauncher) Rebuild(ctx *LauncherContext) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	apps, err := l.appLoader.LoadApps(true)
+	loaded, err := l.appLoader.LoadApps(true)
 	if err != nil {
 		return fmt.Errorf("failed to reload apps: %w", err)
 	}
 
-	l.apps = apps
+	l.apps = apps.VisibleApps(loaded, l.config.Launcher.Search.ShowHiddenApps)
 	l.appsLoaded = true
 
-	log.Printf("[APP-LAUNCHER] Rebuilt: loaded %d apps", len(apps))
+	log.Printf("[APP-LAUNCHER] Rebuilt: loaded %d apps", len(l.apps))
 	return nil
 }
 
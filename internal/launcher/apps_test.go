@@ -0,0 +1,141 @@
+package launcher
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/apps"
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestAppLauncherPartialIndex(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Launcher.Search.MaxResults = 10
+	l := NewAppLauncher(cfg)
+
+	updates := 0
+	l.SetIndexUpdateCallback(func() { updates++ })
+
+	if items := l.Populate("fi", nil); len(items) != 0 {
+		t.Errorf("Expected no results before any app is parsed, got %d", len(items))
+	}
+
+	l.addPartialApp(apps.App{Name: "Firefox", File: "firefox.desktop"})
+	items := l.Populate("fi", nil)
+	if len(items) != 1 || items[0].Title != "Firefox" {
+		t.Fatalf("Expected partial match for Firefox, got %v", items)
+	}
+	if l.IndexComplete() {
+		t.Error("Index should not be complete while scanning")
+	}
+	if updates != 1 {
+		t.Errorf("Expected first parsed app to notify, got %d updates", updates)
+	}
+
+	l.addPartialApp(apps.App{Name: "Files", File: "files.desktop"})
+	if items := l.Populate("fi", nil); len(items) != 2 {
+		t.Errorf("Expected 2 partial matches, got %d", len(items))
+	}
+	if items := l.Populate("", nil); len(items) != 2 {
+		t.Errorf("Expected empty query to list partial apps, got %d", len(items))
+	}
+
+	l.finishLoad([]apps.App{
+		{Name: "Files", File: "files.desktop"},
+		{Name: "Firefox", File: "firefox.desktop"},
+		{Name: "Fish", File: "fish.desktop"},
+	})
+	if !l.IndexComplete() {
+		t.Error("Index should be complete after loading finishes")
+	}
+	if updates != 2 {
+		t.Errorf("Expected completion to notify, got %d updates", updates)
+	}
+	if items := l.Populate("fi", nil); len(items) != 3 {
+		t.Errorf("Expected 3 matches from the full index, got %d", len(items))
+	}
+
+	// Late parse callbacks after completion are ignored
+	l.addPartialApp(apps.App{Name: "Finch"})
+	if items := l.Populate("fi", nil); len(items) != 3 {
+		t.Errorf("Expected late partial app to be ignored, got %d", len(items))
+	}
+}
+
+func TestSearchDoesNotCachePartialResults(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	cfg.Launcher.Performance.SearchCacheSize = 50
+
+	r := NewLauncherRegistry(cfg)
+	l := NewAppLauncher(cfg)
+	if err := r.Register(l); err != nil {
+		t.Fatal(err)
+	}
+
+	l.addPartialApp(apps.App{Name: "Firefox", File: "firefox.desktop"})
+	if items, _ := r.Search("fire"); len(items) != 1 {
+		t.Fatalf("Expected partial result, got %d", len(items))
+	}
+	if r.searchCache.Contains("fire", r.currentAppsHash()) {
+		t.Error("Partial results must not be cached")
+	}
+
+	l.finishLoad([]apps.App{{Name: "Firefox", File: "firefox.desktop"}})
+	r.UpdateAppsHashFromLauncher()
+	r.Search("fire")
+	if !r.searchCache.Contains("fire", r.currentAppsHash()) {
+		t.Error("Expected results from the complete index to be cached")
+	}
+}
+
+func TestAppLauncherShowHiddenApps(t *testing.T) {
+	loaded := []apps.App{
+		{Name: "Settings", File: "settings.desktop"},
+		{Name: "Settings Daemon", File: "settings-daemon.desktop", NoDisplay: true},
+	}
+
+	for _, showHidden := range []bool{false, true} {
+		cfg := &config.Config{}
+		cfg.Launcher.Search.MaxResults = 10
+		cfg.Launcher.Search.ShowHiddenApps = showHidden
+		l := NewAppLauncher(cfg)
+
+		l.addPartialApp(loaded[1])
+		if items := l.Populate("daemon", nil); (len(items) == 1) != showHidden {
+			t.Errorf("show_hidden_apps=%v: got %d partial results for a hidden app", showHidden, len(items))
+		}
+
+		l.finishLoad(loaded)
+		want := 1
+		if showHidden {
+			want = 2
+		}
+		if items := l.Populate("settings", nil); len(items) != want {
+			t.Errorf("show_hidden_apps=%v: expected %d results, got %v", showHidden, want, titlesOf(items))
+		}
+		if items := l.Populate("", nil); len(items) != want {
+			t.Errorf("show_hidden_apps=%v: expected %d apps listed, got %v", showHidden, want, titlesOf(items))
+		}
+	}
+}
+
+func TestAppItemSubtitle(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Launcher.Search.MaxResults = 10
+	l := NewAppLauncher(cfg)
+	l.finishLoad([]apps.App{
+		{Name: "Firefox", File: "firefox.desktop", Exec: "firefox %u", Description: "Browse the World Wide Web"},
+		{Name: "Foot", File: "foot.desktop", Exec: "foot %F"},
+	})
+
+	subtitles := map[string]string{}
+	for _, item := range l.Populate("f", nil) {
+		subtitles[item.Title] = item.Subtitle
+	}
+	if got := subtitles["Firefox"]; got != "Browse the World Wide Web" {
+		t.Errorf("Expected the Comment as the subtitle, got %q", got)
+	}
+	if got := subtitles["Foot"]; got != "foot" {
+		t.Errorf("Expected the command without field codes as the subtitle, got %q", got)
+	}
+}
@@ -0,0 +1,248 @@
+package launcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Bookmark is a single entry of the user's bookmarks file
+type Bookmark struct {
+	Name string   `toml:"name" json:"name"`
+	URL  string   `toml:"url" json:"url"`
+	Tags []string `toml:"tags" json:"tags"`
+}
+
+type BookmarksLauncher struct {
+	config *config.Config
+
+	bookmarks []Bookmark
+	loadedAt  time.Time // mtime of the file when last loaded
+	mu        sync.Mutex
+}
+
+type BookmarksLauncherFactory struct{}
+
+func (f *BookmarksLauncherFactory) Name() string {
+	return "bookmarks"
+}
+
+func (f *BookmarksLauncherFactory) Create(cfg *config.Config) Launcher {
+	return NewBookmarksLauncher(cfg)
+}
+
+func init() {
+	RegisterLauncherFactory(&BookmarksLauncherFactory{})
+}
+
+func NewBookmarksLauncher(cfg *config.Config) *BookmarksLauncher {
+	return &BookmarksLauncher{
+		config: cfg,
+	}
+}
+
+func (l *BookmarksLauncher) Name() string {
+	return "bookmarks"
+}
+
+func (l *BookmarksLauncher) CommandTriggers() []string {
+	return []string{"bookmarks", "bm", "b"}
+}
+
+func (l *BookmarksLauncher) GetSizeMode() LauncherSizeMode {
+	return LauncherSizeModeDefault
+}
+
+func (l *BookmarksLauncher) GetGridConfig() *GridConfig {
+	return nil
+}
+
+func (l *BookmarksLauncher) bookmarksFile() string {
+	path := l.config.Launcher.Bookmarks.File
+	if path == "" {
+		path = "~/.config/locus/bookmarks.toml"
+	}
+	if strings.HasPrefix(path, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// refresh reloads the bookmarks file when its modification time changed
+func (l *BookmarksLauncher) refresh() error {
+	path := l.bookmarksFile()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		l.bookmarks = nil
+		l.loadedAt = time.Time{}
+		return err
+	}
+	if info.ModTime().Equal(l.loadedAt) {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	bookmarks, err := ParseBookmarks(data, filepath.Ext(path))
+	if err != nil {
+		return err
+	}
+
+	l.bookmarks = bookmarks
+	l.loadedAt = info.ModTime()
+	return nil
+}
+
+func (l *BookmarksLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.refresh(); err != nil {
+		subtitle := err.Error()
+		if os.IsNotExist(err) {
+			subtitle = "Create " + l.bookmarksFile() + " to add bookmarks"
+		}
+		return []*LauncherItem{{
+			Title:    "No bookmarks",
+			Subtitle: subtitle,
+			Icon:     "user-bookmarks",
+			Launcher: l,
+		}}
+	}
+
+	opener := l.config.Launcher.Bookmarks.Browser
+	if opener == "" {
+		opener = "xdg-open"
+	}
+
+	matches := FilterBookmarks(l.bookmarks, query, ctx.CaseSensitive())
+	items := make([]*LauncherItem, 0, len(matches))
+	for _, b := range matches {
+		subtitle := b.URL
+		if len(b.Tags) > 0 {
+			subtitle += " · #" + strings.Join(b.Tags, " #")
+		}
+		items = append(items, &LauncherItem{
+			Title:      b.Name,
+			Subtitle:   subtitle,
+			Icon:       "user-bookmarks",
+			ActionData: NewShScriptAction(opener + " " + shellQuote(b.URL)),
+			Launcher:   l,
+		})
+	}
+
+	return items
+}
+
+// ParseBookmarks parses a bookmarks file; ext selects JSON (".json") or TOML.
+// Entries without a URL are skipped and a missing name defaults to the URL.
+func ParseBookmarks(data []byte, ext string) ([]Bookmark, error) {
+	var bookmarks []Bookmark
+
+	if strings.EqualFold(ext, ".json") {
+		if err := json.Unmarshal(data, &bookmarks); err != nil {
+			return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+		}
+	} else {
+		var file struct {
+			Bookmarks []Bookmark `toml:"bookmarks"`
+		}
+		if err := toml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+		}
+		bookmarks = file.Bookmarks
+	}
+
+	valid := make([]Bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if b.URL == "" {
+			continue
+		}
+		if b.Name == "" {
+			b.Name = b.URL
+		}
+		valid = append(valid, b)
+	}
+
+	return valid, nil
+}
+
+// FilterBookmarks matches bookmarks against a query. Words starting with '#'
+// are tags that must all be present, in any case; other words must all
+// appear in the name or URL, ignoring case unless caseSensitive.
+func FilterBookmarks(bookmarks []Bookmark, query string, caseSensitive bool) []Bookmark {
+	var tags []string
+	var words []textMatcher
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "#") {
+			if tag := strings.TrimPrefix(field, "#"); tag != "" {
+				tags = append(tags, tag)
+			}
+		} else {
+			words = append(words, newTextMatcher(field, caseSensitive))
+		}
+	}
+
+	var matches []Bookmark
+	for _, b := range bookmarks {
+		if bookmarkMatches(b, tags, words) {
+			matches = append(matches, b)
+		}
+	}
+	return matches
+}
+
+func bookmarkMatches(b Bookmark, tags []string, words []textMatcher) bool {
+	for _, tag := range tags {
+		found := false
+		for _, t := range b.Tags {
+			if strings.EqualFold(t, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	haystack := b.Name + " " + b.URL
+	for _, word := range words {
+		if !word.Match(haystack) {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *BookmarksLauncher) GetHooks() []Hook {
+	return []Hook{}
+}
+
+func (l *BookmarksLauncher) Rebuild(ctx *LauncherContext) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.loadedAt = time.Time{}
+	return nil
+}
+
+func (l *BookmarksLauncher) Cleanup() {
+}
+
+func (l *BookmarksLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
+	return nil, false
+}
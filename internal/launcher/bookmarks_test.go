@@ -0,0 +1,90 @@
+package launcher
+
+import "testing"
+
+const sampleBookmarksTOML = `
+[[bookmarks]]
+name = "GitHub"
+url = "https://github.com"
+tags = ["dev", "code"]
+
+[[bookmarks]]
+name = "Go Docs"
+url = "https://go.dev/doc"
+tags = ["dev", "docs"]
+
+[[bookmarks]]
+url = "https://news.ycombinator.com"
+tags = ["news"]
+
+[[bookmarks]]
+name = "Broken"
+`
+
+func TestParseBookmarks(t *testing.T) {
+	bookmarks, err := ParseBookmarks([]byte(sampleBookmarksTOML), ".toml")
+	if err != nil {
+		t.Fatalf("Failed to parse TOML bookmarks: %v", err)
+	}
+	if len(bookmarks) != 3 {
+		t.Fatalf("Expected 3 bookmarks (entry without url skipped), got %d", len(bookmarks))
+	}
+	if bookmarks[2].Name != "https://news.ycombinator.com" {
+		t.Errorf("Expected missing name to default to URL, got %q", bookmarks[2].Name)
+	}
+
+	jsonData := `[{"name": "Mail", "url": "https://mail.example.com", "tags": ["work"]}]`
+	bookmarks, err = ParseBookmarks([]byte(jsonData), ".json")
+	if err != nil {
+		t.Fatalf("Failed to parse JSON bookmarks: %v", err)
+	}
+	if len(bookmarks) != 1 || bookmarks[0].Tags[0] != "work" {
+		t.Errorf("Unexpected JSON bookmarks: %+v", bookmarks)
+	}
+
+	if _, err := ParseBookmarks([]byte("[[bookmarks]\nname ="), ".toml"); err == nil {
+		t.Error("Expected error for malformed TOML")
+	}
+}
+
+func TestFilterBookmarks(t *testing.T) {
+	bookmarks, err := ParseBookmarks([]byte(sampleBookmarksTOML), ".toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"GitHub", "Go Docs", "https://news.ycombinator.com"}},
+		{"#dev", []string{"GitHub", "Go Docs"}},
+		{"#DEV #docs", []string{"Go Docs"}},
+		{"#dev git", []string{"GitHub"}},
+		{"ycombinator", []string{"https://news.ycombinator.com"}},
+		{"#news go", nil},
+		{"#", []string{"GitHub", "Go Docs", "https://news.ycombinator.com"}},
+	}
+
+	for _, tt := range tests {
+		got := FilterBookmarks(bookmarks, tt.query, false)
+		if len(got) != len(tt.expected) {
+			t.Errorf("Query %q: expected %v, got %+v", tt.query, tt.expected, got)
+			continue
+		}
+		for i, b := range got {
+			if b.Name != tt.expected[i] {
+				t.Errorf("Query %q: expected %v, got %+v", tt.query, tt.expected, got)
+				break
+			}
+		}
+	}
+
+	// Case sensitivity applies to words; tags always ignore case
+	if got := FilterBookmarks(bookmarks, "#DEV docs", true); len(got) != 0 {
+		t.Errorf("Expected no case sensitive match for docs, got %+v", got)
+	}
+	if got := FilterBookmarks(bookmarks, "#DEV Docs", true); len(got) != 1 || got[0].Name != "Go Docs" {
+		t.Errorf("Expected Go Docs for a case sensitive Docs, got %+v", got)
+	}
+}
@@ -2,6 +2,7 @@ package launcher
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/chess10kp/locus/internal/config"
@@ -297,11 +298,17 @@ func (l *HelpLauncher) Populate(query string, ctx *LauncherContext) []*LauncherI
 		}
 	}
 
+	matcher := newTextMatcher(query, ctx.CaseSensitive())
+	if strings.EqualFold(matcher.query, "triggers") {
+		return l.triggerItems(ctx.Registry)
+	}
+
 	launchers := ctx.Registry.GetAllLaunchers()
+	sort.Slice(launchers, func(i, j int) bool {
+		return launchers[i].Name() < launchers[j].Name()
+	})
 	items := make([]*LauncherItem, 0, len(launchers))
 
-	query = strings.ToLower(strings.TrimSpace(query))
-
 	for _, launcher := range launchers {
 		name := launcher.Name()
 		triggers := launcher.CommandTriggers()
@@ -311,67 +318,48 @@ func (l *HelpLauncher) Populate(query string, ctx *LauncherContext) []*LauncherI
 			triggerStr = strings.Join(triggers, ", ")
 		}
 
-		title := fmt.Sprintf("%s", name)
+		title := name
 		subtitle := fmt.Sprintf("Prefixes: %s", triggerStr)
 
-		if triggerStr == "" {
-			subtitle = "Default launcher (no prefix needed)"
+		prefix, hasPrefix := ctx.Registry.CustomPrefix(name)
+		if hasPrefix {
+			subtitle += fmt.Sprintf(" (custom: %s)", prefix)
 		}
 
-		if query != "" {
-			if !strings.Contains(strings.ToLower(title), query) && !strings.Contains(strings.ToLower(subtitle), query) {
-				continue
-			}
+		if triggerStr == "" && !hasPrefix {
+			subtitle = "Default launcher (no prefix needed)"
 		}
 
-		var icon string
-		switch name {
-		case "apps":
-			icon = "applications-other"
-		case "shell":
-			icon = "utilities-terminal"
-		case "web":
-			icon = "web-browser"
-		case "calc":
-			icon = "accessories-calculator"
-		case "timer":
-			icon = "clock"
-		case "help":
-			icon = "help-about"
-		case "wifi":
-			icon = "network-wireless"
-		case "music":
-			icon = "audio-x-generic"
-		case "wallpaper":
-			icon = "preferences-desktop-wallpaper"
-		case "file":
-			icon = "folder"
-		case "screenshot":
-			icon = "camera-photo"
-		case "lock":
-			icon = "system-lock-screen"
-		case "focus":
-			icon = "view-restore"
-		case "kill":
-			icon = "window-close"
-		case "brightness":
-			icon = "display-brightness"
-		case "clipboard":
-			icon = "edit-paste"
-		case "color":
-			icon = "color-select"
-		case "wm":
-			icon = "preferences-system-windows"
-		default:
-			icon = "application-x-executable"
+		if !matcher.Match(title, subtitle) {
+			continue
 		}
 
-		items = append(items, &LauncherItem{
+		item := &LauncherItem{
 			Title:    title,
 			Subtitle: subtitle,
-			Icon:     icon,
+			Icon:     helpIcon(name),
 			Launcher: l,
-		})
+		}
+		if trigger := ownedTrigger(ctx.Registry, launcher); trigger != "" {
+			item.ActionData = NewPrefillAction(triggerInput(trigger))
+		}
+		items = append(items, item)
+	}
+
+	if ctx.Config != nil {
+		for _, binding := range keyBindings(ctx.Config.Launcher.Keys) {
+			title := binding.action
+			subtitle := fmt.Sprintf("Keys: %s", strings.Join(binding.keys, ", "))
+			if !matcher.Match(title, subtitle) {
+				continue
+			}
+			items = append(items, &LauncherItem{
+				Title:    title,
+				Subtitle: subtitle,
+				Icon:     "input-keyboard",
+				Launcher: l,
+			})
+		}
 	}
 
 	if len(items) == 0 {
@@ -388,6 +376,138 @@ func (l *HelpLauncher) Populate(query string, ctx *LauncherContext) []*LauncherI
 	return items
 }
 
+// helpIcon returns the icon shown for a launcher in the help listing
+func helpIcon(name string) string {
+	switch name {
+	case "apps":
+		return "applications-other"
+	case "shell":
+		return "utilities-terminal"
+	case "web":
+		return "web-browser"
+	case "calc":
+		return "accessories-calculator"
+	case "timer":
+		return "clock"
+	case "help":
+		return "help-about"
+	case "wifi":
+		return "network-wireless"
+	case "music":
+		return "audio-x-generic"
+	case "wallpaper":
+		return "preferences-desktop-wallpaper"
+	case "file":
+		return "folder"
+	case "screenshot":
+		return "camera-photo"
+	case "define":
+		return "accessories-dictionary"
+	case "bookmarks":
+		return "user-bookmarks"
+	case "recent":
+		return "document-open-recent"
+	case "lock":
+		return "system-lock-screen"
+	case "focus":
+		return "view-restore"
+	case "kill":
+		return "window-close"
+	case "brightness":
+		return "display-brightness"
+	case "clipboard":
+		return "edit-paste"
+	case "color":
+		return "color-select"
+	case "wm":
+		return "preferences-system-windows"
+	default:
+		return "application-x-executable"
+	}
+
+}
+
+// ownedTrigger returns the first trigger of launcher that the registry maps
+// to it, falling back to its custom prefix
+func ownedTrigger(registry *LauncherRegistry, launcher Launcher) string {
+	for _, trigger := range launcher.CommandTriggers() {
+		if owner, ok := registry.GetLauncher(trigger); ok && owner == launcher {
+			return trigger
+		}
+	}
+	if prefix, ok := registry.CustomPrefix(launcher.Name()); ok {
+		return prefix
+	}
+	return ""
+}
+
+// triggerInput returns the search text that activates trigger
+func triggerInput(trigger string) string {
+	if trigger == "?" || trigger == "%" {
+		return trigger
+	}
+	return ">" + trigger + " "
+}
+
+type keyBinding struct {
+	action string
+	keys   []string
+}
+
+// keyBindings lists the configured keys for each launcher action
+func keyBindings(keys config.KeysConfig) []keyBinding {
+	bindings := []keyBinding{
+		{"Move up", keys.Up},
+		{"Move down", keys.Down},
+		{"Activate", keys.Activate},
+		{"Close", keys.Close},
+		{"Tab complete", keys.TabComplete},
+		{"Quick select", keys.QuickSelect},
+		{"Number action", keys.NumberAction},
+	}
+
+	result := bindings[:0]
+	for _, b := range bindings {
+		if len(b.keys) > 0 {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// triggerItems lists trigger conflicts followed by every trigger and the
+// launcher that owns it ("?triggers")
+func (l *HelpLauncher) triggerItems(registry *LauncherRegistry) []*LauncherItem {
+	items := []*LauncherItem{}
+
+	for _, conflict := range registry.TriggerConflicts() {
+		items = append(items, &LauncherItem{
+			Title:    fmt.Sprintf("Conflict: %s", conflict.Trigger),
+			Subtitle: fmt.Sprintf("Owned by %s, ignored for %s", conflict.Owner, conflict.Rejected),
+			Icon:     "dialog-warning",
+			Launcher: l,
+		})
+	}
+
+	triggers := registry.DiagnoseTriggers()
+	keys := make([]string, 0, len(triggers))
+	for trigger := range triggers {
+		keys = append(keys, trigger)
+	}
+	sort.Strings(keys)
+
+	for _, trigger := range keys {
+		items = append(items, &LauncherItem{
+			Title:    trigger,
+			Subtitle: fmt.Sprintf("→ %s", triggers[trigger]),
+			Icon:     "help-about",
+			Launcher: l,
+		})
+	}
+
+	return items
+}
+
 func (l *HelpLauncher) GetHooks() []Hook {
 	return []Hook{}
 }
@@ -100,6 +100,16 @@ func (c *SearchCache) Get(query, appsHash string) ([]*LauncherItem, bool) {
 	return nil, false
 }
 
+// Contains reports whether a query has an entry for appsHash, without
+// touching hit/miss statistics or recency
+func (c *SearchCache) Contains(query, appsHash string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, found := c.cache.Peek(c.makeKey(query, appsHash))
+	return found && entry.AppsHash == appsHash
+}
+
 // Put stores search results in the cache
 func (c *SearchCache) Put(query, appsHash string, results []*LauncherItem, durationMs float64) {
 	c.mu.Lock()
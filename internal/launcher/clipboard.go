@@ -1,13 +1,30 @@
 package launcher
 
 import (
+	"context"
+	"log"
+	"os/exec"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/chess10kp/locus/internal/config"
 )
 
+const clipboardCaptureTimeout = time.Second
+
+// clipboardClearAction is the custom action type of the "Clear Clipboard" item
+const clipboardClearAction = "clipboard_clear"
+
 type ClipboardLauncher struct {
-	config *config.Config
+	config  *config.Config
+	history *ClipboardHistory
+
+	// capturePending is set each time the launcher opens so the next search
+	// records the clipboard once, not on every keystroke
+	capturePending atomic.Bool
+
+	runCommand func(ctx context.Context, name string, args ...string) ([]byte, error)
 }
 
 type ClipboardLauncherFactory struct{}
@@ -25,9 +42,27 @@ func init() {
 }
 
 func NewClipboardLauncher(cfg *config.Config) *ClipboardLauncher {
-	return &ClipboardLauncher{
-		config: cfg,
+	cb := cfg.Launcher.Clipboard
+	l := &ClipboardLauncher{
+		config:     cfg,
+		history:    NewClipboardHistory(DataDir(cfg), cb.MaxEntries, maxImageBytes(cb)),
+		runCommand: runClipboardCommand,
 	}
+	l.capturePending.Store(true)
+	return l
+}
+
+// maxImageBytes returns the largest image kept in history, falling back to
+// the default when max_image_size is unset
+func maxImageBytes(cb config.ClipboardConfig) int {
+	if cb.MaxImageSize <= 0 {
+		return config.DefaultConfig.Launcher.Clipboard.MaxImageSize * 1024
+	}
+	return cb.MaxImageSize * 1024
+}
+
+func runClipboardCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
 }
 
 func (l *ClipboardLauncher) Name() string {
@@ -47,34 +82,84 @@ func (l *ClipboardLauncher) GetGridConfig() *GridConfig {
 }
 
 func (l *ClipboardLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	// Record what is on the clipboard now, so it shows up in the history
+	if l.capturePending.CompareAndSwap(true, false) {
+		captureCtx, cancel := context.WithTimeout(ctx.Context(), clipboardCaptureTimeout)
+		defer cancel()
+		if err := l.history.CaptureClipboard(captureCtx, l.runCommand); err != nil {
+			log.Printf("[CLIPBOARD] Failed to capture clipboard: %v", err)
+		}
+	}
+
 	q := strings.TrimSpace(query)
+	items := l.historyItems(q, ctx.CaseSensitive())
 	if q == "" {
-		return []*LauncherItem{
-			{
-				Title:      "Clear Clipboard",
-				Subtitle:   "Clear all clipboard history",
-				Icon:       "edit-clear-all",
-				ActionData: NewShellAction("wl-copy --clear"),
-				Launcher:   l,
-			},
-		}
+		return append(items, &LauncherItem{
+			Title:      "Clear Clipboard",
+			Subtitle:   "Clear all clipboard history",
+			Icon:       "edit-clear-all",
+			ActionData: NewCustomAction(clipboardClearAction, nil),
+			Launcher:   l,
+		})
 	}
 
-	items := []*LauncherItem{
-		{
-			Title:      "Show Clipboard History",
-			Subtitle:   "List and select from clipboard history",
+	items = append(items, &LauncherItem{
+		Title:      "Show Clipboard History",
+		Subtitle:   "List and select from clipboard history",
+		Icon:       "edit-paste",
+		ActionData: NewShellAction("cliphist list | head -50 | while read -r line; do echo \"$line\"; done | wl-copy -r 1"),
+		Launcher:   l,
+	})
+
+	return items
+}
+
+// historyItems lists kept entries matching query; images match "image" and
+// their type. Selecting an entry copies it back, images as images.
+func (l *ClipboardLauncher) historyItems(query string, caseSensitive bool) []*LauncherItem {
+	matcher := newTextMatcher(query, caseSensitive)
+
+	var items []*LauncherItem
+	for _, entry := range l.history.Entries() {
+		preview := entry.Preview()
+		if !matcher.Match(preview) {
+			continue
+		}
+
+		item := &LauncherItem{
+			Title:      preview,
+			Subtitle:   entry.AddedAt.Format("Jan 2 15:04"),
 			Icon:       "edit-paste",
-			ActionData: NewShellAction("cliphist list | head -50 | while read -r line; do echo \"$line\"; done | wl-copy -r 1"),
+			ActionData: NewShScriptAction(ClipboardCopyCommand(entry)),
 			Launcher:   l,
-		},
+		}
+		if entry.IsImage() {
+			item.Icon = "image-x-generic"
+			item.ImagePath = entry.Path
+		}
+		items = append(items, item)
 	}
-
 	return items
 }
 
+// LauncherShown implements ShowListener
+func (l *ClipboardLauncher) LauncherShown() {
+	l.capturePending.Store(true)
+}
+
+// clear empties both the kept history and the clipboard itself
+func (l *ClipboardLauncher) clear() error {
+	if err := l.history.Clear(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clipboardCaptureTimeout)
+	defer cancel()
+	_, err := l.runCommand(ctx, "wl-copy", "--clear")
+	return err
+}
+
 func (l *ClipboardLauncher) GetHooks() []Hook {
-	return []Hook{}
+	return []Hook{&clipboardHook{launcher: l}}
 }
 
 func (l *ClipboardLauncher) Rebuild(ctx *LauncherContext) error {
@@ -87,3 +172,37 @@ func (l *ClipboardLauncher) Cleanup() {
 func (l *ClipboardLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
 	return nil, false
 }
+
+// clipboardHook runs the "Clear Clipboard" item, which needs the launcher's
+// history rather than a shell command
+type clipboardHook struct {
+	launcher *ClipboardLauncher
+}
+
+func (h *clipboardHook) ID() string {
+	return "clipboard_hook"
+}
+
+func (h *clipboardHook) Priority() int {
+	return 100
+}
+
+func (h *clipboardHook) OnSelect(execCtx context.Context, ctx *HookContext, data ActionData) HookResult {
+	if data == nil || data.Type() != clipboardClearAction {
+		return HookResult{Handled: false}
+	}
+	if err := h.launcher.clear(); err != nil {
+		log.Printf("[CLIPBOARD] Failed to clear clipboard: %v", err)
+	}
+	return HookResult{Handled: true}
+}
+
+func (h *clipboardHook) OnEnter(execCtx context.Context, ctx *HookContext, text string) HookResult {
+	return HookResult{Handled: false}
+}
+
+func (h *clipboardHook) OnTab(execCtx context.Context, ctx *HookContext, text string) TabResult {
+	return TabResult{Handled: false}
+}
+
+func (h *clipboardHook) Cleanup() {}
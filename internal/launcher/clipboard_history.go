@@ -0,0 +1,253 @@
+package launcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultClipboardMaxEntries = 50
+	clipboardPreviewLength     = 80
+)
+
+// ClipboardEntry is one item of the clipboard history. Text is kept inline;
+// binary payloads such as images are stored in a file next to the history.
+type ClipboardEntry struct {
+	MIME    string    `json:"mime"`
+	Text    string    `json:"text,omitempty"`
+	Path    string    `json:"path,omitempty"`
+	Size    int       `json:"size"`
+	Hash    string    `json:"hash"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// IsImage reports whether the entry holds an image
+func (e *ClipboardEntry) IsImage() bool {
+	return strings.HasPrefix(e.MIME, "image/")
+}
+
+// Preview is a one-line description of the entry
+func (e *ClipboardEntry) Preview() string {
+	if e.IsImage() {
+		return fmt.Sprintf("Image (%s, %s)", strings.TrimPrefix(e.MIME, "image/"), formatByteSize(e.Size))
+	}
+	text := strings.Join(strings.Fields(e.Text), " ")
+	if runes := []rune(text); len(runes) > clipboardPreviewLength {
+		text = string(runes[:clipboardPreviewLength]) + "…"
+	}
+	return text
+}
+
+// ClipboardHistory keeps recent clipboard contents, newest first
+type ClipboardHistory struct {
+	mu           sync.Mutex
+	entries      []*ClipboardEntry
+	dir          string
+	maxEntries   int
+	maxImageSize int // bytes; larger images are not kept
+}
+
+// NewClipboardHistory loads the clipboard history kept under dataDir
+func NewClipboardHistory(dataDir string, maxEntries, maxImageSize int) *ClipboardHistory {
+	if maxEntries <= 0 {
+		maxEntries = defaultClipboardMaxEntries
+	}
+	h := &ClipboardHistory{
+		dir:          filepath.Join(dataDir, "clipboard"),
+		maxEntries:   maxEntries,
+		maxImageSize: maxImageSize,
+	}
+
+	if data, err := os.ReadFile(h.indexPath()); err == nil {
+		json.Unmarshal(data, &h.entries)
+	}
+	return h
+}
+
+func (h *ClipboardHistory) indexPath() string {
+	return filepath.Join(h.dir, "history.json")
+}
+
+// Entries returns the kept entries, newest first
+func (h *ClipboardHistory) Entries() []*ClipboardEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*ClipboardEntry(nil), h.entries...)
+}
+
+// Add records a clipboard payload of the given MIME type, moving it to the
+// front if it is already kept. Images over the size cap are rejected. A
+// payload matching the newest entry leaves the history untouched.
+func (h *ClipboardHistory) Add(mime string, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	sum := sha256.Sum256(data)
+	entry := &ClipboardEntry{
+		MIME:    mime,
+		Size:    len(data),
+		Hash:    hex.EncodeToString(sum[:]),
+		AddedAt: time.Now(),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.entries) > 0 && h.entries[0].Hash == entry.Hash {
+		return nil
+	}
+
+	if entry.IsImage() {
+		if h.maxImageSize > 0 && len(data) > h.maxImageSize {
+			return fmt.Errorf("image of %s exceeds the %s limit", formatByteSize(len(data)), formatByteSize(h.maxImageSize))
+		}
+		entry.Path = filepath.Join(h.dir, entry.Hash+clipboardImageExt(mime))
+		if err := os.MkdirAll(h.dir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(entry.Path, data, 0600); err != nil {
+			return err
+		}
+	} else {
+		entry.Text = string(data)
+	}
+
+	entries := []*ClipboardEntry{entry}
+	for _, e := range h.entries {
+		if e.Hash != entry.Hash {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) > h.maxEntries {
+		for _, dropped := range entries[h.maxEntries:] {
+			if dropped.Path != "" {
+				os.Remove(dropped.Path)
+			}
+		}
+		entries = entries[:h.maxEntries]
+	}
+	h.entries = entries
+	return h.save()
+}
+
+// Clear forgets every entry and removes stored payloads
+func (h *ClipboardHistory) Clear() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, e := range h.entries {
+		if e.Path != "" {
+			os.Remove(e.Path)
+		}
+	}
+	h.entries = nil
+	return h.save()
+}
+
+func (h *ClipboardHistory) save() error {
+	data, err := json.Marshal(h.entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(h.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(h.indexPath(), data, 0600)
+}
+
+// clipboardTextTypes are the text types wl-paste may offer, preferred first
+var clipboardTextTypes = []string{"text/plain;charset=utf-8", "UTF8_STRING", "text/plain", "STRING"}
+
+// ClipboardMIME picks the type to store from those wl-paste --list-types
+// offers: an image when there is one, PNG first, otherwise text. It returns
+// "" when neither is offered.
+func ClipboardMIME(types []string) string {
+	offered := make(map[string]bool, len(types))
+	image := ""
+	for _, t := range types {
+		t = strings.TrimSpace(t)
+		offered[t] = true
+		if strings.HasPrefix(t, "image/") && image == "" {
+			image = t
+		}
+	}
+
+	if offered["image/png"] {
+		return "image/png"
+	}
+	if image != "" {
+		return image
+	}
+	for _, t := range clipboardTextTypes {
+		if offered[t] {
+			return t
+		}
+	}
+	return ""
+}
+
+// CaptureClipboard reads the current clipboard with wl-paste through run
+// and adds it to history
+func (h *ClipboardHistory) CaptureClipboard(ctx context.Context, run func(ctx context.Context, name string, args ...string) ([]byte, error)) error {
+	out, err := run(ctx, "wl-paste", "--list-types")
+	if err != nil {
+		return err
+	}
+	mime := ClipboardMIME(strings.Split(strings.TrimSpace(string(out)), "\n"))
+	if mime == "" {
+		return nil
+	}
+
+	args := []string{"--type", mime}
+	if !strings.HasPrefix(mime, "image/") {
+		args = append(args, "--no-newline")
+	}
+	data, err := run(ctx, "wl-paste", args...)
+	if err != nil {
+		return err
+	}
+	return h.Add(mime, data)
+}
+
+// ClipboardCopyCommand is the shell command putting entry back on the
+// clipboard, as an image for image entries
+func ClipboardCopyCommand(entry *ClipboardEntry) string {
+	if entry.Path != "" {
+		return "wl-copy --type " + shellQuote(entry.MIME) + " < " + shellQuote(entry.Path)
+	}
+	return "wl-copy -- " + shellQuote(entry.Text)
+}
+
+func clipboardImageExt(mime string) string {
+	switch mime {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "image/bmp":
+		return ".bmp"
+	}
+	return ".img"
+}
+
+func formatByteSize(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
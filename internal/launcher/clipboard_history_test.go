@@ -0,0 +1,174 @@
+package launcher
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestClipboardMIME(t *testing.T) {
+	tests := []struct {
+		types []string
+		want  string
+	}{
+		{[]string{"text/html", "image/png", "text/plain"}, "image/png"},
+		{[]string{"image/jpeg", "image/png"}, "image/png"},
+		{[]string{"image/webp", "text/plain"}, "image/webp"},
+		{[]string{"TEXT", "STRING", "UTF8_STRING", "text/plain;charset=utf-8", "text/plain"}, "text/plain;charset=utf-8"},
+		{[]string{"text/plain", "STRING"}, "text/plain"},
+		{[]string{"x-special/gnome-copied-files"}, ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := ClipboardMIME(tt.types); got != tt.want {
+			t.Errorf("ClipboardMIME(%v) = %q, want %q", tt.types, got, tt.want)
+		}
+	}
+}
+
+func TestClipboardCopyCommand(t *testing.T) {
+	image := &ClipboardEntry{MIME: "image/png", Path: "/data/clipboard/ab12.png"}
+	if got, want := ClipboardCopyCommand(image), "wl-copy --type 'image/png' < '/data/clipboard/ab12.png'"; got != want {
+		t.Errorf("ClipboardCopyCommand(image) = %q, want %q", got, want)
+	}
+
+	text := &ClipboardEntry{MIME: "text/plain", Text: "it's here"}
+	if got, want := ClipboardCopyCommand(text), `wl-copy -- 'it'\''s here'`; got != want {
+		t.Errorf("ClipboardCopyCommand(text) = %q, want %q", got, want)
+	}
+}
+
+func TestClipboardHistoryAdd(t *testing.T) {
+	dir := t.TempDir()
+	h := NewClipboardHistory(dir, 2, 1024)
+
+	png := []byte("\x89PNG fake image")
+	if err := h.Add("image/png", png); err != nil {
+		t.Fatal(err)
+	}
+	entries := h.Entries()
+	if len(entries) != 1 || !entries[0].IsImage() || !strings.HasSuffix(entries[0].Path, ".png") {
+		t.Fatalf("expected a stored image entry, got %+v", entries)
+	}
+	if data, err := os.ReadFile(entries[0].Path); err != nil || string(data) != string(png) {
+		t.Errorf("expected the image payload on disk, got %q, %v", data, err)
+	}
+	imagePath := entries[0].Path
+
+	if err := h.Add("image/png", make([]byte, 2048)); err == nil {
+		t.Errorf("expected images over the size cap to be rejected")
+	}
+
+	h.Add("text/plain", []byte("first"))
+	h.Add("text/plain", []byte("second"))
+	entries = h.Entries()
+	if len(entries) != 2 || entries[0].Text != "second" || entries[1].Text != "first" {
+		t.Fatalf("expected the two newest entries, got %+v", entries)
+	}
+	if _, err := os.Stat(imagePath); !os.IsNotExist(err) {
+		t.Errorf("expected the dropped image's payload to be removed")
+	}
+
+	// Re-adding moves an entry to the front instead of duplicating it
+	h.Add("text/plain", []byte("first"))
+	if entries = h.Entries(); len(entries) != 2 || entries[0].Text != "first" {
+		t.Errorf("expected first to move to the front, got %+v", entries)
+	}
+
+	// Seeing the newest entry again leaves the history file alone
+	os.Remove(h.indexPath())
+	h.Add("text/plain", []byte("first"))
+	if _, err := os.Stat(h.indexPath()); !os.IsNotExist(err) {
+		t.Errorf("expected an unchanged clipboard not to rewrite the history")
+	}
+
+	h.save()
+
+	// The history survives a restart
+	if reloaded := NewClipboardHistory(dir, 2, 1024).Entries(); len(reloaded) != 2 || reloaded[0].Text != "first" {
+		t.Errorf("expected the history to be reloaded, got %+v", reloaded)
+	}
+}
+
+func TestMaxImageBytes(t *testing.T) {
+	defaultBytes := config.DefaultConfig.Launcher.Clipboard.MaxImageSize * 1024
+	if got := maxImageBytes(config.ClipboardConfig{}); got != defaultBytes {
+		t.Errorf("unset max_image_size = %d bytes, want %d", got, defaultBytes)
+	}
+	if got := maxImageBytes(config.ClipboardConfig{MaxImageSize: -1}); got != defaultBytes {
+		t.Errorf("negative max_image_size = %d bytes, want %d", got, defaultBytes)
+	}
+	if got := maxImageBytes(config.ClipboardConfig{MaxImageSize: 64}); got != 64*1024 {
+		t.Errorf("max_image_size 64 = %d bytes, want %d", got, 64*1024)
+	}
+}
+
+func TestClipboardLauncherCapturesImages(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Clipboard.MaxImageSize = 64
+	l := NewClipboardLauncher(cfg)
+
+	var calls []string
+	l.runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		if args[0] == "--list-types" {
+			return []byte("image/png\ntext/html\n"), nil
+		}
+		return []byte("\x89PNG pixels"), nil
+	}
+
+	items := l.Populate("", &LauncherContext{Config: cfg})
+	if len(calls) != 2 || calls[1] != "wl-paste --type image/png" {
+		t.Errorf("expected the image to be read as PNG, got %v", calls)
+	}
+	if len(items) != 2 || items[0].ImagePath == "" || !strings.HasPrefix(items[0].Title, "Image (png") {
+		t.Fatalf("expected an image entry before the clear item, got %v", titlesOf(items))
+	}
+	action, ok := items[0].ActionData.(*ShellAction)
+	if !ok || !strings.Contains(action.Command, "wl-copy --type 'image/png' < ") {
+		t.Errorf("expected selecting the image to copy it back as an image, got %+v", items[0].ActionData)
+	}
+
+	if items := l.Populate("image", &LauncherContext{Config: cfg}); len(items) != 2 || items[0].ImagePath == "" {
+		t.Errorf("expected images to match \"image\", got %v", titlesOf(items))
+	}
+	if len(calls) != 2 {
+		t.Errorf("expected the clipboard to be read once per show, got %v", calls)
+	}
+
+	l.LauncherShown()
+	l.Populate("", &LauncherContext{Config: cfg})
+	if len(calls) != 4 {
+		t.Errorf("expected the clipboard to be read again after the launcher opened, got %v", calls)
+	}
+}
+
+func TestClipboardLauncherClear(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	l := NewClipboardLauncher(cfg)
+	l.capturePending.Store(false)
+	l.history.Add("text/plain", []byte("secret"))
+
+	var calls []string
+	l.runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		return nil, nil
+	}
+
+	items := l.Populate("", &LauncherContext{Config: cfg})
+	clearItem := items[len(items)-1]
+	hook := l.GetHooks()[0]
+	if result := hook.OnSelect(context.Background(), &HookContext{}, clearItem.ActionData); !result.Handled {
+		t.Fatalf("expected the clipboard hook to handle %q", clearItem.Title)
+	}
+	if entries := l.history.Entries(); len(entries) != 0 {
+		t.Errorf("expected the kept history to be cleared, got %+v", entries)
+	}
+	if len(calls) != 1 || calls[0] != "wl-copy --clear" {
+		t.Errorf("expected the clipboard itself to be cleared, got %v", calls)
+	}
+}
@@ -0,0 +1,22 @@
+package launcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/chess10kp/locus/internal/command"
+)
+
+// CommandRunner runs external commands and returns their standard output.
+// Launchers that shell out take one so tests can stub the output;
+// command.Runner runs them for real, within the configured command timeout.
+type CommandRunner interface {
+	// Output runs name with args, killing it when ctx is done
+	Output(ctx context.Context, name string, args ...string) ([]byte, error)
+
+	// RunWithTimeout runs name with args, killing it after timeout
+	RunWithTimeout(timeout time.Duration, name string, args ...string) ([]byte, error)
+}
+
+// defaultRunner runs the commands of package-level helpers
+var defaultRunner CommandRunner = command.Runner{Timeout: command.DefaultTimeout}
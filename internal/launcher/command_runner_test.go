@@ -0,0 +1,29 @@
+package launcher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// fakeRunner answers commands from canned output keyed by the command line,
+// recording the commands it was asked to run
+type fakeRunner struct {
+	outputs map[string]string
+	ran     []string
+}
+
+func (r *fakeRunner) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	line := strings.Join(append([]string{name}, args...), " ")
+	r.ran = append(r.ran, line)
+	output, ok := r.outputs[line]
+	if !ok {
+		return nil, errors.New("exit status 1")
+	}
+	return []byte(output), nil
+}
+
+func (r *fakeRunner) RunWithTimeout(timeout time.Duration, name string, args ...string) ([]byte, error) {
+	return r.Output(context.Background(), name, args...)
+}
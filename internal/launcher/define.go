@@ -0,0 +1,381 @@
+package launcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+const (
+	defaultDefineAPIURL     = "https://api.dictionaryapi.dev/api/v2/entries/en/%s"
+	defineLookupTimeout     = 3 * time.Second
+	maxDefineCacheEntries   = 200
+	defaultDefineMaxResults = 10
+
+	// dict exits with these when the word has no exact match
+	dictExitNoMatch     = 20
+	dictExitApproxMatch = 21
+)
+
+// Definition is a single sense of a looked-up word
+type Definition struct {
+	PartOfSpeech string
+	Text         string
+}
+
+type DefineLauncher struct {
+	config *config.Config
+
+	cache   map[string][]Definition
+	cacheMu sync.Mutex
+
+	lookPath   func(string) (string, error)
+	runCommand func(ctx context.Context, name string, args ...string) ([]byte, error)
+	httpGet    func(ctx context.Context, url string) ([]byte, error)
+}
+
+type DefineLauncherFactory struct{}
+
+func (f *DefineLauncherFactory) Name() string {
+	return "define"
+}
+
+func (f *DefineLauncherFactory) Create(cfg *config.Config) Launcher {
+	return NewDefineLauncher(cfg)
+}
+
+func init() {
+	RegisterLauncherFactory(&DefineLauncherFactory{})
+}
+
+func NewDefineLauncher(cfg *config.Config) *DefineLauncher {
+	return &DefineLauncher{
+		config:     cfg,
+		cache:      make(map[string][]Definition),
+		lookPath:   exec.LookPath,
+		runCommand: runDefineCommand,
+		httpGet:    httpGetBody,
+	}
+}
+
+func (l *DefineLauncher) Name() string {
+	return "define"
+}
+
+func (l *DefineLauncher) CommandTriggers() []string {
+	return []string{"define", "def", "dict"}
+}
+
+func (l *DefineLauncher) GetSizeMode() LauncherSizeMode {
+	return LauncherSizeModeDefault
+}
+
+func (l *DefineLauncher) GetGridConfig() *GridConfig {
+	return nil
+}
+
+func (l *DefineLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	word := strings.ToLower(strings.TrimSpace(query))
+	if word == "" {
+		return []*LauncherItem{{
+			Title:    "Define a word",
+			Subtitle: "Type a word to look up its definition",
+			Icon:     "accessories-dictionary",
+			Launcher: l,
+		}}
+	}
+
+	defs, err := l.Lookup(word)
+	if err != nil {
+		return []*LauncherItem{{
+			Title:    "Lookup failed",
+			Subtitle: err.Error(),
+			Icon:     "dialog-warning",
+			Launcher: l,
+		}}
+	}
+	if len(defs) == 0 {
+		return []*LauncherItem{{
+			Title:    fmt.Sprintf("No definitions for '%s'", word),
+			Icon:     "accessories-dictionary",
+			Launcher: l,
+		}}
+	}
+
+	maxResults := l.config.Launcher.Define.MaxResults
+	if maxResults <= 0 {
+		maxResults = defaultDefineMaxResults
+	}
+
+	items := make([]*LauncherItem, 0, min(len(defs), maxResults))
+	for i := 0; i < len(defs) && i < maxResults; i++ {
+		def := defs[i]
+		subtitle := word
+		if def.PartOfSpeech != "" {
+			subtitle += " · " + def.PartOfSpeech
+		}
+		items = append(items, &LauncherItem{
+			Title:      def.Text,
+			Subtitle:   subtitle,
+			Icon:       "accessories-dictionary",
+			ActionData: NewShScriptAction("wl-copy " + shellQuote(def.Text)),
+			Launcher:   l,
+		})
+	}
+
+	return items
+}
+
+// Lookup returns definitions for word, consulting the cache first.
+// Failed lookups are not cached.
+func (l *DefineLauncher) Lookup(word string) ([]Definition, error) {
+	l.cacheMu.Lock()
+	defs, ok := l.cache[word]
+	l.cacheMu.Unlock()
+	if ok {
+		return defs, nil
+	}
+
+	defs, err := l.lookupUncached(word)
+	if err != nil {
+		return nil, err
+	}
+
+	l.cacheMu.Lock()
+	if len(l.cache) >= maxDefineCacheEntries {
+		l.cache = make(map[string][]Definition)
+	}
+	l.cache[word] = defs
+	l.cacheMu.Unlock()
+
+	return defs, nil
+}
+
+func (l *DefineLauncher) lookupUncached(word string) ([]Definition, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defineLookupTimeout)
+	defer cancel()
+
+	cfg := l.config.Launcher.Define
+	backend := cfg.Backend
+	if backend == "" || backend == "auto" {
+		switch {
+		case l.hasTool("dict"):
+			backend = "dict"
+		case l.hasTool("wn"):
+			backend = "wn"
+		default:
+			return nil, fmt.Errorf("no dictionary tool found (install dict or wordnet, or set backend = \"api\")")
+		}
+	}
+
+	switch backend {
+	case "dict":
+		out, err := l.runCommand(ctx, "dict", "--", word)
+		if err != nil {
+			if code, ok := exitCode(err); ok && (code == dictExitNoMatch || code == dictExitApproxMatch) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("dict failed: %w", err)
+		}
+		return parseDictOutput(string(out)), nil
+	case "wn":
+		// wn always reads the word from its first argument and has no "--",
+		// so keep a leading dash from being taken for a search option
+		if strings.HasPrefix(word, "-") {
+			return nil, nil
+		}
+		// wn exits with the number of senses it found, so only empty output
+		// tells whether it matched
+		out, err := l.runCommand(ctx, "wn", word, "-over")
+		if len(out) > 0 {
+			return parseWnOutput(string(out)), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("wn failed: %w", err)
+		}
+		return nil, nil
+	case "api":
+		apiURL := cfg.APIURL
+		if apiURL == "" {
+			apiURL = defaultDefineAPIURL
+		}
+		body, err := l.httpGet(ctx, fmt.Sprintf(apiURL, url.PathEscape(word)))
+		if err != nil {
+			return nil, fmt.Errorf("dictionary API unreachable: %w", err)
+		}
+		return parseDictionaryAPIResponse(body)
+	default:
+		return nil, fmt.Errorf("unknown dictionary backend: %s", backend)
+	}
+}
+
+func (l *DefineLauncher) hasTool(name string) bool {
+	_, err := l.lookPath(name)
+	return err == nil
+}
+
+func runDefineCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// exitCode returns the status a command exited with, if err reports one
+func exitCode(err error) (int, bool) {
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), true
+	}
+	return 0, false
+}
+
+// httpGetBody fetches url, treating 404 as an empty result
+func httpGetBody(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return []byte("[]"), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+var (
+	dictSenseRe = regexp.MustCompile(`^\s+(?:(n|v|adj|adv)\s+)?\d+:\s+(.*)$`)
+	wnOverRe    = regexp.MustCompile(`^Overview of (\w+)`)
+	wnSenseRe   = regexp.MustCompile(`^\d+\.\s+(?:\(\d+\)\s+)?.*?--\s+\((.*)\)\s*$`)
+)
+
+var dictPartsOfSpeech = map[string]string{
+	"n":   "noun",
+	"v":   "verb",
+	"adj": "adjective",
+	"adv": "adverb",
+}
+
+// parseDictOutput extracts numbered senses from `dict` (WordNet-style) output
+func parseDictOutput(out string) []Definition {
+	var defs []Definition
+	pos := ""
+	inSense := false
+
+	for _, line := range strings.Split(out, "\n") {
+		if m := dictSenseRe.FindStringSubmatch(line); m != nil {
+			if m[1] != "" {
+				pos = dictPartsOfSpeech[m[1]]
+			}
+			defs = append(defs, Definition{PartOfSpeech: pos, Text: strings.TrimSpace(m[2])})
+			inSense = true
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(line, "From ") || !strings.HasPrefix(line, "   ") {
+			inSense = false
+			continue
+		}
+		if inSense {
+			defs[len(defs)-1].Text += " " + trimmed
+		}
+	}
+
+	for i := range defs {
+		defs[i].Text = stripDefinitionExamples(defs[i].Text)
+	}
+	return defs
+}
+
+// parseWnOutput extracts senses from `wn <word> -over` output
+func parseWnOutput(out string) []Definition {
+	var defs []Definition
+	pos := ""
+
+	for _, line := range strings.Split(out, "\n") {
+		if m := wnOverRe.FindStringSubmatch(line); m != nil {
+			pos = m[1]
+			continue
+		}
+		if m := wnSenseRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			defs = append(defs, Definition{PartOfSpeech: pos, Text: stripDefinitionExamples(m[1])})
+		}
+	}
+
+	return defs
+}
+
+// stripDefinitionExamples drops the quoted usage examples WordNet appends
+func stripDefinitionExamples(text string) string {
+	if i := strings.Index(text, `; "`); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
+
+// parseDictionaryAPIResponse parses a dictionaryapi.dev style response
+func parseDictionaryAPIResponse(body []byte) ([]Definition, error) {
+	var entries []struct {
+		Meanings []struct {
+			PartOfSpeech string `json:"partOfSpeech"`
+			Definitions  []struct {
+				Definition string `json:"definition"`
+			} `json:"definitions"`
+		} `json:"meanings"`
+	}
+
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse dictionary response: %w", err)
+	}
+
+	var defs []Definition
+	for _, entry := range entries {
+		for _, meaning := range entry.Meanings {
+			for _, d := range meaning.Definitions {
+				if d.Definition == "" {
+					continue
+				}
+				defs = append(defs, Definition{PartOfSpeech: meaning.PartOfSpeech, Text: d.Definition})
+			}
+		}
+	}
+
+	return defs, nil
+}
+
+func (l *DefineLauncher) GetHooks() []Hook {
+	return []Hook{}
+}
+
+func (l *DefineLauncher) Rebuild(ctx *LauncherContext) error {
+	return nil
+}
+
+func (l *DefineLauncher) Cleanup() {
+	l.cacheMu.Lock()
+	l.cache = make(map[string][]Definition)
+	l.cacheMu.Unlock()
+}
+
+func (l *DefineLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
+	return nil, false
+}
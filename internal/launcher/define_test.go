@@ -0,0 +1,199 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+const sampleDictOutput = `1 definition found
+
+From WordNet (r) 3.0 (2006) [wn]:
+
+  word
+      n 1: a unit of language that native speakers can identify;
+           "words are the blocks from which sentences are made"
+      2: a brief statement; "he didn't say a word about it"
+      v 1: put into words or an expression; "He formulated his
+           concerns to the board of trustees"
+`
+
+const sampleWnOutput = `
+Overview of noun word
+
+The noun word has 2 senses (first 2 from tagged texts)
+
+1. (48) word -- (a unit of language that native speakers can identify; "words are the blocks from which sentences are made")
+2. (16) news, intelligence, tidings, word -- (information about recent and important events; "they awaited news of the outcome")
+
+Overview of verb word
+
+The verb word has 1 sense (first 1 from tagged texts)
+
+1. (1) give voice, formulate, word, phrase, articulate -- (put into words or an expression)
+`
+
+const sampleAPIResponse = `[{"word":"word","meanings":[
+	{"partOfSpeech":"noun","definitions":[{"definition":"The smallest unit of language."},{"definition":""}]},
+	{"partOfSpeech":"verb","definitions":[{"definition":"To say or write using particular words."}]}
+]}]`
+
+func TestParseDictOutput(t *testing.T) {
+	defs := parseDictOutput(sampleDictOutput)
+
+	expected := []Definition{
+		{"noun", "a unit of language that native speakers can identify"},
+		{"noun", "a brief statement"},
+		{"verb", "put into words or an expression"},
+	}
+	if len(defs) != len(expected) {
+		t.Fatalf("Expected %d definitions, got %d: %+v", len(expected), len(defs), defs)
+	}
+	for i, def := range defs {
+		if def != expected[i] {
+			t.Errorf("Definition %d: expected %+v, got %+v", i, expected[i], def)
+		}
+	}
+}
+
+func TestParseWnOutput(t *testing.T) {
+	defs := parseWnOutput(sampleWnOutput)
+
+	expected := []Definition{
+		{"noun", "a unit of language that native speakers can identify"},
+		{"noun", "information about recent and important events"},
+		{"verb", "put into words or an expression"},
+	}
+	if len(defs) != len(expected) {
+		t.Fatalf("Expected %d definitions, got %d: %+v", len(expected), len(defs), defs)
+	}
+	for i, def := range defs {
+		if def != expected[i] {
+			t.Errorf("Definition %d: expected %+v, got %+v", i, expected[i], def)
+		}
+	}
+}
+
+func TestParseDictionaryAPIResponse(t *testing.T) {
+	defs, err := parseDictionaryAPIResponse([]byte(sampleAPIResponse))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(defs) != 2 || defs[0].PartOfSpeech != "noun" || defs[1].Text != "To say or write using particular words." {
+		t.Errorf("Unexpected definitions: %+v", defs)
+	}
+
+	if _, err := parseDictionaryAPIResponse([]byte(`{"title":"oops"`)); err == nil {
+		t.Error("Expected error for malformed response")
+	}
+}
+
+func TestDefineLookupCache(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.Launcher.Define.Backend = "api"
+	l := NewDefineLauncher(&cfg)
+
+	calls := 0
+	fail := false
+	l.httpGet = func(ctx context.Context, url string) ([]byte, error) {
+		calls++
+		if fail {
+			return nil, fmt.Errorf("offline")
+		}
+		return []byte(sampleAPIResponse), nil
+	}
+
+	for i := 0; i < 3; i++ {
+		defs, err := l.Lookup("word")
+		if err != nil || len(defs) != 2 {
+			t.Fatalf("Lookup %d failed: %v (%d defs)", i, err, len(defs))
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 API call with caching, got %d", calls)
+	}
+
+	fail = true
+	if _, err := l.Lookup("other"); err == nil {
+		t.Error("Expected error when offline")
+	}
+	fail = false
+	if _, err := l.Lookup("other"); err != nil {
+		t.Errorf("Expected failed lookup not to be cached, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 API calls, got %d", calls)
+	}
+}
+
+func TestDefineNoTools(t *testing.T) {
+	cfg := config.DefaultConfig
+	l := NewDefineLauncher(&cfg)
+	l.lookPath = func(name string) (string, error) { return "", fmt.Errorf("not found") }
+
+	items := l.Populate("word", nil)
+	if len(items) != 1 || items[0].Title != "Lookup failed" || items[0].ActionData != nil {
+		t.Errorf("Expected a single informational item without tools, got %+v", items)
+	}
+}
+
+func TestDefineDictEndsOptions(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.Launcher.Define.Backend = "dict"
+	l := NewDefineLauncher(&cfg)
+
+	var got []string
+	l.runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		got = append([]string{name}, args...)
+		return []byte(sampleDictOutput), nil
+	}
+
+	if _, err := l.Lookup("-word"); err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if len(got) != 3 || got[0] != "dict" || got[1] != "--" || got[2] != "-word" {
+		t.Errorf("Expected dict -- -word, got %v", got)
+	}
+}
+
+// exitStatus stands in for *exec.ExitError
+type exitStatus int
+
+func (e exitStatus) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e exitStatus) ExitCode() int { return int(e) }
+
+func TestDefineDictFailures(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.Launcher.Define.Backend = "dict"
+	l := NewDefineLauncher(&cfg)
+
+	var runErr error
+	calls := 0
+	l.runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		calls++
+		if runErr != nil {
+			return nil, runErr
+		}
+		return []byte(sampleDictOutput), nil
+	}
+
+	runErr = exitStatus(dictExitNoMatch)
+	if defs, err := l.Lookup("zzz"); err != nil || len(defs) != 0 {
+		t.Errorf("Expected no match without an error, got %v, %v", defs, err)
+	}
+
+	// A server failure is reported and retried on the next lookup
+	runErr = exitStatus(1)
+	if _, err := l.Lookup("word"); err == nil {
+		t.Error("Expected an error when dict fails")
+	}
+	runErr = nil
+	if defs, err := l.Lookup("word"); err != nil || len(defs) != 3 {
+		t.Errorf("Expected the failed lookup not to be cached, got %v, %v", defs, err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 dict calls, got %d", calls)
+	}
+}
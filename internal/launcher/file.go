@@ -2,7 +2,6 @@ package launcher
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -14,6 +13,7 @@ import (
 )
 
 type FileLauncher struct {
+	FileActions
 	config *config.Config
 }
 
@@ -42,7 +42,7 @@ func (l *FileLauncher) Name() string {
 }
 
 func (l *FileLauncher) CommandTriggers() []string {
-	return []string{"file", "f"}
+	return []string{"file"}
 }
 
 func (l *FileLauncher) GetSizeMode() LauncherSizeMode {
@@ -75,7 +75,8 @@ func (l *FileLauncher) Populate(query string, launcherCtx *LauncherContext) []*L
 					Title:      filepath.Base(path),
 					Subtitle:   path,
 					Icon:       "folder",
-					ActionData: NewShellAction(l.config.FileSearch.FileOpener + " " + path),
+					ActionData: fileOpenAction(l.config.FileSearch, path),
+					Metadata:   map[string]string{"path": path},
 					Launcher:   l,
 				})
 			}
@@ -84,17 +85,24 @@ func (l *FileLauncher) Populate(query string, launcherCtx *LauncherContext) []*L
 		return items
 	}
 
-	q = strings.ToLower(q)
+	nameTest := "-iname"
+	if launcherCtx.CaseSensitive() {
+		nameTest = "-name"
+	}
 	homeDir, _ := os.UserHomeDir()
 
 	// Execute find command with timeout to prevent hanging
-	cmdCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	cmdCtx, cancel := context.WithTimeout(launcherCtx.Context(), 2*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(cmdCtx, "find", homeDir, "-iname", "*"+q+"*", "-type", "f", "-size", "-100M", "-maxdepth", "4")
+	cmd := exec.CommandContext(cmdCtx, "find", homeDir, nameTest, "*"+q+"*", "-type", "f", "-size", "-100M", "-maxdepth", "4")
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
 	output, err := cmd.CombinedOutput()
 
+	if launcherCtx.Cancelled() {
+		return nil
+	}
+
 	if cmdCtx.Err() == context.DeadlineExceeded {
 		return []*LauncherItem{
 			{
@@ -134,7 +142,8 @@ func (l *FileLauncher) Populate(query string, launcherCtx *LauncherContext) []*L
 			Title:      filename,
 			Subtitle:   absPath,
 			Icon:       l.getFileIcon(filename),
-			ActionData: NewShellAction(fmt.Sprintf("%s %s", l.config.FileSearch.FileOpener, absPath)),
+			ActionData: fileOpenAction(l.config.FileSearch, absPath),
+			Metadata:   map[string]string{"path": absPath},
 			Launcher:   l,
 		})
 
@@ -146,6 +155,23 @@ func (l *FileLauncher) Populate(query string, launcherCtx *LauncherContext) []*L
 	return items
 }
 
+// fileOpenAction opens path with fileOpenerFor's opener, quoting the path
+func fileOpenAction(cfg config.FileSearchConfig, path string) *ShellAction {
+	return NewShScriptAction(fileOpenerFor(cfg, path) + " " + shellQuote(path))
+}
+
+// fileOpenerFor returns the opener configured for path's extension,
+// falling back to FileOpener and then xdg-open
+func fileOpenerFor(cfg config.FileSearchConfig, path string) string {
+	if opener, ok := cfg.FileOpeners[strings.ToLower(filepath.Ext(path))]; ok && opener != "" {
+		return opener
+	}
+	if cfg.FileOpener != "" {
+		return cfg.FileOpener
+	}
+	return "xdg-open"
+}
+
 func (l *FileLauncher) getFileIcon(filename string) string {
 	ext := strings.ToLower(filepath.Ext(filename))
 	switch ext {
@@ -174,7 +200,3 @@ func (l *FileLauncher) Rebuild(ctx *LauncherContext) error {
 
 func (l *FileLauncher) Cleanup() {
 }
-
-func (l *FileLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
-	return nil, false
-}
@@ -0,0 +1,73 @@
+package launcher
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+)
+
+// fileCtrlAction is a Ctrl+number action on a file result
+type fileCtrlAction struct {
+	label   string
+	command string
+}
+
+// fileCtrlActionCount is how many Ctrl+number actions fileCtrlActions gives
+const fileCtrlActionCount = 3
+
+// fileCtrlActions returns the Ctrl+number actions for the file at path, in
+// number order: copy its path, open its folder, and show its properties
+// through the freedesktop FileManager1 interface
+func fileCtrlActions(path string) []fileCtrlAction {
+	uri := (&url.URL{Scheme: "file", Path: path}).String()
+	return []fileCtrlAction{
+		{"Copy path", "wl-copy -- " + shellQuote(path)},
+		{"Open folder", "xdg-open " + shellQuote(filepath.Dir(path))},
+		{"Properties", "dbus-send --session --print-reply --dest=org.freedesktop.FileManager1 " +
+			"/org/freedesktop/FileManager1 org.freedesktop.FileManager1.ShowItemProperties " +
+			shellQuote("array:string:"+uri) + " string:"},
+	}
+}
+
+// FileActions gives file-oriented launchers Ctrl+number actions for items
+// whose "path" metadata names a file. Embed it in the launcher.
+type FileActions struct{}
+
+// CtrlNumberActions describes the Ctrl+number actions for item
+func (FileActions) CtrlNumberActions(item *LauncherItem) map[int]string {
+	path := item.Metadata["path"]
+	if path == "" {
+		return nil
+	}
+
+	actions := fileCtrlActions(path)
+	labels := make(map[int]string, len(actions))
+	for i, action := range actions {
+		labels[i+1] = action.label
+	}
+	return labels
+}
+
+func (FileActions) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
+	if number < 1 || number > fileCtrlActionCount {
+		return nil, false
+	}
+	return func(item *LauncherItem) error {
+		path := item.Metadata["path"]
+		if path == "" {
+			return fmt.Errorf("item is not a file")
+		}
+
+		// Start the action detached so a slow file manager or clipboard tool
+		// doesn't block the UI
+		cmd := exec.Command("sh", "-c", fileCtrlActions(path)[number-1].command)
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start file action: %w", err)
+		}
+		go cmd.Wait()
+		return nil
+	}, true
+}
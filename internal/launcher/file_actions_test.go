@@ -0,0 +1,50 @@
+package launcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFileCtrlActions(t *testing.T) {
+	actions := fileCtrlActions("/home/user/My Docs/it's.pdf")
+	if len(actions) != fileCtrlActionCount {
+		t.Fatalf("Expected %d actions, got %d", fileCtrlActionCount, len(actions))
+	}
+
+	want := []fileCtrlAction{
+		{"Copy path", `wl-copy -- '/home/user/My Docs/it'\''s.pdf'`},
+		{"Open folder", `xdg-open '/home/user/My Docs'`},
+		{"Properties", "dbus-send --session --print-reply --dest=org.freedesktop.FileManager1 " +
+			"/org/freedesktop/FileManager1 org.freedesktop.FileManager1.ShowItemProperties " +
+			`'array:string:file:///home/user/My%20Docs/it%27s.pdf' string:`},
+	}
+	if !reflect.DeepEqual(actions, want) {
+		t.Errorf("fileCtrlActions() =\n%+v\nwant\n%+v", actions, want)
+	}
+}
+
+func TestFileActionsLabels(t *testing.T) {
+	var launchers = []CtrlNumberActionMap{&FileLauncher{}, &RecentFilesLauncher{}, &MusicLauncher{}}
+	file := &LauncherItem{Title: "notes.txt", Metadata: map[string]string{"path": "/tmp/notes.txt"}}
+
+	for _, l := range launchers {
+		labels := l.CtrlNumberActions(file)
+		if want := map[int]string{1: "Copy path", 2: "Open folder", 3: "Properties"}; !reflect.DeepEqual(labels, want) {
+			t.Errorf("%T labels = %v, want %v", l, labels, want)
+		}
+		if labels := l.CtrlNumberActions(&LauncherItem{Title: "Search Error"}); labels != nil {
+			t.Errorf("%T should have no actions for items without a path, got %v", l, labels)
+		}
+	}
+
+	action, ok := (&FileLauncher{}).GetCtrlNumberAction(1)
+	if !ok {
+		t.Fatal("Expected a Ctrl+1 action")
+	}
+	if err := action(&LauncherItem{Title: "Search Error"}); err == nil {
+		t.Error("Expected an error for an item without a path")
+	}
+	if _, ok := (&FileLauncher{}).GetCtrlNumberAction(4); ok {
+		t.Error("Expected no action for an unbound number")
+	}
+}
@@ -0,0 +1,78 @@
+package launcher
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// fileWatchMask covers in-place writes as well as the rename-over and
+// delete/recreate sequences editors use to save
+const fileWatchMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+// fileWatcher calls onChange whenever a single file is written, replaced or
+// removed. It watches the parent directory so saves that replace the file
+// are still seen.
+type fileWatcher struct {
+	file *os.File
+	done chan struct{}
+}
+
+// watchFile starts watching path; the directory containing it must exist
+func watchFile(path string, onChange func()) (*fileWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_NONBLOCK | syscall.IN_CLOEXEC)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := syscall.InotifyAddWatch(fd, filepath.Dir(path), fileWatchMask); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+
+	// A non-blocking fd goes through the runtime poller, so closing the file
+	// unblocks the pending read
+	w := &fileWatcher{
+		file: os.NewFile(uintptr(fd), "inotify"),
+		done: make(chan struct{}),
+	}
+	go w.run(filepath.Base(path), onChange)
+	return w, nil
+}
+
+func (w *fileWatcher) run(name string, onChange func()) {
+	defer close(w.done)
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+
+		changed := false
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			start := offset + syscall.SizeofInotifyEvent
+			end := start + int(event.Len)
+			if end > n {
+				break
+			}
+			if string(bytes.TrimRight(buf[start:end], "\x00")) == name {
+				changed = true
+			}
+			offset = end
+		}
+		if changed {
+			onChange()
+		}
+	}
+}
+
+// Close stops the watch and waits for the reader goroutine to exit
+func (w *fileWatcher) Close() {
+	w.file.Close()
+	<-w.done
+}
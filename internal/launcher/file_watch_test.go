@@ -0,0 +1,60 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watched.toml")
+
+	changes := make(chan struct{}, 16)
+	w, err := watchFile(path, func() { changes <- struct{}{} })
+	if err != nil {
+		t.Fatalf("watchFile failed: %v", err)
+	}
+	defer w.Close()
+
+	expectChange := func(what string) {
+		t.Helper()
+		select {
+		case <-changes:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected a change after %s", what)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectChange("creating the file")
+
+	// Editors often save by renaming a temporary file over the original
+	tmp := filepath.Join(dir, "watched.toml.tmp")
+	if err := os.WriteFile(tmp, []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for len(changes) > 0 {
+		<-changes
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	expectChange("renaming over the file")
+
+	// Other files in the directory are ignored
+	for len(changes) > 0 {
+		<-changes
+	}
+	if err := os.WriteFile(filepath.Join(dir, "other"), []byte("c"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changes:
+		t.Error("Expected no change for another file")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
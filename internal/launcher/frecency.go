@@ -161,6 +161,17 @@ func (f *FrecencyTracker) calculateTrendScore(recentLaunches []int64, now time.T
 	return score
 }
 
+// LastLaunched returns when appName was last launched, zero if never
+func (f *FrecencyTracker) LastLaunched(appName string) time.Time {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if record, exists := f.records[appName]; exists {
+		return record.LastLaunched
+	}
+	return time.Time{}
+}
+
 func (f *FrecencyTracker) GetUsageStats(appName string) *AppUsageRecord {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
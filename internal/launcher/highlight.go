@@ -0,0 +1,101 @@
+package launcher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MatchSpan is a contiguous byte range [Start, End) of matched text
+type MatchSpan struct {
+	Start int
+	End   int
+}
+
+var markupEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\"", "&quot;",
+	"'", "&#39;",
+)
+
+// EscapeMarkup escapes text for use in Pango markup
+func EscapeMarkup(text string) string {
+	return markupEscaper.Replace(text)
+}
+
+// MatchSpans merges matched byte indexes (as reported by the fuzzy matcher)
+// into contiguous spans. Each index covers the full rune starting there;
+// out-of-range indexes are ignored.
+func MatchSpans(text string, indexes []int) []MatchSpan {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	sorted := make([]int, len(indexes))
+	copy(sorted, indexes)
+	sort.Ints(sorted)
+
+	var spans []MatchSpan
+	for _, idx := range sorted {
+		if idx < 0 || idx >= len(text) {
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[idx:])
+		end := idx + size
+
+		if n := len(spans); n > 0 && idx <= spans[n-1].End {
+			if end > spans[n-1].End {
+				spans[n-1].End = end
+			}
+			continue
+		}
+		spans = append(spans, MatchSpan{Start: idx, End: end})
+	}
+
+	return spans
+}
+
+// HighlightMarkup returns Pango markup for text with the matched characters
+// emphasized in bold, tinted with color when it is set
+func HighlightMarkup(text string, indexes []int, color string) string {
+	spans := MatchSpans(text, indexes)
+
+	openTag := "<b>"
+	closeTag := "</b>"
+	if color != "" {
+		openTag = fmt.Sprintf(`<span weight="bold" foreground="%s">`, EscapeMarkup(color))
+		closeTag = "</span>"
+	}
+
+	var b strings.Builder
+	pos := 0
+	for _, span := range spans {
+		b.WriteString(EscapeMarkup(text[pos:span.Start]))
+		b.WriteString(openTag)
+		b.WriteString(EscapeMarkup(text[span.Start:span.End]))
+		b.WriteString(closeTag)
+		pos = span.End
+	}
+	b.WriteString(EscapeMarkup(text[pos:]))
+
+	return b.String()
+}
+
+// TruncateRunes shortens text to at most max runes, ending it with an
+// ellipsis when cut. It never splits a multibyte character; max <= 0
+// leaves text unchanged.
+func TruncateRunes(text string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(text) <= max {
+		return text
+	}
+
+	end := 0
+	for i := 0; i < max-1; i++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+	return text[:end] + "…"
+}
@@ -0,0 +1,96 @@
+package launcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMatchSpans(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		indexes  []int
+		expected []MatchSpan
+	}{
+		{"no matches", "Firefox", nil, nil},
+		{"single", "Firefox", []int{0}, []MatchSpan{{0, 1}}},
+		{"adjacent merged", "Firefox", []int{0, 1, 2}, []MatchSpan{{0, 3}}},
+		{"separate", "Firefox", []int{0, 4}, []MatchSpan{{0, 1}, {4, 5}}},
+		{"unsorted", "Firefox", []int{4, 0, 1}, []MatchSpan{{0, 2}, {4, 5}}},
+		{"out of range ignored", "abc", []int{-1, 1, 10}, []MatchSpan{{1, 2}}},
+		{"multibyte rune", "café", []int{3}, []MatchSpan{{3, 5}}},
+	}
+
+	for _, tt := range tests {
+		got := MatchSpans(tt.text, tt.indexes)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestHighlightMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		indexes  []int
+		color    string
+		expected string
+	}{
+		{"plain", "Files", nil, "", "Files"},
+		{"bold", "Files", []int{0, 1}, "", "<b>Fi</b>les"},
+		{"colored", "Files", []int{4}, "#fabd2f", `File<span weight="bold" foreground="#fabd2f">s</span>`},
+		{"escapes title", "<b>A&B</b>", []int{3}, "", "&lt;b&gt;<b>A</b>&amp;B&lt;/b&gt;"},
+	}
+
+	for _, tt := range tests {
+		got := HighlightMarkup(tt.text, tt.indexes, tt.color)
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		max      int
+		expected string
+	}{
+		{"short", "Firefox", 10, "Firefox"},
+		{"exact", "Firefox", 7, "Firefox"},
+		{"ascii cut", "Firefox Web Browser", 8, "Firefox…"},
+		{"no limit", "Firefox", 0, "Firefox"},
+		{"accented", "Café crème brûlée", 6, "Café …"},
+		{"middle dot", "code · ~/src/locus", 7, "code ·…"},
+		{"cjk", "日本語のテキスト", 4, "日本語…"},
+		{"emoji", "🎵🎶🎸🎹", 3, "🎵🎶…"},
+		{"single", "abc", 1, "…"},
+	}
+
+	for _, tt := range tests {
+		got := TruncateRunes(tt.text, tt.max)
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("%s: result %q is not valid UTF-8", tt.name, got)
+		}
+		if tt.max > 0 && utf8.RuneCountInString(got) > tt.max {
+			t.Errorf("%s: %q is longer than %d runes", tt.name, got, tt.max)
+		}
+	}
+}
+
+func TestTruncateRunesNeverSplitsRunes(t *testing.T) {
+	// Every cut point of a string made only of multibyte runes must stay valid
+	text := strings.Repeat("é·日🎵", 20)
+	for max := 1; max <= utf8.RuneCountInString(text)+1; max++ {
+		if got := TruncateRunes(text, max); !utf8.ValidString(got) {
+			t.Fatalf("max %d split a rune: %q", max, got)
+		}
+	}
+}
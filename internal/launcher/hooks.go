@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -93,18 +94,59 @@ type AsyncHook interface {
 	OnTabAsync(ctx *HookContext, text string) <-chan TabResult
 }
 
+// Hook events recorded in HandlerRecords
+const (
+	HookEventSelect = "select"
+	HookEventEnter  = "enter"
+	HookEventTab    = "tab"
+)
+
+// HandlerRecord identifies the hook that handled an event
+type HandlerRecord struct {
+	Event        string
+	LauncherName string
+	HookID       string
+	Time         time.Time
+}
+
 // HookStats tracks hook execution statistics
 type HookStats struct {
 	TotalExecutions      int64
 	SuccessfulExecutions int64
 	FailedExecutions     int64
 	AverageExecutionTime time.Duration
+	lastHandlers         map[string]HandlerRecord // event -> last handler
 	mu                   sync.RWMutex
 }
 
 // NewHookStats creates a new HookStats instance
 func NewHookStats() *HookStats {
-	return &HookStats{}
+	return &HookStats{lastHandlers: make(map[string]HandlerRecord)}
+}
+
+// RecordHandler records the hook that handled an event
+func (s *HookStats) RecordHandler(event, launcherName, hookID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastHandlers == nil {
+		s.lastHandlers = make(map[string]HandlerRecord)
+	}
+	s.lastHandlers[event] = HandlerRecord{
+		Event:        event,
+		LauncherName: launcherName,
+		HookID:       hookID,
+		Time:         time.Now(),
+	}
+}
+
+// LastHandler returns the hook that handled the last event of the given kind
+func (s *HookStats) LastHandler(event string) (HandlerRecord, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.lastHandlers[event]
+	return record, ok
 }
 
 // RecordExecution records a hook execution result
@@ -143,11 +185,15 @@ func (s *HookStats) GetStats() HookStats {
 	}
 }
 
+// DefaultHookTimeout bounds each synchronous hook call
+const DefaultHookTimeout = 2 * time.Second
+
 // HookRegistry manages hooks for all launchers
 type HookRegistry struct {
 	hooks         map[string][]Hook // launcherName -> sorted hooks
 	stats         *HookStats
 	asyncExecutor *AsyncExecutor
+	hookTimeout   time.Duration
 	mu            sync.RWMutex
 }
 
@@ -192,9 +238,62 @@ func NewHookRegistry() *HookRegistry {
 		hooks:         make(map[string][]Hook),
 		stats:         NewHookStats(),
 		asyncExecutor: NewAsyncExecutor(10), // Max 10 concurrent async hooks
+		hookTimeout:   DefaultHookTimeout,
 	}
 }
 
+// SetHookTimeout sets how long a synchronous hook may run before it is
+// skipped; non-positive values run hooks inline without a deadline
+func (r *HookRegistry) SetHookTimeout(timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hookTimeout = timeout
+}
+
+// runHook runs fn with the hook timeout. fn runs in its own goroutine so a
+// hook blocked on a subprocess can't hang the caller; its context is
+// cancelled once the deadline passes. Panics are returned as errors.
+func (r *HookRegistry) runHook(execCtx context.Context, event string, hook Hook, fn func(context.Context)) error {
+	if execCtx == nil {
+		execCtx = context.Background()
+	}
+
+	r.mu.RLock()
+	timeout := r.hookTimeout
+	r.mu.RUnlock()
+
+	if timeout <= 0 {
+		return callHook(execCtx, event, hook, fn)
+	}
+
+	hookCtx, cancel := context.WithTimeout(execCtx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- callHook(hookCtx, event, hook, fn)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-hookCtx.Done():
+		return fmt.Errorf("%s hook '%s' timed out after %v", event, hook.ID(), timeout)
+	}
+}
+
+// callHook calls fn, converting a panic into an error
+func callHook(ctx context.Context, event string, hook Hook, fn func(context.Context)) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic in %s hook '%s': %v", event, hook.ID(), p)
+		}
+	}()
+
+	fn(ctx)
+	return nil
+}
+
 // Register registers a hook for a launcher
 func (r *HookRegistry) Register(launcherName string, hook Hook) error {
 	r.mu.Lock()
@@ -288,6 +387,7 @@ func (r *HookRegistry) ExecuteSelectHooks(execCtx context.Context, ctx *HookCont
 
 		if result.Handled {
 			log.Printf("[HOOK-REGISTRY] Hook '%s' handled OnSelect event", hook.ID())
+			r.stats.RecordHandler(HookEventSelect, ctx.LauncherName, hook.ID())
 			return result
 		}
 
@@ -307,15 +407,15 @@ func (r *HookRegistry) ExecuteSelectHooks(execCtx context.Context, ctx *HookCont
 	return HookResult{Handled: false}
 }
 
-// executeSingleHookSelect executes a single hook and recovers from panics
+// executeSingleHookSelect executes a single hook with the hook timeout
 func (r *HookRegistry) executeSingleHookSelect(execCtx context.Context, hook Hook, ctx *HookContext, data ActionData) (HookResult, error) {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("[HOOK-REGISTRY] Panic in OnSelect hook '%s': %v", hook.ID(), r)
-		}
-	}()
-
-	result := hook.OnSelect(execCtx, ctx, data)
+	var result HookResult
+	err := r.runHook(execCtx, "OnSelect", hook, func(hookCtx context.Context) {
+		result = hook.OnSelect(hookCtx, ctx, data)
+	})
+	if err != nil {
+		return HookResult{}, err
+	}
 	return result, nil
 }
 
@@ -346,6 +446,7 @@ func (r *HookRegistry) ExecuteEnterHooks(execCtx context.Context, ctx *HookConte
 
 		if result.Handled {
 			log.Printf("[HOOK-REGISTRY] Hook '%s' handled OnEnter event", hook.ID())
+			r.stats.RecordHandler(HookEventEnter, ctx.LauncherName, hook.ID())
 			return result
 		}
 
@@ -358,16 +459,17 @@ func (r *HookRegistry) ExecuteEnterHooks(execCtx context.Context, ctx *HookConte
 	return HookResult{Handled: false}
 }
 
-// executeSingleHookEnter executes a single hook and recovers from panics
+// executeSingleHookEnter executes a single hook with the hook timeout
 func (r *HookRegistry) executeSingleHookEnter(execCtx context.Context, hook Hook, ctx *HookContext, text string) (HookResult, error) {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("[HOOK-REGISTRY] Panic in OnEnter hook '%s': %v", hook.ID(), r)
-		}
-	}()
-
 	log.Printf("[HOOK-REGISTRY] Executing OnEnter hook '%s' for launcher '%s'", hook.ID(), ctx.LauncherName)
-	result := hook.OnEnter(execCtx, ctx, text)
+
+	var result HookResult
+	err := r.runHook(execCtx, "OnEnter", hook, func(hookCtx context.Context) {
+		result = hook.OnEnter(hookCtx, ctx, text)
+	})
+	if err != nil {
+		return HookResult{}, err
+	}
 	return result, nil
 }
 
@@ -398,6 +500,7 @@ func (r *HookRegistry) ExecuteTabHooks(execCtx context.Context, ctx *HookContext
 
 		if result.Handled {
 			log.Printf("[HOOK-REGISTRY] Hook '%s' handled OnTab event with text: '%s'", hook.ID(), result.NewText)
+			r.stats.RecordHandler(HookEventTab, ctx.LauncherName, hook.ID())
 			return result
 		}
 	}
@@ -405,19 +508,33 @@ func (r *HookRegistry) ExecuteTabHooks(execCtx context.Context, ctx *HookContext
 	return TabResult{Handled: false}
 }
 
-// executeSingleHookTab executes a single hook and recovers from panics
+// executeSingleHookTab executes a single hook with the hook timeout
 func (r *HookRegistry) executeSingleHookTab(execCtx context.Context, hook Hook, ctx *HookContext, text string) (TabResult, error) {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("[HOOK-REGISTRY] Panic in OnTab hook '%s': %v", hook.ID(), r)
-		}
-	}()
-
 	log.Printf("[HOOK-REGISTRY] Executing OnTab hook '%s' for launcher '%s'", hook.ID(), ctx.LauncherName)
-	result := hook.OnTab(execCtx, ctx, text)
+
+	var result TabResult
+	err := r.runHook(execCtx, "OnTab", hook, func(hookCtx context.Context) {
+		result = hook.OnTab(hookCtx, ctx, text)
+	})
+	if err != nil {
+		return TabResult{}, err
+	}
 	return result, nil
 }
 
+// HasAsyncHooks reports whether any hook for launcherName implements AsyncHook
+func (r *HookRegistry) HasAsyncHooks(launcherName string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, hook := range r.hooks[launcherName] {
+		if _, ok := hook.(AsyncHook); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // ExecuteSelectHooksAsync executes all OnSelect hooks asynchronously
 func (r *HookRegistry) ExecuteSelectHooksAsync(ctx *HookContext, data ActionData, timeout time.Duration) HookResult {
 	r.mu.RLock()
@@ -452,6 +569,7 @@ func (r *HookRegistry) ExecuteSelectHooksAsync(ctx *HookContext, data ActionData
 	defer cancel()
 
 	// Start all async hooks
+	started := 0
 	for _, hook := range asyncHooks {
 		h := hook // Capture loop variable
 		err := r.asyncExecutor.ExecuteWithTimeout(func() {
@@ -488,16 +606,19 @@ func (r *HookRegistry) ExecuteSelectHooksAsync(ctx *HookContext, data ActionData
 		if err != nil {
 			// Executor queue full, log and continue
 			log.Printf("[HOOK-REGISTRY] Failed to execute async hook '%s': %v", h.ID(), err)
+			continue
 		}
+		started++
 	}
 
 	// Collect results with timeout
 	collected := 0
-	for collected < len(asyncHooks) {
+	for collected < started {
 		select {
 		case result := <-results:
 			collected++
 			if result.result.Handled {
+				r.stats.RecordHandler(HookEventSelect, ctx.LauncherName, result.hook.ID())
 				return result.result
 			}
 			if result.result.StopPropagation {
@@ -525,6 +646,32 @@ func (r *HookRegistry) GetStats() HookStats {
 	return r.stats.GetStats()
 }
 
+// LastHandler returns the hook that handled the last event of the given kind
+func (r *HookRegistry) LastHandler(event string) (HandlerRecord, bool) {
+	return r.stats.LastHandler(event)
+}
+
+// DebugSummary describes hook statistics and the hooks that handled the
+// last select, enter and tab events
+func (r *HookRegistry) DebugSummary() string {
+	stats := r.stats.GetStats()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "hooks: %d executions (%d ok, %d failed), avg %v",
+		stats.TotalExecutions, stats.SuccessfulExecutions, stats.FailedExecutions, stats.AverageExecutionTime)
+
+	for _, event := range []string{HookEventSelect, HookEventEnter, HookEventTab} {
+		record, ok := r.stats.LastHandler(event)
+		if !ok {
+			fmt.Fprintf(&b, "; last %s: none", event)
+			continue
+		}
+		fmt.Fprintf(&b, "; last %s: %s/%s at %s", event, record.LauncherName, record.HookID, record.Time.Format("15:04:05"))
+	}
+
+	return b.String()
+}
+
 // Cleanup cleans up all hooks
 func (r *HookRegistry) Cleanup() {
 	r.mu.Lock()
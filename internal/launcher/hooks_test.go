@@ -2,6 +2,7 @@ package launcher
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -321,3 +322,197 @@ func TestHookContext(t *testing.T) {
 		t.Error("SendStatus callback should not be nil")
 	}
 }
+
+// MockAsyncHook is an AsyncHook whose OnSelect takes delay to complete
+type MockAsyncHook struct {
+	MockHook
+	delay  time.Duration
+	result HookResult
+}
+
+func (h *MockAsyncHook) OnSelectAsync(ctx *HookContext, data ActionData) <-chan HookResult {
+	ch := make(chan HookResult, 1)
+	go func() {
+		time.Sleep(h.delay)
+		ch <- h.result
+	}()
+	return ch
+}
+
+func (h *MockAsyncHook) OnEnterAsync(ctx *HookContext, text string) <-chan HookResult {
+	ch := make(chan HookResult, 1)
+	ch <- HookResult{}
+	return ch
+}
+
+func (h *MockAsyncHook) OnTabAsync(ctx *HookContext, text string) <-chan TabResult {
+	ch := make(chan TabResult, 1)
+	ch <- TabResult{}
+	return ch
+}
+
+func TestHookRegistryExecuteSelectHooksAsync(t *testing.T) {
+	registry := NewHookRegistry()
+	registry.Register("fast", &MockAsyncHook{
+		MockHook: MockHook{id: "fast"},
+		delay:    10 * time.Millisecond,
+		result:   HookResult{Handled: true},
+	})
+	registry.Register("slow", &MockAsyncHook{
+		MockHook: MockHook{id: "slow"},
+		delay:    2 * time.Second,
+		result:   HookResult{Handled: true},
+	})
+	registry.Register("sync", &MockHook{id: "sync"})
+
+	if !registry.HasAsyncHooks("fast") || registry.HasAsyncHooks("sync") {
+		t.Error("HasAsyncHooks did not detect async hooks correctly")
+	}
+
+	result := registry.ExecuteSelectHooksAsync(&HookContext{LauncherName: "fast"}, NewShellAction("true"), time.Second)
+	if !result.Handled || result.Error != nil {
+		t.Errorf("Expected fast hook to handle the action, got %+v", result)
+	}
+
+	start := time.Now()
+	result = registry.ExecuteSelectHooksAsync(&HookContext{LauncherName: "slow"}, NewShellAction("true"), 50*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Slow hook blocked for %v despite timeout", elapsed)
+	}
+	if result.Handled || result.Error == nil {
+		t.Errorf("Expected timeout error from slow hook, got %+v", result)
+	}
+}
+
+func TestMusicHookIsAsync(t *testing.T) {
+	var hook Hook = NewMusicHook(NewMusicLauncher(&config.Config{}))
+	if _, ok := hook.(AsyncHook); !ok {
+		t.Fatal("MusicHook should implement AsyncHook")
+	}
+
+	// Unknown actions are left to default execution
+	result := <-hook.(AsyncHook).OnSelectAsync(&HookContext{LauncherName: "music"}, NewMusicAction("view_queue", ""))
+	if result.Handled {
+		t.Error("Expected view_queue to be left unhandled")
+	}
+}
+
+func TestHookRegistryTimeout(t *testing.T) {
+	registry := NewHookRegistry()
+	registry.SetHookTimeout(50 * time.Millisecond)
+
+	cancelled := make(chan struct{})
+	var order []string
+	registry.Register("test", &MockHook{
+		id:       "slow",
+		priority: 1,
+		onSelect: func(execCtx context.Context, ctx *HookContext, data ActionData) HookResult {
+			<-execCtx.Done()
+			close(cancelled)
+			return HookResult{Handled: true}
+		},
+		onEnter: func(execCtx context.Context, ctx *HookContext, text string) HookResult {
+			time.Sleep(time.Second)
+			return HookResult{Handled: true}
+		},
+	})
+	registry.Register("test", &MockHook{
+		id:       "fast",
+		priority: 2,
+		onSelect: func(execCtx context.Context, ctx *HookContext, data ActionData) HookResult {
+			order = append(order, "fast")
+			return HookResult{Handled: true}
+		},
+	})
+
+	ctx := &HookContext{LauncherName: "test"}
+
+	start := time.Now()
+	result := registry.ExecuteSelectHooks(context.Background(), ctx, NewShellAction("true"))
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Slow hook was not skipped after the timeout (took %v)", elapsed)
+	}
+	if !result.Handled || len(order) != 1 {
+		t.Errorf("Expected the next hook to handle the event after the timeout, got %+v", result)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected the timed out hook's context to be cancelled")
+	}
+
+	start = time.Now()
+	result = registry.ExecuteEnterHooks(context.Background(), ctx, "text")
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Slow OnEnter hook was not skipped after the timeout (took %v)", elapsed)
+	}
+	if result.Handled {
+		t.Error("Timed out hook result should be discarded")
+	}
+}
+
+func TestHookRegistryRecoversPanics(t *testing.T) {
+	registry := NewHookRegistry()
+	registry.Register("test", &MockHook{
+		id:       "panics",
+		priority: 1,
+		onSelect: func(execCtx context.Context, ctx *HookContext, data ActionData) HookResult {
+			panic("boom")
+		},
+	})
+	registry.Register("test", &MockHook{
+		id:       "handles",
+		priority: 2,
+		onSelect: func(execCtx context.Context, ctx *HookContext, data ActionData) HookResult {
+			return HookResult{Handled: true}
+		},
+	})
+
+	result := registry.ExecuteSelectHooks(context.Background(), &HookContext{LauncherName: "test"}, NewShellAction("true"))
+	if !result.Handled {
+		t.Error("Expected the hook after a panicking hook to run")
+	}
+}
+
+func TestHookRegistryLastHandler(t *testing.T) {
+	registry := NewHookRegistry()
+	registry.Register("music", &MockHook{
+		id: "music_hook",
+		onTab: func(execCtx context.Context, ctx *HookContext, text string) TabResult {
+			return TabResult{NewText: text + "ic ", Handled: true}
+		},
+		onEnter: func(execCtx context.Context, ctx *HookContext, text string) HookResult {
+			return HookResult{Handled: true}
+		},
+	})
+	registry.Register("music", &MockHook{id: "passive", priority: -1})
+
+	if _, ok := registry.LastHandler(HookEventTab); ok {
+		t.Error("Expected no tab handler before any tab event")
+	}
+
+	ctx := &HookContext{LauncherName: "music"}
+	result := registry.ExecuteTabHooks(context.Background(), ctx, ">mus")
+	if !result.Handled || result.NewText != ">music " {
+		t.Fatalf("Expected tab hook to complete the text, got %+v", result)
+	}
+
+	record, ok := registry.LastHandler(HookEventTab)
+	if !ok || record.HookID != "music_hook" || record.LauncherName != "music" {
+		t.Errorf("Unexpected tab handler record: %+v", record)
+	}
+	if _, ok := registry.LastHandler(HookEventEnter); ok {
+		t.Error("Tab event should not record an enter handler")
+	}
+
+	registry.ExecuteEnterHooks(context.Background(), ctx, ">music next")
+	if record, ok := registry.LastHandler(HookEventEnter); !ok || record.HookID != "music_hook" {
+		t.Errorf("Unexpected enter handler record: %+v", record)
+	}
+
+	summary := registry.DebugSummary()
+	if !strings.Contains(summary, "last tab: music/music_hook") || !strings.Contains(summary, "last select: none") {
+		t.Errorf("Unexpected debug summary: %s", summary)
+	}
+}
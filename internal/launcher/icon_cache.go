@@ -19,6 +19,7 @@ type IconCache struct {
 	maxSize   int
 	mu        sync.RWMutex
 	fallback  string
+	chain     []string
 	cacheHits int64
 	cacheMiss int64
 }
@@ -40,10 +41,10 @@ func NewIconCache(cfg *config.Config) (*IconCache, error) {
 		return nil, fmt.Errorf("failed to create icon cache: %w", err)
 	}
 
-	// Get the default icon theme
-	iconTheme, err := gtk.IconThemeGetDefault()
+	// Get the configured icon theme (default theme when unset)
+	iconTheme, err := IconTheme(cfg.Launcher.Icons.Theme)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get default icon theme: %w", err)
+		return nil, fmt.Errorf("failed to get icon theme: %w", err)
 	}
 
 	fallback := cfg.Launcher.Icons.FallbackIcon
@@ -56,6 +57,7 @@ func NewIconCache(cfg *config.Config) (*IconCache, error) {
 		theme:    iconTheme,
 		maxSize:  maxSize,
 		fallback: fallback,
+		chain:    cfg.Launcher.Icons.FallbackChain,
 	}, nil
 }
 
@@ -89,31 +91,31 @@ func (ic *IconCache) GetIcon(name string, size int) (*gdk.Pixbuf, error) {
 
 	log.Printf("[ICON-CACHE] MISS: %s", key)
 
-	// Load from theme - check if icon exists first
-	hasIcon := ic.theme.HasIcon(name)
-	if !hasIcon {
-		log.Printf("[ICON-CACHE] Icon '%s' not found in theme, returning nil", name)
-		return nil, fmt.Errorf("icon '%s' not found in theme", name)
-	}
+	// Load from theme, walking the fallback chain
+	for _, candidate := range IconCandidates(name, ic.chain, ic.fallback) {
+		if !ic.theme.HasIcon(candidate) {
+			continue
+		}
 
-	pixbuf, err := ic.theme.LoadIcon(name, size, gtk.ICON_LOOKUP_USE_BUILTIN)
-	if err != nil || pixbuf == nil {
-		// Try fallback icon if not already trying fallback
-		if name != ic.fallback {
-			log.Printf("[ICON-CACHE] Failed to load '%s' (%v), trying fallback '%s'", name, err, ic.fallback)
-			ic.mu.Unlock()
-			defer ic.mu.Lock()
-			return ic.GetIcon(ic.fallback, size)
+		pixbuf, err := ic.theme.LoadIcon(candidate, size, gtk.ICON_LOOKUP_USE_BUILTIN)
+		if err != nil || pixbuf == nil {
+			log.Printf("[ICON-CACHE] Failed to load '%s': %v", candidate, err)
+			continue
 		}
-		log.Printf("[ICON-CACHE] Failed to load fallback icon '%s': %v", ic.fallback, err)
-		return nil, err
-	}
 
-	// Cache the loaded icon
-	ic.cache.Add(key, pixbuf)
-	log.Printf("[ICON-CACHE] STORED: %s (cache size: %d)", key, ic.cache.Len())
+		if candidate != name {
+			log.Printf("[ICON-CACHE] Icon '%s' resolved to fallback '%s'", name, candidate)
+		}
+
+		// Cache the loaded icon under the requested name
+		ic.cache.Add(key, pixbuf)
+		log.Printf("[ICON-CACHE] STORED: %s (cache size: %d)", key, ic.cache.Len())
+
+		return pixbuf, nil
+	}
 
-	return pixbuf, nil
+	log.Printf("[ICON-CACHE] Icon '%s' not found in theme, returning nil", name)
+	return nil, fmt.Errorf("icon '%s' not found in theme", name)
 }
 
 // PreloadCommonIcons loads commonly used icons into cache
@@ -0,0 +1,71 @@
+package launcher
+
+/*
+#cgo pkg-config: gtk+-3.0
+#include <stdlib.h>
+#include <gtk/gtk.h>
+*/
+import "C"
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/gtk"
+)
+
+var (
+	iconThemes   = make(map[string]*gtk.IconTheme)
+	iconThemesMu sync.Mutex
+)
+
+// IconTheme returns the named icon theme, or the default theme when name is
+// empty. Resolved themes are cached for the lifetime of the process.
+func IconTheme(name string) (*gtk.IconTheme, error) {
+	if name == "" {
+		return gtk.IconThemeGetDefault()
+	}
+
+	iconThemesMu.Lock()
+	defer iconThemesMu.Unlock()
+
+	if theme, ok := iconThemes[name]; ok {
+		return theme, nil
+	}
+
+	theme, err := gtk.IconThemeNew()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create icon theme '%s': %w", name, err)
+	}
+
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	C.gtk_icon_theme_set_custom_theme((*C.GtkIconTheme)(unsafe.Pointer(theme.Theme)), (*C.gchar)(cname))
+
+	iconThemes[name] = theme
+	return theme, nil
+}
+
+// IconCandidates returns the icon names to try, in order: the requested name,
+// the configured fallback chain, then the final fallback. Empty and duplicate
+// names are skipped.
+func IconCandidates(name string, chain []string, fallback string) []string {
+	candidates := make([]string, 0, len(chain)+2)
+	seen := make(map[string]bool)
+
+	add := func(n string) {
+		if n == "" || seen[n] {
+			return
+		}
+		seen[n] = true
+		candidates = append(candidates, n)
+	}
+
+	add(name)
+	for _, n := range chain {
+		add(n)
+	}
+	add(fallback)
+
+	return candidates
+}
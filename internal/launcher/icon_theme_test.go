@@ -0,0 +1,31 @@
+package launcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIconCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		icon     string
+		chain    []string
+		fallback string
+		expected []string
+	}{
+		{"no chain", "firefox", nil, "image-missing", []string{"firefox", "image-missing"}},
+		{"chain order", "org.foo.Bar", []string{"application-x-executable", "applications-other"}, "image-missing",
+			[]string{"org.foo.Bar", "application-x-executable", "applications-other", "image-missing"}},
+		{"empty name", "", []string{"application-x-executable"}, "image-missing",
+			[]string{"application-x-executable", "image-missing"}},
+		{"duplicates skipped", "image-missing", []string{"foo", "", "foo"}, "image-missing",
+			[]string{"image-missing", "foo"}},
+	}
+
+	for _, tt := range tests {
+		got := IconCandidates(tt.icon, tt.chain, tt.fallback)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
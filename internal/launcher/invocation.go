@@ -0,0 +1,114 @@
+package launcher
+
+// DefaultPrompt is the search entry placeholder when no prompt is given
+const DefaultPrompt = "Search or type a command..."
+
+// InvocationParams are options an external caller, such as a dmenu script,
+// passes over IPC for a single showing of the launcher
+type InvocationParams struct {
+	Prompt        string `json:"prompt,omitempty"`
+	InitialQuery  string `json:"initial_query,omitempty"`
+	CaseSensitive *bool  `json:"case_sensitive,omitempty"` // nil follows launcher.search.case_sensitive
+	MultiSelect   bool   `json:"multi_select,omitempty"`
+	Lines         int    `json:"lines,omitempty"` // visible result rows, like dmenu -l; 0 keeps launcher.window.visible_rows
+	// Dmenu picks from Options instead of launcher results, even when
+	// there are none
+	Dmenu bool `json:"dmenu,omitempty"`
+	// Options are dmenu lines to pick from instead of launcher results
+	Options []string `json:"options,omitempty"`
+}
+
+// PromptText returns the search entry placeholder to show
+func (p *InvocationParams) PromptText() string {
+	if p == nil || p.Prompt == "" {
+		return DefaultPrompt
+	}
+	return p.Prompt
+}
+
+// Query returns the text to prefill the search entry with
+func (p *InvocationParams) Query() string {
+	if p == nil {
+		return ""
+	}
+	return p.InitialQuery
+}
+
+// VisibleRows returns how many result rows the list shows, given the
+// configured number
+func (p *InvocationParams) VisibleRows(visibleRows int) int {
+	if p != nil && p.Lines > 0 {
+		return p.Lines
+	}
+	return visibleRows
+}
+
+// DmenuMode reports whether results are picked from Options rather than
+// searched for
+func (p *InvocationParams) DmenuMode() bool {
+	return p != nil && (p.Dmenu || len(p.Options) > 0)
+}
+
+// MultiSelectEnabled reports whether several results may be selected
+func (p *InvocationParams) MultiSelectEnabled() bool {
+	return p != nil && p.MultiSelect
+}
+
+// ReturnsSelection reports whether activating results answers the caller
+// with the chosen lines instead of running them
+func (p *InvocationParams) ReturnsSelection() bool {
+	return p.MultiSelectEnabled() || p.DmenuMode()
+}
+
+// optionItems returns the options containing query, as results
+func optionItems(options []string, query string, caseSensitive bool) []*LauncherItem {
+	matcher := newTextMatcher(query, caseSensitive)
+
+	var items []*LauncherItem
+	for _, option := range options {
+		if matcher.Match(option) {
+			items = append(items, &LauncherItem{
+				Title:    option,
+				Metadata: map[string]string{"line": option},
+			})
+		}
+	}
+	return items
+}
+
+// CaseSensitive reports whether matching should respect case: the
+// invocation's choice if it made one, otherwise the configured default
+func (c *LauncherContext) CaseSensitive() bool {
+	if c == nil {
+		return false
+	}
+	if c.Params != nil && c.Params.CaseSensitive != nil {
+		return *c.Params.CaseSensitive
+	}
+	return c.Config != nil && c.Config.Launcher.Search.CaseSensitive
+}
+
+// SetInvocationParams applies params to the searches of the current
+// showing; nil restores the defaults. Cached launcher results were built
+// under the previous params, so they are dropped.
+func (r *LauncherRegistry) SetInvocationParams(params *InvocationParams) {
+	r.ctxMu.Lock()
+	r.ctx.Params = params
+	r.ctxMu.Unlock()
+	r.resultCache.InvalidateAll()
+}
+
+// InvocationParams returns the params of the current showing, or nil
+func (r *LauncherRegistry) InvocationParams() *InvocationParams {
+	r.ctxMu.RLock()
+	defer r.ctxMu.RUnlock()
+	return r.ctx.Params
+}
+
+// launcherContext returns a copy of the context searches run under, taken
+// while no invocation params are being set
+func (r *LauncherRegistry) launcherContext() LauncherContext {
+	r.ctxMu.RLock()
+	defer r.ctxMu.RUnlock()
+	return *r.ctx
+}
@@ -0,0 +1,95 @@
+package launcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// paramsLauncher records the invocation params each Populate call sees
+type paramsLauncher struct {
+	triggerLauncher
+	seen []*InvocationParams
+}
+
+func (l *paramsLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	l.seen = append(l.seen, ctx.Params)
+	return l.triggerLauncher.Populate(query, ctx)
+}
+
+func TestInvocationParamsPresentation(t *testing.T) {
+	var none *InvocationParams
+	if none.PromptText() != DefaultPrompt || none.Query() != "" || none.MultiSelectEnabled() {
+		t.Errorf("expected defaults without params")
+	}
+
+	params := &InvocationParams{Prompt: "Pick a file:", InitialQuery: ">file ", MultiSelect: true}
+	if got := params.PromptText(); got != "Pick a file:" {
+		t.Errorf("PromptText() = %q", got)
+	}
+	if got := params.Query(); got != ">file " {
+		t.Errorf("Query() = %q", got)
+	}
+	if !params.MultiSelectEnabled() {
+		t.Errorf("expected multi-select")
+	}
+	if got := (&InvocationParams{}).PromptText(); got != DefaultPrompt {
+		t.Errorf("expected the default prompt for an empty one, got %q", got)
+	}
+}
+
+func TestInvocationParamsCaseSensitive(t *testing.T) {
+	cfg := &config.Config{}
+	yes, no := true, false
+
+	tests := []struct {
+		configured bool
+		params     *InvocationParams
+		want       bool
+	}{
+		{false, nil, false},
+		{true, nil, true},
+		{true, &InvocationParams{}, true},
+		{false, &InvocationParams{CaseSensitive: &yes}, true},
+		{true, &InvocationParams{CaseSensitive: &no}, false},
+	}
+	for _, tt := range tests {
+		cfg.Launcher.Search.CaseSensitive = tt.configured
+		ctx := &LauncherContext{Config: cfg, Params: tt.params}
+		if got := ctx.CaseSensitive(); got != tt.want {
+			t.Errorf("CaseSensitive() with config %v and params %+v = %v, want %v", tt.configured, tt.params, got, tt.want)
+		}
+	}
+}
+
+func TestInvocationParamsReachLaunchers(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	cfg.Launcher.Performance.SearchCacheSize = 50
+	registry := NewLauncherRegistry(cfg)
+	apps := &fakeAppsLauncher{}
+	file := &paramsLauncher{triggerLauncher: triggerLauncher{name: "file", triggers: []string{"file"}}}
+	registry.Register(apps)
+	registry.Register(file)
+
+	params := &InvocationParams{Prompt: "Pick:", MultiSelect: true}
+	registry.SetInvocationParams(params)
+	if registry.InvocationParams() != params {
+		t.Fatalf("expected the registry to keep the params")
+	}
+	registry.SearchContext(context.Background(), ">file notes")
+	registry.SetInvocationParams(nil)
+	registry.SearchContext(context.Background(), ">file notes")
+
+	if len(file.seen) != 2 || file.seen[0] != params || file.seen[1] != nil {
+		t.Errorf("expected Populate to see the params only while set, got %v", file.seen)
+	}
+
+	// App results found under params are not cached for plain searches
+	registry.SetInvocationParams(params)
+	registry.SearchContext(context.Background(), "fire")
+	if registry.searchCache.Contains("fire", registry.currentAppsHash()) {
+		t.Errorf("expected searches under params to bypass the search cache")
+	}
+}
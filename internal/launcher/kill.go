@@ -1,26 +1,43 @@
 package launcher
 
 import (
-	"context"
+	"bytes"
 	"fmt"
-	"os/exec"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/chess10kp/locus/internal/config"
-	"github.com/sahilm/fuzzy"
+)
+
+const (
+	// clockTicks is USER_HZ, which is 100 on all mainstream Linux configurations
+	clockTicks = 100
+	// sigkillConfirmWindow is how long a first Ctrl+N press arms SIGKILL for
+	sigkillConfirmWindow = 5 * time.Second
 )
 
 type Process struct {
-	PID     int
-	Name    string
-	Command string
+	PID      int
+	Name     string
+	Command  string
+	CPU      float64 // percent, averaged over the process lifetime
+	MemoryKB int64   // resident set size
 }
 
 type KillLauncher struct {
-	config    *config.Config
-	processes []Process
+	config   *config.Config
+	procRoot string
+	selfPID  int
+
+	armedPID int
+	armedAt  time.Time
+	armMu    sync.Mutex
 }
 
 type KillLauncherFactory struct{}
@@ -39,8 +56,9 @@ func init() {
 
 func NewKillLauncher(cfg *config.Config) *KillLauncher {
 	return &KillLauncher{
-		config:    cfg,
-		processes: []Process{},
+		config:   cfg,
+		procRoot: "/proc",
+		selfPID:  os.Getpid(),
 	}
 }
 
@@ -63,14 +81,7 @@ func (l *KillLauncher) GetGridConfig() *GridConfig {
 func (l *KillLauncher) Populate(query string, launcherCtx *LauncherContext) []*LauncherItem {
 	q := strings.TrimSpace(query)
 
-	// Get processes with timeout
-	cmdCtx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
-	defer cancel()
-
-	// Get process list - using ps to get PID, user, and command
-	cmd := exec.CommandContext(cmdCtx, "ps", "-eo", "pid,comm,cmd", "--sort=-pid")
-	output, err := cmd.Output()
-
+	processes, err := readProcesses(l.procRoot, l.selfPID)
 	if err != nil {
 		return []*LauncherItem{
 			{
@@ -82,27 +93,15 @@ func (l *KillLauncher) Populate(query string, launcherCtx *LauncherContext) []*L
 		}
 	}
 
-	// Parse process list
-	processes, err := l.parseProcesses(string(output))
-	if err != nil {
-		return []*LauncherItem{
-			{
-				Title:    "Error parsing processes",
-				Subtitle: err.Error(),
-				Icon:     "dialog-error-symbolic",
-				Launcher: l,
-			},
-		}
-	}
-
-	l.processes = processes
-
 	// Filter by query
 	if q != "" {
-		return l.filterProcesses(q)
+		return l.filterProcesses(processes, q, launcherCtx.CaseSensitive())
 	}
 
-	// Return top processes
+	// Return top processes by CPU usage
+	sort.SliceStable(processes, func(i, j int) bool {
+		return processes[i].CPU > processes[j].CPU
+	})
 	maxResults := 20
 	if len(processes) > maxResults {
 		processes = processes[:maxResults]
@@ -111,72 +110,161 @@ func (l *KillLauncher) Populate(query string, launcherCtx *LauncherContext) []*L
 	return l.processesToItems(processes)
 }
 
-func (l *KillLauncher) parseProcesses(output string) ([]Process, error) {
-	processes := []Process{}
-	lines := strings.Split(output, "\n")
+// readProcesses lists user processes from a procfs root, skipping kernel
+// threads and the process with selfPID
+func readProcesses(procRoot string, selfPID int) ([]Process, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", procRoot, err)
+	}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
+	uptime, err := readUptime(filepath.Join(procRoot, "uptime"))
+	if err != nil {
+		return nil, err
+	}
+	pageKB := int64(os.Getpagesize() / 1024)
 
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
+	processes := []Process{}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == selfPID {
 			continue
 		}
 
-		pid, err := strconv.Atoi(fields[0])
+		dir := filepath.Join(procRoot, entry.Name())
+		statData, err := os.ReadFile(filepath.Join(dir, "stat"))
+		if err != nil {
+			continue // process exited
+		}
+		stat, err := parseProcStat(string(statData))
 		if err != nil {
 			continue
 		}
 
-		name := fields[1]
-		command := strings.Join(fields[2:], " ")
-
-		// Skip system processes and kernel threads
-		if name == "[kthreadd]" || strings.HasPrefix(name, "[") {
-			continue
+		cmdline, _ := os.ReadFile(filepath.Join(dir, "cmdline"))
+		if len(cmdline) == 0 {
+			continue // kernel thread
 		}
 
-		processes = append(processes, Process{
-			PID:     pid,
-			Name:    name,
-			Command: command,
-		})
+		proc := Process{
+			PID:      pid,
+			Name:     stat.comm,
+			Command:  strings.TrimSpace(string(bytes.ReplaceAll(cmdline, []byte{0}, []byte{' '}))),
+			MemoryKB: stat.rssPages * pageKB,
+		}
+		if elapsed := uptime - float64(stat.startTicks)/clockTicks; elapsed > 0 {
+			proc.CPU = float64(stat.utime+stat.stime) / clockTicks / elapsed * 100
+		}
+		processes = append(processes, proc)
 	}
 
 	return processes, nil
 }
 
-func (l *KillLauncher) filterProcesses(query string) []*LauncherItem {
+type procStat struct {
+	comm       string
+	state      string
+	utime      int64
+	stime      int64
+	startTicks int64
+	rssPages   int64
+}
+
+// parseProcStat parses /proc/<pid>/stat. The comm field is parenthesised
+// and may itself contain spaces or parentheses, so it is delimited by the
+// last ')'.
+func parseProcStat(data string) (procStat, error) {
+	open := strings.IndexByte(data, '(')
+	closeIdx := strings.LastIndexByte(data, ')')
+	if open < 0 || closeIdx < open {
+		return procStat{}, fmt.Errorf("malformed stat: missing comm")
+	}
+
+	// Fields after comm start at field 3 (state)
+	fields := strings.Fields(data[closeIdx+1:])
+	if len(fields) < 22 {
+		return procStat{}, fmt.Errorf("malformed stat: %d fields", len(fields))
+	}
+
+	parse := func(field int) int64 {
+		v, _ := strconv.ParseInt(fields[field-3], 10, 64)
+		return v
+	}
+
+	return procStat{
+		comm:       data[open+1 : closeIdx],
+		state:      fields[0],
+		utime:      parse(14),
+		stime:      parse(15),
+		startTicks: parse(22),
+		rssPages:   parse(24),
+	}, nil
+}
+
+func readUptime(path string) (float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read uptime: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("malformed uptime")
+	}
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+// filterProcesses matches processes read by this search; they aren't kept
+// on the launcher since superseded searches may still be running
+func (l *KillLauncher) filterProcesses(processes []Process, query string, caseSensitive bool) []*LauncherItem {
 	// Get process names for fuzzy search
-	names := make([]string, len(l.processes))
-	for i, proc := range l.processes {
+	names := make([]string, len(processes))
+	for i, proc := range processes {
 		names[i] = proc.Name
 	}
 
 	// Use fuzzy search
-	matches := fuzzy.Find(query, names)
+	matches := fuzzyFind(query, names, caseSensitive)
 
 	maxResults := 20
 	items := make([]*LauncherItem, 0, minInt(len(matches), maxResults))
 
 	for i := 0; i < len(matches) && i < maxResults; i++ {
 		match := matches[i]
-		items = append(items, l.processToItem(l.processes[match.Index]))
+		item := l.processToItem(processes[match.Index])
+		item.MatchedIndexes = match.MatchedIndexes
+		items = append(items, item)
 	}
 
 	return items
 }
 
+// signalAction builds the shell action sending sig to pid
+func signalAction(pid int, sig syscall.Signal) *ShellAction {
+	name := strings.TrimPrefix(signalName(sig), "SIG")
+	return NewShellAction(fmt.Sprintf("kill -%s %d", name, pid))
+}
+
+func signalName(sig syscall.Signal) string {
+	switch sig {
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	case syscall.SIGKILL:
+		return "SIGKILL"
+	default:
+		return fmt.Sprintf("%d", int(sig))
+	}
+}
+
 func (l *KillLauncher) processToItem(proc Process) *LauncherItem {
 	return &LauncherItem{
 		Title:      fmt.Sprintf("%s (PID: %d)", proc.Name, proc.PID),
-		Subtitle:   proc.Command,
+		Subtitle:   fmt.Sprintf("CPU %.1f%% · %.1f MB · %s", proc.CPU, float64(proc.MemoryKB)/1024, proc.Command),
 		Icon:       "process-stop-symbolic",
-		ActionData: NewShellAction(fmt.Sprintf("kill %d", proc.PID)),
+		ActionData: signalAction(proc.PID, syscall.SIGTERM),
 		Launcher:   l,
+		Metadata: map[string]string{
+			"pid": strconv.Itoa(proc.PID),
+		},
 	}
 }
 
@@ -206,6 +294,38 @@ func (l *KillLauncher) Rebuild(ctx *LauncherContext) error {
 func (l *KillLauncher) Cleanup() {
 }
 
+// GetCtrlNumberAction sends SIGKILL to the item's process. The first press
+// only arms the kill; pressing again on the same process within
+// sigkillConfirmWindow confirms it.
 func (l *KillLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
-	return nil, false
+	return func(item *LauncherItem) error {
+		pid, err := strconv.Atoi(item.Metadata["pid"])
+		if err != nil {
+			return fmt.Errorf("item has no pid")
+		}
+		if !l.confirmSigkill(pid, time.Now()) {
+			return &ConfirmPrompt{Prompt: fmt.Sprintf("Press again to confirm SIGKILL for PID %d", pid)}
+		}
+		return syscall.Kill(pid, syscall.SIGKILL)
+	}, true
+}
+
+// confirmSigkill arms SIGKILL for pid, returning true when it was already
+// armed recently. It never confirms locus's own process.
+func (l *KillLauncher) confirmSigkill(pid int, now time.Time) bool {
+	l.armMu.Lock()
+	defer l.armMu.Unlock()
+
+	if pid == l.selfPID || pid <= 1 {
+		return false
+	}
+
+	if l.armedPID == pid && now.Sub(l.armedAt) <= sigkillConfirmWindow {
+		l.armedPID = 0
+		return true
+	}
+
+	l.armedPID = pid
+	l.armedAt = now
+	return false
 }
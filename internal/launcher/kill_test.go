@@ -0,0 +1,123 @@
+package launcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseProcStat(t *testing.T) {
+	data := "1234 (Web Content (x)) S 1 1234 1234 0 -1 4194560 100 0 0 0 250 50 0 0 20 0 30 0 5000 123456789 2048 18446744073709551615\n"
+
+	stat, err := parseProcStat(data)
+	if err != nil {
+		t.Fatalf("parseProcStat failed: %v", err)
+	}
+	if stat.comm != "Web Content (x)" {
+		t.Errorf("Expected comm with nested parens, got %q", stat.comm)
+	}
+	if stat.state != "S" || stat.utime != 250 || stat.stime != 50 || stat.startTicks != 5000 || stat.rssPages != 2048 {
+		t.Errorf("Unexpected stat fields: %+v", stat)
+	}
+
+	if _, err := parseProcStat("1234 bash S 1"); err == nil {
+		t.Error("Expected error for stat without comm")
+	}
+	if _, err := parseProcStat("1234 (bash) S 1 2 3"); err == nil {
+		t.Error("Expected error for truncated stat")
+	}
+}
+
+func TestReadProcesses(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("uptime", "200.00 100.00\n")
+	// Started at tick 10000 (100s), used 5000 ticks (50s) over 100s: 50% CPU
+	write("100/stat", "100 (firefox) S 1 100 100 0 -1 0 0 0 0 0 4000 1000 0 0 20 0 1 0 10000 0 256 0\n")
+	write("100/cmdline", "firefox\x00--new-window\x00")
+	// Kernel thread: empty cmdline
+	write("2/stat", "2 (kthreadd) S 0 0 0 0 -1 0 0 0 0 0 0 0 0 0 20 0 1 0 1 0 0 0\n")
+	write("2/cmdline", "")
+	// Our own process must be excluded
+	write("300/stat", "300 (locus) S 1 300 300 0 -1 0 0 0 0 0 0 0 0 0 20 0 1 0 1 0 0 0\n")
+	write("300/cmdline", "locus\x00")
+	write("self/stat", "ignored")
+
+	processes, err := readProcesses(root, 300)
+	if err != nil {
+		t.Fatalf("readProcesses failed: %v", err)
+	}
+	if len(processes) != 1 {
+		t.Fatalf("Expected 1 process, got %d: %+v", len(processes), processes)
+	}
+
+	proc := processes[0]
+	if proc.PID != 100 || proc.Name != "firefox" || proc.Command != "firefox --new-window" {
+		t.Errorf("Unexpected process: %+v", proc)
+	}
+	if proc.CPU < 49.9 || proc.CPU > 50.1 {
+		t.Errorf("Expected ~50%% CPU, got %.2f", proc.CPU)
+	}
+	if want := 256 * int64(os.Getpagesize()/1024); proc.MemoryKB != want {
+		t.Errorf("Expected %d KB RSS, got %d", want, proc.MemoryKB)
+	}
+}
+
+func TestSignalAction(t *testing.T) {
+	if got := signalAction(42, syscall.SIGTERM).Command; got != "kill -TERM 42" {
+		t.Errorf("Unexpected SIGTERM command: %q", got)
+	}
+	if got := signalAction(42, syscall.SIGKILL).Command; got != "kill -KILL 42" {
+		t.Errorf("Unexpected SIGKILL command: %q", got)
+	}
+}
+
+func TestConfirmSigkill(t *testing.T) {
+	l := &KillLauncher{selfPID: 300}
+	now := time.Now()
+
+	if l.confirmSigkill(100, now) {
+		t.Error("First press should only arm SIGKILL")
+	}
+	if !l.confirmSigkill(100, now.Add(time.Second)) {
+		t.Error("Second press within the window should confirm SIGKILL")
+	}
+	if l.confirmSigkill(100, now.Add(2*time.Second)) {
+		t.Error("Confirmation should reset after firing")
+	}
+	if l.confirmSigkill(100, now.Add(time.Minute)) {
+		t.Error("Press after the window expired should only re-arm")
+	}
+	if l.confirmSigkill(200, now.Add(time.Minute+time.Second)) {
+		t.Error("Pressing on a different PID should re-arm, not confirm")
+	}
+
+	l.confirmSigkill(300, now)
+	if l.confirmSigkill(300, now) {
+		t.Error("SIGKILL must never be confirmed for locus itself")
+	}
+}
+
+func TestSigkillActionPrompts(t *testing.T) {
+	l := &KillLauncher{selfPID: 300}
+	action, ok := l.GetCtrlNumberAction(1)
+	if !ok {
+		t.Fatal("Expected a Ctrl+1 action")
+	}
+
+	var prompt *ConfirmPrompt
+	err := action(&LauncherItem{Metadata: map[string]string{"pid": "300"}})
+	if !errors.As(err, &prompt) || prompt.Prompt != "Press again to confirm SIGKILL for PID 300" {
+		t.Errorf("action() = %v, want a confirmation prompt", err)
+	}
+}
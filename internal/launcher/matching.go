@@ -0,0 +1,86 @@
+package launcher
+
+import (
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// textMatcher filters results by substring, folding case unless the search
+// is case sensitive
+type textMatcher struct {
+	query         string
+	caseSensitive bool
+}
+
+// newTextMatcher creates a matcher for the trimmed query
+func newTextMatcher(query string, caseSensitive bool) textMatcher {
+	query = strings.TrimSpace(query)
+	if !caseSensitive {
+		query = strings.ToLower(query)
+	}
+	return textMatcher{query: query, caseSensitive: caseSensitive}
+}
+
+// Empty reports whether there is no query, which matches everything
+func (m textMatcher) Empty() bool {
+	return m.query == ""
+}
+
+// Match reports whether any of targets contains the query
+func (m textMatcher) Match(targets ...string) bool {
+	if m.query == "" {
+		return true
+	}
+	for _, target := range targets {
+		if !m.caseSensitive {
+			target = strings.ToLower(target)
+		}
+		if strings.Contains(target, m.query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Prefix reports whether target starts with the query
+func (m textMatcher) Prefix(target string) bool {
+	if !m.caseSensitive {
+		target = strings.ToLower(target)
+	}
+	return strings.HasPrefix(target, m.query)
+}
+
+// fuzzyFind fuzzy matches query against data. The fuzzy matcher always
+// folds case, so a case sensitive search also requires query's characters
+// to appear in order with their exact case.
+func fuzzyFind(query string, data []string, caseSensitive bool) fuzzy.Matches {
+	found := fuzzy.Find(query, data)
+	if !caseSensitive {
+		return found
+	}
+	kept := found[:0]
+	for _, match := range found {
+		if containsSubsequence(match.Str, query) {
+			kept = append(kept, match)
+		}
+	}
+	return kept
+}
+
+// containsSubsequence reports whether the runes of sub appear in s in order
+func containsSubsequence(s, sub string) bool {
+	want := []rune(sub)
+	if len(want) == 0 {
+		return true
+	}
+	for _, r := range s {
+		if r == want[0] {
+			want = want[1:]
+			if len(want) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
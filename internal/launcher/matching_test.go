@@ -0,0 +1,80 @@
+package launcher
+
+import "testing"
+
+func TestTextMatcher(t *testing.T) {
+	tests := []struct {
+		query         string
+		caseSensitive bool
+		targets       []string
+		want          bool
+	}{
+		{"", false, []string{"Firefox"}, true},
+		{"  ", true, []string{"Firefox"}, true},
+		{"fire", false, []string{"Firefox"}, true},
+		{"FIRE", false, []string{"Firefox"}, true},
+		{"fire", true, []string{"Firefox"}, false},
+		{"Fire", true, []string{"Firefox"}, true},
+		{" Fire ", true, []string{"Firefox"}, true},
+		{"term", true, []string{"foot", "Terminal emulator", "terminal"}, true},
+		{"Term", true, []string{"foot", "terminal"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := newTextMatcher(tt.query, tt.caseSensitive).Match(tt.targets...); got != tt.want {
+			t.Errorf("query %q (case sensitive %v) against %v: got %v, want %v",
+				tt.query, tt.caseSensitive, tt.targets, got, tt.want)
+		}
+	}
+}
+
+func TestTextMatcherPrefix(t *testing.T) {
+	if !newTextMatcher("Reg", false).Prefix("region") {
+		t.Error("expected a case-insensitive prefix match")
+	}
+	if newTextMatcher("Reg", true).Prefix("region") {
+		t.Error("expected a case-sensitive prefix not to match other case")
+	}
+	if !newTextMatcher("reg", true).Prefix("region") {
+		t.Error("expected a case-sensitive prefix match")
+	}
+}
+
+func TestFuzzyFindCaseSensitive(t *testing.T) {
+	names := []string{"Firefox", "foot", "File Roller"}
+
+	if got := fuzzyFind("f", names, false); len(got) != 3 {
+		t.Errorf("case insensitive: expected 3 matches, got %d", len(got))
+	}
+
+	got := fuzzyFind("F", names, true)
+	var strs []string
+	for _, match := range got {
+		strs = append(strs, match.Str)
+	}
+	if len(strs) != 2 || !containsSubsequence(strs[0], "F") || !containsSubsequence(strs[1], "F") {
+		t.Errorf("case sensitive: expected Firefox and File Roller, got %v", strs)
+	}
+
+	if got := fuzzyFind("fR", names, true); len(got) != 0 {
+		t.Errorf("expected no exact case matches for fR, got %d", len(got))
+	}
+}
+
+func TestWMWindowItemsCaseSensitive(t *testing.T) {
+	l := &WMLauncher{wmCommand: "swaymsg"}
+	l.iconIndexOnce.Do(func() {})
+
+	windows := []WindowInfo{
+		{Name: "Inbox", ConID: 7, Workspace: "2", WindowClass: "thunderbird"},
+		{Name: "inbox notes", ConID: 9, Workspace: "3"},
+	}
+
+	if items := l.buildWindowItems(windows, newTextMatcher("inbox", false)); len(items) != 2 {
+		t.Errorf("case insensitive: expected 2 windows, got %d", len(items))
+	}
+	items := l.buildWindowItems(windows, newTextMatcher("Inbox", true))
+	if len(items) != 1 || items[0].Title != "Inbox" {
+		t.Errorf("case sensitive: expected only Inbox, got %v", titlesOf(items))
+	}
+}
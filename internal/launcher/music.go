@@ -4,21 +4,23 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/chess10kp/locus/internal/config"
 )
 
 type MusicLauncher struct {
+	FileActions
 	config     *config.Config
 	musicDir   string
 	filesCache []map[string]string
 	scanning   bool
 	scanned    bool
 	mu         sync.RWMutex
+	runner     CommandRunner
 }
 
 type MusicLauncherFactory struct{}
@@ -44,6 +46,7 @@ func NewMusicLauncher(cfg *config.Config) *MusicLauncher {
 	return &MusicLauncher{
 		config:   cfg,
 		musicDir: musicDir,
+		runner:   cfg.CommandRunner(),
 	}
 }
 
@@ -76,25 +79,23 @@ func (l *MusicLauncher) Populate(query string, ctx *LauncherContext) []*Launcher
 
 	// Add control buttons
 	status := l.getStatus()
-	l.addControls(&items, status, query)
+	l.addControls(&items, status, newTextMatcher(query, ctx.CaseSensitive()))
 
 	q := strings.TrimSpace(query)
 
 	// Check if this is a queue view
 	if strings.HasPrefix(strings.ToLower(q), "queue") {
 		remainingQuery := strings.TrimSpace(strings.TrimPrefix(q, "queue"))
-		l.populateQueue(&items, remainingQuery)
+		l.populateQueue(&items, newTextMatcher(remainingQuery, ctx.CaseSensitive()))
 	} else {
 		// Library view
-		l.populateLibrary(&items, q)
+		l.populateLibrary(&items, q, ctx.CaseSensitive())
 	}
 
 	return items
 }
 
-func (l *MusicLauncher) addControls(items *[]*LauncherItem, status map[string]string, query string) {
-	lowerQuery := strings.ToLower(query)
-
+func (l *MusicLauncher) addControls(items *[]*LauncherItem, status map[string]string, matcher textMatcher) {
 	stateIcon := "⏹" // stopped
 	if status["state"] == "playing" {
 		stateIcon = "⏵"
@@ -108,8 +109,7 @@ func (l *MusicLauncher) addControls(items *[]*LauncherItem, status map[string]st
 	}
 
 	// Add control item if query matches or is empty
-	if query == "" || strings.Contains(strings.ToLower(header), lowerQuery) ||
-		strings.Contains(strings.ToLower(status["volume"]), lowerQuery) {
+	if matcher.Match(header, status["volume"]) {
 		*items = append(*items, &LauncherItem{
 			Title:      header,
 			Subtitle:   fmt.Sprintf("Volume: %s", status["volume"]),
@@ -132,8 +132,7 @@ func (l *MusicLauncher) addControls(items *[]*LauncherItem, status map[string]st
 
 	for _, ctrl := range controls {
 		// Only show control if query matches or is empty
-		if query == "" || strings.Contains(strings.ToLower(ctrl.title), lowerQuery) ||
-			strings.Contains(strings.ToLower(ctrl.subtitle), lowerQuery) {
+		if matcher.Match(ctrl.title, ctrl.subtitle) {
 			*items = append(*items, &LauncherItem{
 				Title:      ctrl.title,
 				Subtitle:   ctrl.subtitle,
@@ -145,7 +144,7 @@ func (l *MusicLauncher) addControls(items *[]*LauncherItem, status map[string]st
 	}
 }
 
-func (l *MusicLauncher) populateQueue(items *[]*LauncherItem, query string) {
+func (l *MusicLauncher) populateQueue(items *[]*LauncherItem, matcher textMatcher) {
 	output := l.runMPC([]string{"playlist", "-f", "%position%\t%file%"})
 	lines := strings.Split(strings.TrimSpace(output), "\n")
 
@@ -179,7 +178,7 @@ func (l *MusicLauncher) populateQueue(items *[]*LauncherItem, query string) {
 		}
 
 		// Filter by query if provided
-		if query != "" && !strings.Contains(strings.ToLower(displayName), strings.ToLower(query)) {
+		if !matcher.Match(displayName) {
 			continue
 		}
 
@@ -198,7 +197,7 @@ func (l *MusicLauncher) populateQueue(items *[]*LauncherItem, query string) {
 	}
 }
 
-func (l *MusicLauncher) populateLibrary(items *[]*LauncherItem, query string) {
+func (l *MusicLauncher) populateLibrary(items *[]*LauncherItem, query string, caseSensitive bool) {
 	l.mu.RLock()
 	files := make([]map[string]string, len(l.filesCache))
 	copy(files, l.filesCache)
@@ -225,13 +224,14 @@ func (l *MusicLauncher) populateLibrary(items *[]*LauncherItem, query string) {
 		return
 	}
 
+	matcher := newTextMatcher(query, caseSensitive)
 	index := 1
 	for _, item := range files {
 		name := item["name"]
 		path := item["path"]
 
 		// Filter by query
-		if query != "" && !strings.Contains(strings.ToLower(name), strings.ToLower(query)) {
+		if !matcher.Match(name) {
 			continue
 		}
 
@@ -240,6 +240,7 @@ func (l *MusicLauncher) populateLibrary(items *[]*LauncherItem, query string) {
 			Subtitle:   path,
 			Icon:       "audio-x-generic",
 			ActionData: NewMusicAction("play_file", path),
+			Metadata:   map[string]string{"path": filepath.Join(l.musicDir, path)},
 			Launcher:   l,
 		})
 
@@ -302,7 +303,7 @@ func (l *MusicLauncher) scanMusicDirectory() {
 }
 
 func (l *MusicLauncher) getStatus() map[string]string {
-	output, err := exec.Command("mpc", "status").Output()
+	output, err := l.runner.RunWithTimeout(musicCommandTimeout, "mpc", "status")
 	if err != nil {
 		return map[string]string{
 			"state":  "stopped",
@@ -345,8 +346,14 @@ func (l *MusicLauncher) getStatus() map[string]string {
 }
 
 func (l *MusicLauncher) runMPC(args []string) string {
-	cmd := exec.Command("mpc", args...)
-	output, err := cmd.Output()
+	ctx, cancel := context.WithTimeout(context.Background(), musicCommandTimeout)
+	defer cancel()
+	return l.runMPCContext(ctx, args)
+}
+
+// runMPCContext runs mpc, killing it when ctx is done
+func (l *MusicLauncher) runMPCContext(ctx context.Context, args []string) string {
+	output, err := l.runner.Output(ctx, "mpc", args...)
 	if err != nil {
 		return ""
 	}
@@ -368,9 +375,9 @@ func (l *MusicLauncher) Rebuild(ctx *LauncherContext) error {
 func (l *MusicLauncher) Cleanup() {
 }
 
-func (l *MusicLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
-	return nil, false
-}
+// musicCommandTimeout bounds mpc calls made from hooks, which may talk to a
+// remote MPD server
+const musicCommandTimeout = 5 * time.Second
 
 // MusicHook handles music-specific actions
 type MusicHook struct {
@@ -393,13 +400,13 @@ func (h *MusicHook) OnSelect(execCtx context.Context, ctx *HookContext, data Act
 	if musicAction, ok := data.(*MusicAction); ok {
 		switch musicAction.Action {
 		case "play_file":
-			h.launcher.playFile(musicAction.Value)
+			h.launcher.playFile(execCtx, musicAction.Value)
 			return HookResult{Handled: true}
 		case "play_position":
-			h.launcher.playPosition(musicAction.Value)
+			h.launcher.playPosition(execCtx, musicAction.Value)
 			return HookResult{Handled: true}
 		case "toggle", "next", "prev", "clear":
-			h.launcher.control(musicAction.Action)
+			h.launcher.control(execCtx, musicAction.Action)
 			return HookResult{Handled: true}
 		case "view_queue":
 			// This will be handled by setting search text to "m: queue"
@@ -423,7 +430,7 @@ func (h *MusicHook) OnEnter(execCtx context.Context, ctx *HookContext, text stri
 
 		switch cmd {
 		case "clear", "pause", "play", "next", "prev":
-			h.launcher.control(cmd)
+			h.launcher.control(execCtx, cmd)
 			return HookResult{Handled: true}
 		case "queue":
 			// This will be handled by the populate method
@@ -437,35 +444,63 @@ func (h *MusicHook) OnTab(execCtx context.Context, ctx *HookContext, text string
 	return TabResult{Handled: false}
 }
 
+// OnSelectAsync runs OnSelect off the caller's goroutine with
+// musicCommandTimeout bounding the mpc calls
+func (h *MusicHook) OnSelectAsync(ctx *HookContext, data ActionData) <-chan HookResult {
+	ch := make(chan HookResult, 1)
+	go func() {
+		execCtx, cancel := context.WithTimeout(context.Background(), musicCommandTimeout)
+		defer cancel()
+		ch <- h.OnSelect(execCtx, ctx, data)
+	}()
+	return ch
+}
+
+func (h *MusicHook) OnEnterAsync(ctx *HookContext, text string) <-chan HookResult {
+	ch := make(chan HookResult, 1)
+	go func() {
+		execCtx, cancel := context.WithTimeout(context.Background(), musicCommandTimeout)
+		defer cancel()
+		ch <- h.OnEnter(execCtx, ctx, text)
+	}()
+	return ch
+}
+
+func (h *MusicHook) OnTabAsync(ctx *HookContext, text string) <-chan TabResult {
+	ch := make(chan TabResult, 1)
+	ch <- h.OnTab(context.Background(), ctx, text)
+	return ch
+}
+
 func (h *MusicHook) Cleanup() {
 }
 
 // Helper methods for music actions
-func (l *MusicLauncher) playFile(relPath string) {
-	l.runMPC([]string{"add", relPath})
+func (l *MusicLauncher) playFile(ctx context.Context, relPath string) {
+	l.runMPCContext(ctx, []string{"add", relPath})
 	status := l.getStatus()
 	if status["state"] != "playing" {
-		l.runMPC([]string{"play"})
+		l.runMPCContext(ctx, []string{"play"})
 	}
 }
 
-func (l *MusicLauncher) playPosition(pos string) {
-	l.runMPC([]string{"play", pos})
+func (l *MusicLauncher) playPosition(ctx context.Context, pos string) {
+	l.runMPCContext(ctx, []string{"play", pos})
 }
 
-func (l *MusicLauncher) control(command string) {
+func (l *MusicLauncher) control(ctx context.Context, command string) {
 	switch command {
 	case "toggle":
-		l.runMPC([]string{"toggle"})
+		l.runMPCContext(ctx, []string{"toggle"})
 	case "play":
-		l.runMPC([]string{"play"})
+		l.runMPCContext(ctx, []string{"play"})
 	case "pause":
-		l.runMPC([]string{"pause"})
+		l.runMPCContext(ctx, []string{"pause"})
 	case "next":
-		l.runMPC([]string{"next"})
+		l.runMPCContext(ctx, []string{"next"})
 	case "prev":
-		l.runMPC([]string{"prev"})
+		l.runMPCContext(ctx, []string{"prev"})
 	case "clear":
-		l.runMPC([]string{"clear"})
+		l.runMPCContext(ctx, []string{"clear"})
 	}
 }
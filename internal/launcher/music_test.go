@@ -0,0 +1,39 @@
+package launcher
+
+import (
+	"testing"
+)
+
+func TestMusicLauncherStatus(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{
+		"mpc status": "Artist - Song\n[playing] #3/10   1:02/3:45 (27%)\nvolume: 80%   repeat: off   random: on\n",
+	}}
+	l := &MusicLauncher{runner: runner}
+
+	status := l.getStatus()
+	if status["state"] != "playing" || status["song"] != "Artist - Song" {
+		t.Errorf("Unexpected status %v", status)
+	}
+	if status["volume"] != "80%" || status["random"] != "on" {
+		t.Errorf("Expected the flags parsed, got %v", status)
+	}
+}
+
+func TestMusicLauncherStatusWithoutMPD(t *testing.T) {
+	l := &MusicLauncher{runner: &fakeRunner{}}
+	if status := l.getStatus(); status["state"] != "stopped" || status["song"] != "MPD not running" {
+		t.Errorf("Unexpected status without MPD: %v", status)
+	}
+}
+
+func TestMusicLauncherRunMPC(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{"mpc current": "Artist - Song\n"}}
+	l := &MusicLauncher{runner: runner}
+
+	if got := l.runMPC([]string{"current"}); got != "Artist - Song" {
+		t.Errorf("runMPC() = %q, want the trimmed output", got)
+	}
+	if got := l.runMPC([]string{"bogus"}); got != "" {
+		t.Errorf("runMPC() = %q, want nothing for a failed command", got)
+	}
+}
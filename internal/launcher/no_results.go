@@ -0,0 +1,55 @@
+package launcher
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// Actions offered by the "nothing found" placeholder
+const (
+	NoResultsActionShell = "shell"
+	NoResultsActionWeb   = "web"
+	NoResultsActionNone  = "none"
+)
+
+// noResultsItem returns the placeholder shown when query found nothing: on
+// activate it runs the query as a shell command or searches the web for it,
+// following launcher.search.no_results_action. It returns nil when the
+// action is "none" or unset, or the query is empty.
+func noResultsItem(cfg *config.Config, query string) *LauncherItem {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	switch cfg.Launcher.Search.NoResultsAction {
+	case NoResultsActionShell:
+		return &LauncherItem{
+			Title:      "No results",
+			Subtitle:   fmt.Sprintf("Press Enter to run '%s' as a command", query),
+			Icon:       "utilities-terminal",
+			ActionData: NewShScriptAction(query),
+		}
+	case NoResultsActionWeb:
+		return &LauncherItem{
+			Title:      "No results",
+			Subtitle:   fmt.Sprintf("Press Enter to search the web for '%s'", query),
+			Icon:       "web-browser",
+			ActionData: NewShScriptAction(webSearchBrowser(cfg) + " " + shellQuote(webSearchURL(cfg, query))),
+		}
+	}
+	return nil
+}
+
+// withNoResultsItem returns items, or the placeholder if there are none
+func withNoResultsItem(cfg *config.Config, query string, items []*LauncherItem) []*LauncherItem {
+	if len(items) > 0 {
+		return items
+	}
+	if item := noResultsItem(cfg, query); item != nil {
+		return []*LauncherItem{item}
+	}
+	return items
+}
@@ -0,0 +1,84 @@
+package launcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestNoResultsItem(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		action  string
+		query   string
+		command string // "" for no placeholder
+	}{
+		{NoResultsActionShell, "make -C ~/src", `sh -c "make -C ~/src"`},
+		{NoResultsActionShell, "echo \"hi\" | wl-copy", `sh -c "echo \"hi\" | wl-copy"`},
+		{NoResultsActionWeb, "go generics", `sh -c "xdg-open 'https://duckduckgo.com/?q=go+generics'"`},
+		{NoResultsActionWeb, "a&b", `sh -c "xdg-open 'https://duckduckgo.com/?q=a%26b'"`},
+		{NoResultsActionNone, "make", ""},
+		{"", "make", ""},
+		{NoResultsActionShell, "  ", ""},
+	}
+
+	for _, tt := range tests {
+		cfg.Launcher.Search.NoResultsAction = tt.action
+		item := noResultsItem(cfg, tt.query)
+		if tt.command == "" {
+			if item != nil {
+				t.Errorf("noResultsItem(%q, %q) = %+v, want nil", tt.action, tt.query, item)
+			}
+			continue
+		}
+		if item == nil {
+			t.Errorf("noResultsItem(%q, %q) = nil", tt.action, tt.query)
+			continue
+		}
+		action, ok := item.ActionData.(*ShellAction)
+		if !ok || action.Command != tt.command {
+			t.Errorf("noResultsItem(%q, %q) runs %+v, want %q", tt.action, tt.query, item.ActionData, tt.command)
+		}
+	}
+}
+
+func TestSearchShowsNoResultsItem(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	cfg.Launcher.Performance.SearchCacheSize = 50
+	cfg.Launcher.Search.NoResultsAction = NoResultsActionShell
+	registry := NewLauncherRegistry(cfg)
+	registry.Register(&emptyAppsLauncher{})
+
+	items, err := registry.SearchContext(context.Background(), "htop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Title != "No results" {
+		t.Fatalf("expected the placeholder, got %v", titlesOf(items))
+	}
+	if action, ok := items[0].ActionData.(*ShellAction); !ok || action.Command != `sh -c "htop"` {
+		t.Errorf("expected the placeholder to run the query, got %+v", items[0].ActionData)
+	}
+
+	// Cached empty results get the placeholder too
+	items, _ = registry.SearchContext(context.Background(), "htop")
+	if len(items) != 1 || items[0].Title != "No results" {
+		t.Errorf("expected the placeholder for a cached search, got %v", titlesOf(items))
+	}
+
+	// An empty query shows nothing
+	if items, _ := registry.SearchContext(context.Background(), ""); len(items) != 0 {
+		t.Errorf("expected no placeholder for an empty query, got %v", titlesOf(items))
+	}
+}
+
+// emptyAppsLauncher is an apps launcher that finds nothing
+type emptyAppsLauncher struct{ fakeAppsLauncher }
+
+func (l *emptyAppsLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	l.fakeAppsLauncher.Populate(query, ctx)
+	return nil
+}
@@ -0,0 +1,137 @@
+package launcher
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Preview is what the preview pane shows for the selected item
+type Preview struct {
+	Title     string
+	Text      string
+	ImagePath string
+	// Monospace shows Text in a fixed-width font, as for file contents
+	Monospace bool
+}
+
+// Previewer is implemented by launchers that describe their items in the
+// preview pane themselves. Returning false falls back to the item's image
+// or file path.
+type Previewer interface {
+	Preview(item *LauncherItem) (Preview, bool)
+}
+
+// previewMaxBytes bounds how much of a file is read for its head
+const previewMaxBytes = 64 * 1024
+
+// previewMaxEntries bounds how many entries of a directory are listed
+const previewMaxEntries = 200
+
+var previewImageExts = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true,
+	".webp": true, ".bmp": true, ".svg": true,
+}
+
+// PreviewFor resolves the preview of item: the launcher's own, then its
+// image, then the file at Metadata["path"] (the first maxLines lines of a
+// text file, or a directory listing). It returns false if there is nothing
+// to show.
+func PreviewFor(item *LauncherItem, maxLines int) (Preview, bool) {
+	if item == nil {
+		return Preview{}, false
+	}
+	if p, ok := item.Launcher.(Previewer); ok {
+		if preview, ok := p.Preview(item); ok {
+			return preview, true
+		}
+	}
+	if item.ImagePath != "" {
+		return Preview{Title: item.Title, ImagePath: item.ImagePath}, true
+	}
+	if path := item.Metadata["path"]; path != "" {
+		return previewPath(path, maxLines)
+	}
+	return Preview{}, false
+}
+
+func previewPath(path string, maxLines int) (Preview, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Preview{}, false
+	}
+	preview := Preview{Title: filepath.Base(path)}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return Preview{}, false
+		}
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() {
+				name += "/"
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) > previewMaxEntries {
+			names = append(names[:previewMaxEntries], fmt.Sprintf("… %d more", len(entries)-previewMaxEntries))
+		}
+		preview.Text = strings.Join(names, "\n")
+		preview.Monospace = true
+		return preview, true
+	}
+
+	if previewImageExts[strings.ToLower(filepath.Ext(path))] {
+		preview.ImagePath = path
+		return preview, true
+	}
+
+	head, err := readHead(path, maxLines)
+	if err != nil {
+		return Preview{}, false
+	}
+	if !utf8.Valid(head) || bytes.IndexByte(head, 0) >= 0 {
+		preview.Text = fmt.Sprintf("Binary file, %d bytes", info.Size())
+		return preview, true
+	}
+	preview.Text = string(head)
+	preview.Monospace = true
+	return preview, true
+}
+
+// readHead returns up to maxLines lines from the start of the file at path
+func readHead(path string, maxLines int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, previewMaxBytes))
+	if err != nil {
+		return nil, err
+	}
+	// A multi-byte rune cut at the byte limit isn't a sign of binary data
+	for i := 0; i < utf8.UTFMax && len(data) == previewMaxBytes && !utf8.Valid(data); i++ {
+		data = data[:len(data)-1]
+	}
+
+	lines := 0
+	for i, b := range data {
+		if b == '\n' {
+			lines++
+			if lines == maxLines {
+				return data[:i], nil
+			}
+		}
+	}
+	return bytes.TrimSuffix(data, []byte("\n")), nil
+}
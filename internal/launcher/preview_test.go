@@ -0,0 +1,100 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/apps"
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestPreviewForTextFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\nfour\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	item := &LauncherItem{Title: "notes.txt", Metadata: map[string]string{"path": path}}
+	preview, ok := PreviewFor(item, 2)
+	if !ok {
+		t.Fatal("Expected a preview for a text file")
+	}
+	if preview.Text != "one\ntwo" || !preview.Monospace {
+		t.Errorf("Expected the first 2 lines in monospace, got %+v", preview)
+	}
+
+	preview, _ = PreviewFor(item, 10)
+	if preview.Text != "one\ntwo\nthree\nfour" {
+		t.Errorf("Expected the whole short file, got %q", preview.Text)
+	}
+}
+
+func TestPreviewForBinaryAndDirectory(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "blob")
+	if err := os.WriteFile(bin, []byte{0x7f, 'E', 'L', 'F', 0, 0, 1}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	preview, ok := PreviewFor(&LauncherItem{Metadata: map[string]string{"path": bin}}, 10)
+	if !ok || !strings.HasPrefix(preview.Text, "Binary file") {
+		t.Errorf("Expected a binary file note, got %+v", preview)
+	}
+
+	preview, ok = PreviewFor(&LauncherItem{Metadata: map[string]string{"path": dir}}, 10)
+	if !ok || preview.Text != "blob\nsub/" {
+		t.Errorf("Expected the directory listing, got %+v", preview)
+	}
+}
+
+func TestPreviewForImage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Photo.JPG")
+	if err := os.WriteFile(path, []byte("not really a jpeg"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	preview, ok := PreviewFor(&LauncherItem{Metadata: map[string]string{"path": path}}, 10)
+	if !ok || preview.ImagePath != path || preview.Text != "" {
+		t.Errorf("Expected an image preview for %s, got %+v", path, preview)
+	}
+
+	preview, ok = PreviewFor(&LauncherItem{Title: "Wall", ImagePath: "/walls/a.png"}, 10)
+	if !ok || preview.ImagePath != "/walls/a.png" {
+		t.Errorf("Expected the item's image, got %+v", preview)
+	}
+}
+
+func TestPreviewForApp(t *testing.T) {
+	l := NewAppLauncher(&config.Config{})
+	l.finishLoad([]apps.App{
+		{Name: "Firefox", File: "firefox.desktop", Exec: "firefox %u", Description: "Browse the web", Category: "Network"},
+	})
+
+	preview, ok := PreviewFor(l.appToItem(l.apps[0]), 10)
+	if !ok {
+		t.Fatal("Expected a preview for an app")
+	}
+	want := "Browse the web\n\nCommand: firefox %u\nCategory: Network"
+	if preview.Title != "Firefox" || preview.Text != want {
+		t.Errorf("Unexpected app preview %+v", preview)
+	}
+
+	missing := &LauncherItem{Title: "Gone", ActionData: NewDesktopAction("gone.desktop"), Launcher: l}
+	if _, ok := PreviewFor(missing, 10); ok {
+		t.Error("Expected no preview for an unknown app")
+	}
+}
+
+func TestPreviewForNothing(t *testing.T) {
+	if _, ok := PreviewFor(&LauncherItem{Title: "Calculator result"}, 10); ok {
+		t.Error("Expected no preview for an item without a path or image")
+	}
+	if _, ok := PreviewFor(&LauncherItem{Metadata: map[string]string{"path": "/does/not/exist"}}, 10); ok {
+		t.Error("Expected no preview for a missing file")
+	}
+}
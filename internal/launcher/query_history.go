@@ -0,0 +1,152 @@
+package launcher
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// QueryHistory keeps past search queries, newest first, and a cursor for
+// stepping through them from the search entry
+type QueryHistory struct {
+	mu       sync.Mutex
+	entries  []string
+	max      int
+	filePath string
+	cursor   int // index of the recalled entry, -1 when not recalling
+}
+
+// NewQueryHistory loads the query history kept in dataDir, holding at most
+// max entries
+func NewQueryHistory(dataDir string, max int) *QueryHistory {
+	h := &QueryHistory{
+		max:      max,
+		filePath: filepath.Join(dataDir, "query_history.json"),
+		cursor:   -1,
+	}
+
+	if err := h.load(); err != nil && !os.IsNotExist(err) {
+		log.Printf("[QUERY-HISTORY] Failed to load query history: %v", err)
+	}
+	return h
+}
+
+// Add records query as the newest entry, moving it up if it was already
+// kept, and resets the cursor
+func (h *QueryHistory) Add(query string) {
+	query = strings.TrimSpace(query)
+	if query == "" || h.max <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries := make([]string, 0, len(h.entries)+1)
+	entries = append(entries, query)
+	for _, entry := range h.entries {
+		if entry != query {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) > h.max {
+		entries = entries[:h.max]
+	}
+	h.entries = entries
+	h.cursor = -1
+
+	if err := h.save(); err != nil {
+		log.Printf("[QUERY-HISTORY] Failed to save query history: %v", err)
+	}
+}
+
+// Entries returns the kept queries, newest first
+func (h *QueryHistory) Entries() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.entries...)
+}
+
+// Previous steps to the next older query. current is the search text; once
+// it no longer matches the recalled entry the user has edited it, and
+// recall starts again from the newest query.
+func (h *QueryHistory) Previous(current string) (string, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.syncCursor(current)
+	if h.cursor+1 >= len(h.entries) {
+		return "", false
+	}
+	h.cursor++
+	return h.entries[h.cursor], true
+}
+
+// Next steps to the next newer query, returning an empty query after the
+// newest one
+func (h *QueryHistory) Next(current string) (string, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.syncCursor(current)
+	if h.cursor < 0 {
+		return "", false
+	}
+	h.cursor--
+	if h.cursor < 0 {
+		return "", true
+	}
+	return h.entries[h.cursor], true
+}
+
+// Recalling reports whether current is the query last recalled
+func (h *QueryHistory) Recalling(current string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.syncCursor(current)
+	return h.cursor >= 0
+}
+
+// Reset ends recall, so the next Previous starts from the newest query
+func (h *QueryHistory) Reset() {
+	h.mu.Lock()
+	h.cursor = -1
+	h.mu.Unlock()
+}
+
+func (h *QueryHistory) syncCursor(current string) {
+	if h.cursor >= 0 && (h.cursor >= len(h.entries) || h.entries[h.cursor] != current) {
+		h.cursor = -1
+	}
+}
+
+func (h *QueryHistory) load() error {
+	data, err := os.ReadFile(h.filePath)
+	if err != nil {
+		return err
+	}
+
+	var entries []string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	if h.max >= 0 && len(entries) > h.max {
+		entries = entries[:h.max]
+	}
+	h.entries = entries
+	return nil
+}
+
+func (h *QueryHistory) save() error {
+	data, err := json.Marshal(h.entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.filePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(h.filePath, data, 0644)
+}
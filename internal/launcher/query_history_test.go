@@ -0,0 +1,97 @@
+package launcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryHistoryAdd(t *testing.T) {
+	h := NewQueryHistory(t.TempDir(), 3)
+	for _, q := range []string{"firefox", "  ", "term", "firefox", "calc", "mail"} {
+		h.Add(q)
+	}
+
+	want := []string{"mail", "calc", "firefox"}
+	if got := h.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() = %v, want %v", got, want)
+	}
+}
+
+func TestQueryHistoryRecallOrder(t *testing.T) {
+	h := NewQueryHistory(t.TempDir(), 10)
+	for _, q := range []string{"one", "two", "three"} {
+		h.Add(q)
+	}
+
+	text := ""
+	var recalled []string
+	for {
+		q, ok := h.Previous(text)
+		if !ok {
+			break
+		}
+		recalled = append(recalled, q)
+		text = q
+	}
+	if want := []string{"three", "two", "one"}; !reflect.DeepEqual(recalled, want) {
+		t.Errorf("Previous() order = %v, want %v", recalled, want)
+	}
+
+	if q, ok := h.Next(text); !ok || q != "two" {
+		t.Errorf("Next() = %q, %v; want two", q, ok)
+	}
+	if q, ok := h.Next("two"); !ok || q != "three" {
+		t.Errorf("Next() = %q, %v; want three", q, ok)
+	}
+	if q, ok := h.Next("three"); !ok || q != "" {
+		t.Errorf("Next() past the newest = %q, %v; want an empty query", q, ok)
+	}
+	if _, ok := h.Next(""); ok {
+		t.Error("Next() should not recall when not recalling")
+	}
+}
+
+func TestQueryHistoryEditResetsRecall(t *testing.T) {
+	h := NewQueryHistory(t.TempDir(), 10)
+	h.Add("one")
+	h.Add("two")
+
+	h.Previous("")
+	if q, _ := h.Previous("two"); q != "one" {
+		t.Fatalf("Previous() = %q, want one", q)
+	}
+	if !h.Recalling("one") {
+		t.Error("Expected to be recalling while the entry shows the recalled query")
+	}
+
+	// Editing the recalled text restarts from the newest query
+	if h.Recalling("one more") {
+		t.Error("Expected recall to end once the text is edited")
+	}
+	if q, _ := h.Previous("one more"); q != "two" {
+		t.Errorf("Previous() after an edit = %q, want two", q)
+	}
+
+	h.Reset()
+	if q, _ := h.Previous("two"); q != "two" {
+		t.Errorf("Previous() after Reset = %q, want two", q)
+	}
+}
+
+func TestQueryHistoryPersists(t *testing.T) {
+	dir := t.TempDir()
+	h := NewQueryHistory(dir, 10)
+	h.Add("first")
+	h.Add("second")
+
+	reloaded := NewQueryHistory(dir, 1)
+	if got, want := reloaded.Entries(), []string{"second"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reloaded Entries() = %v, want %v", got, want)
+	}
+
+	disabled := NewQueryHistory(t.TempDir(), 0)
+	disabled.Add("ignored")
+	if got := disabled.Entries(); len(got) != 0 {
+		t.Errorf("Expected no entries with a zero cap, got %v", got)
+	}
+}
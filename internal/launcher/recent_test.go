@@ -0,0 +1,102 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+const sampleXbel = `<?xml version="1.0" encoding="UTF-8"?>
+<xbel version="1.0"
+      xmlns:bookmark="http://www.freedesktop.org/standards/desktop-bookmarks"
+      xmlns:mime="http://www.freedesktop.org/standards/shared-mime-info">
+  <bookmark href="file:///home/user/old%20notes.txt" added="2024-01-01T10:00:00Z" modified="2024-01-01T10:00:00Z" visited="2024-01-01T10:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="text/plain"/>
+        <bookmark:applications>
+          <bookmark:application name="gedit" exec="&apos;gedit %u&apos;" modified="2024-01-01T10:00:00Z" count="1"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+  <bookmark href="https://example.com/remote.pdf" added="2024-03-01T10:00:00Z" modified="2024-03-01T10:00:00Z" visited="2024-03-01T10:00:00Z"/>
+  <bookmark href="file:///home/user/paper.pdf" added="2024-01-02T10:00:00Z" modified="2024-01-02T10:00:00Z" visited="2024-01-02T10:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="application/pdf"/>
+        <bookmark:applications>
+          <bookmark:application name="Evince" exec="&apos;evince %u&apos;" modified="2024-02-01T09:00:00Z" count="2"/>
+          <bookmark:application name="Okular" exec="&apos;okular %u&apos;" modified="2024-02-05T09:00:00.123456Z" count="1"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+</xbel>`
+
+func TestParseRecentFiles(t *testing.T) {
+	files, err := ParseRecentFiles([]byte(sampleXbel))
+	if err != nil {
+		t.Fatalf("ParseRecentFiles failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 local files (remote URI skipped), got %d", len(files))
+	}
+
+	if files[0].Path != "/home/user/paper.pdf" {
+		t.Errorf("Expected most recently used file first, got %q", files[0].Path)
+	}
+	if files[0].App != "Okular" {
+		t.Errorf("Expected most recent app Okular, got %q", files[0].App)
+	}
+	if files[0].MimeType != "application/pdf" {
+		t.Errorf("Expected mime type application/pdf, got %q", files[0].MimeType)
+	}
+	if files[1].Path != "/home/user/old notes.txt" {
+		t.Errorf("Expected unescaped path, got %q", files[1].Path)
+	}
+
+	if _, err := ParseRecentFiles([]byte("<xbel><bookmark")); err == nil {
+		t.Error("Expected error for malformed xbel")
+	}
+}
+
+func TestExistingRecentFiles(t *testing.T) {
+	dir := t.TempDir()
+	alive := filepath.Join(dir, "alive.txt")
+	if err := os.WriteFile(alive, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := existingRecentFiles([]RecentFile{
+		{Path: filepath.Join(dir, "deleted.txt")},
+		{Path: alive},
+	})
+	if len(files) != 1 || files[0].Path != alive {
+		t.Errorf("Expected only the existing file, got %+v", files)
+	}
+}
+
+func TestFileOpenerFor(t *testing.T) {
+	cfg := config.FileSearchConfig{
+		FileOpener:  "xdg-open",
+		FileOpeners: map[string]string{".pdf": "zathura"},
+	}
+
+	if got := fileOpenerFor(cfg, "/tmp/Paper.PDF"); got != "zathura" {
+		t.Errorf("Expected extension opener, got %q", got)
+	}
+	if got := fileOpenerFor(cfg, "/tmp/notes.txt"); got != "xdg-open" {
+		t.Errorf("Expected fallback opener, got %q", got)
+	}
+	if got := fileOpenerFor(config.FileSearchConfig{}, "/tmp/notes.txt"); got != "xdg-open" {
+		t.Errorf("Expected xdg-open default, got %q", got)
+	}
+
+	want := NewShScriptAction(`zathura '/tmp/My Papers/it'\''s.pdf'`)
+	if got := fileOpenAction(cfg, "/tmp/My Papers/it's.pdf"); *got != *want {
+		t.Errorf("Expected a quoted path, got %q", got.Command)
+	}
+}
@@ -3,18 +3,19 @@ package launcher
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
+	"sort"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"syscall"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/chess10kp/locus/internal/apps"
 	"github.com/chess10kp/locus/internal/config"
@@ -30,6 +31,25 @@ type LauncherContext struct {
 	UI             LauncherUI
 	ShowLockScreen func() error
 	Registry       *LauncherRegistry
+	// Ctx is cancelled when the search this Populate call serves is
+	// superseded; nil outside of SearchContext
+	Ctx context.Context
+	// Params holds the options of an IPC invocation, nil for a plain show
+	Params *InvocationParams
+}
+
+// Context returns the search context, or context.Background() if none
+func (c *LauncherContext) Context() context.Context {
+	if c == nil || c.Ctx == nil {
+		return context.Background()
+	}
+	return c.Ctx
+}
+
+// Cancelled reports whether the current search was superseded, letting
+// slow launchers bail out of Populate early
+func (c *LauncherContext) Cancelled() bool {
+	return c.Context().Err() != nil
 }
 
 // LauncherSizeMode represents launcher window size mode
@@ -53,6 +73,8 @@ type LauncherItem struct {
 	ImagePath     string
 	Metadata      map[string]string
 	PreviewAction func() error
+	// MatchedIndexes are byte offsets into Title matched by the query
+	MatchedIndexes []int
 }
 
 // GridConfig represents configuration for grid view layout
@@ -83,6 +105,29 @@ const (
 // CtrlNumberAction is a function that performs an action on a launcher item
 type CtrlNumberAction func(item *LauncherItem) error
 
+// ConfirmPrompt is returned by a CtrlNumberAction that did nothing yet and
+// waits for a second press; the launcher shows Prompt and stays open
+type ConfirmPrompt struct {
+	Prompt string
+}
+
+func (p *ConfirmPrompt) Error() string {
+	return p.Prompt
+}
+
+// CtrlNumberActionMap is implemented by launchers whose Ctrl+number keys pick
+// one of several actions for the selected item, rather than acting on the
+// item at that position. The labels describe each number's action (badges).
+type CtrlNumberActionMap interface {
+	CtrlNumberActions(item *LauncherItem) map[int]string
+}
+
+// ShowListener is implemented by launchers that refresh their state once
+// each time the launcher window opens, rather than on every keystroke
+type ShowListener interface {
+	LauncherShown()
+}
+
 // LauncherFactory creates launcher instances
 type LauncherFactory interface {
 	Name() string
@@ -146,15 +191,32 @@ type Launcher interface {
 
 // LauncherRegistry manages all launchers
 type LauncherRegistry struct {
-	launchers       map[string]Launcher
-	triggerMap      map[string]Launcher
-	customPrefix    map[string]string // name -> custom prefix
-	config          *config.Config
-	ctx             *LauncherContext
-	searchCache     *SearchCache
-	appsHash        string
-	hookRegistry    *HookRegistry
-	frecencyTracker *FrecencyTracker
+	launchers        map[string]Launcher
+	triggerMap       map[string]Launcher
+	customPrefix     map[string]string // name -> custom prefix
+	triggerConflicts []TriggerConflict
+	config           *config.Config
+	ctx              *LauncherContext
+	ctxMu            sync.RWMutex // ctx is updated on the GTK thread and copied by searches
+	searchCache      *SearchCache
+	resultCache      *resultCache   // launcher-specific results, see ResultCacher
+	metrics          *SearchMetrics // nil unless search.metrics is enabled
+	appsHash         string
+	appsHashMu       sync.RWMutex // appsHash is read by the warm-up goroutine
+	hookRegistry     *HookRegistry
+	frecencyTracker  *FrecencyTracker
+}
+
+// DataDir returns the directory launcher state such as frecency and query
+// history is kept in
+func DataDir(cfg *config.Config) string {
+	if cfg.CacheDir != "" {
+		return cfg.CacheDir
+	}
+	if homeDir := os.Getenv("HOME"); homeDir != "" {
+		return filepath.Join(homeDir, ".local", "share", "locus")
+	}
+	return "/tmp/locus"
 }
 
 // NewLauncherRegistry creates a new launcher registry
@@ -166,17 +228,7 @@ func NewLauncherRegistry(cfg *config.Config) *LauncherRegistry {
 		cache = nil
 	}
 
-	dataDir := cfg.CacheDir
-	if dataDir == "" {
-		homeDir := os.Getenv("HOME")
-		if homeDir != "" {
-			dataDir = filepath.Join(homeDir, ".local", "share", "locus")
-		} else {
-			dataDir = "/tmp/locus"
-		}
-	}
-
-	frecencyTracker, err := NewFrecencyTracker(dataDir)
+	frecencyTracker, err := NewFrecencyTracker(DataDir(cfg))
 	if err != nil {
 		log.Printf("Failed to create frecency tracker: %v", err)
 		frecencyTracker = nil
@@ -191,16 +243,36 @@ func NewLauncherRegistry(cfg *config.Config) *LauncherRegistry {
 			Config: cfg,
 		},
 		searchCache:     cache,
+		resultCache:     newResultCache(),
 		appsHash:        "",
 		hookRegistry:    NewHookRegistry(),
 		frecencyTracker: frecencyTracker,
 	}
 
+	if cfg.Launcher.Search.Metrics {
+		registry.metrics = NewSearchMetrics()
+	}
+
 	registry.ctx.Registry = registry
+	registry.hookRegistry.SetHookTimeout(time.Duration(cfg.Launcher.Performance.HookTimeout) * time.Millisecond)
 	return registry
 }
 
-// Register registers a launcher
+// ErrTriggerConflict is returned (wrapped) when a launcher claims a trigger
+// already owned by another launcher
+var ErrTriggerConflict = errors.New("trigger conflict")
+
+// TriggerConflict records a trigger claimed by more than one launcher; the
+// first launcher to register it keeps it
+type TriggerConflict struct {
+	Trigger  string
+	Owner    string
+	Rejected string
+}
+
+// Register registers a launcher. Triggers already owned by another launcher
+// are not taken over; the launcher is still registered and the returned
+// error wraps ErrTriggerConflict.
 func (r *LauncherRegistry) Register(launcher Launcher) error {
 	name := launcher.Name()
 
@@ -211,46 +283,103 @@ func (r *LauncherRegistry) Register(launcher Launcher) error {
 	r.launchers[name] = launcher
 
 	// Register triggers
+	var conflicts []error
 	for _, trigger := range launcher.CommandTriggers() {
-		r.triggerMap[trigger] = launcher
+		if err := r.claimTrigger(trigger, launcher); err != nil {
+			conflicts = append(conflicts, err)
+			continue
+		}
 		log.Printf("Registered trigger: %s -> %s", trigger, name)
 	}
 
-	return nil
+	return errors.Join(conflicts...)
 }
 
-// RegisterWithCustomPrefix registers a launcher with custom prefix
+// RegisterWithCustomPrefix registers a launcher (if not already registered)
+// with an additional custom prefix
 func (r *LauncherRegistry) RegisterWithCustomPrefix(launcher Launcher, prefix string) error {
 	name := launcher.Name()
 
-	if err := r.Register(launcher); err != nil {
-		return err
+	var registerErr error
+	if existing, exists := r.launchers[name]; !exists {
+		registerErr = r.Register(launcher)
+		if registerErr != nil && !errors.Is(registerErr, ErrTriggerConflict) {
+			return registerErr
+		}
+	} else if existing != launcher {
+		return fmt.Errorf("launcher '%s' already registered", name)
 	}
 
+	if err := r.claimTrigger(prefix, launcher); err != nil {
+		return errors.Join(registerErr, err)
+	}
 	r.customPrefix[name] = prefix
-	r.triggerMap[prefix] = launcher
 
 	log.Printf("Registered custom prefix: %s -> %s", prefix, name)
 
+	return registerErr
+}
+
+// claimTrigger maps trigger to launcher unless another launcher owns it
+func (r *LauncherRegistry) claimTrigger(trigger string, launcher Launcher) error {
+	if owner, exists := r.triggerMap[trigger]; exists && owner != launcher {
+		r.triggerConflicts = append(r.triggerConflicts, TriggerConflict{
+			Trigger:  trigger,
+			Owner:    owner.Name(),
+			Rejected: launcher.Name(),
+		})
+		log.Printf("Trigger conflict: '%s' already registered to %s, ignoring for %s", trigger, owner.Name(), launcher.Name())
+		return fmt.Errorf("%w: '%s' belongs to launcher '%s', not '%s'", ErrTriggerConflict, trigger, owner.Name(), launcher.Name())
+	}
+
+	r.triggerMap[trigger] = launcher
 	return nil
 }
 
+// DiagnoseTriggers returns every registered trigger mapped to the name of
+// the launcher that owns it
+func (r *LauncherRegistry) DiagnoseTriggers() map[string]string {
+	triggers := make(map[string]string, len(r.triggerMap))
+	for trigger, launcher := range r.triggerMap {
+		triggers[trigger] = launcher.Name()
+	}
+	return triggers
+}
+
+// CustomPrefix returns the custom prefix registered for a launcher
+func (r *LauncherRegistry) CustomPrefix(name string) (string, bool) {
+	prefix, ok := r.customPrefix[name]
+	return prefix, ok
+}
+
+// TriggerConflicts returns the conflicts found while registering launchers
+func (r *LauncherRegistry) TriggerConflicts() []TriggerConflict {
+	conflicts := make([]TriggerConflict, len(r.triggerConflicts))
+	copy(conflicts, r.triggerConflicts)
+	return conflicts
+}
+
 // Unregister unregisters a launcher
 func (r *LauncherRegistry) Unregister(name string) {
 	if launcher, exists := r.launchers[name]; exists {
-		// Remove triggers
+		// Remove triggers this launcher owns
 		for _, trigger := range launcher.CommandTriggers() {
-			delete(r.triggerMap, trigger)
+			if r.triggerMap[trigger] == launcher {
+				delete(r.triggerMap, trigger)
+			}
 		}
 
 		// Remove custom prefix
 		if prefix, ok := r.customPrefix[name]; ok {
-			delete(r.triggerMap, prefix)
+			if r.triggerMap[prefix] == launcher {
+				delete(r.triggerMap, prefix)
+			}
 			delete(r.customPrefix, name)
 		}
 
 		launcher.Cleanup()
 		delete(r.launchers, name)
+		r.resultCache.Invalidate(name)
 
 		log.Printf("Unregistered launcher: %s", name)
 	}
@@ -272,6 +401,14 @@ func (r *LauncherRegistry) FindLauncherForInput(input string) (trigger string, l
 		}
 	}
 
+	// Check for ! prefix (bang-style web search, e.g. "!g query")
+	if strings.HasPrefix(input, "!") {
+		launcher, exists := r.GetLauncher("!")
+		if exists {
+			return "!", launcher, input[1:]
+		}
+	}
+
 	// Check for % prefix (timer launcher)
 	if strings.HasPrefix(input, "%") {
 		launcher, exists := r.GetLauncher("%")
@@ -338,6 +475,16 @@ func (r *LauncherRegistry) GetAllLaunchers() []Launcher {
 	return launchers
 }
 
+// LauncherShown tells launchers implementing ShowListener that the launcher
+// window was opened
+func (r *LauncherRegistry) LauncherShown() {
+	for _, launcher := range r.launchers {
+		if listener, ok := launcher.(ShowListener); ok {
+			listener.LauncherShown()
+		}
+	}
+}
+
 // Cleanup cleans up all launchers
 func (r *LauncherRegistry) Cleanup() {
 	for name, launcher := range r.launchers {
@@ -348,105 +495,235 @@ func (r *LauncherRegistry) Cleanup() {
 	r.launchers = make(map[string]Launcher)
 	r.triggerMap = make(map[string]Launcher)
 	r.customPrefix = make(map[string]string)
+	r.triggerConflicts = nil
 
 	// Clear search cache
 	if r.searchCache != nil {
 		r.searchCache.Invalidate()
 	}
-	r.appsHash = ""
+	r.resultCache.InvalidateAll()
+	r.setAppsHash("")
 }
 
 // Search searches for items matching the query
 func (r *LauncherRegistry) Search(query string) ([]*LauncherItem, error) {
+	return r.SearchContext(context.Background(), query)
+}
+
+// SearchContext searches for items matching the query, returning
+// ctx.Err() as soon as ctx is cancelled. Populate calls see ctx through
+// LauncherContext.Ctx so they can stop early; results of a cancelled
+// search are discarded and never cached.
+func (r *LauncherRegistry) SearchContext(ctx context.Context, query string) ([]*LauncherItem, error) {
 	startTime := time.Now()
-	log.Printf("[REGISTRY-SEARCH] Started for query='%s'", query)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	launcherCtx := r.launcherContext()
+	launcherCtx.Ctx = ctx
+
+	// dmenu invocations pick from their own options, all of which stay
+	// reachable, so search.max_results doesn't apply
+	if params := launcherCtx.Params; params.DmenuMode() {
+		return optionItems(params.Options, query, launcherCtx.CaseSensitive()), nil
+	}
 
 	_, l, q := r.FindLauncherForInput(query)
 
 	if l != nil {
 		// Launcher-specific search - only search this launcher
-		log.Printf("[REGISTRY-SEARCH] Launcher-specific search: launcher='%s', query='%s'", l.Name(), q)
+		if min, short := r.queryTooShort(l, q); short {
+			log.Printf("[REGISTRY-SEARCH] Query shorter than %d characters, skipping launcher='%s'", min, l.Name())
+			return []*LauncherItem{typeMoreItem(l, min)}, nil
+		}
+		ttl := resultCacheTTL(l)
+		if ttl > 0 {
+			if cached, found := r.resultCache.Get(l.Name(), q); found {
+				return cached, nil
+			}
+		}
 		populateStart := time.Now()
-		items := l.Populate(q, r.ctx)
-		log.Printf("[REGISTRY-SEARCH] Launcher-specific populate completed in %v, %d items", time.Since(populateStart), len(items))
+		items, err := populateContext(ctx, func() []*LauncherItem {
+			return l.Populate(q, &launcherCtx)
+		})
+		if err != nil {
+			log.Printf("[REGISTRY-SEARCH] Cancelled launcher-specific search for query='%s'", query)
+			r.metrics.ObserveCancelled()
+			return nil, err
+		}
+		r.metrics.ObservePopulate(l.Name(), time.Since(populateStart))
 
 		// Apply max results limit
 		maxResults := r.config.Launcher.Search.MaxResults
 		if len(items) > maxResults {
 			items = items[:maxResults]
-			log.Printf("[REGISTRY-SEARCH] Limited results to %d (max configured)", maxResults)
 		}
 
-		// Don't cache launcher-specific searches
-		log.Printf("[REGISTRY-SEARCH] Completed launcher-specific search in %v", time.Since(startTime))
+		// Launcher-specific results are only cached briefly, by launchers
+		// that opt in
+		if ttl > 0 {
+			r.resultCache.Put(l.Name(), q, items, ttl)
+		}
+		r.metrics.ObserveSearch(time.Since(startTime))
 		return items, nil
 	}
 
+	// Scope launchers return live results (files, calculations), so merged
+	// searches bypass the search cache, as do searches under invocation
+	// params, which may change how results match
+	scope := r.scopeLaunchers()
+	useCache := r.searchCache != nil && len(scope) == 0 && launcherCtx.Params == nil
+
 	// General app search - check cache first
-	if r.searchCache != nil {
-		cacheCheckStart := time.Now()
-		if cachedResults, found := r.searchCache.Get(query, r.appsHash); found {
-			log.Printf("[REGISTRY-SEARCH] Cache HIT for query='%s', returned %d items in %v", query, len(cachedResults), time.Since(cacheCheckStart))
-			// Log cache stats periodically (every 10 hits to avoid spam)
-			if atomic.LoadInt64(&r.searchCache.hits)%10 == 0 {
-				stats := r.searchCache.GetStats()
-				log.Printf("[REGISTRY-SEARCH] Cache stats: hits=%d, misses=%d, hit_rate=%.2f%%", stats.Hits, stats.Misses, stats.HitRate*100)
-			}
-			return cachedResults, nil
+	if useCache {
+		if cachedResults, found := r.searchCache.Get(query, r.currentAppsHash()); found {
+			r.metrics.ObserveCache(true)
+			r.metrics.ObserveSearch(time.Since(startTime))
+			// Re-sorted so frecency changes since caching still apply
+			return withNoResultsItem(r.config, query, r.sortResults(cachedResults)), nil
 		}
-		log.Printf("[REGISTRY-SEARCH] Cache MISS for query='%s' in %v", query, time.Since(cacheCheckStart))
-	} else {
-		log.Printf("[REGISTRY-SEARCH] No cache available")
+		r.metrics.ObserveCache(false)
 	}
 
-	// Find app launcher and search it (only search apps for general queries)
-	var items []*LauncherItem
-	var appLauncher Launcher
+	appLauncher := r.findAppLauncher()
+	items, err := populateContext(ctx, func() []*LauncherItem {
+		items := r.searchApps(appLauncher, query, &launcherCtx)
+		if len(scope) == 0 || strings.TrimSpace(query) == "" {
+			return items
+		}
+
+		groups := append([][]*LauncherItem{items}, r.searchScope(scope, query, &launcherCtx)...)
+		items = r.sortResults(r.deduplicateResults(mergeScopeResults(query, groups...)))
+		if maxResults := r.config.Launcher.Search.MaxResults; len(items) > maxResults {
+			items = items[:maxResults]
+		}
+		return items
+	})
+	if err != nil {
+		log.Printf("[REGISTRY-SEARCH] Cancelled general search for query='%s'", query)
+		r.metrics.ObserveCancelled()
+		return nil, err
+	}
+
+	// Results from a partial index are superseded once loading finishes,
+	// so only cache results from the complete index
+	if al, ok := appLauncher.(*AppLauncher); ok && !al.IndexComplete() {
+		log.Printf("[REGISTRY-SEARCH] Partial app index, not caching query='%s'", query)
+		r.metrics.ObserveSearch(time.Since(startTime))
+		return withNoResultsItem(r.config, query, items), nil
+	}
+
+	// Cache the results if cache is available
+	if useCache {
+		durationMs := float64(time.Since(startTime).Nanoseconds()) / 1e6
+		r.searchCache.Put(query, r.currentAppsHash(), items, durationMs)
+	}
+
+	r.metrics.ObserveSearch(time.Since(startTime))
+	return withNoResultsItem(r.config, query, items), nil
+}
+
+// sortResults orders general search results as configured by search.sort.
+// Launcher-specific results keep the order their launcher gave them.
+func (r *LauncherRegistry) sortResults(items []*LauncherItem) []*LauncherItem {
+	return sortResults(items, r.config.Launcher.Search.Sort, r.frecencyTracker)
+}
+
+// OnAppIndexUpdate registers fn to run as the apps launcher's index fills
+// in during a background load and once it completes
+func (r *LauncherRegistry) OnAppIndexUpdate(fn func()) {
+	if appLauncher, ok := r.findAppLauncher().(*AppLauncher); ok {
+		appLauncher.SetIndexUpdateCallback(fn)
+	}
+}
 
+// populateContext runs populate, returning early with ctx.Err() if ctx is
+// cancelled first. The populate goroutine is left to finish on its own and
+// its results are dropped.
+func populateContext(ctx context.Context, populate func() []*LauncherItem) ([]*LauncherItem, error) {
+	done := make(chan []*LauncherItem, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Printf("[REGISTRY-SEARCH] Recovered from panic in populate: %v", rec)
+				done <- nil
+			}
+		}()
+		done <- populate()
+	}()
+
+	select {
+	case items := <-done:
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return items, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// findAppLauncher returns the default apps launcher, if registered
+func (r *LauncherRegistry) findAppLauncher() Launcher {
 	for _, l := range r.launchers {
 		if l.Name() == "apps" {
-			appLauncher = l
-			break
+			return l
 		}
 	}
+	return nil
+}
 
-	if appLauncher != nil {
-		log.Printf("[REGISTRY-SEARCH] Using AppLauncher for general query='%s'", query)
-		populateStart := time.Now()
-		items = appLauncher.Populate(query, r.ctx)
-		log.Printf("[REGISTRY-SEARCH] AppLauncher populate completed in %v, %d items", time.Since(populateStart), len(items))
-	} else {
-		// Fallback: search all launchers (shouldn't happen)
-		log.Printf("[REGISTRY-SEARCH] WARNING: No AppLauncher found, falling back to all launchers")
-		for _, launcher := range r.launchers {
-			launcherItems := launcher.Populate(query, r.ctx)
-			items = append(items, launcherItems...)
-		}
+// searchApps runs a general (non-triggered) query against the apps
+// launcher, deduplicating and limiting the results. Without an apps
+// launcher it returns nothing; other launchers are only searched for a
+// plain query when listed in search.scope.
+func (r *LauncherRegistry) searchApps(appLauncher Launcher, query string, launcherCtx *LauncherContext) []*LauncherItem {
+	if appLauncher == nil {
+		log.Printf("[REGISTRY-SEARCH] WARNING: No AppLauncher registered, no app results for query='%s'", query)
+		return nil
 	}
 
-	// Deduplicate results
-	originalCount := len(items)
-	items = r.deduplicateResults(items)
-	if len(items) != originalCount {
-		log.Printf("[REGISTRY-SEARCH] Deduplication removed %d duplicates (%d -> %d)", originalCount-len(items), originalCount, len(items))
+	if min, short := r.queryTooShort(appLauncher, query); short {
+		log.Printf("[REGISTRY-SEARCH] Query shorter than %d characters, skipping apps", min)
+		return nil
 	}
 
+	populateStart := time.Now()
+	items := appLauncher.Populate(query, launcherCtx)
+	r.metrics.ObservePopulate(appLauncher.Name(), time.Since(populateStart))
+
+	// Deduplicate and sort before the limit, so better-ranked items past it
+	// aren't dropped
+	items = r.sortResults(r.deduplicateResults(items))
+
 	// Apply max results limit
 	maxResults := r.config.Launcher.Search.MaxResults
 	if len(items) > maxResults {
 		items = items[:maxResults]
-		log.Printf("[REGISTRY-SEARCH] Limited results to %d (max configured)", maxResults)
 	}
 
-	// Cache the results if cache is available
-	if r.searchCache != nil {
-		durationMs := float64(time.Since(startTime).Nanoseconds()) / 1e6
-		r.searchCache.Put(query, r.appsHash, items, durationMs)
-		log.Printf("[REGISTRY-SEARCH] Cached results for query='%s' (duration=%.2fms)", query, durationMs)
-	}
+	return items
+}
 
-	log.Printf("[REGISTRY-SEARCH] Completed general search in %v, final result count: %d", time.Since(startTime), len(items))
-	return items, nil
+// queryTooShort reports whether query is non-empty but shorter than the
+// min_query_length configured for l, returning that minimum. Empty queries
+// always search so launchers can show their defaults.
+func (r *LauncherRegistry) queryTooShort(l Launcher, query string) (int, bool) {
+	min := r.config.Launcher.Search.MinQueryLength[l.Name()]
+	n := utf8.RuneCountInString(strings.TrimSpace(query))
+	return min, n > 0 && n < min
+}
+
+// typeMoreItem is shown in place of results for a query that is too short
+func typeMoreItem(l Launcher, min int) *LauncherItem {
+	return &LauncherItem{
+		Title:    "Type more…",
+		Subtitle: fmt.Sprintf("%s searches need at least %d characters", l.Name(), min),
+		Icon:     "edit-find-symbolic",
+		Launcher: l,
+	}
 }
 
 // deduplicateResults removes duplicate results based on title and subtitle
@@ -475,7 +752,7 @@ func (r *LauncherRegistry) deduplicateResults(items []*LauncherItem) []*Launcher
 // UpdateAppsHash updates the apps hash for cache invalidation
 func (r *LauncherRegistry) UpdateAppsHash(apps []apps.App) {
 	if r.searchCache != nil {
-		r.appsHash = ComputeAppsHash(apps)
+		r.setAppsHash(ComputeAppsHash(apps))
 	}
 }
 
@@ -484,13 +761,25 @@ func (r *LauncherRegistry) UpdateAppsHashFromLauncher() {
 	if r.searchCache != nil {
 		for _, launcher := range r.launchers {
 			if appLauncher, ok := launcher.(*AppLauncher); ok {
-				r.appsHash = appLauncher.GetAppsHash()
+				r.setAppsHash(appLauncher.GetAppsHash())
 				break
 			}
 		}
 	}
 }
 
+func (r *LauncherRegistry) currentAppsHash() string {
+	r.appsHashMu.RLock()
+	defer r.appsHashMu.RUnlock()
+	return r.appsHash
+}
+
+func (r *LauncherRegistry) setAppsHash(hash string) {
+	r.appsHashMu.Lock()
+	defer r.appsHashMu.Unlock()
+	r.appsHash = hash
+}
+
 // GetCacheStats returns current cache statistics
 func (r *LauncherRegistry) GetCacheStats() *CacheStats {
 	if r.searchCache != nil {
@@ -571,6 +860,17 @@ func (r *LauncherRegistry) ExecuteWithActionData(launcherName string, data Actio
 		}
 		return r.executeColorAction(colorAction)
 
+	case "type":
+		typeAction, ok := data.(*TypeAction)
+		if !ok {
+			return fmt.Errorf("invalid type action type")
+		}
+		return r.executeTypeAction(typeAction)
+
+	case "prefill":
+		// Prefill actions are applied by the launcher window
+		return fmt.Errorf("prefill action requires the launcher window")
+
 	default:
 		// Custom action - pass to launcher hooks if available
 		ctx := &HookContext{
@@ -629,7 +929,7 @@ func (r *LauncherRegistry) executeDesktopAction(filePath string) error {
 	}
 
 	// Strip field codes like %f, %u, etc. (similar to Python implementation)
-	execCmd = r.stripFieldCodes(execCmd)
+	execCmd = apps.StripFieldCodes(execCmd)
 
 	// Split the command with proper quote handling (like Python's shlex.split)
 	parts, err := r.splitCommand(execCmd)
@@ -654,7 +954,7 @@ func (r *LauncherRegistry) executeDesktopAction(filePath string) error {
 	}
 
 	// Sanitize environment (remove LD_PRELOAD like Python)
-	cmd.Env = r.sanitizeEnvironment()
+	cmd.Env = sanitizeEnvironment()
 
 	// Set working directory if specified in desktop file
 	if workingDir != "" {
@@ -693,13 +993,6 @@ func (r *LauncherRegistry) parseDesktopFile(filePath string) (execCmd string, wo
 	return execCmd, workingDir, nil
 }
 
-// stripFieldCodes removes desktop entry field codes like %f, %u, etc.
-func (r *LauncherRegistry) stripFieldCodes(cmd string) string {
-	// Remove field codes using regex (similar to Python's re.sub)
-	re := regexp.MustCompile(`%[uUfFdDnNickvm]`)
-	return strings.TrimSpace(re.ReplaceAllString(cmd, ""))
-}
-
 // splitCommand splits a command string like shlex.split() in Python
 func (r *LauncherRegistry) splitCommand(cmd string) ([]string, error) {
 	var parts []string
@@ -752,7 +1045,7 @@ func (r *LauncherRegistry) isSystemdRunAvailable() bool {
 }
 
 // sanitizeEnvironment removes problematic environment variables
-func (r *LauncherRegistry) sanitizeEnvironment() []string {
+func sanitizeEnvironment() []string {
 	env := os.Environ()
 	var sanitized []string
 
@@ -820,6 +1113,14 @@ func (r *LauncherRegistry) executeWindowFocusAction(action *WindowFocusAction) e
 	return r.executeShellCommand(focusCmd)
 }
 
+// executeTypeAction types text into the focused window once the launcher
+// has had time to hide, copying it to the clipboard when no typing tool is
+// installed
+func (r *LauncherRegistry) executeTypeAction(action *TypeAction) error {
+	typeText(action.Text, sanitizeEnvironment())
+	return nil
+}
+
 // executeColorAction handles color picker operations
 func (r *LauncherRegistry) executeColorAction(action *ColorAction) error {
 	switch action.Action {
@@ -856,6 +1157,8 @@ func (r *LauncherRegistry) RefreshLauncher(name string) error {
 		return fmt.Errorf("launcher '%s' not found", name)
 	}
 
+	r.resultCache.Invalidate(name)
+
 	// Call the launcher's Rebuild method if it implements it
 	if rebuildable, ok := launcher.(interface{ Rebuild(*LauncherContext) error }); ok {
 		return rebuildable.Rebuild(r.ctx)
@@ -864,6 +1167,12 @@ func (r *LauncherRegistry) RefreshLauncher(name string) error {
 	return fmt.Errorf("launcher '%s' does not support rebuilding", name)
 }
 
+// Metrics returns the aggregated search metrics, nil unless search.metrics
+// is enabled
+func (r *LauncherRegistry) Metrics() *SearchMetrics {
+	return r.metrics
+}
+
 // GetHookRegistry returns the hook registry
 func (r *LauncherRegistry) GetHookRegistry() *HookRegistry {
 	return r.hookRegistry
@@ -871,9 +1180,11 @@ func (r *LauncherRegistry) GetHookRegistry() *HookRegistry {
 
 // SetLockScreenCallback sets the callback to show lock screen and registers the hook
 func (r *LauncherRegistry) SetLockScreenCallback(callback func() error) {
+	r.ctxMu.Lock()
 	if r.ctx != nil {
 		r.ctx.ShowLockScreen = callback
 	}
+	r.ctxMu.Unlock()
 	// Register or update the lock screen hook with the callback
 	// Note: Use "lock" as the key since that's the launcher name
 	lockHook := NewLockScreenHook(callback)
@@ -882,6 +1193,8 @@ func (r *LauncherRegistry) SetLockScreenCallback(callback func() error) {
 
 // GetLockScreenCallback returns the lock screen callback
 func (r *LauncherRegistry) GetLockScreenCallback() func() error {
+	r.ctxMu.RLock()
+	defer r.ctxMu.RUnlock()
 	if r.ctx != nil {
 		return r.ctx.ShowLockScreen
 	}
@@ -910,7 +1223,20 @@ func (r *LauncherRegistry) LoadBuiltIn() error {
 
 	factories := GetLauncherFactories()
 
-	for name, factory := range factories {
+	// Register in name order so trigger conflicts resolve deterministically
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !r.config.Launcher.LauncherEnabled(name) {
+			log.Printf("Launcher %s is disabled in config", name)
+			continue
+		}
+
+		factory := factories[name]
 		launcher := factory.Create(r.config)
 
 		// Special handling for AppLauncher - set frecency tracker and start background load
@@ -925,8 +1251,11 @@ func (r *LauncherRegistry) LoadBuiltIn() error {
 		}
 
 		if err := r.Register(launcher); err != nil {
-			log.Printf("Failed to register launcher %s: %v", name, err)
-			continue
+			if !errors.Is(err, ErrTriggerConflict) {
+				log.Printf("Failed to register launcher %s: %v", name, err)
+				continue
+			}
+			log.Printf("Registered launcher %s with trigger conflicts: %v", name, err)
 		}
 
 		r.registerLauncherHooks(launcher)
@@ -942,5 +1271,7 @@ func (r *LauncherRegistry) LoadBuiltIn() error {
 	// Update apps hash after registration
 	r.UpdateAppsHashFromLauncher()
 
+	r.StartWarmUp()
+
 	return nil
 }
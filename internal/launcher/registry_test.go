@@ -1,7 +1,11 @@
 package launcher
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/chess10kp/locus/internal/config"
 )
@@ -34,6 +38,7 @@ func TestLauncherRegistration(t *testing.T) {
 		"apps", "shell", "web", "calc", "brightness",
 		"screenshot", "lock", "timer", "kill",
 		"focus", "wallpaper", "clipboard", "wifi", "file", "music",
+		"define", "recent",
 	}
 
 	for _, name := range expectedLaunchers {
@@ -69,6 +74,8 @@ func TestLauncherQueryParsing(t *testing.T) {
 		{">lock", "lock", true},
 		{"%5m", "timer", true},
 		{">kill", "kill", true},
+		{">def serendipity", "define", true},
+		{">recent", "recent", true},
 		{">focus left", "focus", true},
 		{">wallpaper", "wallpaper", true},
 		{"?", "help", true},
@@ -88,3 +95,341 @@ func TestLauncherQueryParsing(t *testing.T) {
 		}
 	}
 }
+
+// slowLauncher blocks in Populate until its search is cancelled or a long
+// timeout elapses, recording whether it ran to completion
+type slowLauncher struct {
+	fakeAppsLauncher
+	name      string
+	completed chan bool
+}
+
+func (l *slowLauncher) Name() string              { return l.name }
+func (l *slowLauncher) CommandTriggers() []string { return []string{l.name} }
+
+func (l *slowLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	select {
+	case <-ctx.Context().Done():
+		l.completed <- false
+		return nil
+	case <-time.After(5 * time.Second):
+		l.completed <- true
+		return []*LauncherItem{{Title: "slow", Launcher: l}}
+	}
+}
+
+func TestSearchContextCancellation(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	cfg.Launcher.Performance.SearchCacheSize = 50
+
+	for _, tc := range []struct {
+		name  string
+		query string
+	}{
+		{"apps", "firefox"},
+		{"slow", ">slow query"},
+	} {
+		registry := NewLauncherRegistry(cfg)
+		slow := &slowLauncher{name: tc.name, completed: make(chan bool, 1)}
+		if err := registry.Register(slow); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		start := time.Now()
+		items, err := registry.SearchContext(ctx, tc.query)
+		if !errors.Is(err, context.Canceled) || items != nil {
+			t.Errorf("%s: expected cancellation, got %v items, err=%v", tc.name, items, err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: cancelled search took %v", tc.name, elapsed)
+		}
+		if completed := <-slow.completed; completed {
+			t.Errorf("%s: populate ran to completion despite cancellation", tc.name)
+		}
+		if registry.searchCache.Contains(tc.query, registry.currentAppsHash()) {
+			t.Errorf("%s: cancelled search results were cached", tc.name)
+		}
+	}
+}
+
+func TestSearchContextAlreadyCancelled(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	registry := NewLauncherRegistry(cfg)
+	fake := &fakeAppsLauncher{}
+	registry.Register(fake)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := registry.SearchContext(ctx, "firefox"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(fake.queries) != 0 {
+		t.Errorf("Expected no populate for a cancelled search, got %v", fake.queries)
+	}
+}
+
+func TestSearchWithoutAppsLauncher(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	cfg.Launcher.Search.ScopeMaxResults = 5
+	cfg.Launcher.Search.NoResultsAction = NoResultsActionNone
+	registry := NewLauncherRegistry(cfg)
+	wm := &triggerLauncher{name: "wm", triggers: []string{"wm"}}
+	file := &triggerLauncher{name: "file", triggers: []string{"file"}}
+	registry.Register(wm)
+	registry.Register(file)
+
+	items, err := registry.SearchContext(context.Background(), "firefox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("Expected no results without an apps launcher, got %v", titlesOf(items))
+	}
+	if len(wm.queries) != 0 || len(file.queries) != 0 {
+		t.Errorf("Expected no unrelated launchers to be populated, got wm=%v file=%v", wm.queries, file.queries)
+	}
+
+	// Scope launchers are still searched, and only those
+	cfg.Launcher.Search.Scope = []string{"file"}
+	items, err = registry.SearchContext(context.Background(), "notes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Title != "notes-result" {
+		t.Errorf("Expected the scope launcher's result, got %v", titlesOf(items))
+	}
+	if len(wm.queries) != 0 {
+		t.Errorf("Expected wm not to be populated, got %v", wm.queries)
+	}
+	if len(file.queries) != 1 {
+		t.Errorf("Expected file to be populated once, got %v", file.queries)
+	}
+}
+
+// triggerLauncher is a minimal launcher with configurable name and triggers
+type triggerLauncher struct {
+	fakeAppsLauncher
+	name     string
+	triggers []string
+}
+
+func (l *triggerLauncher) Name() string              { return l.name }
+func (l *triggerLauncher) CommandTriggers() []string { return l.triggers }
+
+func TestTriggerConflictDetection(t *testing.T) {
+	registry := NewLauncherRegistry(&config.Config{CacheDir: t.TempDir()})
+
+	first := &triggerLauncher{name: "first", triggers: []string{"x", "one"}}
+	second := &triggerLauncher{name: "second", triggers: []string{"x", "two"}}
+
+	if err := registry.Register(first); err != nil {
+		t.Fatalf("Unexpected error registering first launcher: %v", err)
+	}
+	err := registry.Register(second)
+	if !errors.Is(err, ErrTriggerConflict) {
+		t.Fatalf("Expected ErrTriggerConflict, got %v", err)
+	}
+
+	if l, _ := registry.GetLauncher("x"); l != first {
+		t.Error("Expected the first launcher to keep the conflicting trigger")
+	}
+	if l, _ := registry.GetLauncher("two"); l != second {
+		t.Error("Expected non-conflicting triggers of the second launcher to register")
+	}
+
+	conflicts := registry.TriggerConflicts()
+	if len(conflicts) != 1 || conflicts[0] != (TriggerConflict{Trigger: "x", Owner: "first", Rejected: "second"}) {
+		t.Errorf("Unexpected conflicts: %+v", conflicts)
+	}
+
+	if err := registry.RegisterWithCustomPrefix(second, "one"); !errors.Is(err, ErrTriggerConflict) {
+		t.Errorf("Expected custom prefix conflict, got %v", err)
+	}
+	if err := registry.RegisterWithCustomPrefix(first, "x"); err != nil {
+		t.Errorf("Re-claiming an owned trigger as a prefix should not conflict: %v", err)
+	}
+
+	// Unregistering the rejected launcher must not remove the owner's trigger
+	registry.Unregister("second")
+	if l, _ := registry.GetLauncher("x"); l != first {
+		t.Error("Unregistering the rejected launcher removed the owner's trigger")
+	}
+}
+
+func TestDiagnoseTriggers(t *testing.T) {
+	registry := NewLauncherRegistry(&config.Config{CacheDir: t.TempDir()})
+	registry.Register(&triggerLauncher{name: "first", triggers: []string{"x", "one"}})
+	registry.Register(&triggerLauncher{name: "second", triggers: []string{"x"}})
+	help := NewHelpLauncher()
+	registry.Register(help)
+
+	triggers := registry.DiagnoseTriggers()
+	want := map[string]string{"x": "first", "one": "first", "?": "help"}
+	if len(triggers) != len(want) {
+		t.Errorf("Expected %d triggers, got %v", len(want), triggers)
+	}
+	for trigger, name := range want {
+		if triggers[trigger] != name {
+			t.Errorf("Trigger %q: expected %q, got %q", trigger, name, triggers[trigger])
+		}
+	}
+
+	items := help.Populate("triggers", registry.ctx)
+	if len(items) != 4 {
+		t.Fatalf("Expected 1 conflict and 3 trigger items, got %d", len(items))
+	}
+	if items[0].Title != "Conflict: x" || items[0].Subtitle != "Owned by first, ignored for second" {
+		t.Errorf("Unexpected conflict item: %q / %q", items[0].Title, items[0].Subtitle)
+	}
+	if items[1].Title != "?" || items[2].Title != "one" || items[3].Title != "x" {
+		t.Errorf("Expected triggers sorted, got %q %q %q", items[1].Title, items[2].Title, items[3].Title)
+	}
+}
+
+func TestBuiltInTriggersDoNotConflict(t *testing.T) {
+	registry := NewLauncherRegistry(&config.Config{CacheDir: t.TempDir()})
+	if err := registry.LoadBuiltIn(); err != nil {
+		t.Fatal(err)
+	}
+	if conflicts := registry.TriggerConflicts(); len(conflicts) != 0 {
+		t.Errorf("Built-in launchers have conflicting triggers: %+v", conflicts)
+	}
+}
+
+func TestHelpListsLaunchersAndKeybindings(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Keys = config.KeysConfig{
+		Up:    []string{"Up", "Ctrl+P"},
+		Close: []string{"Escape"},
+	}
+	registry := NewLauncherRegistry(cfg)
+	registry.Register(&triggerLauncher{name: "zeta", triggers: []string{"z"}})
+	registry.RegisterWithCustomPrefix(&triggerLauncher{name: "alpha", triggers: []string{"al"}}, "a")
+	help := NewHelpLauncher()
+	registry.Register(help)
+
+	items := help.Populate("", registry.ctx)
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title
+	}
+	want := []string{"alpha", "help", "zeta", "Move up", "Close"}
+	if strings.Join(titles, ",") != strings.Join(want, ",") {
+		t.Fatalf("Expected %v, got %v", want, titles)
+	}
+
+	if items[0].Subtitle != "Prefixes: al (custom: a)" {
+		t.Errorf("Unexpected subtitle for alpha: %q", items[0].Subtitle)
+	}
+	if items[3].Subtitle != "Keys: Up, Ctrl+P" {
+		t.Errorf("Unexpected keybinding subtitle: %q", items[3].Subtitle)
+	}
+
+	prefills := map[string]string{"alpha": ">al ", "help": "?", "zeta": ">z "}
+	for _, item := range items[:3] {
+		action, ok := item.ActionData.(*PrefillAction)
+		if !ok {
+			t.Errorf("Expected prefill action for %s, got %T", item.Title, item.ActionData)
+			continue
+		}
+		if action.Text != prefills[item.Title] {
+			t.Errorf("Expected %s to prefill %q, got %q", item.Title, prefills[item.Title], action.Text)
+		}
+	}
+
+	items = help.Populate("escape", registry.ctx)
+	if len(items) != 1 || items[0].Title != "Close" {
+		t.Errorf("Expected query to match the Close keybinding, got %+v", items)
+	}
+}
+
+func TestLoadBuiltInSkipsDisabledLaunchers(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Enabled = map[string]bool{"music": false, "calc": true}
+	registry := NewLauncherRegistry(cfg)
+	if err := registry.LoadBuiltIn(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, l := range registry.GetAllLaunchers() {
+		if l.Name() == "music" {
+			t.Fatal("Disabled music launcher was registered")
+		}
+	}
+	for _, trigger := range []string{"music", "m"} {
+		if _, ok := registry.GetLauncher(trigger); ok {
+			t.Errorf("Trigger %q of a disabled launcher still resolves", trigger)
+		}
+	}
+	if _, l, _ := registry.FindLauncherForInput(">music next"); l != nil {
+		t.Errorf("Expected no launcher for >music, got %s", l.Name())
+	}
+
+	if _, ok := registry.GetLauncher("calc"); !ok {
+		t.Error("Explicitly enabled calc launcher should be registered")
+	}
+}
+
+func TestSearchMinQueryLength(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	cfg.Launcher.Search.ScopeMaxResults = 5
+	cfg.Launcher.Search.Scope = []string{"file"}
+	cfg.Launcher.Search.MinQueryLength = map[string]int{"file": 3, "apps": 0}
+	cfg.Launcher.Search.NoResultsAction = NoResultsActionNone
+	registry := NewLauncherRegistry(cfg)
+	apps := &fakeAppsLauncher{}
+	file := &triggerLauncher{name: "file", triggers: []string{"file"}}
+	registry.Register(apps)
+	registry.Register(file)
+
+	items, err := registry.SearchContext(context.Background(), ">file no")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.queries) != 0 {
+		t.Errorf("Expected a short query to skip populate, got %v", file.queries)
+	}
+	if len(items) != 1 || items[0].Title != "Type more…" || items[0].Launcher != file {
+		t.Errorf("Expected a type-more hint, got %v", titlesOf(items))
+	}
+
+	// Empty queries still populate so launchers can show their defaults
+	if _, err := registry.SearchContext(context.Background(), ">file "); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.SearchContext(context.Background(), ">file not"); err != nil {
+		t.Fatal(err)
+	}
+	if len(file.queries) != 2 {
+		t.Errorf("Expected empty and long enough queries to populate, got %v", file.queries)
+	}
+
+	// Scope launchers below their minimum are left out of merged results
+	items, err = registry.SearchContext(context.Background(), "ab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.queries) != 2 {
+		t.Errorf("Expected the scope launcher to be skipped, got %v", file.queries)
+	}
+	if got := titlesOf(items); len(got) != 1 || got[0] != "ab-result" {
+		t.Errorf("Expected only app results, got %v", got)
+	}
+
+	// Apps default to no minimum
+	if len(apps.queries) != 1 {
+		t.Errorf("Expected apps to search on a two-character query, got %v", apps.queries)
+	}
+	cfg.Launcher.Search.MinQueryLength["apps"] = 2
+	if items, _ := registry.SearchContext(context.Background(), "x"); len(items) != 0 {
+		t.Errorf("Expected no app results below the apps minimum, got %v", titlesOf(items))
+	}
+}
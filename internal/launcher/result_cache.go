@@ -0,0 +1,92 @@
+package launcher
+
+import (
+	"sync"
+	"time"
+)
+
+// ResultCacher is implemented by launchers whose results may be reused for
+// a short while, so repeated keystrokes don't refetch them (e.g. WM windows).
+// A TTL of zero or less disables caching.
+type ResultCacher interface {
+	ResultCacheTTL() time.Duration
+}
+
+type resultCacheEntry struct {
+	items   []*LauncherItem
+	expires time.Time
+}
+
+// resultCache holds launcher-specific search results per launcher and query
+// until their launcher's TTL runs out
+type resultCache struct {
+	mu      sync.Mutex
+	entries map[string]map[string]resultCacheEntry // launcher -> query -> entry
+	now     func() time.Time
+}
+
+func newResultCache() *resultCache {
+	return &resultCache{
+		entries: make(map[string]map[string]resultCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// resultCacheTTL returns how long l's results may be reused, or 0
+func resultCacheTTL(l Launcher) time.Duration {
+	if cacher, ok := l.(ResultCacher); ok {
+		return cacher.ResultCacheTTL()
+	}
+	return 0
+}
+
+// Get returns the results cached for launcher and query if they have not
+// expired
+func (c *resultCache) Get(launcher, query string) ([]*LauncherItem, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[launcher][query]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries[launcher], query)
+		return nil, false
+	}
+	return entry.items, true
+}
+
+// Put caches items for launcher and query for ttl. Expired entries of the
+// launcher are dropped so the cache stays small.
+func (c *resultCache) Put(launcher, query string, items []*LauncherItem, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	queries := c.entries[launcher]
+	if queries == nil {
+		queries = make(map[string]resultCacheEntry)
+		c.entries[launcher] = queries
+	}
+	for q, entry := range queries {
+		if !now.Before(entry.expires) {
+			delete(queries, q)
+		}
+	}
+	queries[query] = resultCacheEntry{items: items, expires: now.Add(ttl)}
+}
+
+// Invalidate drops every result cached for launcher
+func (c *resultCache) Invalidate(launcher string) {
+	c.mu.Lock()
+	delete(c.entries, launcher)
+	c.mu.Unlock()
+}
+
+// InvalidateAll drops every cached result
+func (c *resultCache) InvalidateAll() {
+	c.mu.Lock()
+	c.entries = make(map[string]map[string]resultCacheEntry)
+	c.mu.Unlock()
+}
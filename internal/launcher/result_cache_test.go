@@ -0,0 +1,91 @@
+package launcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+type cachingLauncher struct {
+	triggerLauncher
+	ttl time.Duration
+}
+
+func (l *cachingLauncher) ResultCacheTTL() time.Duration { return l.ttl }
+
+func TestLauncherResultCache(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	registry := NewLauncherRegistry(cfg)
+	now := time.Unix(1000, 0)
+	registry.resultCache.now = func() time.Time { return now }
+
+	windows := &cachingLauncher{
+		triggerLauncher: triggerLauncher{name: "wm", triggers: []string{"wm"}},
+		ttl:             300 * time.Millisecond,
+	}
+	registry.Register(windows)
+
+	search := func(query string) []*LauncherItem {
+		t.Helper()
+		items, err := registry.SearchContext(context.Background(), query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return items
+	}
+
+	first := search(">wm fire")
+	now = now.Add(200 * time.Millisecond)
+	second := search(">wm fire")
+	if len(windows.queries) != 1 {
+		t.Errorf("Expected results within the TTL to be reused, got queries %v", windows.queries)
+	}
+	if len(second) != 1 || second[0] != first[0] {
+		t.Errorf("Expected the cached items, got %v", titlesOf(second))
+	}
+
+	// Other queries are cached separately
+	search(">wm term")
+	if len(windows.queries) != 2 {
+		t.Errorf("Expected a new query to populate, got %v", windows.queries)
+	}
+
+	now = now.Add(100 * time.Millisecond)
+	search(">wm fire")
+	if len(windows.queries) != 3 {
+		t.Errorf("Expected results to be recomputed after the TTL, got %v", windows.queries)
+	}
+
+	if err := registry.RefreshLauncher("wm"); err != nil {
+		t.Fatal(err)
+	}
+	search(">wm fire")
+	if len(windows.queries) != 4 {
+		t.Errorf("Expected a rebuild to invalidate cached results, got %v", windows.queries)
+	}
+}
+
+func TestLauncherResultCacheOptIn(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	registry := NewLauncherRegistry(cfg)
+
+	plain := &triggerLauncher{name: "file", triggers: []string{"file"}}
+	disabled := &cachingLauncher{triggerLauncher: triggerLauncher{name: "kill", triggers: []string{"kill"}}}
+	registry.Register(plain)
+	registry.Register(disabled)
+
+	for i := 0; i < 2; i++ {
+		registry.SearchContext(context.Background(), ">file notes")
+		registry.SearchContext(context.Background(), ">kill fire")
+	}
+	if len(plain.queries) != 2 {
+		t.Errorf("Expected launchers without a TTL to populate every time, got %v", plain.queries)
+	}
+	if len(disabled.queries) != 2 {
+		t.Errorf("Expected a zero TTL to disable caching, got %v", disabled.queries)
+	}
+}
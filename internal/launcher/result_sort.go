@@ -0,0 +1,59 @@
+package launcher
+
+import (
+	"sort"
+	"strings"
+)
+
+// Result orders for search.sort
+const (
+	SortRelevance = "relevance"
+	SortAlpha     = "alpha"
+	SortFrecency  = "frecency"
+	SortRecent    = "recent"
+)
+
+// sortResults returns items ordered by mode. Relevance keeps the order the
+// launchers matched them in, and the other modes fall back to it for ties.
+// Frecency and recent order by the tracker, so without one they keep the
+// relevance order too. items itself is left untouched since it may be
+// cached.
+func sortResults(items []*LauncherItem, mode string, tracker *FrecencyTracker) []*LauncherItem {
+	if len(items) < 2 {
+		return items
+	}
+
+	var less func(a, b *LauncherItem) bool
+	switch mode {
+	case SortAlpha:
+		less = func(a, b *LauncherItem) bool {
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		}
+	case SortFrecency:
+		if tracker == nil {
+			return items
+		}
+		scores := make(map[*LauncherItem]float64, len(items))
+		for _, item := range items {
+			scores[item] = tracker.GetFrecencyScore(item.Title)
+		}
+		less = func(a, b *LauncherItem) bool { return scores[a] > scores[b] }
+	case SortRecent:
+		if tracker == nil {
+			return items
+		}
+		lastLaunched := make(map[*LauncherItem]int64, len(items))
+		for _, item := range items {
+			if t := tracker.LastLaunched(item.Title); !t.IsZero() {
+				lastLaunched[item] = t.UnixNano()
+			}
+		}
+		less = func(a, b *LauncherItem) bool { return lastLaunched[a] > lastLaunched[b] }
+	default:
+		return items
+	}
+
+	sorted := append([]*LauncherItem(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	return sorted
+}
@@ -0,0 +1,51 @@
+package launcher
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortResults(t *testing.T) {
+	tracker, err := NewFrecencyTracker(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	tracker.records["firefox"] = &AppUsageRecord{LaunchCount: 2, LastLaunched: now.Add(-time.Hour)}
+	tracker.records["Files"] = &AppUsageRecord{LaunchCount: 30, LastLaunched: now.Add(-48 * time.Hour)}
+	tracker.records["foot"] = &AppUsageRecord{LaunchCount: 1, LastLaunched: now.Add(-time.Minute)}
+
+	// Relevance order as the launcher matched them
+	items := []*LauncherItem{
+		{Title: "foot"},
+		{Title: "fzf"},
+		{Title: "firefox"},
+		{Title: "Files"},
+		{Title: "feh"},
+	}
+
+	tests := []struct {
+		mode    string
+		tracker *FrecencyTracker
+		want    []string
+	}{
+		{SortRelevance, tracker, []string{"foot", "fzf", "firefox", "Files", "feh"}},
+		{"", tracker, []string{"foot", "fzf", "firefox", "Files", "feh"}},
+		{SortAlpha, tracker, []string{"feh", "Files", "firefox", "foot", "fzf"}},
+		{SortFrecency, tracker, []string{"Files", "firefox", "foot", "fzf", "feh"}},
+		{SortRecent, tracker, []string{"foot", "firefox", "Files", "fzf", "feh"}},
+		{SortFrecency, nil, []string{"foot", "fzf", "firefox", "Files", "feh"}},
+	}
+
+	for _, tt := range tests {
+		got := titlesOf(sortResults(items, tt.mode, tt.tracker))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sort %q: got %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+
+	if got := titlesOf(items); got[0] != "foot" || got[4] != "feh" {
+		t.Errorf("sortResults reordered its input: %v", got)
+	}
+}
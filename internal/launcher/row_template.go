@@ -0,0 +1,98 @@
+package launcher
+
+import (
+	"strings"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// RowTemplate lays out a list row from an item's fields. Each field is text
+// with {key} placeholders for the item's Metadata; {title} and {subtitle}
+// stand for the item's own title and subtitle. An empty Title or Subtitle
+// keeps the item's own, and an empty Badge shows no badge.
+type RowTemplate struct {
+	Title    string
+	Subtitle string
+	Badge    string // shown on the right of the row
+}
+
+// RowTemplater is implemented by launchers that lay out their rows from
+// item metadata
+type RowTemplater interface {
+	RowTemplate() *RowTemplate
+}
+
+// RowText is the text shown in a list row
+type RowText struct {
+	Title    string
+	Subtitle string
+	Badge    string
+}
+
+// RowTemplateFor returns the row template for l's items: the one configured
+// under launcher.row_templates, else the launcher's own, else nil for the
+// default layout
+func RowTemplateFor(cfg *config.Config, l Launcher) *RowTemplate {
+	if l == nil {
+		return nil
+	}
+	if t, ok := cfg.Launcher.RowTemplates[l.Name()]; ok {
+		return &RowTemplate{Title: t.Title, Subtitle: t.Subtitle, Badge: t.Badge}
+	}
+	if templater, ok := l.(RowTemplater); ok {
+		return templater.RowTemplate()
+	}
+	return nil
+}
+
+// ResolveRow fills in t for item. A nil template gives the default layout.
+func ResolveRow(t *RowTemplate, item *LauncherItem) RowText {
+	if t == nil {
+		return RowText{Title: item.Title, Subtitle: item.Subtitle}
+	}
+
+	text := RowText{
+		Title:    item.Title,
+		Subtitle: item.Subtitle,
+		Badge:    expandRowField(t.Badge, item),
+	}
+	if t.Title != "" {
+		text.Title = expandRowField(t.Title, item)
+	}
+	if t.Subtitle != "" {
+		text.Subtitle = expandRowField(t.Subtitle, item)
+	}
+	return text
+}
+
+// expandRowField replaces {key} placeholders in field. Keys missing from
+// the item expand to nothing; text that isn't a placeholder is kept as is.
+func expandRowField(field string, item *LauncherItem) string {
+	var out strings.Builder
+	for {
+		start := strings.IndexByte(field, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(field[start:], '}')
+		if end < 0 {
+			break
+		}
+		out.WriteString(field[:start])
+		out.WriteString(rowFieldValue(field[start+1:start+end], item))
+		field = field[start+end+1:]
+	}
+	out.WriteString(field)
+	return strings.TrimSpace(out.String())
+}
+
+func rowFieldValue(key string, item *LauncherItem) string {
+	switch key {
+	case "title":
+		return item.Title
+	case "subtitle":
+		return item.Subtitle
+	default:
+		return item.Metadata[key]
+	}
+}
@@ -0,0 +1,68 @@
+package launcher
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestResolveRow(t *testing.T) {
+	item := &LauncherItem{
+		Title:    "Firefox",
+		Subtitle: "firefox · 2",
+		Metadata: map[string]string{"workspace": "2", "app_class": "firefox"},
+	}
+
+	tests := []struct {
+		name     string
+		template *RowTemplate
+		want     RowText
+	}{
+		{"default layout", nil, RowText{Title: "Firefox", Subtitle: "firefox · 2"}},
+		{"badge only", &RowTemplate{Badge: "{workspace}"}, RowText{Title: "Firefox", Subtitle: "firefox · 2", Badge: "2"}},
+		{"metadata subtitle", &RowTemplate{Subtitle: "{app_class}"}, RowText{Title: "Firefox", Subtitle: "firefox"}},
+		{"mixed text", &RowTemplate{Title: "{title} on {workspace}", Badge: "ws {workspace}"},
+			RowText{Title: "Firefox on 2", Subtitle: "firefox · 2", Badge: "ws 2"}},
+		{"missing key", &RowTemplate{Badge: "{duration}", Subtitle: "{album}"}, RowText{Title: "Firefox"}},
+		{"unclosed placeholder is literal", &RowTemplate{Badge: "{workspace"}, RowText{Title: "Firefox", Subtitle: "firefox · 2", Badge: "{workspace"}},
+	}
+
+	for _, tc := range tests {
+		if got := ResolveRow(tc.template, item); got != tc.want {
+			t.Errorf("%s: ResolveRow() = %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+
+	if got := ResolveRow(&RowTemplate{Badge: "{workspace}"}, &LauncherItem{Title: "No metadata"}); got.Badge != "" {
+		t.Errorf("Expected no badge without metadata, got %q", got.Badge)
+	}
+}
+
+func TestRowTemplateFor(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Launcher.RowTemplates = map[string]config.RowTemplateConfig{
+		"music": {Badge: "{duration}"},
+	}
+
+	music := &triggerLauncher{name: "music"}
+	wm := &WMLauncher{}
+	plain := &triggerLauncher{name: "calc"}
+
+	if got := RowTemplateFor(cfg, music); got == nil || got.Badge != "{duration}" {
+		t.Errorf("Expected the configured template, got %+v", got)
+	}
+	if got := RowTemplateFor(cfg, wm); got == nil || got.Badge != "{workspace}" {
+		t.Errorf("Expected the launcher's own template, got %+v", got)
+	}
+	if got := RowTemplateFor(cfg, plain); got != nil {
+		t.Errorf("Expected the default layout, got %+v", got)
+	}
+	if got := RowTemplateFor(cfg, nil); got != nil {
+		t.Errorf("Expected the default layout for items without a launcher, got %+v", got)
+	}
+
+	cfg.Launcher.RowTemplates["wm"] = config.RowTemplateConfig{Subtitle: "{app_class}"}
+	if got := RowTemplateFor(cfg, wm); got == nil || got.Subtitle != "{app_class}" || got.Badge != "" {
+		t.Errorf("Expected the configured template to override the launcher's, got %+v", got)
+	}
+}
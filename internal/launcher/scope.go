@@ -0,0 +1,109 @@
+package launcher
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultScopeMaxResults caps each scope launcher when unset in config
+const defaultScopeMaxResults = 3
+
+// scopeLaunchers returns the registered launchers configured to join
+// unprefixed searches alongside apps
+func (r *LauncherRegistry) scopeLaunchers() []Launcher {
+	names := r.config.Launcher.Search.Scope
+	if len(names) == 0 {
+		return nil
+	}
+
+	launchers := make([]Launcher, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "apps" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if l, ok := r.launchers[name]; ok {
+			launchers = append(launchers, l)
+		}
+	}
+	return launchers
+}
+
+// searchScope populates the scope launchers concurrently, keeping at most
+// ScopeMaxResults items from each. Results are grouped in scope order.
+func (r *LauncherRegistry) searchScope(launchers []Launcher, query string, launcherCtx *LauncherContext) [][]*LauncherItem {
+	limit := r.config.Launcher.Search.ScopeMaxResults
+	if limit <= 0 {
+		limit = defaultScopeMaxResults
+	}
+
+	groups := make([][]*LauncherItem, len(launchers))
+	var wg sync.WaitGroup
+	for i, l := range launchers {
+		if _, short := r.queryTooShort(l, query); short {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, l Launcher) {
+			defer wg.Done()
+			defer func() {
+				if rec := recover(); rec != nil {
+					log.Printf("[REGISTRY-SEARCH] Recovered from panic in scope launcher %s: %v", l.Name(), rec)
+				}
+			}()
+
+			start := time.Now()
+			items := l.Populate(query, launcherCtx)
+			r.metrics.ObservePopulate(l.Name(), time.Since(start))
+			if len(items) > limit {
+				items = items[:limit]
+			}
+			groups[i] = items
+		}(i, l)
+	}
+	wg.Wait()
+
+	return groups
+}
+
+// mergeScopeResults ranks the groups together by how closely item titles
+// match query. Ties keep earlier groups first and each group's own order,
+// so apps stay ahead of scope launchers with equally good matches.
+func mergeScopeResults(query string, groups ...[]*LauncherItem) []*LauncherItem {
+	var merged []*LauncherItem
+	for _, group := range groups {
+		merged = append(merged, group...)
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	tiers := make(map[*LauncherItem]int, len(merged))
+	for _, item := range merged {
+		tiers[item] = matchTier(strings.ToLower(item.Title), query)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return tiers[merged[i]] < tiers[merged[j]]
+	})
+	return merged
+}
+
+// matchTier ranks a lowercased title against a lowercased query: exact
+// matches first, then prefix, substring and finally anything else
+func matchTier(title, query string) int {
+	switch {
+	case title == query:
+		return 0
+	case strings.HasPrefix(title, query):
+		return 1
+	case strings.Contains(title, query):
+		return 2
+	default:
+		return 3
+	}
+}
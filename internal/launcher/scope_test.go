@@ -0,0 +1,99 @@
+package launcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// staticLauncher returns fixed item titles for every query
+type staticLauncher struct {
+	triggerLauncher
+	titles []string
+}
+
+func (l *staticLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	items := make([]*LauncherItem, len(l.titles))
+	for i, title := range l.titles {
+		items[i] = &LauncherItem{Title: title, Launcher: l}
+	}
+	return items
+}
+
+func newScopeRegistry(t *testing.T, scope []string) *LauncherRegistry {
+	t.Helper()
+
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 4
+	cfg.Launcher.Search.Scope = scope
+	cfg.Launcher.Search.ScopeMaxResults = 2
+	cfg.Launcher.Performance.SearchCacheSize = 50
+
+	r := NewLauncherRegistry(cfg)
+	r.Register(&fakeAppsLauncher{})
+	r.Register(&staticLauncher{
+		triggerLauncher: triggerLauncher{name: "calc", triggers: []string{"calc"}},
+		titles:          []string{"fire", "x1", "x2"},
+	})
+	r.Register(&staticLauncher{
+		triggerLauncher: triggerLauncher{name: "file", triggers: []string{"file"}},
+		titles:          []string{"firefox.txt", "notes"},
+	})
+	r.setAppsHash("hash-1")
+	return r
+}
+
+func titlesOf(items []*LauncherItem) []string {
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title
+	}
+	return titles
+}
+
+func TestSearchScopeMergesLaunchers(t *testing.T) {
+	r := newScopeRegistry(t, []string{"calc", "file", "missing", "apps"})
+
+	items, err := r.SearchContext(context.Background(), "fire")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Exact, then prefix (apps first on ties), then the rest; capped at
+	// two items per scope launcher and four overall
+	want := []string{"fire", "fire-result", "firefox.txt", "x1"}
+	got := titlesOf(items)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+	}
+
+	if r.searchCache.Contains("fire", "hash-1") {
+		t.Error("Merged scope results should not be cached")
+	}
+
+	items, _ = r.SearchContext(context.Background(), "")
+	if len(items) != 1 || items[0].Title != "-result" {
+		t.Errorf("Expected apps only for the empty query, got %v", titlesOf(items))
+	}
+}
+
+func TestSearchScopeDefaultsToApps(t *testing.T) {
+	r := newScopeRegistry(t, nil)
+
+	items, err := r.SearchContext(context.Background(), "fire")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Title != "fire-result" {
+		t.Errorf("Expected apps-only results, got %v", titlesOf(items))
+	}
+	if !r.searchCache.Contains("fire", "hash-1") {
+		t.Error("Apps-only results should be cached")
+	}
+}
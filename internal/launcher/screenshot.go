@@ -1,11 +1,38 @@
 package launcher
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
 	"github.com/chess10kp/locus/internal/config"
 )
 
+type screenshotMode string
+
+const (
+	screenshotFull   screenshotMode = "full"
+	screenshotWindow screenshotMode = "window"
+	screenshotRegion screenshotMode = "region"
+)
+
+// screenshotTimestamp is expanded by the shell when the capture runs
+const screenshotTimestamp = "$(date +%Y%m%d-%H%M%S)"
+
 type ScreenshotLauncher struct {
-	config *config.Config
+	config    *config.Config
+	lookPath  func(string) (string, error)
+	fetchRect func() (OutputRect, error)
+
+	// The focused window is looked up once per show rather than on every
+	// keystroke
+	mu          sync.Mutex
+	rectFetched bool
+	windowRect  OutputRect
+	windowErr   error
 }
 
 type ScreenshotLauncherFactory struct{}
@@ -24,7 +51,9 @@ func init() {
 
 func NewScreenshotLauncher(cfg *config.Config) *ScreenshotLauncher {
 	return &ScreenshotLauncher{
-		config: cfg,
+		config:    cfg,
+		lookPath:  exec.LookPath,
+		fetchRect: FetchFocusedWindowRect,
 	}
 }
 
@@ -44,68 +73,138 @@ func (l *ScreenshotLauncher) GetGridConfig() *GridConfig {
 	return nil
 }
 
-func (l *ScreenshotLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
-	items := []*LauncherItem{
-		{
-			Title:      "Take Screenshot (Screen)",
-			Subtitle:   "Capture entire screen to clipboard",
-			Icon:       "camera-photo-symbolic",
-			ActionData: NewShellAction("grim - | wl-copy"),
-			Launcher:   l,
-		},
-		{
-			Title:      "Take Screenshot (Screen, Save)",
-			Subtitle:   "Capture entire screen to file",
-			Icon:       "camera-photo-symbolic",
-			ActionData: NewShellAction("grim ~/Pictures/screenshot-$(date +%s).png"),
-			Launcher:   l,
-		},
-		{
-			Title:      "Take Screenshot (Region)",
-			Subtitle:   "Select region to capture to clipboard",
-			Icon:       "camera-photo-symbolic",
-			ActionData: NewShellAction("slurp | grim -g - - | wl-copy"),
-			Launcher:   l,
-		},
-		{
-			Title:      "Take Screenshot (Region, Save)",
-			Subtitle:   "Select region to capture to file",
-			Icon:       "camera-photo-symbolic",
-			ActionData: NewShellAction("slurp | grim -g - ~/Pictures/screenshot-$(date +%s).png"),
-			Launcher:   l,
-		},
-		{
-			Title:      "Take Screenshot (Window)",
-			Subtitle:   "Capture focused window to clipboard",
-			Icon:       "camera-photo-symbolic",
-			ActionData: NewShellAction("swaymsg -t get_tree | jq -r '.. | select(.focused? and .pid?) | .rect | \"\\(.x),\\(.y) \\(.width)x\\(.height)\"' | grim -g - - | wl-copy"),
-			Launcher:   l,
-		},
+func (l *ScreenshotLauncher) hasTool(name string) bool {
+	_, err := l.lookPath(name)
+	return err == nil
+}
+
+func (l *ScreenshotLauncher) saveDir() string {
+	dir := l.config.Launcher.Screenshot.SaveDir
+	if dir == "" {
+		dir = "~/Pictures/Screenshots"
+	}
+	if strings.HasPrefix(dir, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, dir[1:])
+		}
 	}
+	return dir
+}
 
-	q := query
-	if q == "region" {
+func (l *ScreenshotLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	if !l.hasTool("grim") {
 		return []*LauncherItem{{
-			Title:      "Screenshot Region",
-			Subtitle:   "Select region to capture",
-			Icon:       "camera-photo-symbolic",
-			ActionData: NewShellAction("slurp | grim -g - - | wl-copy"),
-			Launcher:   l,
+			Title:    "grim not found",
+			Subtitle: "Install grim to take screenshots",
+			Icon:     "dialog-warning",
+			Launcher: l,
 		}}
 	}
-	if q == "window" {
-		return []*LauncherItem{{
-			Title:      "Screenshot Window",
-			Subtitle:   "Capture focused window",
+
+	canCopy := l.hasTool("wl-copy")
+	saveDir := l.saveDir()
+
+	modes := []struct {
+		mode     screenshotMode
+		title    string
+		subtitle string
+	}{
+		{screenshotFull, "Screen", "entire screen"},
+		{screenshotWindow, "Window", "focused window"},
+		{screenshotRegion, "Region", "selected region"},
+	}
+
+	matcher := newTextMatcher(query, ctx.CaseSensitive())
+
+	var items []*LauncherItem
+	for _, m := range modes {
+		if !matcher.Empty() && !matcher.Prefix(string(m.mode)) && !matcher.Match(m.title) {
+			continue
+		}
+		if m.mode == screenshotRegion && !l.hasTool("slurp") {
+			continue
+		}
+
+		geometry := ""
+		if m.mode == screenshotWindow {
+			rect, err := l.focusedWindowRect()
+			if err != nil {
+				continue
+			}
+			geometry = formatGeometry(rect)
+		}
+
+		if canCopy {
+			items = append(items, &LauncherItem{
+				Title:      fmt.Sprintf("Screenshot %s (Copy)", m.title),
+				Subtitle:   fmt.Sprintf("Capture %s to clipboard", m.subtitle),
+				Icon:       "camera-photo-symbolic",
+				ActionData: NewShScriptAction(screenshotCommand(m.mode, geometry, "")),
+				Launcher:   l,
+			})
+		}
+		items = append(items, &LauncherItem{
+			Title:      fmt.Sprintf("Screenshot %s (Save)", m.title),
+			Subtitle:   fmt.Sprintf("Capture %s to %s", m.subtitle, saveDir),
 			Icon:       "camera-photo-symbolic",
-			ActionData: NewShellAction("swaymsg -t get_tree | jq -r '.. | select(.focused? and .pid?) | .rect | \"\\(.x),\\(.y) \\(.width)x\\(.height)\"' | grim -g - - | wl-copy"),
+			ActionData: NewShScriptAction(screenshotCommand(m.mode, geometry, saveDir)),
 			Launcher:   l,
-		}}
+		})
 	}
 
 	return items
 }
 
+// focusedWindowRect returns the focused window's geometry, asking the WM
+// the first time it is needed after the launcher is shown
+func (l *ScreenshotLauncher) focusedWindowRect() (OutputRect, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.rectFetched {
+		l.windowRect, l.windowErr = l.fetchRect()
+		l.rectFetched = true
+	}
+	return l.windowRect, l.windowErr
+}
+
+// LauncherShown implements ShowListener
+func (l *ScreenshotLauncher) LauncherShown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rectFetched = false
+}
+
+// formatGeometry formats a rect as a grim/slurp geometry string
+func formatGeometry(rect OutputRect) string {
+	return fmt.Sprintf("%d,%d %dx%d", rect.X, rect.Y, rect.Width, rect.Height)
+}
+
+// screenshotCommand builds the grim invocation for a mode. Window mode uses
+// the given geometry and region mode asks slurp for one. An empty saveDir
+// copies the capture to the clipboard instead of saving it.
+func screenshotCommand(mode screenshotMode, geometry, saveDir string) string {
+	grim := "grim"
+	switch mode {
+	case screenshotWindow:
+		grim += " -g " + shellQuote(geometry)
+	case screenshotRegion:
+		grim += ` -g "$(slurp)"`
+	}
+
+	if saveDir == "" {
+		return grim + " - | wl-copy -t image/png"
+	}
+
+	dir := shellQuote(saveDir)
+	return fmt.Sprintf(`mkdir -p %s && %s %s/"screenshot-%s.png"`, dir, grim, dir, screenshotTimestamp)
+}
+
+// shellQuote wraps s in single quotes for use in a sh -c command
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 func (l *ScreenshotLauncher) GetHooks() []Hook {
 	return []Hook{}
 }
@@ -0,0 +1,114 @@
+package launcher
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestScreenshotCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     screenshotMode
+		geometry string
+		saveDir  string
+		expected string
+	}{
+		{"full copy", screenshotFull, "", "", "grim - | wl-copy -t image/png"},
+		{"window copy", screenshotWindow, "10,20 800x600", "", "grim -g '10,20 800x600' - | wl-copy -t image/png"},
+		{"region copy", screenshotRegion, "", "", `grim -g "$(slurp)" - | wl-copy -t image/png`},
+		{"full save", screenshotFull, "", "/tmp/shots",
+			`mkdir -p '/tmp/shots' && grim '/tmp/shots'/"screenshot-$(date +%Y%m%d-%H%M%S).png"`},
+		{"region save quoted dir", screenshotRegion, "", "/tmp/my shots",
+			`mkdir -p '/tmp/my shots' && grim -g "$(slurp)" '/tmp/my shots'/"screenshot-$(date +%Y%m%d-%H%M%S).png"`},
+	}
+
+	for _, tt := range tests {
+		got := screenshotCommand(tt.mode, tt.geometry, tt.saveDir)
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestFocusedWindowRect(t *testing.T) {
+	window := int64(42)
+	tree := SwayNode{
+		Type: "root",
+		Nodes: []SwayNode{{
+			Type: "workspace",
+			Nodes: []SwayNode{
+				{Pid: 100, Rect: OutputRect{X: 0, Y: 0, Width: 100, Height: 100}},
+			},
+			FloatingNodes: []SwayNode{
+				{Window: &window, Focused: true, Rect: OutputRect{X: 10, Y: 20, Width: 800, Height: 600}},
+			},
+		}},
+	}
+
+	rect, ok := FocusedWindowRect(tree)
+	if !ok {
+		t.Fatal("Expected a focused window")
+	}
+	if got := formatGeometry(rect); got != "10,20 800x600" {
+		t.Errorf("Expected geometry 10,20 800x600, got %s", got)
+	}
+
+	if _, ok := FocusedWindowRect(SwayNode{Focused: true}); ok {
+		t.Error("Expected focused container without a window to be ignored")
+	}
+}
+
+func TestScreenshotLauncherMissingTools(t *testing.T) {
+	cfg := config.DefaultConfig
+	l := NewScreenshotLauncher(&cfg)
+	l.lookPath = func(name string) (string, error) {
+		if name == "grim" {
+			return "/usr/bin/grim", nil
+		}
+		return "", fmt.Errorf("%s not found", name)
+	}
+
+	items := l.Populate("full", nil)
+	if len(items) != 1 || items[0].Title != "Screenshot Screen (Save)" {
+		t.Errorf("Expected only the save action without wl-copy, got %d items", len(items))
+	}
+	if items := l.Populate("region", nil); len(items) != 0 {
+		t.Errorf("Expected no region actions without slurp, got %d", len(items))
+	}
+
+	l.lookPath = func(name string) (string, error) { return "", fmt.Errorf("%s not found", name) }
+	items = l.Populate("", nil)
+	if len(items) != 1 || items[0].ActionData != nil {
+		t.Errorf("Expected a single informational item without grim, got %d", len(items))
+	}
+}
+
+func TestScreenshotLauncherFetchesWindowOncePerShow(t *testing.T) {
+	cfg := config.DefaultConfig
+	l := NewScreenshotLauncher(&cfg)
+	l.lookPath = func(name string) (string, error) { return "/usr/bin/" + name, nil }
+	fetches := 0
+	l.fetchRect = func() (OutputRect, error) {
+		fetches++
+		return OutputRect{X: 10, Y: 20, Width: 300, Height: 200}, nil
+	}
+
+	for _, query := range []string{"w", "wi", "win"} {
+		items := l.Populate(query, nil)
+		if len(items) != 2 || !strings.Contains(items[0].ActionData.(*ShellAction).Command, "10,20 300x200") {
+			t.Fatalf("Expected window actions for %q, got %d items", query, len(items))
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("Expected the focused window to be fetched once while typing, got %d", fetches)
+	}
+
+	l.LauncherShown()
+	l.Populate("window", nil)
+	if fetches != 2 {
+		t.Errorf("Expected a new fetch after the launcher is shown again, got %d", fetches)
+	}
+}
@@ -0,0 +1,187 @@
+package launcher
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// searchLatencyBuckets are the upper bounds of the latency histograms
+var searchLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+}
+
+// latencyHistogram counts observations per searchLatencyBuckets bound, the
+// last count holding those above every bound
+type latencyHistogram struct {
+	counts []uint64
+	count  uint64
+	sum    time.Duration
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(searchLatencyBuckets)+1)
+	}
+	i := sort.Search(len(searchLatencyBuckets), func(i int) bool { return d <= searchLatencyBuckets[i] })
+	h.counts[i]++
+	h.count++
+	h.sum += d
+}
+
+// SearchMetrics aggregates search timings: overall search latency, search
+// cache hits and misses, and populate time per launcher. A nil
+// *SearchMetrics records nothing, so callers need not check whether
+// metrics are enabled.
+type SearchMetrics struct {
+	mu          sync.Mutex
+	searches    latencyHistogram
+	cancelled   uint64
+	cacheHits   uint64
+	cacheMisses uint64
+	populate    map[string]*latencyHistogram
+}
+
+// NewSearchMetrics creates empty search metrics
+func NewSearchMetrics() *SearchMetrics {
+	return &SearchMetrics{populate: make(map[string]*latencyHistogram)}
+}
+
+// ObserveSearch records a completed search that took d
+func (m *SearchMetrics) ObserveSearch(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.searches.observe(d)
+	m.mu.Unlock()
+}
+
+// ObserveCancelled records a search cancelled before it completed
+func (m *SearchMetrics) ObserveCancelled() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.cancelled++
+	m.mu.Unlock()
+}
+
+// ObserveCache records a search cache lookup
+func (m *SearchMetrics) ObserveCache(hit bool) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	if hit {
+		m.cacheHits++
+	} else {
+		m.cacheMisses++
+	}
+	m.mu.Unlock()
+}
+
+// ObservePopulate records a Populate call of launcher that took d
+func (m *SearchMetrics) ObservePopulate(launcher string, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	h := m.populate[launcher]
+	if h == nil {
+		h = &latencyHistogram{}
+		m.populate[launcher] = h
+	}
+	h.observe(d)
+	m.mu.Unlock()
+}
+
+// CacheHitRate is the fraction of search cache lookups that hit
+func (m *SearchMetrics) CacheHitRate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cacheHitRateLocked()
+}
+
+func (m *SearchMetrics) cacheHitRateLocked() float64 {
+	total := m.cacheHits + m.cacheMisses
+	if total == 0 {
+		return 0
+	}
+	return float64(m.cacheHits) / float64(total)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text format
+func (m *SearchMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "# HELP locus_search_duration_seconds Time taken by completed launcher searches.")
+	fmt.Fprintln(&buf, "# TYPE locus_search_duration_seconds histogram")
+	writeHistogram(&buf, "locus_search_duration_seconds", "", &m.searches)
+
+	fmt.Fprintln(&buf, "# HELP locus_search_cancelled_total Searches cancelled before completing.")
+	fmt.Fprintln(&buf, "# TYPE locus_search_cancelled_total counter")
+	fmt.Fprintf(&buf, "locus_search_cancelled_total %d\n", m.cancelled)
+
+	fmt.Fprintln(&buf, "# HELP locus_search_cache_requests_total Search cache lookups by result.")
+	fmt.Fprintln(&buf, "# TYPE locus_search_cache_requests_total counter")
+	fmt.Fprintf(&buf, "locus_search_cache_requests_total{result=\"hit\"} %d\n", m.cacheHits)
+	fmt.Fprintf(&buf, "locus_search_cache_requests_total{result=\"miss\"} %d\n", m.cacheMisses)
+
+	fmt.Fprintln(&buf, "# HELP locus_search_cache_hit_ratio Fraction of search cache lookups that hit.")
+	fmt.Fprintln(&buf, "# TYPE locus_search_cache_hit_ratio gauge")
+	fmt.Fprintf(&buf, "locus_search_cache_hit_ratio %g\n", m.cacheHitRateLocked())
+
+	fmt.Fprintln(&buf, "# HELP locus_launcher_populate_duration_seconds Time taken by launcher Populate calls.")
+	fmt.Fprintln(&buf, "# TYPE locus_launcher_populate_duration_seconds histogram")
+	names := make([]string, 0, len(m.populate))
+	for name := range m.populate {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeHistogram(&buf, "locus_launcher_populate_duration_seconds", fmt.Sprintf("launcher=%q", name), m.populate[name])
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeHistogram writes h as cumulative buckets, sum and count, with labels
+// added to every sample
+func writeHistogram(w io.Writer, name, labels string, h *latencyHistogram) {
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+
+	var cumulative uint64
+	for i, bound := range searchLatencyBuckets {
+		if h.counts != nil {
+			cumulative += h.counts[i]
+		}
+		fmt.Fprintf(w, "%s_bucket{%s%sle=\"%g\"} %d\n", name, labels, sep, bound.Seconds(), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, h.count)
+
+	suffix := ""
+	if labels != "" {
+		suffix = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %g\n", name, suffix, h.sum.Seconds())
+	fmt.Fprintf(w, "%s_count%s %d\n", name, suffix, h.count)
+}
@@ -0,0 +1,97 @@
+package launcher
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestSearchMetricsAggregation(t *testing.T) {
+	m := NewSearchMetrics()
+	for _, d := range []time.Duration{500 * time.Microsecond, 3 * time.Millisecond, 40 * time.Millisecond, 2 * time.Second} {
+		m.ObserveSearch(d)
+	}
+	m.ObserveCancelled()
+	m.ObserveCache(true)
+	m.ObserveCache(true)
+	m.ObserveCache(true)
+	m.ObserveCache(false)
+	m.ObservePopulate("apps", 2*time.Millisecond)
+	m.ObservePopulate("apps", 4*time.Millisecond)
+	m.ObservePopulate("files", 300*time.Millisecond)
+
+	if got := m.CacheHitRate(); got != 0.75 {
+		t.Errorf("CacheHitRate() = %v, want 0.75", got)
+	}
+	if m.searches.count != 4 || m.searches.sum != 2043500*time.Microsecond {
+		t.Errorf("searches count=%d sum=%v, want 4 and 2.0435s", m.searches.count, m.searches.sum)
+	}
+	// 1ms, 5ms, 50ms and overflow buckets
+	wantCounts := []uint64{1, 1, 0, 0, 1, 0, 0, 0, 0, 1}
+	for i, want := range wantCounts {
+		if m.searches.counts[i] != want {
+			t.Errorf("bucket %d = %d, want %d", i, m.searches.counts[i], want)
+		}
+	}
+	if apps := m.populate["apps"]; apps == nil || apps.count != 2 || apps.sum != 6*time.Millisecond {
+		t.Errorf("apps populate = %+v, want 2 observations totalling 6ms", apps)
+	}
+
+	var out strings.Builder
+	if err := m.WritePrometheus(&out); err != nil {
+		t.Fatalf("WritePrometheus: %v", err)
+	}
+	for _, line := range []string{
+		`locus_search_duration_seconds_bucket{le="0.001"} 1`,
+		`locus_search_duration_seconds_bucket{le="0.05"} 3`,
+		`locus_search_duration_seconds_bucket{le="+Inf"} 4`,
+		`locus_search_duration_seconds_count 4`,
+		`locus_search_cancelled_total 1`,
+		`locus_search_cache_requests_total{result="hit"} 3`,
+		`locus_search_cache_requests_total{result="miss"} 1`,
+		`locus_search_cache_hit_ratio 0.75`,
+		`locus_launcher_populate_duration_seconds_bucket{launcher="apps",le="0.005"} 2`,
+		`locus_launcher_populate_duration_seconds_count{launcher="files"} 1`,
+		`locus_launcher_populate_duration_seconds_sum{launcher="apps"} 0.006`,
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("output lacks %q:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestSearchMetricsNil(t *testing.T) {
+	var m *SearchMetrics
+	m.ObserveSearch(time.Millisecond)
+	m.ObserveCancelled()
+	m.ObserveCache(true)
+	m.ObservePopulate("apps", time.Millisecond)
+}
+
+func TestSearchRecordsMetrics(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	cfg.Launcher.Search.Metrics = true
+	cfg.Launcher.Performance.SearchCacheSize = 50
+	r := NewLauncherRegistry(cfg)
+	r.Register(&fakeAppsLauncher{})
+
+	if _, err := r.Search("fire"); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if _, err := r.Search("fire"); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	if r.metrics.searches.count != 2 {
+		t.Errorf("searches = %d, want 2", r.metrics.searches.count)
+	}
+	if r.metrics.cacheHits != 1 || r.metrics.cacheMisses != 1 {
+		t.Errorf("cache hits=%d misses=%d, want 1 and 1", r.metrics.cacheHits, r.metrics.cacheMisses)
+	}
+	if apps := r.metrics.populate["apps"]; apps == nil || apps.count != 1 {
+		t.Errorf("apps populate = %+v, want one observation", apps)
+	}
+}
@@ -0,0 +1,77 @@
+package launcher
+
+import "strings"
+
+// Selection is the set of items picked in multi-select mode, kept in the
+// order they were picked
+type Selection struct {
+	picked []string
+}
+
+// SelectionText is the line an item stands for when returned to a dmenu
+// caller: its option line, or its title for other items
+func SelectionText(item *LauncherItem) string {
+	if line, ok := item.Metadata["line"]; ok {
+		return line
+	}
+	return item.Title
+}
+
+// Toggle adds item to the selection, or removes it if it was already
+// picked, and reports whether it is now selected
+func (s *Selection) Toggle(item *LauncherItem) bool {
+	text := SelectionText(item)
+	for i, picked := range s.picked {
+		if picked == text {
+			s.picked = append(s.picked[:i], s.picked[i+1:]...)
+			return false
+		}
+	}
+	s.picked = append(s.picked, text)
+	return true
+}
+
+// Selected reports whether item is picked
+func (s *Selection) Selected(item *LauncherItem) bool {
+	text := SelectionText(item)
+	for _, picked := range s.picked {
+		if picked == text {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear empties the selection
+func (s *Selection) Clear() {
+	s.picked = nil
+}
+
+// Lines returns the picked lines. Enter with nothing picked returns the
+// current item, or the typed text when no row matches, as dmenu does;
+// current may be nil.
+func (s *Selection) Lines(current *LauncherItem, typed string) []string {
+	if len(s.picked) > 0 {
+		return append([]string(nil), s.picked...)
+	}
+	if current != nil {
+		return []string{SelectionText(current)}
+	}
+	if typed != "" {
+		return []string{typed}
+	}
+	return nil
+}
+
+// SelectionPayload joins lines the way dmenu prints them
+func SelectionPayload(lines []string) string {
+	return strings.Join(lines, "\n")
+}
+
+// SelectionMark is the checkbox shown before rows in multi-select mode
+func SelectionMark(selected bool) string {
+	if selected {
+		return "☑"
+	}
+	return "☐"
+}
@@ -0,0 +1,123 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestSelectionToggle(t *testing.T) {
+	a := &LauncherItem{Title: "a.txt", Metadata: map[string]string{"line": "a.txt"}}
+	b := &LauncherItem{Title: "b.txt", Metadata: map[string]string{"line": "b.txt"}}
+	app := &LauncherItem{Title: "Firefox"}
+
+	var s Selection
+	if !s.Toggle(b) || !s.Toggle(a) || !s.Toggle(app) {
+		t.Fatalf("expected toggling unpicked items to select them")
+	}
+	if !s.Selected(a) || !s.Selected(b) {
+		t.Errorf("expected a and b to be selected")
+	}
+	if s.Toggle(app) || s.Selected(app) {
+		t.Errorf("expected a second toggle to unselect")
+	}
+
+	// Picks are returned in the order they were made
+	if got, want := s.Lines(a, "typed"), []string{"b.txt", "a.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() = %v, want %v", got, want)
+	}
+
+	s.Clear()
+	if got, want := s.Lines(app, "fire"), []string{"Firefox"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the current item without picks, got %v", got)
+	}
+	if got, want := s.Lines(nil, "new.txt"), []string{"new.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the typed text without a matching row, got %v", got)
+	}
+	if got := s.Lines(nil, ""); got != nil {
+		t.Errorf("expected no lines without picks, a current item or typed text, got %v", got)
+	}
+}
+
+func TestSelectionPayload(t *testing.T) {
+	if got := SelectionPayload([]string{"a.txt", "b c.txt"}); got != "a.txt\nb c.txt" {
+		t.Errorf("SelectionPayload() = %q", got)
+	}
+	if SelectionMark(true) == SelectionMark(false) {
+		t.Errorf("expected selected and unselected rows to be marked differently")
+	}
+}
+
+func TestSearchOptions(t *testing.T) {
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	registry := NewLauncherRegistry(cfg)
+	apps := &fakeAppsLauncher{}
+	registry.Register(apps)
+
+	params := &InvocationParams{Options: []string{"Notes.md", "notes.txt", "todo.txt"}}
+	registry.SetInvocationParams(params)
+	if !params.ReturnsSelection() {
+		t.Errorf("expected a dmenu invocation to return its selection")
+	}
+
+	items, err := registry.SearchContext(context.Background(), "notes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := titlesOf(items); !reflect.DeepEqual(got, []string{"Notes.md", "notes.txt"}) {
+		t.Errorf("expected matching options, got %v", got)
+	}
+	if len(apps.queries) != 0 {
+		t.Errorf("expected launchers not to be searched, got %v", apps.queries)
+	}
+
+	yes := true
+	params.CaseSensitive = &yes
+	items, _ = registry.SearchContext(context.Background(), "notes")
+	if got := titlesOf(items); !reflect.DeepEqual(got, []string{"notes.txt"}) {
+		t.Errorf("expected case-sensitive matching, got %v", got)
+	}
+
+	items, _ = registry.SearchContext(context.Background(), "")
+	if len(items) != 3 || SelectionText(items[2]) != "todo.txt" {
+		t.Errorf("expected every option for an empty query, got %v", titlesOf(items))
+	}
+
+	params.Lines = 2
+	items, _ = registry.SearchContext(context.Background(), "")
+	if len(items) != 3 {
+		t.Errorf("expected lines to size the list, not cap the options, got %v", titlesOf(items))
+	}
+	if got := params.VisibleRows(8); got != 2 {
+		t.Errorf("VisibleRows() = %d, want 2", got)
+	}
+
+	// Options past search.max_results can still be picked
+	many := make([]string, 25)
+	for i := range many {
+		many[i] = fmt.Sprintf("option %d", i+1)
+	}
+	registry.SetInvocationParams(&InvocationParams{Options: many})
+	items, _ = registry.SearchContext(context.Background(), "")
+	if len(items) != len(many) {
+		t.Errorf("expected all %d options despite max_results, got %d", len(many), len(items))
+	}
+
+	// Empty stdin still answers from the (empty) options
+	registry.SetInvocationParams(&InvocationParams{Dmenu: true})
+	items, _ = registry.SearchContext(context.Background(), "")
+	if len(items) != 0 || len(apps.queries) != 0 {
+		t.Errorf("expected no results and no launcher search for empty options, got %v", titlesOf(items))
+	}
+	if !registry.InvocationParams().ReturnsSelection() {
+		t.Errorf("expected a dmenu invocation without options to return its selection")
+	}
+
+	if (&InvocationParams{}).ReturnsSelection() || !(&InvocationParams{MultiSelect: true}).ReturnsSelection() {
+		t.Errorf("expected only dmenu and multi-select invocations to return selections")
+	}
+}
@@ -0,0 +1,357 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Snippet is a named piece of text from the user's snippets file
+type Snippet struct {
+	Name string
+	Text string
+}
+
+// Snippet actions: copy the expanded text to the clipboard or type it into
+// the focused window
+const (
+	SnippetActionCopy = "copy"
+	SnippetActionType = "type"
+)
+
+// snippetPlaceholders are the placeholders a snippet may contain
+var snippetPlaceholders = map[string]bool{
+	"date":      true,
+	"time":      true,
+	"clipboard": true,
+}
+
+type SnippetsLauncher struct {
+	config    *config.Config
+	clipboard func() (string, error)
+
+	snippets []Snippet
+	loadErr  error
+	mu       sync.Mutex
+
+	// The snippets file is watched and only reloaded once it changed
+	watcher     *fileWatcher
+	watchedPath string
+	stale       atomic.Bool
+}
+
+type SnippetsLauncherFactory struct{}
+
+func (f *SnippetsLauncherFactory) Name() string {
+	return "snippets"
+}
+
+func (f *SnippetsLauncherFactory) Create(cfg *config.Config) Launcher {
+	return NewSnippetsLauncher(cfg)
+}
+
+func init() {
+	RegisterLauncherFactory(&SnippetsLauncherFactory{})
+}
+
+func NewSnippetsLauncher(cfg *config.Config) *SnippetsLauncher {
+	return &SnippetsLauncher{
+		config: cfg,
+		clipboard: func() (string, error) {
+			return readClipboard(cfg.CommandRunner())
+		},
+	}
+}
+
+func (l *SnippetsLauncher) Name() string {
+	return "snippets"
+}
+
+func (l *SnippetsLauncher) CommandTriggers() []string {
+	return []string{"snippets", "sn"}
+}
+
+func (l *SnippetsLauncher) GetSizeMode() LauncherSizeMode {
+	return LauncherSizeModeDefault
+}
+
+func (l *SnippetsLauncher) GetGridConfig() *GridConfig {
+	return nil
+}
+
+func (l *SnippetsLauncher) snippetsFile() string {
+	path := l.config.Launcher.Snippets.File
+	if path == "" {
+		path = "~/.config/locus/snippets.toml"
+	}
+	if strings.HasPrefix(path, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// watch starts watching path, replacing the watch on a previous path. Without
+// a watch (e.g. the directory doesn't exist yet) every search reloads.
+func (l *SnippetsLauncher) watch(path string) {
+	if l.watcher != nil && l.watchedPath == path {
+		return
+	}
+	l.stopWatching()
+
+	watcher, err := watchFile(path, func() { l.stale.Store(true) })
+	if err != nil {
+		return
+	}
+	l.watcher = watcher
+	l.watchedPath = path
+	l.stale.Store(true)
+}
+
+func (l *SnippetsLauncher) stopWatching() {
+	if l.watcher != nil {
+		l.watcher.Close()
+		l.watcher = nil
+		l.watchedPath = ""
+	}
+}
+
+// refresh reloads the snippets file when the watch reported a change
+func (l *SnippetsLauncher) refresh() error {
+	path := l.snippetsFile()
+	l.watch(path)
+	if l.watcher != nil && !l.stale.Load() {
+		return l.loadErr
+	}
+	// Cleared before reading so a save during the read triggers a reload
+	l.stale.Store(false)
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		var snippets []Snippet
+		if snippets, err = ParseSnippets(data); err == nil {
+			l.snippets = snippets
+		}
+	}
+	if err != nil {
+		l.snippets = nil
+	}
+	l.loadErr = err
+	return err
+}
+
+func (l *SnippetsLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.refresh(); err != nil {
+		subtitle := err.Error()
+		if os.IsNotExist(err) {
+			subtitle = "Create " + l.snippetsFile() + " to add snippets"
+		}
+		return []*LauncherItem{{
+			Title:    "No snippets",
+			Subtitle: subtitle,
+			Icon:     "accessories-text-editor",
+			Launcher: l,
+		}}
+	}
+
+	// Read the clipboard at most once per search
+	var clipText string
+	var clipErr error
+	clipRead := false
+	clipboard := func() (string, error) {
+		if !clipRead {
+			clipText, clipErr = l.clipboard()
+			clipRead = true
+		}
+		return clipText, clipErr
+	}
+
+	now := time.Now()
+	matcher := newTextMatcher(query, ctx.CaseSensitive())
+	var items []*LauncherItem
+	for _, snippet := range l.snippets {
+		if !matcher.Match(snippet.Name, snippet.Text) {
+			continue
+		}
+
+		text, err := ExpandSnippet(snippet.Text, now, clipboard)
+		if err != nil {
+			items = append(items, &LauncherItem{
+				Title:    snippet.Name,
+				Subtitle: err.Error(),
+				Icon:     "dialog-warning",
+				Launcher: l,
+			})
+			continue
+		}
+
+		items = append(items, &LauncherItem{
+			Title:      snippet.Name,
+			Subtitle:   strings.Join(strings.Fields(text), " "),
+			Icon:       "accessories-text-editor",
+			ActionData: snippetAction(l.config.Launcher.Snippets.Action, text),
+			Launcher:   l,
+			Metadata:   map[string]string{"text": text},
+		})
+	}
+
+	return items
+}
+
+// ParseSnippets parses a snippets file: a [snippets] table of name = text.
+// Snippets are sorted by name and their placeholders validated.
+func ParseSnippets(data []byte) ([]Snippet, error) {
+	var file struct {
+		Snippets map[string]string `toml:"snippets"`
+	}
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse snippets: %w", err)
+	}
+
+	snippets := make([]Snippet, 0, len(file.Snippets))
+	for name, text := range file.Snippets {
+		if err := ValidateSnippet(text); err != nil {
+			return nil, fmt.Errorf("snippet %q: %w", name, err)
+		}
+		snippets = append(snippets, Snippet{Name: name, Text: text})
+	}
+	sort.Slice(snippets, func(i, j int) bool {
+		return snippets[i].Name < snippets[j].Name
+	})
+
+	return snippets, nil
+}
+
+// ValidateSnippet checks that every {placeholder} in text is known and
+// closed. "{{" and "}}" stand for literal braces.
+func ValidateSnippet(text string) error {
+	_, err := expandSnippet(text, func(string) (string, error) { return "", nil })
+	return err
+}
+
+// ExpandSnippet replaces {date}, {time} and {clipboard} in text. The
+// clipboard is only read when the snippet uses it.
+func ExpandSnippet(text string, now time.Time, clipboard func() (string, error)) (string, error) {
+	return expandSnippet(text, func(name string) (string, error) {
+		switch name {
+		case "date":
+			return now.Format("2006-01-02"), nil
+		case "time":
+			return now.Format("15:04"), nil
+		default:
+			value, err := clipboard()
+			if err != nil {
+				return "", fmt.Errorf("failed to read clipboard: %w", err)
+			}
+			return value, nil
+		}
+	})
+}
+
+func expandSnippet(text string, value func(name string) (string, error)) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '{' && i+1 < len(text) && text[i+1] == '{':
+			out.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(text) && text[i+1] == '}':
+			out.WriteByte('}')
+			i++
+		case c == '}':
+			return "", fmt.Errorf("unmatched '}' at offset %d (use }} for a literal brace)", i)
+		case c == '{':
+			end := strings.IndexByte(text[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unclosed placeholder at offset %d", i)
+			}
+			name := text[i+1 : i+end]
+			if !snippetPlaceholders[name] {
+				return "", fmt.Errorf("unknown placeholder {%s} (must be one of: date, time, clipboard)", name)
+			}
+			v, err := value(name)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(v)
+			i += end
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String(), nil
+}
+
+// snippetAction returns the action for an expanded snippet: typing it into
+// the focused window, or by default copying it to the clipboard
+func snippetAction(action, text string) ActionData {
+	if action == SnippetActionType {
+		return NewTypeAction(text)
+	}
+	return NewShScriptAction("wl-copy -- " + shellQuote(text))
+}
+
+// readClipboard returns the Wayland clipboard's text
+func readClipboard(runner CommandRunner) (string, error) {
+	out, err := runner.Output(context.Background(), "wl-paste", "--no-newline")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (l *SnippetsLauncher) GetHooks() []Hook {
+	return []Hook{}
+}
+
+func (l *SnippetsLauncher) Rebuild(ctx *LauncherContext) error {
+	l.stale.Store(true)
+	return nil
+}
+
+func (l *SnippetsLauncher) Cleanup() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopWatching()
+}
+
+// CtrlNumberActions describes the Ctrl+number actions for item
+func (l *SnippetsLauncher) CtrlNumberActions(item *LauncherItem) map[int]string {
+	if _, ok := item.Metadata["text"]; !ok {
+		return nil
+	}
+	return map[int]string{1: "Copy", 2: "Type"}
+}
+
+func (l *SnippetsLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
+	if number != 1 && number != 2 {
+		return nil, false
+	}
+	return func(item *LauncherItem) error {
+		text, ok := item.Metadata["text"]
+		if !ok {
+			return fmt.Errorf("item is not a snippet")
+		}
+
+		if number == 1 {
+			return l.config.CommandRunner().Run("wl-copy", "--", text)
+		}
+		typeText(text, sanitizeEnvironment())
+		return nil
+	}, true
+}
@@ -0,0 +1,158 @@
+package launcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+const sampleSnippetsTOML = `
+[snippets]
+sig = "Best,\nAlex"
+today = "Notes for {date}"
+quote = "> {clipboard}"
+`
+
+func TestParseSnippets(t *testing.T) {
+	snippets, err := ParseSnippets([]byte(sampleSnippetsTOML))
+	if err != nil {
+		t.Fatalf("Failed to parse snippets: %v", err)
+	}
+	if len(snippets) != 3 {
+		t.Fatalf("Expected 3 snippets, got %d", len(snippets))
+	}
+	if snippets[0].Name != "quote" || snippets[2].Name != "today" {
+		t.Errorf("Expected snippets sorted by name, got %+v", snippets)
+	}
+
+	if _, err := ParseSnippets([]byte("[snippets]\nbad = \"{nope}\"")); err == nil {
+		t.Error("Expected error for unknown placeholder")
+	}
+	if _, err := ParseSnippets([]byte("[snippets\n")); err == nil {
+		t.Error("Expected error for malformed TOML")
+	}
+}
+
+func TestValidateSnippet(t *testing.T) {
+	valid := []string{"plain", "{date} {time}", "{clipboard}", "{{literal}}", "café {date}"}
+	for _, text := range valid {
+		if err := ValidateSnippet(text); err != nil {
+			t.Errorf("ValidateSnippet(%q) error = %v", text, err)
+		}
+	}
+
+	invalid := []string{"{date", "date}", "{unknown}", "{}", "{ date }"}
+	for _, text := range invalid {
+		if err := ValidateSnippet(text); err == nil {
+			t.Errorf("ValidateSnippet(%q) expected an error", text)
+		}
+	}
+}
+
+func TestExpandSnippet(t *testing.T) {
+	now := time.Date(2024, 3, 9, 14, 5, 0, 0, time.UTC)
+	reads := 0
+	clipboard := func() (string, error) {
+		reads++
+		return "copied text", nil
+	}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"Notes for {date}", "Notes for 2024-03-09"},
+		{"{time} meeting", "14:05 meeting"},
+		{"> {clipboard}", "> copied text"},
+		{"{{date}} is {date}", "{date} is 2024-03-09"},
+		{"naïve {date}", "naïve 2024-03-09"},
+	}
+
+	for _, tt := range tests {
+		got, err := ExpandSnippet(tt.text, now, clipboard)
+		if err != nil {
+			t.Errorf("ExpandSnippet(%q) error = %v", tt.text, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ExpandSnippet(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+	if reads != 1 {
+		t.Errorf("Expected the clipboard to be read only by the snippet using it, got %d reads", reads)
+	}
+
+	failing := func() (string, error) { return "", errors.New("no clipboard") }
+	if _, err := ExpandSnippet("{clipboard}", now, failing); err == nil {
+		t.Error("Expected clipboard errors to be reported")
+	}
+	if _, err := ExpandSnippet("{date}", now, failing); err != nil {
+		t.Errorf("Snippet without {clipboard} should not read it: %v", err)
+	}
+}
+
+func TestSnippetAction(t *testing.T) {
+	copyAction, ok := snippetAction(SnippetActionCopy, "it's").(*ShellAction)
+	if !ok {
+		t.Fatalf("Expected copy to use a shell action, got %T", snippetAction(SnippetActionCopy, "it's"))
+	}
+	if copyAction.Command != NewShScriptAction("wl-copy -- "+shellQuote("it's")).Command {
+		t.Errorf("Unexpected copy command: %q", copyAction.Command)
+	}
+
+	if typeAction, ok := snippetAction(SnippetActionType, "hello").(*TypeAction); !ok || typeAction.Text != "hello" {
+		t.Errorf("Expected a type action, got %#v", snippetAction(SnippetActionType, "hello"))
+	}
+	if _, ok := snippetAction("", "hello").(*ShellAction); !ok {
+		t.Error("Expected copy to be the default action")
+	}
+}
+
+func TestSnippetsLauncherPopulate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snippets.toml")
+	if err := os.WriteFile(path, []byte(sampleSnippetsTOML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig
+	cfg.Launcher.Snippets.File = path
+	cfg.Launcher.Snippets.Action = SnippetActionType
+	l := NewSnippetsLauncher(&cfg)
+	defer l.Cleanup()
+	l.clipboard = func() (string, error) { return "pasted", nil }
+
+	items := l.Populate("quo", nil)
+	if len(items) != 1 || items[0].Metadata["text"] != "> pasted" {
+		t.Fatalf("Unexpected items: %+v", items)
+	}
+	if _, ok := items[0].ActionData.(*TypeAction); !ok {
+		t.Errorf("Expected configured type action, got %T", items[0].ActionData)
+	}
+	if labels := l.CtrlNumberActions(items[0]); labels[1] != "Copy" || labels[2] != "Type" {
+		t.Errorf("Unexpected Ctrl+number actions: %v", labels)
+	}
+
+	if _, ok := l.GetCtrlNumberAction(3); ok {
+		t.Error("Expected no Ctrl+3 action")
+	}
+
+	// Edits to the file are picked up once the watch reports them
+	if err := os.WriteFile(path, []byte("[snippets]\nnew = \"fresh\""), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		items := l.Populate("", nil)
+		if len(items) == 1 && items[0].Title == "new" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected reloaded snippets, got %+v", items)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -1,7 +1,10 @@
 package launcher
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -18,7 +21,15 @@ type ThumbnailCacheEntry struct {
 	Size      int64
 }
 
-// ThumbnailCache provides LRU caching for image thumbnails
+// contentHash memoizes a file's content hash for a given size and mtime
+type contentHash struct {
+	size    int64
+	modTime time.Time
+	sum     string
+}
+
+// ThumbnailCache provides LRU caching for image thumbnails. Entries hold
+// encoded image bytes (PNG) keyed by content hash, see ContentKey.
 type ThumbnailCache struct {
 	cache       *lru.Cache[string, *ThumbnailCacheEntry]
 	maxItems    int
@@ -26,6 +37,7 @@ type ThumbnailCache struct {
 	maxSizeMB   int64
 	mu          sync.RWMutex
 	cacheDir    string
+	hashes      map[string]contentHash
 }
 
 // NewThumbnailCache creates a new thumbnail cache
@@ -58,9 +70,54 @@ func NewThumbnailCache(maxItems int, maxSizeMB int64) (*ThumbnailCache, error) {
 		currentSize: 0,
 		maxSizeMB:   maxSizeMB,
 		cacheDir:    cacheDir,
+		hashes:      make(map[string]contentHash),
 	}, nil
 }
 
+// ContentKey returns a cache key built from the hash of the file's bytes and
+// the thumbnail size. Identical images at different paths share a key, while a
+// renamed-over or modified file gets a new one. Hashes are memoized per path
+// until the file's size or mtime changes.
+func (c *ThumbnailCache) ContentKey(path string, width, height int) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat image: %w", err)
+	}
+
+	c.mu.RLock()
+	memo, found := c.hashes[path]
+	c.mu.RUnlock()
+
+	if !found || memo.size != info.Size() || !memo.modTime.Equal(info.ModTime()) {
+		sum, err := hashFile(path)
+		if err != nil {
+			return "", err
+		}
+		memo = contentHash{size: info.Size(), modTime: info.ModTime(), sum: sum}
+
+		c.mu.Lock()
+		c.hashes[path] = memo
+		c.mu.Unlock()
+	}
+
+	return fmt.Sprintf("%s_%dx%d.png", memo.sum, width, height), nil
+}
+
+// hashFile returns the hex-encoded SHA-256 of a file's contents
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open image: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash image: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // Get retrieves a cached thumbnail
 func (c *ThumbnailCache) Get(key string) ([]byte, bool) {
 	c.mu.RLock()
@@ -139,6 +196,7 @@ func (c *ThumbnailCache) Clear() {
 
 	c.cache.Purge()
 	c.currentSize = 0
+	c.hashes = make(map[string]contentHash)
 	log.Printf("[THUMBNAIL-CACHE] Cleared all cache entries")
 }
 
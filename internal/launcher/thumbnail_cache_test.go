@@ -0,0 +1,79 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestThumbnailCache(t *testing.T) *ThumbnailCache {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	cache, err := NewThumbnailCache(10, 1)
+	if err != nil {
+		t.Fatalf("Failed to create thumbnail cache: %v", err)
+	}
+	return cache
+}
+
+func TestThumbnailContentKeySharedAcrossPaths(t *testing.T) {
+	cache := newTestThumbnailCache(t)
+	dir := t.TempDir()
+
+	a := filepath.Join(dir, "a.png")
+	b := filepath.Join(dir, "b.png")
+	os.WriteFile(a, []byte("same image bytes"), 0644)
+	os.WriteFile(b, []byte("same image bytes"), 0644)
+
+	keyA, err := cache.ContentKey(a, 200, 150)
+	if err != nil {
+		t.Fatalf("ContentKey failed: %v", err)
+	}
+	keyB, err := cache.ContentKey(b, 200, 150)
+	if err != nil {
+		t.Fatalf("ContentKey failed: %v", err)
+	}
+
+	if keyA != keyB {
+		t.Errorf("Expected identical files to share a key, got %s and %s", keyA, keyB)
+	}
+
+	cache.Put(keyA, []byte("thumbnail"))
+	if _, found := cache.Get(keyB); !found {
+		t.Error("Expected cache hit for identical file at another path")
+	}
+
+	keySmall, _ := cache.ContentKey(a, 100, 75)
+	if keySmall == keyA {
+		t.Error("Expected different thumbnail sizes to use different keys")
+	}
+}
+
+func TestThumbnailContentKeyModifiedFileMisses(t *testing.T) {
+	cache := newTestThumbnailCache(t)
+	path := filepath.Join(t.TempDir(), "wall.png")
+
+	os.WriteFile(path, []byte("original"), 0644)
+	before, err := cache.ContentKey(path, 200, 150)
+	if err != nil {
+		t.Fatalf("ContentKey failed: %v", err)
+	}
+	cache.Put(before, []byte("thumbnail"))
+
+	os.WriteFile(path, []byte("replaced image"), 0644)
+	// Ensure the mtime changes even on coarse-grained filesystems
+	later := time.Now().Add(2 * time.Second)
+	os.Chtimes(path, later, later)
+
+	after, err := cache.ContentKey(path, 200, 150)
+	if err != nil {
+		t.Fatalf("ContentKey failed: %v", err)
+	}
+
+	if before == after {
+		t.Fatal("Expected modified file to produce a new key")
+	}
+	if _, found := cache.Get(after); found {
+		t.Error("Expected cache miss for modified file")
+	}
+}
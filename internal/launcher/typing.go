@@ -0,0 +1,60 @@
+package launcher
+
+import (
+	"log"
+	"os/exec"
+	"time"
+)
+
+// typeDelay gives the compositor time to return focus to the previous window
+// after the launcher hides, so typed text doesn't land in the launcher
+const typeDelay = 150 * time.Millisecond
+
+// typeTools are the tools that can type into the focused Wayland window, in
+// order of preference. ydotool needs its daemon but works everywhere; wtype
+// needs the virtual-keyboard protocol.
+var typeTools = []string{"wtype", "ydotool"}
+
+// detectTypeTool returns the first installed typing tool, or "" if none is
+func detectTypeTool(lookPath func(string) (string, error)) string {
+	for _, tool := range typeTools {
+		if _, err := lookPath(tool); err == nil {
+			return tool
+		}
+	}
+	return ""
+}
+
+// typeCommands returns the commands that type text with tool. Without a
+// tool the text is copied to the clipboard and a notification says so.
+func typeCommands(tool, text string) [][]string {
+	switch tool {
+	case "wtype":
+		return [][]string{{"wtype", "--", text}}
+	case "ydotool":
+		return [][]string{{"ydotool", "type", "--", text}}
+	default:
+		return [][]string{
+			{"wl-copy", "--", text},
+			{"notify-send", "-a", "Locus", "Copied to clipboard", "Install wtype or ydotool to type into windows directly"},
+		}
+	}
+}
+
+// typeText types text into the focused window in the background after
+// typeDelay, running the typing commands with env
+func typeText(text string, env []string) {
+	commands := typeCommands(detectTypeTool(exec.LookPath), text)
+
+	go func() {
+		time.Sleep(typeDelay)
+		for _, argv := range commands {
+			cmd := exec.Command(argv[0], argv[1:]...)
+			cmd.Env = env
+			if err := cmd.Run(); err != nil {
+				log.Printf("[TYPE] %s failed: %v", argv[0], err)
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,73 @@
+package launcher
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func fakeLookPath(installed ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		for _, tool := range installed {
+			if tool == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestDetectTypeTool(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed []string
+		want      string
+	}{
+		{"wtype", []string{"wtype"}, "wtype"},
+		{"ydotool", []string{"ydotool"}, "ydotool"},
+		{"prefers wtype", []string{"ydotool", "wtype"}, "wtype"},
+		{"none", []string{"xdotool"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := detectTypeTool(fakeLookPath(tt.installed...)); got != tt.want {
+			t.Errorf("%s: detectTypeTool() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTypeCommands(t *testing.T) {
+	text := "-rf 😀 'quoted' $HOME"
+
+	if got := typeCommands("wtype", text); !reflect.DeepEqual(got, [][]string{{"wtype", "--", text}}) {
+		t.Errorf("unexpected wtype command: %q", got)
+	}
+	if got := typeCommands("ydotool", text); !reflect.DeepEqual(got, [][]string{{"ydotool", "type", "--", text}}) {
+		t.Errorf("unexpected ydotool command: %q", got)
+	}
+
+	fallback := typeCommands("", text)
+	if len(fallback) != 2 || !reflect.DeepEqual(fallback[0], []string{"wl-copy", "--", text}) {
+		t.Fatalf("expected clipboard fallback, got %q", fallback)
+	}
+	if fallback[1][0] != "notify-send" {
+		t.Errorf("expected the fallback to notify, got %q", fallback[1])
+	}
+}
+
+func TestTypeActionJSON(t *testing.T) {
+	action := NewTypeAction("héllo")
+
+	data, err := action.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to marshal to JSON: %v", err)
+	}
+	parsed, err := ParseActionData(data)
+	if err != nil {
+		t.Fatalf("Failed to parse action data: %v", err)
+	}
+	typed, ok := parsed.(*TypeAction)
+	if !ok || typed.Text != "héllo" {
+		t.Errorf("Expected TypeAction with text 'héllo', got %#v", parsed)
+	}
+}
@@ -87,9 +87,10 @@ func (l *WallpaperLauncher) Populate(query string, ctx *LauncherContext) []*Laun
 
 	// Try to match wallpaper files by name
 	wallpapers := l.listWallpapers(wallpaperDir)
+	matcher := newTextMatcher(q, ctx.CaseSensitive())
 	var matched []*LauncherItem
 	for _, wp := range wallpapers {
-		if strings.Contains(strings.ToLower(wp.Title), strings.ToLower(q)) {
+		if matcher.Match(wp.Title) {
 			matched = append(matched, wp)
 		}
 	}
@@ -0,0 +1,94 @@
+package launcher
+
+import (
+	"log"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// warmUpLoadTimeout bounds how long warm-up waits for the app index
+const warmUpLoadTimeout = 30 * time.Second
+
+// warmUpQueries returns the empty query followed by the configured prefixes
+func (r *LauncherRegistry) warmUpQueries() []string {
+	prefixes := r.config.Launcher.Performance.WarmupPrefixes
+	if prefixes == nil {
+		prefixes = config.DefaultConfig.Launcher.Performance.WarmupPrefixes
+	}
+
+	queries := make([]string, 0, len(prefixes)+1)
+	queries = append(queries, "")
+	for _, prefix := range prefixes {
+		if prefix != "" {
+			queries = append(queries, prefix)
+		}
+	}
+	return queries
+}
+
+// StartWarmUp precomputes general search results in the background once
+// apps have loaded, so the first search is served from the cache. It is a
+// no-op unless background loading is enabled.
+func (r *LauncherRegistry) StartWarmUp() {
+	if r.searchCache == nil || !r.config.Launcher.Performance.EnableBackgroundLoading {
+		return
+	}
+	// Merged scope searches aren't cached, so there is nothing to warm
+	if len(r.scopeLaunchers()) > 0 {
+		return
+	}
+
+	appLauncher := r.findAppLauncher()
+	if appLauncher == nil {
+		return
+	}
+	queries := r.warmUpQueries()
+
+	go func() {
+		if loader, ok := appLauncher.(*AppLauncher); ok {
+			if !loader.WaitLoaded(warmUpLoadTimeout) {
+				log.Printf("[WARM-UP] Apps not loaded, skipping warm-up")
+				return
+			}
+			r.setAppsHash(loader.GetAppsHash())
+		}
+
+		start := time.Now()
+		warmed := r.WarmUp(appLauncher, queries)
+		log.Printf("[WARM-UP] Cached %d queries in %v", warmed, time.Since(start))
+	}()
+}
+
+// WarmUp caches results for queries, returning how many were stored.
+// Queries already cached (e.g. by a real search) are skipped, and warm-up
+// stops if the apps hash changes mid-way so stale results are never stored.
+func (r *LauncherRegistry) WarmUp(appLauncher Launcher, queries []string) int {
+	if r.searchCache == nil {
+		return 0
+	}
+
+	hash := r.currentAppsHash()
+	warmed := 0
+
+	for _, query := range queries {
+		if r.searchCache.Contains(query, hash) {
+			continue
+		}
+
+		start := time.Now()
+		launcherCtx := r.launcherContext()
+		items := r.searchApps(appLauncher, query, &launcherCtx)
+
+		if r.currentAppsHash() != hash {
+			log.Printf("[WARM-UP] Apps changed during warm-up, stopping")
+			return warmed
+		}
+
+		durationMs := float64(time.Since(start).Nanoseconds()) / 1e6
+		r.searchCache.Put(query, hash, items, durationMs)
+		warmed++
+	}
+
+	return warmed
+}
@@ -0,0 +1,97 @@
+package launcher
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// fakeAppsLauncher stands in for AppLauncher, recording populated queries
+type fakeAppsLauncher struct {
+	queries  []string
+	onSearch func()
+}
+
+func (l *fakeAppsLauncher) Name() string                   { return "apps" }
+func (l *fakeAppsLauncher) CommandTriggers() []string      { return []string{} }
+func (l *fakeAppsLauncher) GetSizeMode() LauncherSizeMode  { return LauncherSizeModeDefault }
+func (l *fakeAppsLauncher) GetHooks() []Hook               { return []Hook{} }
+func (l *fakeAppsLauncher) Rebuild(*LauncherContext) error { return nil }
+func (l *fakeAppsLauncher) Cleanup()                       {}
+func (l *fakeAppsLauncher) GetGridConfig() *GridConfig     { return nil }
+func (l *fakeAppsLauncher) GetCtrlNumberAction(int) (CtrlNumberAction, bool) {
+	return nil, false
+}
+
+func (l *fakeAppsLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	l.queries = append(l.queries, query)
+	if l.onSearch != nil {
+		l.onSearch()
+	}
+	return []*LauncherItem{{Title: query + "-result", Launcher: l}}
+}
+
+func newWarmUpRegistry(t *testing.T, prefixes []string) (*LauncherRegistry, *fakeAppsLauncher) {
+	t.Helper()
+
+	cfg := &config.Config{CacheDir: t.TempDir()}
+	cfg.Launcher.Search.MaxResults = 10
+	cfg.Launcher.Performance.SearchCacheSize = 50
+	cfg.Launcher.Performance.WarmupPrefixes = prefixes
+
+	r := NewLauncherRegistry(cfg)
+	fake := &fakeAppsLauncher{}
+	if err := r.Register(fake); err != nil {
+		t.Fatal(err)
+	}
+	r.setAppsHash("hash-1")
+	return r, fake
+}
+
+func TestWarmUpPopulatesCache(t *testing.T) {
+	r, fake := newWarmUpRegistry(t, []string{"f", "t"})
+
+	if warmed := r.WarmUp(fake, r.warmUpQueries()); warmed != 3 {
+		t.Fatalf("Expected 3 warmed queries, got %d", warmed)
+	}
+	for _, q := range []string{"", "f", "t"} {
+		if !r.searchCache.Contains(q, "hash-1") {
+			t.Errorf("Expected query %q to be cached", q)
+		}
+	}
+
+	// A real search for a warmed prefix is served from the cache
+	items, err := r.Search("f")
+	if err != nil || len(items) != 1 || items[0].Title != "f-result" {
+		t.Errorf("Unexpected search results: %v (%v)", items, err)
+	}
+	if len(fake.queries) != 3 {
+		t.Errorf("Expected search to hit the cache, launcher populated %v", fake.queries)
+	}
+
+	// Already cached queries are not recomputed
+	if warmed := r.WarmUp(fake, r.warmUpQueries()); warmed != 0 {
+		t.Errorf("Expected no queries to be re-warmed, got %d", warmed)
+	}
+}
+
+func TestWarmUpStopsWhenAppsChange(t *testing.T) {
+	r, fake := newWarmUpRegistry(t, []string{"f"})
+	fake.onSearch = func() { r.setAppsHash("hash-2") }
+
+	if warmed := r.WarmUp(fake, r.warmUpQueries()); warmed != 0 {
+		t.Errorf("Expected warm-up to stop after apps changed, warmed %d", warmed)
+	}
+	if r.searchCache.Contains("", "hash-1") || r.searchCache.Contains("", "hash-2") {
+		t.Error("Expected no stale results to be cached")
+	}
+}
+
+func TestWarmUpQueriesDefault(t *testing.T) {
+	r, _ := newWarmUpRegistry(t, nil)
+
+	queries := r.warmUpQueries()
+	if len(queries) != len(config.DefaultConfig.Launcher.Performance.WarmupPrefixes)+1 || queries[0] != "" {
+		t.Errorf("Expected empty query plus default prefixes, got %v", queries)
+	}
+}
@@ -0,0 +1,153 @@
+package launcher
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+const defaultWebSearchEngine = "ddg"
+
+// WebSearchLauncher opens web searches in the browser. The first word of
+// the query may name an engine by keyword ("s: gh locus", or bang-style
+// "!gh locus"); otherwise the default engine is used.
+type WebSearchLauncher struct {
+	config *config.Config
+}
+
+type WebSearchLauncherFactory struct{}
+
+func (f *WebSearchLauncherFactory) Name() string {
+	return "websearch"
+}
+
+func (f *WebSearchLauncherFactory) Create(cfg *config.Config) Launcher {
+	return NewWebSearchLauncher(cfg)
+}
+
+func init() {
+	RegisterLauncherFactory(&WebSearchLauncherFactory{})
+}
+
+func NewWebSearchLauncher(cfg *config.Config) *WebSearchLauncher {
+	return &WebSearchLauncher{config: cfg}
+}
+
+func (l *WebSearchLauncher) Name() string {
+	return "websearch"
+}
+
+func (l *WebSearchLauncher) CommandTriggers() []string {
+	return []string{"s", "search", "!"}
+}
+
+func (l *WebSearchLauncher) GetSizeMode() LauncherSizeMode {
+	return LauncherSizeModeDefault
+}
+
+func (l *WebSearchLauncher) GetGridConfig() *GridConfig {
+	return nil
+}
+
+// ResolveWebSearch picks the engine for query: the engine named by its
+// first word, whose remaining words are the search terms, or the default
+// engine searching the whole query
+func ResolveWebSearch(engines map[string]string, defaultEngine, query string) (keyword, terms string) {
+	query = strings.TrimSpace(query)
+	first, rest, _ := strings.Cut(query, " ")
+	if _, ok := engines[strings.ToLower(first)]; ok && first != "" {
+		return strings.ToLower(first), strings.TrimSpace(rest)
+	}
+
+	if defaultEngine == "" {
+		defaultEngine = defaultWebSearchEngine
+	}
+	return defaultEngine, query
+}
+
+// WebSearchURL fills template's %s with the URL-encoded terms
+func WebSearchURL(template, terms string) string {
+	return strings.ReplaceAll(template, "%s", url.QueryEscape(terms))
+}
+
+// webSearchURL returns the URL searching the default engine for terms
+func webSearchURL(cfg *config.Config, terms string) string {
+	engines := cfg.Launcher.WebSearch.WebSearchEngines()
+	template, ok := engines[cfg.Launcher.WebSearch.DefaultEngine]
+	if !ok {
+		template = engines[defaultWebSearchEngine]
+	}
+	return WebSearchURL(template, terms)
+}
+
+// webSearchBrowser returns the command web searches are opened with
+func webSearchBrowser(cfg *config.Config) string {
+	if browser := cfg.Launcher.WebSearch.Browser; browser != "" {
+		return browser
+	}
+	return "xdg-open"
+}
+
+func (l *WebSearchLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
+	cfg := l.config.Launcher.WebSearch
+	engines := cfg.WebSearchEngines()
+	keyword, terms := ResolveWebSearch(engines, cfg.DefaultEngine, query)
+
+	// Without search terms, list the engines to pick from
+	if terms == "" {
+		keywords := make([]string, 0, len(engines))
+		for k := range engines {
+			keywords = append(keywords, k)
+		}
+		sort.Strings(keywords)
+
+		items := make([]*LauncherItem, 0, len(keywords))
+		for _, k := range keywords {
+			items = append(items, &LauncherItem{
+				Title:      fmt.Sprintf("Search with %s", k),
+				Subtitle:   engines[k],
+				Icon:       "web-browser",
+				ActionData: NewPrefillAction("!" + k + " "),
+				Launcher:   l,
+			})
+		}
+		return items
+	}
+
+	template, ok := engines[keyword]
+	if !ok {
+		return []*LauncherItem{{
+			Title:    fmt.Sprintf("Unknown search engine: %s", keyword),
+			Subtitle: "Set launcher.web_search.default_engine to a configured engine",
+			Icon:     "dialog-error",
+			Launcher: l,
+		}}
+	}
+
+	searchURL := WebSearchURL(template, terms)
+	return []*LauncherItem{{
+		Title:      fmt.Sprintf("Search %s for: %s", keyword, terms),
+		Subtitle:   searchURL,
+		Icon:       "web-browser",
+		ActionData: NewShScriptAction(webSearchBrowser(l.config) + " " + shellQuote(searchURL)),
+		Launcher:   l,
+	}}
+}
+
+func (l *WebSearchLauncher) GetHooks() []Hook {
+	return []Hook{}
+}
+
+func (l *WebSearchLauncher) Rebuild(ctx *LauncherContext) error {
+	return nil
+}
+
+func (l *WebSearchLauncher) Cleanup() {
+}
+
+func (l *WebSearchLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
+	return nil, false
+}
@@ -0,0 +1,99 @@
+package launcher
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestResolveWebSearch(t *testing.T) {
+	engines := map[string]string{
+		"ddg": "https://duckduckgo.com/?q=%s",
+		"gh":  "https://github.com/search?q=%s",
+	}
+
+	tests := []struct {
+		query   string
+		keyword string
+		terms   string
+	}{
+		{"gh locus launcher", "gh", "locus launcher"},
+		{"GH locus", "gh", "locus"},
+		{"  gh   locus ", "gh", "locus"},
+		{"gh", "gh", ""},
+		{"golang generics", "ddg", "golang generics"},
+		{"", "ddg", ""},
+	}
+
+	for _, tt := range tests {
+		keyword, terms := ResolveWebSearch(engines, "ddg", tt.query)
+		if keyword != tt.keyword || terms != tt.terms {
+			t.Errorf("ResolveWebSearch(%q) = (%q, %q), want (%q, %q)", tt.query, keyword, terms, tt.keyword, tt.terms)
+		}
+	}
+
+	if keyword, _ := ResolveWebSearch(engines, "", "rust"); keyword != defaultWebSearchEngine {
+		t.Errorf("expected %s without a default engine, got %s", defaultWebSearchEngine, keyword)
+	}
+}
+
+func TestWebSearchURL(t *testing.T) {
+	tests := []struct {
+		template string
+		terms    string
+		want     string
+	}{
+		{"https://duckduckgo.com/?q=%s", "go generics", "https://duckduckgo.com/?q=go+generics"},
+		{"https://duckduckgo.com/?q=%s", "c++ & rust?", "https://duckduckgo.com/?q=c%2B%2B+%26+rust%3F"},
+		{"https://example.com/?q=%s&alt=%s", "a/b", "https://example.com/?q=a%2Fb&alt=a%2Fb"},
+	}
+
+	for _, tt := range tests {
+		if got := WebSearchURL(tt.template, tt.terms); got != tt.want {
+			t.Errorf("WebSearchURL(%q, %q) = %q, want %q", tt.template, tt.terms, got, tt.want)
+		}
+	}
+}
+
+func TestWebSearchLauncherPopulate(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Launcher.WebSearch.Engines = map[string]string{"aw": "https://wiki.archlinux.org/index.php?search=%s"}
+	cfg.Launcher.WebSearch.Browser = "firefox"
+	l := NewWebSearchLauncher(cfg)
+
+	items := l.Populate("aw systemd units", &LauncherContext{Config: cfg})
+	if len(items) != 1 {
+		t.Fatalf("expected one item, got %v", titlesOf(items))
+	}
+	action, ok := items[0].ActionData.(*ShellAction)
+	if !ok || action.Command != `sh -c "firefox 'https://wiki.archlinux.org/index.php?search=systemd+units'"` {
+		t.Errorf("unexpected action %+v", items[0].ActionData)
+	}
+
+	// Built-in engines stay available alongside configured ones
+	items = l.Populate("linux kernel", &LauncherContext{Config: cfg})
+	if len(items) != 1 || items[0].Subtitle != "https://duckduckgo.com/?q=linux+kernel" {
+		t.Errorf("expected a default engine search, got %+v", items)
+	}
+
+	// Without terms, engines are offered as bang prefills
+	items = l.Populate("", &LauncherContext{Config: cfg})
+	if len(items) != len(cfg.Launcher.WebSearch.WebSearchEngines()) {
+		t.Fatalf("expected every engine to be listed, got %v", titlesOf(items))
+	}
+	if prefill, ok := items[0].ActionData.(*PrefillAction); !ok || prefill.Text != "!aw " {
+		t.Errorf("expected the first engine to prefill its bang, got %+v", items[0].ActionData)
+	}
+}
+
+func TestBangTriggersWebSearch(t *testing.T) {
+	registry := NewLauncherRegistry(&config.Config{CacheDir: t.TempDir()})
+	registry.Register(NewWebSearchLauncher(registry.config))
+
+	for input, want := range map[string]string{"!g go modules": "g go modules", "s: gh locus": "gh locus"} {
+		_, l, query := registry.FindLauncherForInput(input)
+		if l == nil || l.Name() != "websearch" || query != want {
+			t.Errorf("FindLauncherForInput(%q) = (%v, %q), want websearch with %q", input, l, query, want)
+		}
+	}
+}
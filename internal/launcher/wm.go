@@ -3,9 +3,14 @@ package launcher
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/chess10kp/locus/internal/apps"
 	"github.com/chess10kp/locus/internal/config"
 )
 
@@ -16,10 +21,40 @@ type Workspace struct {
 	Visible bool   `json:"visible"`
 }
 
+type OutputRect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// OutputMode is a display mode; Refresh is in mHz as reported by sway
+type OutputMode struct {
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+	Refresh int `json:"refresh"`
+}
+
+type Output struct {
+	Name        string       `json:"name"`
+	Make        string       `json:"make"`
+	Model       string       `json:"model"`
+	Active      bool         `json:"active"`
+	Focused     bool         `json:"focused"`
+	Primary     bool         `json:"primary"` // i3 only
+	Scale       float64      `json:"scale"`
+	Rect        OutputRect   `json:"rect"`
+	CurrentMode OutputMode   `json:"current_mode"`
+	Modes       []OutputMode `json:"modes"`
+}
+
 type SwayNode struct {
 	ID               int64       `json:"id"`
 	Name             string      `json:"name"`
 	Type             string      `json:"type"`
+	Focused          bool        `json:"focused"`
+	Pid              int         `json:"pid"`
+	Rect             OutputRect  `json:"rect"`
 	Window           *int64      `json:"window"`
 	AppID            string      `json:"app_id"`
 	WindowProperties WindowProps `json:"window_properties"`
@@ -42,10 +77,21 @@ type WindowInfo struct {
 }
 
 type WMLauncher struct {
-	config     *config.Config
-	wmCommand  string
-	workspaces []Workspace
-	windows    []WindowInfo
+	config        *config.Config
+	wmCommand     string
+	outputBackend string // wmCommand, or "hyprctl" under Hyprland
+	runner        CommandRunner
+	workspaces    []Workspace
+	windows       []WindowInfo
+	mu            sync.Mutex // guards windows and the outputs below
+
+	// Outputs rarely change while the launcher is open, so they are
+	// fetched once per show
+	outputs        []Output
+	outputsFetched bool
+
+	iconIndex     map[string]string
+	iconIndexOnce sync.Once
 }
 
 type WMLauncherFactory struct{}
@@ -63,12 +109,19 @@ func init() {
 }
 
 func NewWMLauncher(cfg *config.Config) *WMLauncher {
+	wmCommand := detectWMCommand()
 	return &WMLauncher{
-		config:    cfg,
-		wmCommand: detectWMCommand(),
+		config:        cfg,
+		wmCommand:     wmCommand,
+		outputBackend: detectOutputBackend(wmCommand),
+		runner:        cfg.CommandRunner(),
 	}
 }
 
+// wmCommandTimeout bounds each call to the WM's IPC tool, so a hung
+// compositor can't stall the launcher
+const wmCommandTimeout = 2 * time.Second
+
 func (l *WMLauncher) Name() string {
 	return "wm"
 }
@@ -85,6 +138,11 @@ func (l *WMLauncher) GetGridConfig() *GridConfig {
 	return nil
 }
 
+// RowTemplate badges windows and workspaces with their workspace
+func (l *WMLauncher) RowTemplate() *RowTemplate {
+	return &RowTemplate{Badge: "{workspace}"}
+}
+
 func detectWMCommand() string {
 	commands := []string{"scrollmsg", "swaymsg", "i3-msg"}
 	for _, cmd := range commands {
@@ -95,9 +153,80 @@ func detectWMCommand() string {
 	return "swaymsg"
 }
 
+// ParseOutputs parses the reply of "<wm>msg -t get_outputs"
+func ParseOutputs(data []byte) ([]Output, error) {
+	var outputs []Output
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil, fmt.Errorf("failed to parse outputs: %w", err)
+	}
+	return outputs, nil
+}
+
+// FocusedOutput returns the focused output from a get_outputs reply
+func FocusedOutput(outputs []Output) (Output, bool) {
+	for _, output := range outputs {
+		if output.Focused {
+			return output, true
+		}
+	}
+	return Output{}, false
+}
+
+// FetchFocusedOutput asks the running WM which output currently has focus
+func FetchFocusedOutput() (Output, error) {
+	output, err := defaultRunner.RunWithTimeout(500*time.Millisecond, detectWMCommand(), "-t", "get_outputs")
+	if err != nil {
+		return Output{}, fmt.Errorf("failed to get outputs: %w", err)
+	}
+
+	outputs, err := ParseOutputs(output)
+	if err != nil {
+		return Output{}, err
+	}
+
+	focused, ok := FocusedOutput(outputs)
+	if !ok {
+		return Output{}, fmt.Errorf("no focused output")
+	}
+	return focused, nil
+}
+
+// FocusedWindowRect returns the geometry of the focused window in a get_tree reply
+func FocusedWindowRect(node SwayNode) (OutputRect, bool) {
+	if node.Focused && (node.Pid > 0 || node.Window != nil) {
+		return node.Rect, true
+	}
+	for _, children := range [][]SwayNode{node.Nodes, node.FloatingNodes} {
+		for _, child := range children {
+			if rect, ok := FocusedWindowRect(child); ok {
+				return rect, true
+			}
+		}
+	}
+	return OutputRect{}, false
+}
+
+// FetchFocusedWindowRect asks the running WM for the focused window's geometry
+func FetchFocusedWindowRect() (OutputRect, error) {
+	output, err := defaultRunner.RunWithTimeout(500*time.Millisecond, detectWMCommand(), "-t", "get_tree")
+	if err != nil {
+		return OutputRect{}, fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	var tree SwayNode
+	if err := json.Unmarshal(output, &tree); err != nil {
+		return OutputRect{}, fmt.Errorf("failed to parse tree: %w", err)
+	}
+
+	rect, ok := FocusedWindowRect(tree)
+	if !ok {
+		return OutputRect{}, fmt.Errorf("no focused window")
+	}
+	return rect, nil
+}
+
 func (l *WMLauncher) fetchWorkspaces() ([]Workspace, error) {
-	cmd := exec.Command(l.wmCommand, "-t", "get_workspaces")
-	output, err := cmd.Output()
+	output, err := l.runner.RunWithTimeout(wmCommandTimeout, l.wmCommand, "-t", "get_workspaces")
 	if err != nil {
 		return nil, err
 	}
@@ -111,8 +240,7 @@ func (l *WMLauncher) fetchWorkspaces() ([]Workspace, error) {
 }
 
 func (l *WMLauncher) fetchWindows() ([]WindowInfo, error) {
-	cmd := exec.Command(l.wmCommand, "-t", "get_tree")
-	output, err := cmd.Output()
+	output, err := l.runner.RunWithTimeout(wmCommandTimeout, l.wmCommand, "-t", "get_tree")
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +251,9 @@ func (l *WMLauncher) fetchWindows() ([]WindowInfo, error) {
 	}
 
 	windows := l.extractWindows(tree, "")
+	l.mu.Lock()
 	l.windows = windows
+	l.mu.Unlock()
 	return windows, nil
 }
 
@@ -163,7 +293,7 @@ func (l *WMLauncher) extractWindows(node SwayNode, workspace string) []WindowInf
 func (l *WMLauncher) Populate(query string, ctx *LauncherContext) []*LauncherItem {
 	var items []*LauncherItem
 
-	queryLower := strings.ToLower(strings.TrimSpace(query))
+	matcher := newTextMatcher(query, ctx.CaseSensitive())
 
 	workspaces, err := l.fetchWorkspaces()
 	if err != nil {
@@ -175,29 +305,36 @@ func (l *WMLauncher) Populate(query string, ctx *LauncherContext) []*LauncherIte
 	if err != nil {
 		fmt.Printf("Failed to fetch windows: %v\n", err)
 	} else {
-		windowItems := l.buildWindowItems(windows, queryLower)
+		windowItems := l.buildWindowItems(windows, matcher)
 		items = append(items, windowItems...)
 	}
 
-	wmItems := l.buildWindowManagementItems(queryLower)
+	wmItems := l.buildWindowManagementItems(matcher)
 	items = append(items, wmItems...)
 
-	wsItems := l.buildWorkspaceItems(workspaces, queryLower)
+	wsItems := l.buildWorkspaceItems(workspaces, matcher)
 	items = append(items, wsItems...)
 
-	groupItems := l.buildWindowGroupItems(queryLower)
+	groupItems := l.buildWindowGroupItems(matcher)
 	items = append(items, groupItems...)
 
-	scrollwmItems := l.buildScrollwmItems(queryLower)
+	scrollwmItems := l.buildScrollwmItems(matcher)
 	items = append(items, scrollwmItems...)
 
-	utilityItems := l.buildUtilityItems(queryLower)
+	outputs, err := l.cachedOutputs()
+	if err != nil {
+		log.Printf("Failed to fetch outputs: %v", err)
+	} else {
+		items = append(items, l.buildOutputItems(outputs, matcher)...)
+	}
+
+	utilityItems := l.buildUtilityItems(matcher)
 	items = append(items, utilityItems...)
 
 	return items
 }
 
-func (l *WMLauncher) buildWindowManagementItems(query string) []*LauncherItem {
+func (l *WMLauncher) buildWindowManagementItems(matcher textMatcher) []*LauncherItem {
 	commands := []struct {
 		name      string
 		subtitle  string
@@ -222,7 +359,7 @@ func (l *WMLauncher) buildWindowManagementItems(query string) []*LauncherItem {
 
 	var items []*LauncherItem
 	for _, cmd := range commands {
-		if query != "" && !strings.Contains(strings.ToLower(cmd.name), query) && !strings.Contains(strings.ToLower(cmd.subtitle), query) {
+		if !matcher.Match(cmd.name, cmd.subtitle) {
 			continue
 		}
 		items = append(items, &LauncherItem{
@@ -236,7 +373,7 @@ func (l *WMLauncher) buildWindowManagementItems(query string) []*LauncherItem {
 	return items
 }
 
-func (l *WMLauncher) buildWorkspaceItems(workspaces []Workspace, query string) []*LauncherItem {
+func (l *WMLauncher) buildWorkspaceItems(workspaces []Workspace, matcher textMatcher) []*LauncherItem {
 	var items []*LauncherItem
 
 	utilityCommands := []struct {
@@ -251,7 +388,7 @@ func (l *WMLauncher) buildWorkspaceItems(workspaces []Workspace, query string) [
 	}
 
 	for _, cmd := range utilityCommands {
-		if query != "" && !strings.Contains(strings.ToLower(cmd.name), query) && !strings.Contains(strings.ToLower(cmd.subtitle), query) {
+		if !matcher.Match(cmd.name, cmd.subtitle) {
 			continue
 		}
 		items = append(items, &LauncherItem{
@@ -265,7 +402,7 @@ func (l *WMLauncher) buildWorkspaceItems(workspaces []Workspace, query string) [
 
 	for _, ws := range workspaces {
 		title := fmt.Sprintf("Switch to: %s", ws.Name)
-		if query != "" && !strings.Contains(strings.ToLower(title), query) {
+		if !matcher.Match(title) {
 			continue
 		}
 		items = append(items, &LauncherItem{
@@ -281,7 +418,7 @@ func (l *WMLauncher) buildWorkspaceItems(workspaces []Workspace, query string) [
 	return items
 }
 
-func (l *WMLauncher) buildWindowGroupItems(query string) []*LauncherItem {
+func (l *WMLauncher) buildWindowGroupItems(matcher textMatcher) []*LauncherItem {
 	commands := []struct {
 		name      string
 		subtitle  string
@@ -296,7 +433,7 @@ func (l *WMLauncher) buildWindowGroupItems(query string) []*LauncherItem {
 
 	var items []*LauncherItem
 	for _, cmd := range commands {
-		if query != "" && !strings.Contains(strings.ToLower(cmd.name), query) && !strings.Contains(strings.ToLower(cmd.subtitle), query) {
+		if !matcher.Match(cmd.name, cmd.subtitle) {
 			continue
 		}
 		items = append(items, &LauncherItem{
@@ -310,7 +447,7 @@ func (l *WMLauncher) buildWindowGroupItems(query string) []*LauncherItem {
 	return items
 }
 
-func (l *WMLauncher) buildScrollwmItems(query string) []*LauncherItem {
+func (l *WMLauncher) buildScrollwmItems(matcher textMatcher) []*LauncherItem {
 	if l.wmCommand != "scrollmsg" {
 		return []*LauncherItem{}
 	}
@@ -335,7 +472,7 @@ func (l *WMLauncher) buildScrollwmItems(query string) []*LauncherItem {
 
 	var items []*LauncherItem
 	for _, cmd := range commands {
-		if query != "" && !strings.Contains(strings.ToLower(cmd.name), query) && !strings.Contains(strings.ToLower(cmd.subtitle), query) {
+		if !matcher.Match(cmd.name, cmd.subtitle) {
 			continue
 		}
 		items = append(items, &LauncherItem{
@@ -349,20 +486,12 @@ func (l *WMLauncher) buildScrollwmItems(query string) []*LauncherItem {
 	return items
 }
 
-func (l *WMLauncher) buildWindowItems(windows []WindowInfo, query string) []*LauncherItem {
+func (l *WMLauncher) buildWindowItems(windows []WindowInfo, matcher textMatcher) []*LauncherItem {
 	var items []*LauncherItem
 
 	for _, win := range windows {
-		// Filter by query
-		if query != "" {
-			lowerQuery := strings.ToLower(query)
-			titleMatch := strings.Contains(strings.ToLower(win.Name), lowerQuery)
-			appMatch := strings.Contains(strings.ToLower(win.WindowClass), lowerQuery)
-			workspaceMatch := strings.Contains(strings.ToLower(win.Workspace), lowerQuery)
-
-			if !titleMatch && !appMatch && !workspaceMatch {
-				continue
-			}
+		if !matcher.Match(win.Name, win.WindowClass, win.Workspace) {
+			continue
 		}
 
 		// Build subtitle with app class and workspace
@@ -376,13 +505,7 @@ func (l *WMLauncher) buildWindowItems(windows []WindowInfo, query string) []*Lau
 			subtitle = win.Workspace
 		}
 
-		// Determine icon based on app class
-		icon := "window-new"
-		if win.WindowClass != "" {
-			icon = strings.ToLower(win.WindowClass)
-		} else if win.AppID != "" {
-			icon = strings.ToLower(win.AppID)
-		}
+		icon := resolveWindowIcon(l.appIconIndex(), win.AppID, win.WindowClass)
 
 		items = append(items, &LauncherItem{
 			Title:      win.Name,
@@ -402,7 +525,72 @@ func (l *WMLauncher) buildWindowItems(windows []WindowInfo, query string) []*Lau
 	return items
 }
 
-func (l *WMLauncher) buildUtilityItems(query string) []*LauncherItem {
+// appIconIndex lazily builds the app-id to icon index from installed apps
+func (l *WMLauncher) appIconIndex() map[string]string {
+	l.iconIndexOnce.Do(func() {
+		loaded, err := apps.NewAppLoader(l.config).LoadApps(false)
+		if err != nil {
+			fmt.Printf("Failed to load apps for icon lookup: %v\n", err)
+		}
+		l.iconIndex = buildAppIconIndex(loaded)
+	})
+	return l.iconIndex
+}
+
+// buildAppIconIndex maps lowercased desktop-file ids, StartupWMClass values
+// and app names to the app's icon
+func buildAppIconIndex(list []apps.App) map[string]string {
+	index := make(map[string]string, len(list)*2)
+	for _, app := range list {
+		if app.Icon == "" {
+			continue
+		}
+		keys := []string{app.Name, app.StartupWMClass}
+		if app.File != "" {
+			keys = append(keys, strings.TrimSuffix(filepath.Base(app.File), ".desktop"))
+		}
+		for _, key := range keys {
+			key = strings.ToLower(key)
+			if key == "" {
+				continue
+			}
+			if _, exists := index[key]; !exists {
+				index[key] = app.Icon
+			}
+		}
+	}
+	return index
+}
+
+// resolveWindowIcon finds the icon for a window by matching its app-id or
+// class against the app index (also trying the last segment of reverse-DNS
+// ids), falling back to the lowercased class/app-id, then a generic icon
+func resolveWindowIcon(index map[string]string, appID, windowClass string) string {
+	for _, id := range []string{appID, windowClass} {
+		id = strings.ToLower(id)
+		if id == "" {
+			continue
+		}
+		if icon, ok := index[id]; ok {
+			return icon
+		}
+		if i := strings.LastIndex(id, "."); i >= 0 && i < len(id)-1 {
+			if icon, ok := index[id[i+1:]]; ok {
+				return icon
+			}
+		}
+	}
+
+	if windowClass != "" {
+		return strings.ToLower(windowClass)
+	}
+	if appID != "" {
+		return strings.ToLower(appID)
+	}
+	return "window-new"
+}
+
+func (l *WMLauncher) buildUtilityItems(matcher textMatcher) []*LauncherItem {
 	commands := []struct {
 		name      string
 		subtitle  string
@@ -418,7 +606,7 @@ func (l *WMLauncher) buildUtilityItems(query string) []*LauncherItem {
 
 	var items []*LauncherItem
 	for _, cmd := range commands {
-		if query != "" && !strings.Contains(strings.ToLower(cmd.name), query) && !strings.Contains(strings.ToLower(cmd.subtitle), query) {
+		if !matcher.Match(cmd.name, cmd.subtitle) {
 			continue
 		}
 		items = append(items, &LauncherItem{
@@ -432,19 +620,102 @@ func (l *WMLauncher) buildUtilityItems(query string) []*LauncherItem {
 	return items
 }
 
+// wmCtrlAction is a Ctrl+number action on a window or workspace item
+type wmCtrlAction struct {
+	label   string
+	command string
+}
+
+// wmCtrlActions returns the Ctrl+number actions for item, in number order.
+// Window items can be floated, sent to the scratchpad or killed; workspace
+// items can receive the focused window or be renamed after their windows.
+func wmCtrlActions(wmCommand string, item *LauncherItem, windows []WindowInfo) []wmCtrlAction {
+	if conID, ok := item.Metadata["con_id"]; ok {
+		return []wmCtrlAction{
+			{"Toggle floating", fmt.Sprintf("%s '[con_id=%s]' floating toggle", wmCommand, conID)},
+			{"Move to scratchpad", fmt.Sprintf("%s '[con_id=%s]' move scratchpad", wmCommand, conID)},
+			{"Kill", fmt.Sprintf("%s '[con_id=%s]' kill", wmCommand, conID)},
+		}
+	}
+
+	workspace, ok := item.Metadata["workspace"]
+	if !ok {
+		return nil
+	}
+
+	actions := []wmCtrlAction{
+		{"Move focused window here", fmt.Sprintf("%s move container to workspace %s", wmCommand, shellQuote(workspace))},
+	}
+	if name := workspaceNameFromWindows(workspace, windows); name != "" && name != workspace {
+		actions = append(actions, wmCtrlAction{
+			fmt.Sprintf("Rename to %s", name),
+			fmt.Sprintf("%s rename workspace %s to %s", wmCommand, shellQuote(workspace), shellQuote(name)),
+		})
+	}
+	return actions
+}
+
+// workspaceNameFromWindows names a workspace "<number>:<app>" after the first
+// window on it, keeping the number of names like "3" or "3:old"
+func workspaceNameFromWindows(workspace string, windows []WindowInfo) string {
+	for _, win := range windows {
+		if win.Workspace != workspace {
+			continue
+		}
+		app := win.AppID
+		if app == "" {
+			app = win.WindowClass
+		}
+		if app == "" {
+			return ""
+		}
+		if i := strings.LastIndex(app, "."); i >= 0 && i < len(app)-1 {
+			app = app[i+1:]
+		}
+
+		number, _, _ := strings.Cut(workspace, ":")
+		return number + ":" + strings.ToLower(app)
+	}
+	return ""
+}
+
+// CtrlNumberActions describes the Ctrl+number actions for item
+func (l *WMLauncher) CtrlNumberActions(item *LauncherItem) map[int]string {
+	actions := wmCtrlActions(l.wmCommand, item, l.cachedWindows())
+	labels := make(map[int]string, len(actions))
+	for i, action := range actions {
+		labels[i+1] = action.label
+	}
+	return labels
+}
+
 func (l *WMLauncher) GetCtrlNumberAction(number int) (CtrlNumberAction, bool) {
 	return func(item *LauncherItem) error {
-		workspaceName, ok := item.Metadata["workspace"]
-		if !ok {
-			return fmt.Errorf("item is not a workspace")
+		actions := wmCtrlActions(l.wmCommand, item, l.cachedWindows())
+		if len(actions) == 0 {
+			return fmt.Errorf("item is not a window or workspace")
+		}
+		if number < 1 || number > len(actions) {
+			return fmt.Errorf("no Ctrl+%d action for this item", number)
 		}
 
-		cmd := fmt.Sprintf("%s move container to workspace %s", l.wmCommand, workspaceName)
-		shellCmd := exec.Command("sh", "-c", cmd)
-		return shellCmd.Run()
+		_, err := l.runner.RunWithTimeout(wmCommandTimeout, "sh", "-c", actions[number-1].command)
+		return err
 	}, true
 }
 
+func (l *WMLauncher) cachedWindows() []WindowInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.windows
+}
+
+// ResultCacheTTL lets typing reuse the window list briefly instead of asking
+// the compositor on every keystroke
+func (l *WMLauncher) ResultCacheTTL() time.Duration {
+	return 300 * time.Millisecond
+}
+
 func (l *WMLauncher) GetHooks() []Hook {
 	return []Hook{}
 }
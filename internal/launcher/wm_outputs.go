@@ -0,0 +1,305 @@
+package launcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// outputScales are offered as scale changes for each active output
+var outputScales = []float64{1, 1.25, 1.5, 2}
+
+// hyprMonitor is an entry of "hyprctl monitors all -j"
+type hyprMonitor struct {
+	Name           string   `json:"name"`
+	Make           string   `json:"make"`
+	Model          string   `json:"model"`
+	Width          int      `json:"width"`
+	Height         int      `json:"height"`
+	RefreshRate    float64  `json:"refreshRate"`
+	X              int      `json:"x"`
+	Y              int      `json:"y"`
+	Scale          float64  `json:"scale"`
+	Focused        bool     `json:"focused"`
+	Disabled       bool     `json:"disabled"`
+	AvailableModes []string `json:"availableModes"`
+}
+
+// outputCommand is a single action on an output
+type outputCommand struct {
+	title    string
+	subtitle string
+	command  string
+}
+
+// detectOutputBackend returns "hyprctl" under Hyprland and wmCommand otherwise
+func detectOutputBackend(wmCommand string) string {
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" {
+		if _, err := exec.LookPath("hyprctl"); err == nil {
+			return "hyprctl"
+		}
+	}
+	return wmCommand
+}
+
+// ParseHyprlandMonitors parses the reply of "hyprctl monitors all -j"
+func ParseHyprlandMonitors(data []byte) ([]Output, error) {
+	var monitors []hyprMonitor
+	if err := json.Unmarshal(data, &monitors); err != nil {
+		return nil, fmt.Errorf("failed to parse monitors: %w", err)
+	}
+
+	outputs := make([]Output, 0, len(monitors))
+	for _, m := range monitors {
+		output := Output{
+			Name:    m.Name,
+			Make:    m.Make,
+			Model:   m.Model,
+			Active:  !m.Disabled,
+			Focused: m.Focused,
+			Scale:   m.Scale,
+			Rect:    OutputRect{X: m.X, Y: m.Y, Width: m.Width, Height: m.Height},
+			CurrentMode: OutputMode{
+				Width:   m.Width,
+				Height:  m.Height,
+				Refresh: int(m.RefreshRate*1000 + 0.5),
+			},
+		}
+		for _, mode := range m.AvailableModes {
+			if parsed, ok := parseHyprlandMode(mode); ok {
+				output.Modes = append(output.Modes, parsed)
+			}
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// parseHyprlandMode parses a mode such as "2560x1440@143.97Hz"
+func parseHyprlandMode(s string) (OutputMode, bool) {
+	size, rate, found := strings.Cut(strings.TrimSuffix(s, "Hz"), "@")
+	if !found {
+		return OutputMode{}, false
+	}
+	w, h, found := strings.Cut(size, "x")
+	if !found {
+		return OutputMode{}, false
+	}
+
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	refresh, errR := strconv.ParseFloat(rate, 64)
+	if errW != nil || errH != nil || errR != nil {
+		return OutputMode{}, false
+	}
+	return OutputMode{Width: width, Height: height, Refresh: int(refresh*1000 + 0.5)}, true
+}
+
+// outputModes returns the highest refresh mode of each resolution in the
+// order the outputs reported them
+func outputModes(output Output) []OutputMode {
+	var modes []OutputMode
+	index := make(map[[2]int]int)
+	for _, mode := range output.Modes {
+		key := [2]int{mode.Width, mode.Height}
+		if i, ok := index[key]; ok {
+			if mode.Refresh > modes[i].Refresh {
+				modes[i] = mode
+			}
+			continue
+		}
+		index[key] = len(modes)
+		modes = append(modes, mode)
+	}
+	return modes
+}
+
+// formatRefresh formats a refresh rate in mHz as Hz with the given precision
+func formatRefresh(mhz int, precision int) string {
+	return strconv.FormatFloat(float64(mhz)/1000, 'f', precision, 64)
+}
+
+// formatScale formats a scale factor without trailing zeros
+func formatScale(scale float64) string {
+	return strconv.FormatFloat(scale, 'f', -1, 64)
+}
+
+func formatMode(mode OutputMode) string {
+	if mode.Refresh == 0 {
+		return fmt.Sprintf("%dx%d", mode.Width, mode.Height)
+	}
+	return fmt.Sprintf("%dx%d@%sHz", mode.Width, mode.Height, formatRefresh(mode.Refresh, 0))
+}
+
+// outputCommands returns the enable/disable, focus (or set-primary on i3),
+// mode and scale commands for output. backend is a sway-family msg command,
+// i3-msg (outputs are managed with xrandr) or hyprctl.
+func outputCommands(backend string, output Output) []outputCommand {
+	name := output.Name
+
+	if !output.Active {
+		var enable string
+		switch backend {
+		case "hyprctl":
+			enable = fmt.Sprintf("hyprctl keyword monitor %s,preferred,auto,1", name)
+		case "i3-msg":
+			enable = fmt.Sprintf("xrandr --output %s --auto", name)
+		default:
+			enable = fmt.Sprintf("%s output %s enable", backend, name)
+		}
+		return []outputCommand{{title: "Enable Output: " + name, subtitle: "Turn this output on", command: enable}}
+	}
+
+	var commands []outputCommand
+	switch backend {
+	case "hyprctl":
+		commands = append(commands,
+			outputCommand{"Disable Output: " + name, "Turn this output off", fmt.Sprintf("hyprctl keyword monitor %s,disable", name)},
+			outputCommand{"Focus Output: " + name, "Move focus to this output", fmt.Sprintf("hyprctl dispatch focusmonitor %s", name)},
+		)
+	case "i3-msg":
+		commands = append(commands,
+			outputCommand{"Disable Output: " + name, "Turn this output off", fmt.Sprintf("xrandr --output %s --off", name)},
+		)
+		if !output.Primary {
+			commands = append(commands,
+				outputCommand{"Set Primary: " + name, "Make this the primary output", fmt.Sprintf("xrandr --output %s --primary", name)},
+			)
+		}
+	default:
+		commands = append(commands,
+			outputCommand{"Disable Output: " + name, "Turn this output off", fmt.Sprintf("%s output %s disable", backend, name)},
+			outputCommand{"Focus Output: " + name, "Move focus to this output", fmt.Sprintf("%s focus output %s", backend, name)},
+		)
+	}
+
+	current := output.CurrentMode
+	for _, mode := range outputModes(output) {
+		if mode == current {
+			continue
+		}
+
+		var command string
+		switch backend {
+		case "hyprctl":
+			command = fmt.Sprintf("hyprctl keyword monitor %s,%dx%d@%s,auto,%s",
+				name, mode.Width, mode.Height, formatRefresh(mode.Refresh, 2), formatScale(output.Scale))
+		case "i3-msg":
+			command = fmt.Sprintf("xrandr --output %s --mode %dx%d --rate %s",
+				name, mode.Width, mode.Height, formatRefresh(mode.Refresh, 2))
+		default:
+			command = fmt.Sprintf("%s output %s mode %dx%d@%sHz",
+				backend, name, mode.Width, mode.Height, formatRefresh(mode.Refresh, 3))
+		}
+		commands = append(commands, outputCommand{
+			title:    fmt.Sprintf("%s: %s", name, formatMode(mode)),
+			subtitle: "Change resolution",
+			command:  command,
+		})
+	}
+
+	// Scale changes aren't offered on i3, where xrandr scaling isn't HiDPI scaling
+	if backend == "i3-msg" {
+		return commands
+	}
+	for _, scale := range outputScales {
+		if scale == output.Scale {
+			continue
+		}
+
+		var command string
+		if backend == "hyprctl" {
+			command = fmt.Sprintf("hyprctl keyword monitor %s,%dx%d@%s,auto,%s",
+				name, current.Width, current.Height, formatRefresh(current.Refresh, 2), formatScale(scale))
+		} else {
+			command = fmt.Sprintf("%s output %s scale %s", backend, name, formatScale(scale))
+		}
+		commands = append(commands, outputCommand{
+			title:    fmt.Sprintf("%s: Scale %s", name, formatScale(scale)),
+			subtitle: "Change scale",
+			command:  command,
+		})
+	}
+
+	return commands
+}
+
+func (l *WMLauncher) fetchOutputs() ([]Output, error) {
+	if l.outputBackend == "hyprctl" {
+		output, err := l.runner.RunWithTimeout(wmCommandTimeout, "hyprctl", "monitors", "all", "-j")
+		if err != nil {
+			return nil, err
+		}
+		return ParseHyprlandMonitors(output)
+	}
+
+	output, err := l.runner.RunWithTimeout(wmCommandTimeout, l.wmCommand, "-t", "get_outputs")
+	if err != nil {
+		return nil, err
+	}
+	return ParseOutputs(output)
+}
+
+// cachedOutputs returns the outputs fetched since the launcher was last
+// shown, fetching them on first use. Failures are retried on the next call.
+func (l *WMLauncher) cachedOutputs() ([]Output, error) {
+	l.mu.Lock()
+	if l.outputsFetched {
+		outputs := l.outputs
+		l.mu.Unlock()
+		return outputs, nil
+	}
+	l.mu.Unlock()
+
+	outputs, err := l.fetchOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.outputs = outputs
+	l.outputsFetched = true
+	l.mu.Unlock()
+	return outputs, nil
+}
+
+// LauncherShown implements ShowListener
+func (l *WMLauncher) LauncherShown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.outputsFetched = false
+}
+
+func (l *WMLauncher) buildOutputItems(outputs []Output, matcher textMatcher) []*LauncherItem {
+	var items []*LauncherItem
+
+	for _, output := range outputs {
+		description := strings.TrimSpace(output.Make + " " + output.Model)
+		if output.Active && output.CurrentMode.Width > 0 {
+			description = strings.TrimSpace(description + " · " + formatMode(output.CurrentMode))
+		}
+
+		for _, cmd := range outputCommands(l.outputBackend, output) {
+			subtitle := "Monitor · " + cmd.subtitle
+			if description != "" {
+				subtitle += " · " + description
+			}
+			if !matcher.Match(cmd.title, subtitle) {
+				continue
+			}
+			items = append(items, &LauncherItem{
+				Title:      cmd.title,
+				Subtitle:   subtitle,
+				Icon:       "video-display",
+				ActionData: NewShellAction(cmd.command),
+				Launcher:   l,
+				Metadata:   map[string]string{"output": output.Name},
+			})
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,338 @@
+package launcher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/apps"
+)
+
+const sampleOutputs = `[
+	{"name": "eDP-1", "make": "BOE", "model": "0x095F", "active": true, "focused": false, "scale": 1.5,
+	 "rect": {"x": 0, "y": 0, "width": 1707, "height": 1067}},
+	{"name": "DP-2", "make": "Dell Inc.", "model": "U2720Q", "active": true, "focused": true, "scale": 2.0,
+	 "rect": {"x": 1707, "y": 0, "width": 1920, "height": 1080}}
+]`
+
+func TestParseOutputs(t *testing.T) {
+	outputs, err := ParseOutputs([]byte(sampleOutputs))
+	if err != nil {
+		t.Fatalf("ParseOutputs failed: %v", err)
+	}
+
+	if len(outputs) != 2 {
+		t.Fatalf("Expected 2 outputs, got %d", len(outputs))
+	}
+	if outputs[1].Name != "DP-2" || outputs[1].Scale != 2.0 || outputs[1].Rect.X != 1707 {
+		t.Errorf("Unexpected second output: %+v", outputs[1])
+	}
+
+	focused, ok := FocusedOutput(outputs)
+	if !ok {
+		t.Fatal("Expected a focused output")
+	}
+	if focused.Name != "DP-2" {
+		t.Errorf("Expected DP-2 to be focused, got %s", focused.Name)
+	}
+}
+
+func TestFocusedOutputNone(t *testing.T) {
+	outputs, err := ParseOutputs([]byte(`[{"name": "eDP-1", "focused": false}]`))
+	if err != nil {
+		t.Fatalf("ParseOutputs failed: %v", err)
+	}
+	if _, ok := FocusedOutput(outputs); ok {
+		t.Error("Expected no focused output")
+	}
+}
+
+func TestParseOutputsInvalid(t *testing.T) {
+	if _, err := ParseOutputs([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestResolveWindowIcon(t *testing.T) {
+	index := buildAppIconIndex([]apps.App{
+		{Name: "Firefox", Icon: "firefox", File: "/usr/share/applications/firefox.desktop"},
+		{Name: "Files", Icon: "org.gnome.Nautilus", File: "/usr/share/applications/org.gnome.Nautilus.desktop"},
+		{Name: "Visual Studio Code", Icon: "vscode", File: "/usr/share/applications/code.desktop", StartupWMClass: "Code"},
+		{Name: "Alacritty", Icon: "Alacritty", File: "/usr/share/applications/Alacritty.desktop"},
+	})
+
+	tests := []struct {
+		name     string
+		appID    string
+		class    string
+		expected string
+	}{
+		{"desktop id", "org.gnome.Nautilus", "", "org.gnome.Nautilus"},
+		{"app name", "", "firefox", "firefox"},
+		{"startup wm class", "", "Code", "vscode"},
+		{"reverse dns suffix", "io.github.alacritty", "", "Alacritty"},
+		{"unknown class", "", "SomeApp", "someapp"},
+		{"unknown app id", "com.example.Thing", "", "com.example.thing"},
+		{"nothing", "", "", "window-new"},
+	}
+
+	for _, tt := range tests {
+		got := resolveWindowIcon(index, tt.appID, tt.class)
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+const sampleSwayOutputs = `[
+	{"name": "DP-1", "make": "Dell Inc.", "model": "U2720Q", "active": true, "focused": true, "scale": 1.5,
+	 "current_mode": {"width": 3840, "height": 2160, "refresh": 60000},
+	 "modes": [
+		{"width": 3840, "height": 2160, "refresh": 30000},
+		{"width": 3840, "height": 2160, "refresh": 60000},
+		{"width": 2560, "height": 1440, "refresh": 59951}
+	 ]},
+	{"name": "HDMI-A-1", "active": false}
+]`
+
+const sampleHyprMonitors = `[
+	{"name": "eDP-1", "make": "BOE", "model": "0x095F", "width": 2256, "height": 1504,
+	 "refreshRate": 59.999, "x": 0, "y": 0, "scale": 1.25, "focused": true, "disabled": false,
+	 "availableModes": ["2256x1504@60.00Hz", "1920x1080@48.00Hz", "bogus"]},
+	{"name": "DP-3", "width": 0, "height": 0, "refreshRate": 0, "scale": 1, "focused": false, "disabled": true}
+]`
+
+func commandsByTitle(cmds []outputCommand) map[string]string {
+	m := make(map[string]string, len(cmds))
+	for _, c := range cmds {
+		m[c.title] = c.command
+	}
+	return m
+}
+
+func TestParseOutputsModes(t *testing.T) {
+	outputs, err := ParseOutputs([]byte(sampleSwayOutputs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outputs[0].CurrentMode != (OutputMode{3840, 2160, 60000}) || len(outputs[0].Modes) != 3 {
+		t.Errorf("Unexpected modes: %+v", outputs[0])
+	}
+
+	modes := outputModes(outputs[0])
+	if len(modes) != 2 || modes[0].Refresh != 60000 {
+		t.Errorf("Expected the highest refresh per resolution, got %+v", modes)
+	}
+}
+
+func TestParseHyprlandMonitors(t *testing.T) {
+	outputs, err := ParseHyprlandMonitors([]byte(sampleHyprMonitors))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 2 {
+		t.Fatalf("Expected 2 outputs, got %d", len(outputs))
+	}
+
+	edp := outputs[0]
+	if !edp.Active || !edp.Focused || edp.Scale != 1.25 || edp.CurrentMode != (OutputMode{2256, 1504, 59999}) {
+		t.Errorf("Unexpected eDP-1: %+v", edp)
+	}
+	if len(edp.Modes) != 2 || edp.Modes[1] != (OutputMode{1920, 1080, 48000}) {
+		t.Errorf("Unexpected modes: %+v", edp.Modes)
+	}
+	if outputs[1].Active {
+		t.Error("Expected disabled monitor to be inactive")
+	}
+
+	if _, err := ParseHyprlandMonitors([]byte("nope")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestOutputCommands(t *testing.T) {
+	outputs, _ := ParseOutputs([]byte(sampleSwayOutputs))
+
+	sway := commandsByTitle(outputCommands("swaymsg", outputs[0]))
+	want := map[string]string{
+		"Disable Output: DP-1": "swaymsg output DP-1 disable",
+		"Focus Output: DP-1":   "swaymsg focus output DP-1",
+		"DP-1: 2560x1440@60Hz": "swaymsg output DP-1 mode 2560x1440@59.951Hz",
+		"DP-1: Scale 2":        "swaymsg output DP-1 scale 2",
+		"DP-1: Scale 1.25":     "swaymsg output DP-1 scale 1.25",
+	}
+	for title, command := range want {
+		if sway[title] != command {
+			t.Errorf("%s: expected %q, got %q", title, command, sway[title])
+		}
+	}
+	// Current mode and scale, and lower refresh duplicates, are not offered
+	for _, title := range []string{"DP-1: 3840x2160@60Hz", "DP-1: 3840x2160@30Hz", "DP-1: Scale 1.5", "Set Primary: DP-1"} {
+		if _, ok := sway[title]; ok {
+			t.Errorf("Unexpected command %q", title)
+		}
+	}
+
+	disabled := outputCommands("swaymsg", outputs[1])
+	if len(disabled) != 1 || disabled[0].command != "swaymsg output HDMI-A-1 enable" {
+		t.Errorf("Expected only an enable command for a disabled output, got %+v", disabled)
+	}
+
+	i3 := commandsByTitle(outputCommands("i3-msg", outputs[0]))
+	if i3["Set Primary: DP-1"] != "xrandr --output DP-1 --primary" {
+		t.Errorf("Expected xrandr primary command, got %q", i3["Set Primary: DP-1"])
+	}
+	if _, ok := i3["DP-1: Scale 2"]; ok {
+		t.Error("Scale changes should not be offered on i3")
+	}
+
+	monitors, _ := ParseHyprlandMonitors([]byte(sampleHyprMonitors))
+	hypr := commandsByTitle(outputCommands("hyprctl", monitors[0]))
+	if got := hypr["eDP-1: 1920x1080@48Hz"]; got != "hyprctl keyword monitor eDP-1,1920x1080@48.00,auto,1.25" {
+		t.Errorf("Unexpected hyprctl mode command: %q", got)
+	}
+	if got := hypr["eDP-1: Scale 2"]; got != "hyprctl keyword monitor eDP-1,2256x1504@60.00,auto,2" {
+		t.Errorf("Unexpected hyprctl scale command: %q", got)
+	}
+	if got := hypr["Disable Output: eDP-1"]; got != "hyprctl keyword monitor eDP-1,disable" {
+		t.Errorf("Unexpected hyprctl disable command: %q", got)
+	}
+	if got := commandsByTitle(outputCommands("hyprctl", monitors[1]))["Enable Output: DP-3"]; got != "hyprctl keyword monitor DP-3,preferred,auto,1" {
+		t.Errorf("Unexpected hyprctl enable command: %q", got)
+	}
+}
+
+func TestWMCtrlActions(t *testing.T) {
+	windows := []WindowInfo{
+		{Name: "Inbox", ConID: 7, Workspace: "2", AppID: "org.mozilla.Thunderbird"},
+		{Name: "vim", ConID: 9, Workspace: "3:old", WindowClass: "Alacritty"},
+	}
+	l := &WMLauncher{wmCommand: "swaymsg", windows: windows}
+
+	window := &LauncherItem{Metadata: map[string]string{"con_id": "7", "workspace": "2"}}
+	labels := l.CtrlNumberActions(window)
+	if labels[1] != "Toggle floating" || labels[2] != "Move to scratchpad" || labels[3] != "Kill" || len(labels) != 3 {
+		t.Errorf("Unexpected window actions: %v", labels)
+	}
+	actions := wmCtrlActions("swaymsg", window, windows)
+	if actions[1].command != "swaymsg '[con_id=7]' move scratchpad" {
+		t.Errorf("Unexpected scratchpad command: %q", actions[1].command)
+	}
+
+	tests := []struct {
+		workspace string
+		rename    string
+	}{
+		{"2", "swaymsg rename workspace '2' to '2:thunderbird'"},
+		{"3:old", "swaymsg rename workspace '3:old' to '3:alacritty'"},
+		{"5", ""}, // no windows to name it after
+	}
+	for _, tt := range tests {
+		item := &LauncherItem{Metadata: map[string]string{"workspace": tt.workspace}}
+		actions := wmCtrlActions("swaymsg", item, windows)
+		if actions[0].command != "swaymsg move container to workspace '"+tt.workspace+"'" {
+			t.Errorf("%s: unexpected move command %q", tt.workspace, actions[0].command)
+		}
+		if tt.rename == "" {
+			if len(actions) != 1 {
+				t.Errorf("%s: expected no rename action, got %+v", tt.workspace, actions)
+			}
+			continue
+		}
+		if len(actions) != 2 || actions[1].command != tt.rename {
+			t.Errorf("%s: expected rename %q, got %+v", tt.workspace, tt.rename, actions)
+		}
+	}
+
+	if labels := l.CtrlNumberActions(&LauncherItem{Title: "Reload Configuration"}); len(labels) != 0 {
+		t.Errorf("Expected no actions for command items, got %v", labels)
+	}
+
+	action, _ := l.GetCtrlNumberAction(4)
+	if err := action(window); err == nil {
+		t.Error("Expected an error for a number without an action")
+	}
+}
+
+func TestBuildWindowItemsSubtitleSeparator(t *testing.T) {
+	l := &WMLauncher{wmCommand: "swaymsg"}
+	// Skip loading installed apps for icons
+	l.iconIndexOnce.Do(func() {})
+
+	items := l.buildWindowItems([]WindowInfo{
+		{Name: "Inbox", ConID: 7, Workspace: "2", AppID: "org.mozilla.Thunderbird"},
+		{Name: "notes", ConID: 9, Workspace: "3"},
+	}, textMatcher{})
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	if want := "org.mozilla.Thunderbird · 2"; items[0].Subtitle != want {
+		t.Errorf("expected subtitle %q, got %q", want, items[0].Subtitle)
+	}
+	if !strings.ContainsRune(items[0].Subtitle, '\u00b7') || strings.ContainsRune(items[0].Subtitle, '\u00c2') {
+		t.Errorf("subtitle %q does not use a plain middle dot", items[0].Subtitle)
+	}
+	if items[1].Subtitle != "3" {
+		t.Errorf("expected workspace-only subtitle, got %q", items[1].Subtitle)
+	}
+}
+
+func TestWMLauncherFetchesThroughRunner(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{
+		"swaymsg -t get_workspaces": `[{"num": 1, "name": "1", "focused": true}, {"num": 2, "name": "2:web"}]`,
+		"swaymsg -t get_tree": `{"type": "root", "nodes": [{"type": "workspace", "name": "2:web",
+			"nodes": [{"id": 12, "name": "Docs", "window": 3, "app_id": "firefox"}]}]}`,
+		"swaymsg -t get_outputs": sampleOutputs,
+	}}
+	l := &WMLauncher{wmCommand: "swaymsg", outputBackend: "swaymsg", runner: runner}
+
+	workspaces, err := l.fetchWorkspaces()
+	if err != nil || len(workspaces) != 2 || workspaces[1].Name != "2:web" {
+		t.Errorf("fetchWorkspaces() = %+v, %v", workspaces, err)
+	}
+
+	windows, err := l.fetchWindows()
+	if err != nil || len(windows) != 1 || windows[0].ConID != 12 || windows[0].Workspace != "2:web" {
+		t.Errorf("fetchWindows() = %+v, %v", windows, err)
+	}
+
+	outputs, err := l.fetchOutputs()
+	if err != nil || len(outputs) != 2 {
+		t.Errorf("fetchOutputs() = %+v, %v", outputs, err)
+	}
+
+	fetchesOf := func(command string) int {
+		n := 0
+		for _, ran := range runner.ran {
+			if ran == command {
+				n++
+			}
+		}
+		return n
+	}
+	before := fetchesOf("swaymsg -t get_outputs")
+	l.cachedOutputs()
+	l.cachedOutputs()
+	if got := fetchesOf("swaymsg -t get_outputs") - before; got != 1 {
+		t.Errorf("Expected outputs to be fetched once per show, got %d fetches", got)
+	}
+	l.LauncherShown()
+	l.cachedOutputs()
+	if got := fetchesOf("swaymsg -t get_outputs") - before; got != 2 {
+		t.Errorf("Expected outputs to be fetched again after a show, got %d fetches", got)
+	}
+
+	window := &LauncherItem{Metadata: map[string]string{"con_id": "12", "workspace": "2:web"}}
+	action, _ := l.GetCtrlNumberAction(3)
+	action(window)
+	if last := runner.ran[len(runner.ran)-1]; last != "sh -c swaymsg '[con_id=12]' kill" {
+		t.Errorf("Expected the kill action run through the runner, ran %q", last)
+	}
+}
+
+func TestWMLauncherFetchError(t *testing.T) {
+	l := &WMLauncher{wmCommand: "swaymsg", runner: &fakeRunner{}}
+	if _, err := l.fetchWorkspaces(); err == nil {
+		t.Error("Expected an error when the WM command fails")
+	}
+}
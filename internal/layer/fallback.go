@@ -0,0 +1,52 @@
+package layer
+
+// disableEnv forces the regular window fallback even where layer shell works
+const disableEnv = "LOCUS_DISABLE_LAYER_SHELL"
+
+// selectLayerShell decides whether windows become layer surfaces, given
+// whether the compositor supports the protocol and the value of
+// LOCUS_DISABLE_LAYER_SHELL. reason explains a fallback.
+func selectLayerShell(supported bool, disable string) (use bool, reason string) {
+	switch {
+	case disable != "" && disable != "0":
+		return false, disableEnv + " is set"
+	case !supported:
+		return false, "the compositor does not support wlr-layer-shell (or the session is not Wayland)"
+	default:
+		return true, ""
+	}
+}
+
+// Rect is a monitor or window geometry in pixels
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// fallbackGeometry places a regular window of the given size on monitor
+// the way layer shell would with these anchors and margins: anchored to
+// opposite edges it stretches between them, anchored to one it sits against
+// it, and otherwise it is centred.
+func fallbackGeometry(anchors [4]bool, margins [4]int, monitor Rect, width, height int) Rect {
+	x, w := placeAxis(anchors[EdgeLeft], anchors[EdgeRight], margins[EdgeLeft], margins[EdgeRight], monitor.X, monitor.Width, width)
+	y, h := placeAxis(anchors[EdgeTop], anchors[EdgeBottom], margins[EdgeTop], margins[EdgeBottom], monitor.Y, monitor.Height, height)
+	return Rect{X: x, Y: y, Width: w, Height: h}
+}
+
+// placeAxis positions size along one axis of a monitor spanning extent
+// pixels from origin
+func placeAxis(start, end bool, marginStart, marginEnd, origin, extent, size int) (pos, length int) {
+	switch {
+	case start && end:
+		length = extent - marginStart - marginEnd
+		if length < 1 {
+			length = 1
+		}
+		return origin + marginStart, length
+	case start:
+		return origin + marginStart, size
+	case end:
+		return origin + extent - size - marginEnd, size
+	default:
+		return origin + (extent-size)/2, size
+	}
+}
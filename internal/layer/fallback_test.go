@@ -0,0 +1,75 @@
+package layer
+
+import "testing"
+
+func TestSelectLayerShell(t *testing.T) {
+	tests := []struct {
+		name      string
+		supported bool
+		disable   string
+		want      bool
+	}{
+		{"supported", true, "", true},
+		{"unsupported", false, "", false},
+		{"disabled", true, "1", false},
+		{"explicitly enabled", true, "0", true},
+		{"unsupported and enabled", false, "0", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			use, reason := selectLayerShell(tc.supported, tc.disable)
+			if use != tc.want {
+				t.Errorf("Expected layer shell %v, got %v", tc.want, use)
+			}
+			if !use && reason == "" {
+				t.Error("Expected a reason for falling back")
+			}
+		})
+	}
+}
+
+func TestFallbackGeometry(t *testing.T) {
+	monitor := Rect{X: 1920, Y: 0, Width: 1920, Height: 1080}
+
+	tests := []struct {
+		name    string
+		anchors [4]bool
+		margins [4]int
+		want    Rect
+	}{
+		{"centred", [4]bool{}, [4]int{}, Rect{X: 2560, Y: 390, Width: 640, Height: 300}},
+		{
+			"top bar",
+			[4]bool{EdgeLeft: true, EdgeRight: true, EdgeTop: true},
+			[4]int{EdgeLeft: 10, EdgeRight: 10, EdgeTop: 5},
+			Rect{X: 1930, Y: 5, Width: 1900, Height: 300},
+		},
+		{
+			"top right banner",
+			[4]bool{EdgeTop: true, EdgeRight: true},
+			[4]int{EdgeRight: 10},
+			Rect{X: 3190, Y: 0, Width: 640, Height: 300},
+		},
+		{
+			"bottom",
+			[4]bool{EdgeBottom: true},
+			[4]int{EdgeBottom: 20},
+			Rect{X: 2560, Y: 760, Width: 640, Height: 300},
+		},
+		{
+			"fullscreen",
+			[4]bool{true, true, true, true},
+			[4]int{},
+			monitor,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fallbackGeometry(tc.anchors, tc.margins, monitor, 640, 300); got != tc.want {
+				t.Errorf("Expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
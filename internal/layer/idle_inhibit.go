@@ -0,0 +1,130 @@
+package layer
+
+/*
+#cgo pkg-config: gtk+-wayland-3.0 wayland-client
+#include <string.h>
+#include <gtk/gtk.h>
+#include <gdk/gdkwayland.h>
+#include <wayland-client.h>
+
+// Minimal client side of idle-inhibit-unstable-v1, as wayland-scanner would
+// generate it: the manager's create_inhibitor request and both destructors.
+static const struct wl_interface locus_idle_inhibitor_interface;
+
+static const struct wl_interface *locus_idle_inhibit_types[] = {
+	&locus_idle_inhibitor_interface,
+	&wl_surface_interface,
+};
+
+static const struct wl_message locus_idle_inhibit_manager_requests[] = {
+	{ "destroy", "", locus_idle_inhibit_types + 0 },
+	{ "create_inhibitor", "no", locus_idle_inhibit_types + 0 },
+};
+
+static const struct wl_interface locus_idle_inhibit_manager_interface = {
+	"zwp_idle_inhibit_manager_v1", 1, 2, locus_idle_inhibit_manager_requests, 0, NULL,
+};
+
+static const struct wl_message locus_idle_inhibitor_requests[] = {
+	{ "destroy", "", locus_idle_inhibit_types + 0 },
+};
+
+static const struct wl_interface locus_idle_inhibitor_interface = {
+	"zwp_idle_inhibitor_v1", 1, 1, locus_idle_inhibitor_requests, 0, NULL,
+};
+
+static struct wl_proxy *locus_idle_manager = NULL;
+static int locus_idle_checked = 0;
+
+static void locus_idle_registry_global(void *data, struct wl_registry *registry,
+		uint32_t name, const char *interface, uint32_t version) {
+	if (strcmp(interface, locus_idle_inhibit_manager_interface.name) == 0) {
+		locus_idle_manager = wl_registry_bind(registry, name, &locus_idle_inhibit_manager_interface, 1);
+	}
+}
+
+static void locus_idle_registry_global_remove(void *data, struct wl_registry *registry, uint32_t name) {
+}
+
+static const struct wl_registry_listener locus_idle_registry_listener = {
+	locus_idle_registry_global,
+	locus_idle_registry_global_remove,
+};
+
+// Binds the inhibit manager once per process. Returns 0 if the compositor
+// doesn't offer it or GDK isn't running on Wayland.
+static int locus_idle_inhibit_init(GdkDisplay *display) {
+	if (locus_idle_checked) {
+		return locus_idle_manager != NULL;
+	}
+	locus_idle_checked = 1;
+
+	if (!GDK_IS_WAYLAND_DISPLAY(display)) {
+		return 0;
+	}
+
+	struct wl_display *wl_display = gdk_wayland_display_get_wl_display(display);
+	struct wl_registry *registry = wl_display_get_registry(wl_display);
+	wl_registry_add_listener(registry, &locus_idle_registry_listener, NULL);
+	wl_display_roundtrip(wl_display);
+	wl_registry_destroy(registry);
+
+	return locus_idle_manager != NULL;
+}
+
+static void *locus_idle_inhibit_create(GtkWindow *window) {
+	GdkWindow *gdk_window = gtk_widget_get_window(GTK_WIDGET(window));
+	if (gdk_window == NULL || !GDK_IS_WAYLAND_WINDOW(gdk_window)) {
+		return NULL;
+	}
+	if (!locus_idle_inhibit_init(gdk_window_get_display(gdk_window))) {
+		return NULL;
+	}
+
+	struct wl_surface *surface = gdk_wayland_window_get_wl_surface(gdk_window);
+	if (surface == NULL) {
+		return NULL;
+	}
+
+	return wl_proxy_marshal_constructor(locus_idle_manager, 1,
+		&locus_idle_inhibitor_interface, NULL, surface);
+}
+
+static void locus_idle_inhibit_destroy(void *inhibitor) {
+	wl_proxy_marshal((struct wl_proxy *)inhibitor, 0);
+	wl_proxy_destroy((struct wl_proxy *)inhibitor);
+}
+*/
+import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// ErrIdleInhibitUnsupported is returned when the window isn't a mapped Wayland
+// surface or the compositor lacks the idle-inhibit protocol
+var ErrIdleInhibitUnsupported = errors.New("idle inhibit is not supported by the compositor")
+
+// IdleInhibitor keeps the compositor from blanking or locking the screen
+// while its window's surface is visible
+type IdleInhibitor struct {
+	inhibitor unsafe.Pointer
+}
+
+// InhibitIdle creates an idle inhibitor for a realized window
+func InhibitIdle(window unsafe.Pointer) (*IdleInhibitor, error) {
+	inhibitor := C.locus_idle_inhibit_create((*C.GtkWindow)(window))
+	if inhibitor == nil {
+		return nil, ErrIdleInhibitUnsupported
+	}
+	return &IdleInhibitor{inhibitor: inhibitor}, nil
+}
+
+// Release destroys the inhibitor, letting the screen go idle again
+func (i *IdleInhibitor) Release() {
+	if i.inhibitor == nil {
+		return
+	}
+	C.locus_idle_inhibit_destroy(i.inhibitor)
+	i.inhibitor = nil
+}
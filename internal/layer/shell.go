@@ -3,51 +3,213 @@ package layer
 /*
 #cgo pkg-config: gtk-layer-shell-0
 #include <gtk-layer-shell.h>
+
+// Placement of a window that fell back to a regular top-level window,
+// attached to the window so it lives and dies with it
+typedef struct {
+	gboolean anchors[4];
+	int margins[4];
+	GdkMonitor *monitor;
+} LocusLayerFallback;
+
+static const char *locus_fallback_key = "locus-layer-fallback";
+
+static void locus_fallback_free(gpointer data) {
+	LocusLayerFallback *f = data;
+	if (f->monitor != NULL) {
+		g_object_unref(f->monitor);
+	}
+	g_free(f);
+}
+
+static LocusLayerFallback *locus_fallback_get(GtkWindow *window) {
+	return g_object_get_data(G_OBJECT(window), locus_fallback_key);
+}
+
+static void locus_fallback_init(GtkWindow *window) {
+	LocusLayerFallback *f = g_new0(LocusLayerFallback, 1);
+	g_object_set_data_full(G_OBJECT(window), locus_fallback_key, f, locus_fallback_free);
+	gtk_window_set_decorated(window, FALSE);
+	gtk_window_set_skip_taskbar_hint(window, TRUE);
+	gtk_window_set_skip_pager_hint(window, TRUE);
+}
+
+static void locus_fallback_set_monitor(LocusLayerFallback *f, GdkMonitor *monitor) {
+	if (monitor != NULL) {
+		g_object_ref(monitor);
+	}
+	if (f->monitor != NULL) {
+		g_object_unref(f->monitor);
+	}
+	f->monitor = monitor;
+}
+
+// The geometry of the fallback's monitor, or of the primary (else first)
+// monitor when none was set
+static void locus_fallback_monitor_geometry(GtkWindow *window, LocusLayerFallback *f, GdkRectangle *rect) {
+	GdkMonitor *monitor = f->monitor;
+	if (monitor == NULL) {
+		GdkDisplay *display = gtk_widget_get_display(GTK_WIDGET(window));
+		monitor = gdk_display_get_primary_monitor(display);
+		if (monitor == NULL) {
+			monitor = gdk_display_get_monitor(display, 0);
+		}
+	}
+	if (monitor == NULL) {
+		rect->x = rect->y = rect->width = rect->height = 0;
+		return;
+	}
+	gdk_monitor_get_geometry(monitor, rect);
+}
 */
 import "C"
-import "unsafe"
+import (
+	"log"
+	"os"
+	"sync"
+	"unsafe"
+)
+
+var (
+	supportOnce sync.Once
+	supported   bool
+)
+
+// Supported reports whether windows become layer shell surfaces. Without
+// layer shell, InitForWindow makes windows regular undecorated top-level
+// windows, which the other functions place on a best-effort basis.
+func Supported() bool {
+	supportOnce.Do(func() {
+		var reason string
+		supported, reason = selectLayerShell(C.gtk_layer_is_supported() != 0, os.Getenv(disableEnv))
+		if !supported {
+			log.Printf("layer: %s; using regular windows with best-effort positioning", reason)
+		}
+	})
+	return supported
+}
 
-// InitForWindow initializes a window as a layer shell surface
+// fallbackFor returns the fallback placement of window, or nil if window is
+// a layer surface
+func fallbackFor(window unsafe.Pointer) *C.LocusLayerFallback {
+	return C.locus_fallback_get((*C.GtkWindow)(window))
+}
+
+// reposition moves and sizes a fallback window to match its placement
+func reposition(window unsafe.Pointer, f *C.LocusLayerFallback) {
+	w := (*C.GtkWindow)(window)
+
+	var geometry C.GdkRectangle
+	C.locus_fallback_monitor_geometry(w, f, &geometry)
+	monitor := Rect{X: int(geometry.x), Y: int(geometry.y), Width: int(geometry.width), Height: int(geometry.height)}
+	if monitor.Width == 0 || monitor.Height == 0 {
+		return
+	}
+
+	var anchors [4]bool
+	var margins [4]int
+	for i := range anchors {
+		anchors[i] = f.anchors[i] != 0
+		margins[i] = int(f.margins[i])
+	}
+
+	var width, height C.gint
+	C.gtk_window_get_size(w, &width, &height)
+	r := fallbackGeometry(anchors, margins, monitor, int(width), int(height))
+	if r.Width != int(width) || r.Height != int(height) {
+		C.gtk_window_resize(w, C.gint(r.Width), C.gint(r.Height))
+	}
+	C.gtk_window_move(w, C.gint(r.X), C.gint(r.Y))
+}
+
+// InitForWindow initializes a window as a layer shell surface, or as a
+// fallback top-level window when layer shell is unavailable
 func InitForWindow(window unsafe.Pointer) {
+	if !Supported() {
+		C.locus_fallback_init((*C.GtkWindow)(window))
+		return
+	}
 	C.gtk_layer_init_for_window((*C.GtkWindow)(window))
 }
 
-// SetLayer sets the layer for a layer shell surface
+// SetLayer sets the layer for a layer shell surface. Fallback windows in
+// the top and overlay layers are kept above others, the rest below.
 func SetLayer(window unsafe.Pointer, layer Layer) {
+	if fallbackFor(window) != nil {
+		above := layer == LayerTop || layer == LayerOverlay
+		C.gtk_window_set_keep_above((*C.GtkWindow)(window), gboolean(above))
+		C.gtk_window_set_keep_below((*C.GtkWindow)(window), gboolean(!above))
+		return
+	}
 	C.gtk_layer_set_layer((*C.GtkWindow)(window), C.GtkLayerShellLayer(layer))
 }
 
 // SetAnchor sets which edges to anchor the window to
 func SetAnchor(window unsafe.Pointer, edge Edge, anchorTo bool) {
-	var anchor C.gboolean
-	if anchorTo {
-		anchor = 1
+	if f := fallbackFor(window); f != nil {
+		f.anchors[edge] = gboolean(anchorTo)
+		reposition(window, f)
+		return
 	}
-	C.gtk_layer_set_anchor((*C.GtkWindow)(window), C.GtkLayerShellEdge(edge), anchor)
+	C.gtk_layer_set_anchor((*C.GtkWindow)(window), C.GtkLayerShellEdge(edge), gboolean(anchorTo))
 }
 
 // SetExclusiveZone sets the exclusive zone for the surface
 // This prevents other windows from occupying the same space
 func SetExclusiveZone(window unsafe.Pointer, zone int) {
+	if fallbackFor(window) != nil {
+		return
+	}
 	C.gtk_layer_set_exclusive_zone((*C.GtkWindow)(window), C.int(zone))
 }
 
 // AutoExclusiveZoneEnable automatically sets the exclusive zone
 // to match the window's size when anchored to edges
 func AutoExclusiveZoneEnable(window unsafe.Pointer) {
+	if fallbackFor(window) != nil {
+		return
+	}
 	C.gtk_layer_auto_exclusive_zone_enable((*C.GtkWindow)(window))
 }
 
 // SetMargin sets the margin for a specific edge
 func SetMargin(window unsafe.Pointer, edge Edge, margin int) {
+	if f := fallbackFor(window); f != nil {
+		f.margins[edge] = C.int(margin)
+		reposition(window, f)
+		return
+	}
 	C.gtk_layer_set_margin((*C.GtkWindow)(window), C.GtkLayerShellEdge(edge), C.int(margin))
 }
 
-// SetKeyboardMode sets the keyboard interactivity mode
+// SetMonitor sets the monitor (output) the surface is shown on.
+// A nil monitor lets the compositor choose.
+func SetMonitor(window unsafe.Pointer, monitor unsafe.Pointer) {
+	if f := fallbackFor(window); f != nil {
+		C.locus_fallback_set_monitor(f, (*C.GdkMonitor)(monitor))
+		reposition(window, f)
+		return
+	}
+	C.gtk_layer_set_monitor((*C.GtkWindow)(window), (*C.GdkMonitor)(monitor))
+}
+
+// SetKeyboardMode sets the keyboard interactivity mode. Fallback windows
+// with no keyboard interactivity don't accept focus.
 func SetKeyboardMode(window unsafe.Pointer, mode KeyboardMode) {
+	if fallbackFor(window) != nil {
+		C.gtk_window_set_accept_focus((*C.GtkWindow)(window), gboolean(mode != KeyboardModeNone))
+		return
+	}
 	C.gtk_layer_set_keyboard_mode((*C.GtkWindow)(window), C.GtkLayerShellKeyboardMode(mode))
 }
 
+func gboolean(b bool) C.gboolean {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // Layer represents a layer shell layer
 type Layer int
 
@@ -0,0 +1,67 @@
+package lockscreen
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+)
+
+// Lock screen backgrounds: the CSS background color, or a blurred
+// screenshot of what each monitor showed when the screen was locked
+const (
+	BackgroundColor      = "color"
+	BackgroundScreenshot = "screenshot"
+)
+
+// captureTools are the tools that can capture a region of the screen
+var captureTools = []string{"grim"}
+
+// detectCaptureTool returns the first installed capture tool, or "" if none is
+func detectCaptureTool(lookPath func(string) (string, error)) string {
+	for _, tool := range captureTools {
+		if _, err := lookPath(tool); err == nil {
+			return tool
+		}
+	}
+	return ""
+}
+
+// monitorRect is a monitor's position and size in the compositor's
+// logical coordinates
+type monitorRect struct {
+	x, y, width, height int
+}
+
+// String formats the rect as a grim geometry, "X,Y WxH"
+func (r monitorRect) String() string {
+	return fmt.Sprintf("%d,%d %dx%d", r.x, r.y, r.width, r.height)
+}
+
+// captureBackgrounds captures each monitor into dir with tool, returning the
+// screenshot path for each monitor that was captured
+func captureBackgrounds(tool string, monitors []monitorRect, dir string, run func(name string, args ...string) error) map[monitorRect]string {
+	backgrounds := make(map[monitorRect]string, len(monitors))
+	if tool == "" {
+		return backgrounds
+	}
+
+	for i, rect := range monitors {
+		path := filepath.Join(dir, fmt.Sprintf("monitor-%d.png", i))
+		if err := run(tool, "-g", rect.String(), path); err != nil {
+			log.Printf("Failed to capture monitor %s for the lock screen: %v", rect, err)
+			continue
+		}
+		backgrounds[rect] = path
+	}
+	return backgrounds
+}
+
+// backgroundFor returns the screenshot to show behind the lock screen on
+// the monitor at rect, or "" to use the solid color. Monitors connected
+// after locking have no screenshot.
+func backgroundFor(mode string, backgrounds map[monitorRect]string, rect monitorRect) string {
+	if mode != BackgroundScreenshot {
+		return ""
+	}
+	return backgrounds[rect]
+}
@@ -0,0 +1,69 @@
+package lockscreen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDetectCaptureTool(t *testing.T) {
+	installed := func(tools ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, tool := range tools {
+				if tool == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+
+	if tool := detectCaptureTool(installed("grim")); tool != "grim" {
+		t.Errorf("Expected grim, got %q", tool)
+	}
+	if tool := detectCaptureTool(installed("slurp")); tool != "" {
+		t.Errorf("Expected no capture tool, got %q", tool)
+	}
+}
+
+func TestCaptureBackgrounds(t *testing.T) {
+	left := monitorRect{x: 0, y: 0, width: 1920, height: 1080}
+	right := monitorRect{x: 1920, y: 0, width: 2560, height: 1440}
+
+	var commands [][]string
+	run := func(name string, args ...string) error {
+		commands = append(commands, append([]string{name}, args...))
+		if args[1] == right.String() {
+			return errors.New("capture failed")
+		}
+		return nil
+	}
+
+	backgrounds := captureBackgrounds("grim", []monitorRect{left, right}, "/tmp/lock", run)
+	if len(commands) != 2 || commands[0][0] != "grim" || commands[0][2] != "0,0 1920x1080" || commands[0][3] != "/tmp/lock/monitor-0.png" {
+		t.Errorf("Unexpected capture commands %v", commands)
+	}
+	if len(backgrounds) != 1 || backgrounds[left] != "/tmp/lock/monitor-0.png" {
+		t.Errorf("Expected only the left monitor captured, got %v", backgrounds)
+	}
+
+	commands = nil
+	if backgrounds := captureBackgrounds("", []monitorRect{left}, "/tmp/lock", run); len(backgrounds) != 0 || len(commands) != 0 {
+		t.Errorf("Expected no captures without a tool, got %v", backgrounds)
+	}
+}
+
+func TestBackgroundFor(t *testing.T) {
+	left := monitorRect{x: 0, y: 0, width: 1920, height: 1080}
+	added := monitorRect{x: 1920, y: 0, width: 1920, height: 1080}
+	backgrounds := map[monitorRect]string{left: "/tmp/lock/monitor-0.png"}
+
+	if got := backgroundFor(BackgroundScreenshot, backgrounds, left); got != "/tmp/lock/monitor-0.png" {
+		t.Errorf("Expected the monitor's screenshot, got %q", got)
+	}
+	if got := backgroundFor(BackgroundScreenshot, backgrounds, added); got != "" {
+		t.Errorf("Expected the solid color for a monitor without a screenshot, got %q", got)
+	}
+	if got := backgroundFor(BackgroundColor, backgrounds, left); got != "" {
+		t.Errorf("Expected the solid color in color mode, got %q", got)
+	}
+}
@@ -0,0 +1,23 @@
+package lockscreen
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// lockScreenCSS returns the lock screen stylesheet: the inline css followed
+// by the contents of css_file, so rules from the file take precedence
+func lockScreenCSS(cfg config.LockScreenConfig) (string, error) {
+	css := cfg.CSS
+	if cfg.CSSFile == "" {
+		return css, nil
+	}
+
+	data, err := os.ReadFile(cfg.CSSFile)
+	if err != nil {
+		return css, fmt.Errorf("failed to read lock screen css_file: %w", err)
+	}
+	return css + "\n" + string(data), nil
+}
@@ -0,0 +1,41 @@
+package lockscreen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestLockScreenCSS(t *testing.T) {
+	cfg := config.LockScreenConfig{CSS: "#lockscreen-window { color: red; }"}
+
+	css, err := lockScreenCSS(cfg)
+	if err != nil || css != cfg.CSS {
+		t.Errorf("Expected the inline CSS, got %q (%v)", css, err)
+	}
+
+	cfg.CSSFile = filepath.Join(t.TempDir(), "lockscreen.css")
+	if err := os.WriteFile(cfg.CSSFile, []byte("#lockscreen-entry { color: blue; }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	css, err = lockScreenCSS(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inline, file := strings.Index(css, "#lockscreen-window"), strings.Index(css, "#lockscreen-entry")
+	if inline < 0 || file < inline {
+		t.Errorf("Expected the file's CSS after the inline CSS, got %q", css)
+	}
+
+	cfg.CSSFile = filepath.Join(t.TempDir(), "missing.css")
+	css, err = lockScreenCSS(cfg)
+	if err == nil {
+		t.Error("Expected an error for a missing css_file")
+	}
+	if css != cfg.CSS {
+		t.Errorf("Expected the inline CSS when css_file is missing, got %q", css)
+	}
+}
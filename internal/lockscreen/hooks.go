@@ -0,0 +1,49 @@
+package lockscreen
+
+import (
+	"log"
+	"os/exec"
+)
+
+// SetStateCallback registers fn to run whenever the screen locks or
+// unlocks. It is called with the manager's lock held, so fn must not call
+// back into the manager synchronously.
+func (m *LockScreenManager) SetStateCallback(fn func(locked bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onStateChange = fn
+}
+
+// setLocked records a lock or unlock, running the on_lock or on_unlock
+// command and the state callback when the state actually changes
+func (m *LockScreenManager) setLocked(locked bool) {
+	if m.locked == locked {
+		return
+	}
+	m.locked = locked
+
+	command := m.config.LockScreen.OnUnlock
+	if locked {
+		command = m.config.LockScreen.OnLock
+	}
+	if command != "" {
+		m.runHook(command)
+	}
+	if m.onStateChange != nil {
+		m.onStateChange(locked)
+	}
+}
+
+// runHookCommand runs a lock hook through the shell without waiting for it
+func runHookCommand(command string) {
+	cmd := exec.Command("sh", "-c", command)
+	if err := cmd.Start(); err != nil {
+		log.Printf("Failed to run lock screen hook %q: %v", command, err)
+		return
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Printf("Lock screen hook %q failed: %v", command, err)
+		}
+	}()
+}
@@ -0,0 +1,54 @@
+package lockscreen
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestLockHooksFireOnTransitions(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.LockScreen.OnLock = "playerctl pause"
+	cfg.LockScreen.OnUnlock = "playerctl play"
+
+	monitors := 1
+	m := NewLockScreenManager(cfg)
+	fakeScreens(m, &monitors)
+
+	var hooks []string
+	var states []bool
+	m.runHook = func(command string) { hooks = append(hooks, command) }
+	m.SetStateCallback(func(locked bool) { states = append(states, locked) })
+
+	m.lockScreens = m.createWindows()
+	m.setLocked(true)
+	m.setLocked(true)
+	if len(hooks) != 1 || hooks[0] != "playerctl pause" {
+		t.Fatalf("Expected on_lock to run once, got %v", hooks)
+	}
+
+	if err := m.Hide(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Hide(); err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 2 || hooks[1] != "playerctl play" {
+		t.Errorf("Expected on_unlock to run once after on_lock, got %v", hooks)
+	}
+	if len(states) != 2 || !states[0] || states[1] {
+		t.Errorf("Expected lock then unlock callbacks, got %v", states)
+	}
+}
+
+func TestLockHooksUnset(t *testing.T) {
+	m := NewLockScreenManager(&config.Config{})
+	ran := false
+	m.runHook = func(string) { ran = true }
+
+	m.setLocked(true)
+	m.setLocked(false)
+	if ran {
+		t.Error("Expected no hooks to run when none are configured")
+	}
+}
@@ -0,0 +1,23 @@
+package lockscreen
+
+import "github.com/gotk3/gotk3/gdk"
+
+// capsLockOn reports whether Caps Lock is on once a key press with keyval
+// and modifier state is handled. The state is from before the press, so
+// pressing Caps Lock itself flips it.
+func capsLockOn(keyval, state uint) bool {
+	on := state&uint(gdk.LOCK_MASK) != 0
+	if keyval == gdk.KEY_Caps_Lock {
+		return !on
+	}
+	return on
+}
+
+// passwordToggle returns the entry icon and tooltip for toggling the
+// password's visibility
+func passwordToggle(visible bool) (icon, tooltip string) {
+	if visible {
+		return "view-conceal-symbolic", "Hide password"
+	}
+	return "view-reveal-symbolic", "Show password"
+}
@@ -0,0 +1,41 @@
+package lockscreen
+
+import (
+	"testing"
+
+	"github.com/gotk3/gotk3/gdk"
+)
+
+func TestCapsLockOn(t *testing.T) {
+	lock := uint(gdk.LOCK_MASK)
+	shift := uint(gdk.SHIFT_MASK)
+
+	tests := []struct {
+		name   string
+		keyval uint
+		state  uint
+		want   bool
+	}{
+		{"no modifiers", gdk.KEY_a, 0, false},
+		{"lock modifier", gdk.KEY_a, lock, true},
+		{"lock with shift", gdk.KEY_A, lock | shift, true},
+		{"shift only", gdk.KEY_A, shift, false},
+		{"pressing caps lock turns it on", gdk.KEY_Caps_Lock, 0, true},
+		{"pressing caps lock turns it off", gdk.KEY_Caps_Lock, lock, false},
+	}
+
+	for _, tc := range tests {
+		if got := capsLockOn(tc.keyval, tc.state); got != tc.want {
+			t.Errorf("%s: capsLockOn = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestPasswordToggle(t *testing.T) {
+	if icon, tooltip := passwordToggle(false); icon != "view-reveal-symbolic" || tooltip != "Show password" {
+		t.Errorf("hidden password: got %q, %q", icon, tooltip)
+	}
+	if icon, tooltip := passwordToggle(true); icon != "view-conceal-symbolic" || tooltip != "Hide password" {
+		t.Errorf("visible password: got %q, %q", icon, tooltip)
+	}
+}
@@ -1,19 +1,18 @@
 package lockscreen
 
-/*
-#cgo pkg-config: gtk-layer-shell-0
-#include <gtk-layer-shell.h>
-*/
-import "C"
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/exec"
 	"sync"
 	"time"
 	"unsafe"
 
+	"github.com/chess10kp/locus/internal/command"
 	"github.com/chess10kp/locus/internal/config"
 	"github.com/chess10kp/locus/internal/layer"
 	"github.com/gotk3/gotk3/gdk"
@@ -27,6 +26,7 @@ type LockScreenWindow struct {
 	window              *gtk.Window
 	passwordEntry       *gtk.Entry
 	statusLabel         *gtk.Label
+	capsLockLabel       *gtk.Label
 	lockedLabel         *gtk.Label
 	clockLabel          *gtk.Label
 	centerBox           *gtk.Box
@@ -36,23 +36,44 @@ type LockScreenWindow struct {
 	attempts            int
 	maxAttempts         int
 	unlockCallback      func()
+	background          string // screenshot shown behind the UI, "" for the CSS color
 }
 
 type LockScreenManager struct {
-	config         *config.Config
-	lockScreens    []*LockScreenWindow
-	mu             sync.RWMutex
-	locked         bool
-	destroying     bool
-	monitorHandler glib.SignalHandle
+	config          *config.Config
+	lockScreens     []*LockScreenWindow
+	mu              sync.RWMutex
+	locked          bool
+	destroying      bool // a monitor change is waiting to recreate the lock screens
+	monitorHandlers []glib.SignalHandle
+	cssProvider     *gtk.CssProvider
+	backgrounds     map[monitorRect]string // screenshots taken when locking
+	backgroundDir   string
+
+	// Window operations, replaced in tests
+	createWindows func() []*LockScreenWindow
+	showWindow    func(*LockScreenWindow)
+	destroyWindow func(*LockScreenWindow)
+	runHook       func(command string)
+
+	onStateChange func(locked bool)
 }
 
+// errNoLayerShell is returned instead of locking when the compositor has no
+// layer shell to keep the lock screen above, and focused over, other windows
+var errNoLayerShell = errors.New("layer shell is unavailable; refusing to lock with a window other windows can take focus from")
+
 func NewLockScreenManager(cfg *config.Config) *LockScreenManager {
-	return &LockScreenManager{
+	m := &LockScreenManager{
 		config:      cfg,
 		lockScreens: make([]*LockScreenWindow, 0),
 		locked:      false,
 	}
+	m.createWindows = m.createLockScreenWindows
+	m.showWindow = m.showLockScreenWindow
+	m.destroyWindow = destroyLockScreenWindow
+	m.runHook = runHookCommand
+	return m
 }
 
 func (m *LockScreenManager) Show() error {
@@ -69,20 +90,49 @@ func (m *LockScreenManager) Show() error {
 		return nil
 	}
 
+	// Without layer shell the lock screen would be a regular window that
+	// other windows can take focus from
+	if !layer.Supported() {
+		return errNoLayerShell
+	}
+
+	m.applyCSS()
+	if m.config.LockScreen.Background == BackgroundScreenshot {
+		m.captureMonitorBackgrounds()
+	}
+
 	debugLogger.Println("Creating lock screens for all monitors")
 
+	lockScreens := m.createWindows()
+	if len(lockScreens) == 0 {
+		return fmt.Errorf("no monitors available")
+	}
+	m.lockScreens = lockScreens
+
+	m.setLocked(true)
+
+	for _, ls := range m.lockScreens {
+		m.showWindow(ls)
+	}
+
+	m.setupMonitorChangeHandler()
+
+	debugLogger.Println("Lock screen activated")
+	return nil
+}
+
+// createLockScreenWindows creates a lock screen for each current monitor
+func (m *LockScreenManager) createLockScreenWindows() []*LockScreenWindow {
 	display, err := gdk.DisplayGetDefault()
 	if err != nil {
-		return fmt.Errorf("failed to get default display: %w", err)
+		log.Printf("Failed to get default display: %v", err)
+		return nil
 	}
 
 	nMonitors := display.GetNMonitors()
-	if nMonitors == 0 {
-		return fmt.Errorf("no monitors available")
-	}
-
 	debugLogger.Printf("Found %d monitors", nMonitors)
 
+	var lockScreens []*LockScreenWindow
 	for i := 0; i < nMonitors; i++ {
 		monitor, err := display.GetMonitor(i)
 		if err != nil {
@@ -97,22 +147,146 @@ func (m *LockScreenManager) Show() error {
 			continue
 		}
 
-		m.lockScreens = append(m.lockScreens, lockScreen)
+		lockScreens = append(lockScreens, lockScreen)
 		debugLogger.Printf("Created lock screen for monitor %d (input=%v)", i, isInputEnabled)
 	}
+	return lockScreens
+}
 
-	m.locked = true
+// destroyLockScreenWindow hides and destroys a lock screen's window
+func destroyLockScreenWindow(ls *LockScreenWindow) {
+	if ls.window != nil {
+		ls.window.Hide()
+		ls.window.Destroy()
+	}
+}
 
-	for _, ls := range m.lockScreens {
-		m.showLockScreenWindow(ls)
+// applyCSS loads the configured lock screen CSS into a provider on the
+// default screen. The provider is added once and reloaded on each lock so
+// edits to css_file apply without a restart.
+func (m *LockScreenManager) applyCSS() {
+	css, err := lockScreenCSS(m.config.LockScreen)
+	if err != nil {
+		log.Printf("Lock screen CSS: %v", err)
 	}
 
-	m.setupMonitorChangeHandler()
+	if m.cssProvider == nil {
+		provider, err := gtk.CssProviderNew()
+		if err != nil {
+			log.Printf("Failed to create lock screen CSS provider: %v", err)
+			return
+		}
+		screen, err := gdk.ScreenGetDefault()
+		if err != nil {
+			log.Printf("Failed to get default screen for lock screen CSS: %v", err)
+			return
+		}
+		gtk.AddProviderForScreen(screen, provider, gtk.STYLE_PROVIDER_PRIORITY_APPLICATION)
+		m.cssProvider = provider
+	}
 
-	debugLogger.Println("Lock screen activated")
+	if err := m.cssProvider.LoadFromData(css); err != nil {
+		log.Printf("Failed to parse lock screen CSS: %v", err)
+	}
+}
+
+func rectOf(geo *gdk.Rectangle) monitorRect {
+	return monitorRect{x: geo.GetX(), y: geo.GetY(), width: geo.GetWidth(), height: geo.GetHeight()}
+}
+
+// screenshotTimeout bounds capturing the monitors for the background. It
+// is longer than the configured command timeout: capturing a large or
+// scaled output can take a while.
+const screenshotTimeout = 30 * time.Second
+
+// captureMonitorBackgrounds screenshots every monitor for the lock screen
+// backgrounds. It runs before the lock windows exist so they aren't captured.
+func (m *LockScreenManager) captureMonitorBackgrounds() {
+	tool := detectCaptureTool(exec.LookPath)
+	if tool == "" {
+		log.Println("No screen capture tool found (install grim), using the solid lock screen background")
+		return
+	}
+
+	display, err := gdk.DisplayGetDefault()
+	if err != nil {
+		return
+	}
+	var monitors []monitorRect
+	for i := 0; i < display.GetNMonitors(); i++ {
+		if monitor, err := display.GetMonitor(i); err == nil {
+			monitors = append(monitors, rectOf(monitor.GetGeometry()))
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "locus-lock-")
+	if err != nil {
+		log.Printf("Failed to create lock screen background directory: %v", err)
+		return
+	}
+	m.backgroundDir = dir
+	capture := command.Runner{Timeout: screenshotTimeout}
+	m.backgrounds = captureBackgrounds(tool, monitors, dir, capture.Run)
+}
+
+// removeBackgrounds deletes the screenshots taken when locking
+func (m *LockScreenManager) removeBackgrounds() {
+	if m.backgroundDir != "" {
+		os.RemoveAll(m.backgroundDir)
+	}
+	m.backgroundDir = ""
+	m.backgrounds = nil
+}
+
+// addWithBackground adds child to the lock window, over the window's
+// screenshot background when it has one
+func (m *LockScreenManager) addWithBackground(ls *LockScreenWindow, child gtk.IWidget) error {
+	if ls.background == "" {
+		ls.window.Add(child)
+		return nil
+	}
+
+	geo := ls.monitor.GetGeometry()
+	pixbuf, err := blurredPixbuf(ls.background, geo.GetWidth(), geo.GetHeight(), m.config.LockScreen.Blur)
+	if err != nil {
+		return err
+	}
+	image, err := gtk.ImageNewFromPixbuf(pixbuf)
+	if err != nil {
+		return err
+	}
+	overlay, err := gtk.OverlayNew()
+	if err != nil {
+		return err
+	}
+
+	overlay.Add(image)
+	overlay.AddOverlay(child)
+	ls.window.Add(overlay)
+	image.Show()
+	overlay.Show()
 	return nil
 }
 
+// blurredPixbuf loads the screenshot at path scaled to width x height and
+// blurs it by scaling it down by blur and back up. A blur below 2 leaves it
+// sharp.
+func blurredPixbuf(path string, width, height, blur int) (*gdk.Pixbuf, error) {
+	pixbuf, err := gdk.PixbufNewFromFileAtSize(path, width, height)
+	if err != nil {
+		return nil, err
+	}
+	if blur < 2 {
+		return pixbuf, nil
+	}
+
+	small, err := pixbuf.ScaleSimple(max(width/blur, 1), max(height/blur, 1), gdk.INTERP_BILINEAR)
+	if err != nil {
+		return nil, err
+	}
+	return small.ScaleSimple(width, height, gdk.INTERP_BILINEAR)
+}
+
 func (m *LockScreenManager) Hide() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -124,23 +298,15 @@ func (m *LockScreenManager) Hide() error {
 	debugLogger.Println("Hiding all lock screens")
 
 	for _, ls := range m.lockScreens {
-		if ls.window != nil {
-			ls.window.Hide()
-			ls.window.Destroy()
-		}
+		m.destroyWindow(ls)
 	}
 
 	m.lockScreens = make([]*LockScreenWindow, 0)
-	m.locked = false
+	m.setLocked(false)
 	m.destroying = false
 
-	if m.monitorHandler != 0 {
-		display, _ := gdk.DisplayGetDefault()
-		if display != nil {
-			display.HandlerDisconnect(m.monitorHandler)
-			m.monitorHandler = 0
-		}
-	}
+	m.disconnectMonitorHandlers()
+	m.removeBackgrounds()
 
 	debugLogger.Println("Lock screen deactivated")
 	return nil
@@ -157,6 +323,10 @@ func (m *LockScreenManager) UnlockAll() {
 }
 
 func (m *LockScreenManager) createLockScreenWindow(monitor *gdk.Monitor, isInputEnabled bool) (*LockScreenWindow, error) {
+	if !layer.Supported() {
+		return nil, errNoLayerShell
+	}
+
 	window, err := gtk.WindowNew(gtk.WINDOW_TOPLEVEL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create window: %w", err)
@@ -178,6 +348,7 @@ func (m *LockScreenManager) createLockScreenWindow(monitor *gdk.Monitor, isInput
 		isInputEnabled: isInputEnabled,
 		maxAttempts:    m.config.LockScreen.MaxAttempts,
 		attempts:       0,
+		background:     backgroundFor(m.config.LockScreen.Background, m.backgrounds, rectOf(geo)),
 	}
 
 	if m.config.LockScreen.PasswordHash != "" {
@@ -204,7 +375,7 @@ func (m *LockScreenManager) createLockScreenWindow(monitor *gdk.Monitor, isInput
 	layer.SetMargin(windowPtr, layer.EdgeRight, 0)
 	layer.AutoExclusiveZoneEnable(windowPtr)
 
-	C.gtk_layer_set_monitor((*C.GtkWindow)(windowPtr), (*C.GdkMonitor)(unsafe.Pointer(ls.monitor.Native())))
+	layer.SetMonitor(windowPtr, unsafe.Pointer(ls.monitor.Native()))
 
 	if err := m.buildLockScreenUI(ls); err != nil {
 		return nil, fmt.Errorf("failed to build UI: %w", err)
@@ -219,25 +390,16 @@ func (m *LockScreenManager) createLockScreenWindow(monitor *gdk.Monitor, isInput
 func (m *LockScreenManager) buildLockScreenUI(ls *LockScreenWindow) error {
 	debugLogger.Println("=== buildLockScreenUI START ===")
 
-	// Apply CSS provider for lock screen styling
-	cssProvider, err := gtk.CssProviderNew()
-	if err != nil {
-		return err
-	}
-	cssProvider.LoadFromData(m.config.LockScreen.CSS)
-
-	screen, err := gdk.ScreenGetDefault()
-	if err == nil {
-		gtk.AddProviderForScreen(screen, cssProvider, gtk.STYLE_PROVIDER_PRIORITY_APPLICATION)
-	}
-
 	mainBox, err := gtk.BoxNew(gtk.ORIENTATION_VERTICAL, 0)
 	if err != nil {
 		return err
 	}
 	mainBox.SetVAlign(gtk.ALIGN_FILL)
 	mainBox.SetHAlign(gtk.ALIGN_FILL)
-	ls.window.Add(mainBox)
+	if err := m.addWithBackground(ls, mainBox); err != nil {
+		log.Printf("Failed to show lock screen background, using the solid color: %v", err)
+		ls.window.Add(mainBox)
+	}
 
 	centerBox, err := gtk.BoxNew(gtk.ORIENTATION_VERTICAL, 20)
 	if err != nil {
@@ -265,14 +427,15 @@ func (m *LockScreenManager) buildLockScreenUI(ls *LockScreenWindow) error {
 		if err != nil {
 			return err
 		}
-		passwordEntry.SetVisibility(false)
 		passwordEntry.SetPlaceholderText("Enter password to unlock")
 		passwordEntry.SetWidthChars(30)
 		passwordEntry.SetHAlign(gtk.ALIGN_CENTER)
 		passwordEntry.SetMarginStart(20)
 		passwordEntry.SetMarginEnd(20)
 		passwordEntry.SetName("lockscreen-entry")
+		passwordEntry.SetIconActivatable(gtk.ENTRY_ICON_SECONDARY, true)
 		ls.passwordEntry = passwordEntry
+		ls.setPasswordVisible(false)
 
 		debugLogger.Printf("Password entry created: visibility=%v, has-focus=%v", passwordEntry.GetVisible(), passwordEntry.HasFocus())
 
@@ -285,8 +448,18 @@ func (m *LockScreenManager) buildLockScreenUI(ls *LockScreenWindow) error {
 		statusLabel.SetName("lockscreen-status")
 		ls.statusLabel = statusLabel
 
+		capsLockLabel, err := gtk.LabelNew("Caps Lock is on")
+		if err != nil {
+			return err
+		}
+		capsLockLabel.SetHAlign(gtk.ALIGN_CENTER)
+		capsLockLabel.SetName("lockscreen-capslock")
+		capsLockLabel.SetNoShowAll(true)
+		ls.capsLockLabel = capsLockLabel
+
 		centerBox.PackStart(clockLabel, false, false, 0)
 		centerBox.PackStart(passwordEntry, false, false, 0)
+		centerBox.PackStart(capsLockLabel, false, false, 0)
 		centerBox.PackStart(statusLabel, false, false, 0)
 
 		// Explicitly show widgets
@@ -302,6 +475,9 @@ func (m *LockScreenManager) buildLockScreenUI(ls *LockScreenWindow) error {
 		ls.passwordEntry.Connect("changed", func() {
 			ls.statusLabel.SetMarkup("")
 		})
+		ls.passwordEntry.Connect("icon-press", func() {
+			ls.setPasswordVisible(!ls.passwordEntry.GetVisibility())
+		})
 	} else {
 		lockedLabel, err := gtk.LabelNew("Screen Locked")
 		if err != nil {
@@ -335,6 +511,10 @@ func (m *LockScreenManager) setupKeyHandlers(ls *LockScreenWindow) {
 		keyval := keyEvent.KeyVal()
 		state := keyEvent.State()
 
+		if ls.isInputEnabled {
+			ls.setCapsLockWarning(capsLockOn(keyval, state))
+		}
+
 		if ls.isInputEnabled && keyval == gdk.KEY_Escape {
 			ls.passwordEntry.SetText("")
 			return true
@@ -378,6 +558,12 @@ func (m *LockScreenManager) showLockScreenWindow(ls *LockScreenWindow) {
 		_, err := ls.passwordEntry.GetParent()
 		debugLogger.Printf("Password entry: visible=%v, has-parent=%v, parent-error=%v", ls.passwordEntry.GetVisible(), err == nil, err)
 
+		if display, err := gdk.DisplayGetDefault(); err == nil {
+			if keymap, err := display.GetKeymap(); err == nil {
+				ls.setCapsLockWarning(keymap.GetCapsLockState())
+			}
+		}
+
 		// Grab focus after a short delay to ensure widgets are realized
 		glib.TimeoutAdd(100, func() bool {
 			debugLogger.Printf("Password entry in timeout: visible=%v", ls.passwordEntry.GetVisible())
@@ -398,6 +584,27 @@ func (m *LockScreenManager) showLockScreenWindow(ls *LockScreenWindow) {
 	}
 }
 
+// setPasswordVisible shows or hides the typed password and updates the
+// entry's toggle icon to match
+func (ls *LockScreenWindow) setPasswordVisible(visible bool) {
+	icon, tooltip := passwordToggle(visible)
+	ls.passwordEntry.SetVisibility(visible)
+	ls.passwordEntry.SetIconFromIconName(gtk.ENTRY_ICON_SECONDARY, icon)
+	ls.passwordEntry.SetIconTooltipText(gtk.ENTRY_ICON_SECONDARY, tooltip)
+}
+
+// setCapsLockWarning shows the Caps Lock warning while Caps Lock is on
+func (ls *LockScreenWindow) setCapsLockWarning(on bool) {
+	if ls.capsLockLabel == nil {
+		return
+	}
+	if on {
+		ls.capsLockLabel.Show()
+	} else {
+		ls.capsLockLabel.Hide()
+	}
+}
+
 func (m *LockScreenManager) checkPassword(ls *LockScreenWindow) {
 	if !ls.isInputEnabled {
 		return
@@ -439,32 +646,6 @@ func (m *LockScreenManager) updateClock(ls *LockScreenWindow) {
 	ls.clockLabel.SetMarkup(fmt.Sprintf(`<span size="80000">%s</span>`, timeStr))
 }
 
-func (m *LockScreenManager) setupMonitorChangeHandler() {
-	display, err := gdk.DisplayGetDefault()
-	if err != nil {
-		return
-	}
-
-	m.monitorHandler = display.Connect("monitor-added", func(display *gdk.Display, monitor *gdk.Monitor) {
-		m.mu.Lock()
-		defer m.mu.Unlock()
-
-		if !m.locked || m.destroying {
-			return
-		}
-
-		debugLogger.Println("Monitor configuration changed, recreating lock screens")
-		m.destroying = true
-		m.UnlockAll()
-
-		glib.TimeoutAdd(100, func() bool {
-			m.destroying = false
-			m.Show()
-			return false
-		})
-	})
-}
-
 func (m *LockScreenManager) Cleanup() {
 	m.Hide()
 }
@@ -0,0 +1,106 @@
+package lockscreen
+
+import (
+	"log"
+
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+)
+
+// setupMonitorChangeHandler recreates the lock screens when a monitor is
+// added or removed while locked
+func (m *LockScreenManager) setupMonitorChangeHandler() {
+	display, err := gdk.DisplayGetDefault()
+	if err != nil {
+		return
+	}
+
+	onChange := func() {
+		if !m.beginMonitorChange() {
+			return
+		}
+		// Recreate once GDK has finished updating its monitor list
+		glib.IdleAdd(func() bool {
+			m.finishMonitorChange()
+			return false
+		})
+	}
+
+	for _, signal := range []string{"monitor-added", "monitor-removed"} {
+		m.monitorHandlers = append(m.monitorHandlers, display.Connect(signal, onChange))
+	}
+}
+
+func (m *LockScreenManager) disconnectMonitorHandlers() {
+	if len(m.monitorHandlers) == 0 {
+		return
+	}
+	if display, err := gdk.DisplayGetDefault(); err == nil {
+		for _, handler := range m.monitorHandlers {
+			display.HandlerDisconnect(handler)
+		}
+	}
+	m.monitorHandlers = nil
+}
+
+// beginMonitorChange reports whether a monitor change needs the lock
+// screens recreated. Changes arriving before the recreation runs are
+// folded into it.
+func (m *LockScreenManager) beginMonitorChange() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.locked || m.destroying {
+		return false
+	}
+	debugLogger.Println("Monitor configuration changed, recreating lock screens")
+	m.destroying = true
+	return true
+}
+
+// finishMonitorChange recreates the lock screens for the current monitors
+func (m *LockScreenManager) finishMonitorChange() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.destroying {
+		return
+	}
+	m.destroying = false
+	if m.locked {
+		m.recreateLockScreens()
+	}
+}
+
+// recreateLockScreens replaces the lock screens with ones for the current
+// monitors. The new screens are shown before the old ones are destroyed so
+// the session is never left uncovered, and the old ones are kept if no new
+// screen could take the password.
+func (m *LockScreenManager) recreateLockScreens() {
+	lockScreens := m.createWindows()
+	if !hasInputScreen(lockScreens) {
+		log.Printf("No lock screen accepts input after the monitor change, keeping the current ones")
+		for _, ls := range lockScreens {
+			m.destroyWindow(ls)
+		}
+		return
+	}
+
+	old := m.lockScreens
+	m.lockScreens = lockScreens
+	for _, ls := range lockScreens {
+		m.showWindow(ls)
+	}
+	for _, ls := range old {
+		m.destroyWindow(ls)
+	}
+}
+
+func hasInputScreen(lockScreens []*LockScreenWindow) bool {
+	for _, ls := range lockScreens {
+		if ls.isInputEnabled {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package lockscreen
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// fakeScreens makes m create, show and destroy lock screens without GTK,
+// creating one input-enabled screen per monitor
+func fakeScreens(m *LockScreenManager, monitors *int) (shown, destroyed map[*LockScreenWindow]bool) {
+	shown = make(map[*LockScreenWindow]bool)
+	destroyed = make(map[*LockScreenWindow]bool)
+	m.createWindows = func() []*LockScreenWindow {
+		var screens []*LockScreenWindow
+		for i := 0; i < *monitors; i++ {
+			screens = append(screens, &LockScreenWindow{isInputEnabled: true})
+		}
+		return screens
+	}
+	m.showWindow = func(ls *LockScreenWindow) { shown[ls] = true }
+	m.destroyWindow = func(ls *LockScreenWindow) { destroyed[ls] = true }
+	return shown, destroyed
+}
+
+func newLockedManager(t *testing.T, monitors *int) (*LockScreenManager, map[*LockScreenWindow]bool, map[*LockScreenWindow]bool) {
+	t.Helper()
+	m := NewLockScreenManager(&config.Config{})
+	shown, destroyed := fakeScreens(m, monitors)
+	m.lockScreens = m.createWindows()
+	for _, ls := range m.lockScreens {
+		m.showWindow(ls)
+	}
+	m.locked = true
+	return m, shown, destroyed
+}
+
+// assertLocked checks every current screen is shown and none destroyed
+func assertLocked(t *testing.T, m *LockScreenManager, want int, shown, destroyed map[*LockScreenWindow]bool) {
+	t.Helper()
+	if !m.IsLocked() {
+		t.Fatal("Expected the session to stay locked")
+	}
+	if len(m.lockScreens) != want {
+		t.Fatalf("Expected %d lock screens, got %d", want, len(m.lockScreens))
+	}
+	if !hasInputScreen(m.lockScreens) {
+		t.Error("Expected a lock screen that accepts input")
+	}
+	for _, ls := range m.lockScreens {
+		if !shown[ls] || destroyed[ls] {
+			t.Errorf("Expected current lock screens shown and alive, got shown=%v destroyed=%v", shown[ls], destroyed[ls])
+		}
+	}
+}
+
+func TestMonitorAddedRecreatesLockScreens(t *testing.T) {
+	monitors := 1
+	m, shown, destroyed := newLockedManager(t, &monitors)
+	old := m.lockScreens[0]
+
+	monitors = 2
+	if !m.beginMonitorChange() {
+		t.Fatal("Expected a monitor change while locked to recreate the lock screens")
+	}
+	if m.beginMonitorChange() {
+		t.Error("Expected a second change to fold into the pending recreation")
+	}
+	m.finishMonitorChange()
+
+	assertLocked(t, m, 2, shown, destroyed)
+	if !destroyed[old] {
+		t.Error("Expected the old lock screen to be destroyed")
+	}
+	if m.destroying {
+		t.Error("Expected the pending change to be cleared")
+	}
+}
+
+func TestMonitorRemovedRecreatesLockScreens(t *testing.T) {
+	monitors := 2
+	m, shown, destroyed := newLockedManager(t, &monitors)
+	old := append([]*LockScreenWindow(nil), m.lockScreens...)
+
+	monitors = 1
+	if !m.beginMonitorChange() {
+		t.Fatal("Expected a monitor change while locked to recreate the lock screens")
+	}
+	m.finishMonitorChange()
+
+	assertLocked(t, m, 1, shown, destroyed)
+	for _, ls := range old {
+		if !destroyed[ls] {
+			t.Error("Expected the orphaned lock screens to be destroyed")
+		}
+	}
+}
+
+func TestMonitorChangeKeepsScreensWithoutInput(t *testing.T) {
+	monitors := 1
+	m, shown, destroyed := newLockedManager(t, &monitors)
+	old := m.lockScreens[0]
+
+	monitors = 0
+	m.beginMonitorChange()
+	m.finishMonitorChange()
+
+	assertLocked(t, m, 1, shown, destroyed)
+	if m.lockScreens[0] != old {
+		t.Error("Expected the current lock screen to be kept when none can replace it")
+	}
+}
+
+func TestMonitorChangeWhileUnlocked(t *testing.T) {
+	monitors := 1
+	m := NewLockScreenManager(&config.Config{})
+	fakeScreens(m, &monitors)
+
+	if m.beginMonitorChange() {
+		t.Error("Expected monitor changes to be ignored while unlocked")
+	}
+}
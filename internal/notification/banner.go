@@ -7,6 +7,7 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/chess10kp/locus/internal/config"
 	"github.com/chess10kp/locus/internal/launcher"
 	"github.com/chess10kp/locus/internal/layer"
 	"github.com/gotk3/gotk3/gdk"
@@ -30,18 +31,23 @@ type Banner struct {
 	onClose           func(string)
 	onAction          func(string, string)
 	dismissTimer      *time.Timer
+	countdown         *dismissCountdown
 	timeout           int
 	position          *BannerPosition
 	animating         bool
+	dismissed         bool
 	currentMargin     int
 	width             int
 	height            int
 	iconCache         *launcher.IconCache
 	animationDuration int
+	animation         BannerAnimation
+	layout            bannerLayout
+	css               bannerCSS
 	mu                sync.Mutex
 }
 
-func NewBanner(notif *Notification, onClose func(string), onAction func(string, string), width, height, animationDuration int, iconCache *launcher.IconCache) (*Banner, error) {
+func NewBanner(notif *Notification, onClose func(string), onAction func(string, string), width, height, animationDuration int, animation BannerAnimation, layout bannerLayout, maxLifetime int, style config.BannerStyleConfig, iconCache *launcher.IconCache) (*Banner, error) {
 	log.Printf("Creating banner for notification: %s - %s", notif.Summary, notif.Body)
 
 	b := &Banner{
@@ -49,11 +55,14 @@ func NewBanner(notif *Notification, onClose func(string), onAction func(string,
 		onClose:           onClose,
 		onAction:          onAction,
 		timeout:           notif.ExpireTimeout,
-		currentMargin:     -800,
+		currentMargin:     bannerHiddenMargin,
 		width:             width,
 		height:            height,
 		iconCache:         iconCache,
 		animationDuration: animationDuration,
+		animation:         animation,
+		layout:            layout,
+		css:               generateBannerCSS(style, notif.Urgency),
 	}
 
 	if b.width == 0 {
@@ -66,9 +75,8 @@ func NewBanner(notif *Notification, onClose func(string), onAction func(string,
 		b.animationDuration = 200
 	}
 
-	if b.timeout == 0 {
-		b.timeout = 5000
-	}
+	b.timeout = capLifetime(b.timeout, maxLifetime, notif.Urgency)
+	b.countdown = newDismissCountdown(time.Duration(b.timeout) * time.Millisecond)
 
 	log.Printf("Creating banner window...")
 	if err := b.createWindow(); err != nil {
@@ -101,6 +109,56 @@ func NewBanner(notif *Notification, onClose func(string), onAction func(string,
 	return b, nil
 }
 
+// bannerLayout sizes the parts of a banner
+type bannerLayout struct {
+	iconSize int // px
+	padding  int // px around and between the banner's parts
+}
+
+// bannerLayoutFor reads the banner layout from the daemon config, keeping
+// the default config's sizes for values that are unset or out of range
+func bannerLayoutFor(d config.NotificationDaemonConfig) bannerLayout {
+	defaults := config.DefaultConfig.Notification.Daemon
+	layout := bannerLayout{iconSize: d.IconSize, padding: *defaults.Padding}
+	if layout.iconSize <= 0 {
+		layout.iconSize = defaults.IconSize
+	}
+	if d.Padding != nil && *d.Padding >= 0 {
+		layout.padding = *d.Padding
+	}
+	return layout
+}
+
+const (
+	bannerCloseWidth   = 32 // close button beside the text
+	bannerCharWidth    = 7  // average px per character of body text
+	bannerMinTextChars = 10
+)
+
+// bannerTextWidthChars is how many characters of text fit on a line of a
+// banner width px wide, beside the icon, the close button and the padding
+// around and between them
+func bannerTextWidthChars(width int, layout bannerLayout) int {
+	chrome := layout.iconSize + 4*layout.padding + bannerCloseWidth
+	chars := (width - chrome) / bannerCharWidth
+	if chars < bannerMinTextChars {
+		return bannerMinTextChars
+	}
+	return chars
+}
+
+// capLifetime bounds a non-critical banner's timeout by maxLifetime (ms).
+// Banners that would otherwise never expire are capped as well.
+func capLifetime(timeout, maxLifetime int, urgency Urgency) int {
+	if maxLifetime <= 0 || urgency == UrgencyCritical {
+		return timeout
+	}
+	if timeout <= 0 || timeout > maxLifetime {
+		return maxLifetime
+	}
+	return timeout
+}
+
 func (b *Banner) createWindow() error {
 	win, err := gtk.WindowNew(gtk.WINDOW_TOPLEVEL)
 	if err != nil {
@@ -128,28 +186,21 @@ func (b *Banner) setupLayerShell() {
 }
 
 func (b *Banner) buildUI() error {
-	mainBox, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 10)
+	padding := b.layout.padding
+	mainBox, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, padding)
 	if err != nil {
 		return fmt.Errorf("failed to create main box: %w", err)
 	}
 
-	mainBox.SetMarginStart(10)
-	mainBox.SetMarginEnd(10)
-	mainBox.SetMarginTop(10)
-	mainBox.SetMarginBottom(10)
-
-	borderColor := urgencyColors[b.notification.Urgency]
-	css := fmt.Sprintf(`
-		box {
-			background-color: rgba(14, 20, 25, 0.95);
-			border-left: 3px solid %s;
-		}
-	`, borderColor)
+	mainBox.SetMarginStart(padding)
+	mainBox.SetMarginEnd(padding)
+	mainBox.SetMarginTop(padding)
+	mainBox.SetMarginBottom(padding)
 
-	applyCSS(mainBox, css)
+	applyCSS(mainBox, b.css.box)
 
-	if b.notification.AppIcon != "" {
-		iconBox, err := b.createIconBox()
+	if icon := notificationIcon(b.notification); icon.kind != iconSourceNone {
+		iconBox, err := b.createIconBox(icon)
 		if err == nil {
 			mainBox.PackStart(iconBox, false, false, 0)
 		}
@@ -175,12 +226,17 @@ func (b *Banner) buildUI() error {
 	b.container = mainBox
 
 	mainBox.Connect("button-press-event", b.onBannerClicked)
-	mainBox.Connect("enter-notify-event", b.onHoverEnter)
+
+	// The box has no window of its own, so crossings are tracked on the
+	// toplevel
+	b.window.AddEvents(int(gdk.ENTER_NOTIFY_MASK | gdk.LEAVE_NOTIFY_MASK))
+	b.window.Connect("enter-notify-event", b.onHoverEnter)
+	b.window.Connect("leave-notify-event", b.onHoverLeave)
 
 	return nil
 }
 
-func (b *Banner) createIconBox() (*gtk.Box, error) {
+func (b *Banner) createIconBox(icon iconSource) (*gtk.Box, error) {
 	iconBox, err := gtk.BoxNew(gtk.ORIENTATION_VERTICAL, 0)
 	if err != nil {
 		return nil, err
@@ -191,15 +247,27 @@ func (b *Banner) createIconBox() (*gtk.Box, error) {
 		return nil, err
 	}
 
-	image.SetPixelSize(48)
+	size := b.layout.iconSize
+	image.SetPixelSize(size)
 
-	iconName := b.notification.AppIcon
-	if iconName == "" {
-		iconName = "dialog-information"
+	switch icon.kind {
+	case iconSourceImageData:
+		if pixbuf, err := imageDataPixbuf(icon.image, size); err == nil {
+			image.SetFromPixbuf(pixbuf)
+		} else {
+			log.Printf("Failed to load notification image data: %v", err)
+		}
+	case iconSourceFile:
+		if pixbuf, err := gdk.PixbufNewFromFileAtScale(icon.name, size, size, true); err == nil {
+			image.SetFromPixbuf(pixbuf)
+		} else {
+			log.Printf("Failed to load notification image %s: %v", icon.name, err)
+			b.loadIconAsync(image, "dialog-information", size)
+		}
+	default:
+		b.loadIconAsync(image, icon.name, size)
 	}
 
-	b.loadIconAsync(image, iconName, 48)
-
 	iconBox.PackStart(image, false, false, 0)
 
 	return iconBox, nil
@@ -217,39 +285,30 @@ func (b *Banner) createContentBox() (*gtk.Box, error) {
 	}
 
 	titleLabel.SetHAlign(gtk.ALIGN_START)
+	widthChars := bannerTextWidthChars(b.width, b.layout)
 	titleLabel.SetLineWrap(true)
-	titleLabel.SetMaxWidthChars(40)
+	titleLabel.SetLineWrapMode(pango.WRAP_WORD_CHAR)
+	titleLabel.SetMaxWidthChars(widthChars)
 	titleLabel.SetEllipsize(pango.ELLIPSIZE_END)
 
-	titleCSS := `
-		label {
-			font-weight: bold;
-			font-size: 16px;
-			color: #f8f8f2;
-		}
-	`
-	applyCSS(titleLabel, titleCSS)
+	applyCSS(titleLabel, b.css.title)
 	contentBox.PackStart(titleLabel, false, false, 0)
 
 	if b.notification.Body != "" {
-		bodyLabel, err := gtk.LabelNew(b.notification.Body)
+		bodyLabel, err := gtk.LabelNew("")
 		if err != nil {
 			return nil, err
 		}
+		bodyLabel.SetMarkup(sanitizeBodyMarkup(b.notification.Body))
 
 		bodyLabel.SetHAlign(gtk.ALIGN_START)
 		bodyLabel.SetLineWrap(true)
-		bodyLabel.SetMaxWidthChars(40)
+		bodyLabel.SetLineWrapMode(pango.WRAP_WORD_CHAR)
+		bodyLabel.SetMaxWidthChars(widthChars)
 		bodyLabel.SetLines(3)
 		bodyLabel.SetEllipsize(pango.ELLIPSIZE_END)
 
-		bodyCSS := `
-			label {
-				font-size: 14px;
-				color: #f8f8f2;
-			}
-		`
-		applyCSS(bodyLabel, bodyCSS)
+		applyCSS(bodyLabel, b.css.body)
 		contentBox.PackStart(bodyLabel, false, false, 0)
 	}
 
@@ -261,13 +320,7 @@ func (b *Banner) createContentBox() (*gtk.Box, error) {
 	appLabel.SetHAlign(gtk.ALIGN_START)
 	appLabel.SetSensitive(false)
 
-	appCSS := `
-		label {
-			font-size: 12px;
-			color: #6272a4;
-		}
-	`
-	applyCSS(appLabel, appCSS)
+	applyCSS(appLabel, b.css.app)
 	contentBox.PackStart(appLabel, false, false, 0)
 
 	return contentBox, nil
@@ -347,21 +400,32 @@ func (b *Banner) Show() {
 	log.Printf("Banner.Show() completed - window should be visible")
 }
 
+// inhibitIdle keeps the screen awake while the banner's surface is visible.
+// The window must already be shown.
+func (b *Banner) inhibitIdle() (idleInhibitor, error) {
+	inhibitor, err := layer.InhibitIdle(unsafe.Pointer(b.window.GObject))
+	if err != nil {
+		return nil, err
+	}
+	return inhibitor, nil
+}
+
 func (b *Banner) Dismiss() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if b.animating {
+	if b.dismissed {
 		return
 	}
+	b.dismissed = true
 
 	b.stopDismissTimerLocked()
-	b.animateOut(func() {
-		b.window.Destroy()
-		if b.onClose != nil {
-			b.onClose(b.notification.ID)
-		}
-	})
+	b.countdown.Cancel()
+	if b.animating {
+		// animateIn hands over to animateOut once it finishes
+		return
+	}
+	b.animateOutLocked()
 }
 
 func (b *Banner) UpdatePosition(position BannerPosition) {
@@ -390,11 +454,15 @@ func (b *Banner) startDismissTimer() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	b.scheduleDismissLocked(b.countdown.Start())
+}
+
+func (b *Banner) scheduleDismissLocked(delay time.Duration) {
 	if b.dismissTimer != nil {
 		b.dismissTimer.Stop()
 	}
 
-	b.dismissTimer = time.AfterFunc(time.Duration(b.timeout)*time.Millisecond, func() {
+	b.dismissTimer = time.AfterFunc(delay, func() {
 		glib.IdleAdd(func() {
 			b.Dismiss()
 		})
@@ -410,64 +478,64 @@ func (b *Banner) stopDismissTimerLocked() {
 
 func (b *Banner) animateIn() {
 	b.mu.Lock()
-	b.animating = true
-	b.currentMargin = -800
-	targetMargin := 10
-	startMargin := b.currentMargin
-	steps := b.animationDuration / 16
-	if steps < 1 {
-		steps = 1
-	}
-	stepSize := (targetMargin - startMargin) / steps
-	obj := unsafe.Pointer(b.window.GObject)
-	b.mu.Unlock()
-
-	glib.TimeoutAdd(16, func() bool {
-		b.mu.Lock()
-		defer b.mu.Unlock()
+	defer b.mu.Unlock()
 
-		if b.currentMargin < targetMargin {
-			b.currentMargin = min(b.currentMargin+stepSize, targetMargin)
-			layer.SetMargin(obj, layer.EdgeRight, b.currentMargin)
-			return true
+	b.animateLocked(true, func() {
+		if b.dismissed {
+			b.animateOutLocked()
 		}
+	})
+}
 
-		b.animating = false
-		return false
+func (b *Banner) animateOutLocked() {
+	b.animateLocked(false, func() {
+		b.window.Destroy()
+		if b.onClose != nil {
+			b.onClose(b.notification.ID)
+		}
 	})
 }
 
-func (b *Banner) animateOut(callback func()) {
-	b.mu.Lock()
+// animateLocked steps the banner through its show or hide animation, then
+// calls done with b.mu held
+func (b *Banner) animateLocked(showing bool, done func()) {
 	b.animating = true
-	startMargin := b.currentMargin
-	targetMargin := -800
-	steps := b.animationDuration / 16
-	if steps < 1 {
-		steps = 1
-	}
-	stepSize := (startMargin - targetMargin) / steps
-	obj := unsafe.Pointer(b.window.GObject)
-	b.mu.Unlock()
+	steps := bannerSteps(b.animationDuration)
+	step := 0
+	b.applyFrameLocked(b.animation.frameAt(step, steps, showing))
 
-	glib.TimeoutAdd(16, func() bool {
+	glib.TimeoutAdd(bannerFrameInterval, func() bool {
 		b.mu.Lock()
 		defer b.mu.Unlock()
 
-		if b.currentMargin > targetMargin {
-			b.currentMargin = max(b.currentMargin-stepSize, targetMargin)
-			layer.SetMargin(obj, layer.EdgeRight, b.currentMargin)
+		step++
+		b.applyFrameLocked(b.animation.frameAt(step, steps, showing))
+		if step < steps {
 			return true
 		}
 
 		b.animating = false
-		if callback != nil {
-			callback()
-		}
+		done()
 		return false
 	})
 }
 
+func (b *Banner) applyFrameLocked(f bannerFrame) {
+	b.currentMargin = f.margin
+	layer.SetMargin(unsafe.Pointer(b.window.GObject), layer.EdgeRight, f.margin)
+	b.window.SetOpacity(f.opacity)
+	if b.animation == BannerAnimationScale && b.container != nil {
+		// The window keeps its size, so the styled box is drawn inset
+		// from its edges to look smaller
+		x, y := f.inset(b.width, b.height)
+		padding := b.layout.padding
+		b.container.SetMarginStart(padding + x)
+		b.container.SetMarginEnd(padding + x)
+		b.container.SetMarginTop(padding + y)
+		b.container.SetMarginBottom(padding + y)
+	}
+}
+
 func (b *Banner) onCloseClicked() {
 	b.Dismiss()
 }
@@ -476,11 +544,35 @@ func (b *Banner) onBannerClicked() {
 	b.Dismiss()
 }
 
+// onHoverEnter pauses auto-dismissal while the pointer is over the banner.
+// Critical banners never auto-dismiss, so they are left alone.
 func (b *Banner) onHoverEnter() {
-	if b.notification.Urgency != UrgencyCritical {
-		b.mu.Lock()
+	if b.notification.Urgency == UrgencyCritical {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.countdown.Pause() {
 		b.stopDismissTimerLocked()
-		b.mu.Unlock()
+	}
+}
+
+// onHoverLeave resumes a paused dismissal with the remaining time, extended
+// to at least hoverResumeMin
+func (b *Banner) onHoverLeave(_ *gtk.Window, event *gdk.Event) {
+	// Moving onto a child such as a button isn't leaving the banner
+	if gdk.EventCrossingNewFromEvent(event).Detail() == gdk.NOTIFY_INFERIOR {
+		return
+	}
+	if b.notification.Urgency == UrgencyCritical {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if delay, ok := b.countdown.Resume(); ok {
+		b.scheduleDismissLocked(delay)
 	}
 }
 
@@ -503,6 +595,17 @@ func loadImageIcon(iconName string, size int) (*gdk.Pixbuf, error) {
 	return iconTheme.LoadIcon(iconName, size, gtk.ICON_LOOKUP_FORCE_SIZE)
 }
 
+// imageDataPixbuf converts image-data hint pixels to a pixbuf scaled to fit
+// size, keeping its aspect ratio
+func imageDataPixbuf(img *ImageData, size int) (*gdk.Pixbuf, error) {
+	pixbuf, err := gdk.PixbufNewFromBytes(img.Data, gdk.COLORSPACE_RGB, img.HasAlpha, img.BitsPerSample, img.Width, img.Height, img.Rowstride)
+	if err != nil {
+		return nil, err
+	}
+	scale := float64(size) / float64(max(img.Width, img.Height))
+	return pixbuf.ScaleSimple(max(int(float64(img.Width)*scale), 1), max(int(float64(img.Height)*scale), 1), gdk.INTERP_BILINEAR)
+}
+
 func (b *Banner) loadIconAsync(image *gtk.Image, iconName string, size int) {
 	if b.iconCache != nil {
 		go func() {
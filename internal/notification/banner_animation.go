@@ -0,0 +1,75 @@
+package notification
+
+import "math"
+
+// BannerAnimation is how banners appear and disappear
+type BannerAnimation string
+
+const (
+	BannerAnimationSlide BannerAnimation = "slide" // slide in from the screen edge
+	BannerAnimationFade  BannerAnimation = "fade"  // fade the window in place
+	BannerAnimationScale BannerAnimation = "scale" // grow the content from bannerScaleStart while fading
+)
+
+const (
+	bannerFrameInterval = 16 // ms between animation steps
+	bannerHiddenMargin  = -800
+	bannerShownMargin   = 10
+	bannerScaleStart    = 0.8
+)
+
+// bannerAnimationFor returns the animation named in the config, sliding
+// for unknown names
+func bannerAnimationFor(name string) BannerAnimation {
+	switch BannerAnimation(name) {
+	case BannerAnimationFade, BannerAnimationScale:
+		return BannerAnimation(name)
+	}
+	return BannerAnimationSlide
+}
+
+// bannerSteps is the number of animation steps taken over duration (ms)
+func bannerSteps(duration int) int {
+	if steps := duration / bannerFrameInterval; steps > 1 {
+		return steps
+	}
+	return 1
+}
+
+// bannerFrame is the state of a banner window at one animation step
+type bannerFrame struct {
+	margin  int // right margin
+	opacity float64
+	scale   float64 // fraction of the banner's size
+}
+
+// frameAt returns the banner state after step of steps of showing, or of
+// hiding when showing is false
+func (a BannerAnimation) frameAt(step, steps int, showing bool) bannerFrame {
+	t := float64(step) / float64(steps)
+	if t > 1 {
+		t = 1
+	}
+	if !showing {
+		t = 1 - t
+	}
+
+	f := bannerFrame{margin: bannerShownMargin, opacity: 1, scale: 1}
+	switch a {
+	case BannerAnimationFade:
+		f.opacity = t
+	case BannerAnimationScale:
+		f.opacity = t
+		f.scale = bannerScaleStart + (1-bannerScaleStart)*t
+	default:
+		f.margin = bannerHiddenMargin + int(float64(bannerShownMargin-bannerHiddenMargin)*t)
+	}
+	return f
+}
+
+// inset is how far the banner's content is drawn in from each side of a
+// width by height window to appear at the frame's scale
+func (f bannerFrame) inset(width, height int) (x, y int) {
+	shrink := (1 - f.scale) / 2
+	return int(math.Round(float64(width) * shrink)), int(math.Round(float64(height) * shrink))
+}
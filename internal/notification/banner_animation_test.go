@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBannerAnimationFor(t *testing.T) {
+	tests := map[string]BannerAnimation{
+		"":      BannerAnimationSlide,
+		"slide": BannerAnimationSlide,
+		"fade":  BannerAnimationFade,
+		"scale": BannerAnimationScale,
+		"spin":  BannerAnimationSlide,
+	}
+	for name, want := range tests {
+		if got := bannerAnimationFor(name); got != want {
+			t.Errorf("bannerAnimationFor(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestBannerSteps(t *testing.T) {
+	tests := []struct {
+		duration, want int
+	}{
+		{200, 12},
+		{16, 1},
+		{0, 1},
+	}
+	for _, tt := range tests {
+		if got := bannerSteps(tt.duration); got != tt.want {
+			t.Errorf("bannerSteps(%d) = %d, want %d", tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestBannerFrameInset(t *testing.T) {
+	if x, y := (bannerFrame{scale: bannerScaleStart}).inset(400, 100); x != 40 || y != 10 {
+		t.Errorf("inset at the start of the scale = %d, %d; want 40, 10", x, y)
+	}
+	if x, y := (bannerFrame{scale: 1}).inset(400, 100); x != 0 || y != 0 {
+		t.Errorf("inset at full scale = %d, %d; want 0, 0", x, y)
+	}
+}
+
+func TestBannerFrameProgression(t *testing.T) {
+	const steps = 4
+	tests := []struct {
+		name      string
+		animation BannerAnimation
+		step      int
+		showing   bool
+		want      bannerFrame
+	}{
+		{"slide in start", BannerAnimationSlide, 0, true, bannerFrame{bannerHiddenMargin, 1, 1}},
+		{"slide in half", BannerAnimationSlide, 2, true, bannerFrame{-395, 1, 1}},
+		{"slide in end", BannerAnimationSlide, steps, true, bannerFrame{bannerShownMargin, 1, 1}},
+		{"slide out end", BannerAnimationSlide, steps, false, bannerFrame{bannerHiddenMargin, 1, 1}},
+		{"fade in start", BannerAnimationFade, 0, true, bannerFrame{bannerShownMargin, 0, 1}},
+		{"fade in quarter", BannerAnimationFade, 1, true, bannerFrame{bannerShownMargin, 0.25, 1}},
+		{"fade out quarter", BannerAnimationFade, 1, false, bannerFrame{bannerShownMargin, 0.75, 1}},
+		{"scale in start", BannerAnimationScale, 0, true, bannerFrame{bannerShownMargin, 0, bannerScaleStart}},
+		{"scale in half", BannerAnimationScale, 2, true, bannerFrame{bannerShownMargin, 0.5, 0.9}},
+		{"scale out end", BannerAnimationScale, steps, false, bannerFrame{bannerShownMargin, 0, bannerScaleStart}},
+		{"past the end", BannerAnimationFade, steps + 3, true, bannerFrame{bannerShownMargin, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.animation.frameAt(tt.step, steps, tt.showing)
+			if got.margin != tt.want.margin || math.Abs(got.opacity-tt.want.opacity) > 1e-9 || math.Abs(got.scale-tt.want.scale) > 1e-9 {
+				t.Errorf("frameAt(%d, %d, %v) = %+v, want %+v", tt.step, steps, tt.showing, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// bannerCSS holds the CSS applied to each part of a banner
+type bannerCSS struct {
+	box   string
+	title string
+	body  string
+	app   string
+}
+
+// accentColor returns the configured accent for urgency, falling back to
+// the built-in urgency colors
+func accentColor(style config.BannerStyleConfig, urgency Urgency) string {
+	var color string
+	switch urgency {
+	case UrgencyLow:
+		color = style.LowColor
+	case UrgencyCritical:
+		color = style.CriticalColor
+	default:
+		color = style.NormalColor
+	}
+	if color == "" {
+		color = urgencyColors[urgency]
+	}
+	return color
+}
+
+// bannerStyleWithDefaults fills colors left empty and sizes left at 0 from
+// the default style, so a config without [notification.daemon.style] still
+// renders styled banners. A negative border width hides the accent.
+func bannerStyleWithDefaults(style config.BannerStyleConfig) config.BannerStyleConfig {
+	defaults := config.DefaultConfig.Notification.Daemon.Style
+	if style.Background == "" {
+		style.Background = defaults.Background
+	}
+	if style.Foreground == "" {
+		style.Foreground = defaults.Foreground
+	}
+	if style.AppColor == "" {
+		style.AppColor = defaults.AppColor
+	}
+	if style.BorderRadius == 0 {
+		style.BorderRadius = defaults.BorderRadius
+	}
+	if style.BorderWidth == 0 {
+		style.BorderWidth = defaults.BorderWidth
+	} else if style.BorderWidth < 0 {
+		style.BorderWidth = 0
+	}
+	if style.TitleFontSize == 0 {
+		style.TitleFontSize = defaults.TitleFontSize
+	}
+	if style.BodyFontSize == 0 {
+		style.BodyFontSize = defaults.BodyFontSize
+	}
+	if style.AppFontSize == 0 {
+		style.AppFontSize = defaults.AppFontSize
+	}
+	return style
+}
+
+// generateBannerCSS builds a banner's CSS from the configured style, with
+// the urgency accent drawn as the left border
+func generateBannerCSS(style config.BannerStyleConfig, urgency Urgency) bannerCSS {
+	style = bannerStyleWithDefaults(style)
+	return bannerCSS{
+		box: fmt.Sprintf(`
+		box {
+			background-color: %s;
+			border-radius: %dpx;
+			border-left: %dpx solid %s;
+		}
+	`, style.Background, style.BorderRadius, style.BorderWidth, accentColor(style, urgency)),
+		title: fmt.Sprintf(`
+		label {
+			font-weight: bold;
+			font-size: %dpx;
+			color: %s;
+		}
+	`, style.TitleFontSize, style.Foreground),
+		body: fmt.Sprintf(`
+		label {
+			font-size: %dpx;
+			color: %s;
+		}
+	`, style.BodyFontSize, style.Foreground),
+		app: fmt.Sprintf(`
+		label {
+			font-size: %dpx;
+			color: %s;
+		}
+	`, style.AppFontSize, style.AppColor),
+	}
+}
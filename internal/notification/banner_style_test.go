@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestGenerateBannerCSSDefaults(t *testing.T) {
+	style := config.DefaultConfig.Notification.Daemon.Style
+
+	for urgency, color := range urgencyColors {
+		css := generateBannerCSS(style, urgency)
+		if !strings.Contains(css.box, "border-left: 3px solid "+color) {
+			t.Errorf("urgency %d: expected default accent %s in %q", urgency, color, css.box)
+		}
+		if !strings.Contains(css.box, "background-color: rgba(14, 20, 25, 0.95)") {
+			t.Errorf("urgency %d: expected default background in %q", urgency, css.box)
+		}
+	}
+
+	css := generateBannerCSS(style, UrgencyNormal)
+	if !strings.Contains(css.title, "font-size: 16px") || !strings.Contains(css.body, "font-size: 14px") || !strings.Contains(css.app, "font-size: 12px") {
+		t.Errorf("unexpected default font sizes: %q %q %q", css.title, css.body, css.app)
+	}
+}
+
+func TestGenerateBannerCSSConfigured(t *testing.T) {
+	style := config.BannerStyleConfig{
+		Background:    "#282828",
+		Foreground:    "#ebdbb2",
+		AppColor:      "#928374",
+		BorderRadius:  8,
+		BorderWidth:   5,
+		LowColor:      "#b8bb26",
+		CriticalColor: "#fb4934",
+		TitleFontSize: 18,
+		BodyFontSize:  13,
+		AppFontSize:   10,
+	}
+
+	css := generateBannerCSS(style, UrgencyCritical)
+	for _, want := range []string{"background-color: #282828", "border-radius: 8px", "border-left: 5px solid #fb4934"} {
+		if !strings.Contains(css.box, want) {
+			t.Errorf("box CSS missing %q: %q", want, css.box)
+		}
+	}
+	if !strings.Contains(css.title, "font-size: 18px") || !strings.Contains(css.title, "color: #ebdbb2") {
+		t.Errorf("unexpected title CSS: %q", css.title)
+	}
+	if !strings.Contains(css.body, "font-size: 13px") || !strings.Contains(css.body, "color: #ebdbb2") {
+		t.Errorf("unexpected body CSS: %q", css.body)
+	}
+	if !strings.Contains(css.app, "font-size: 10px") || !strings.Contains(css.app, "color: #928374") {
+		t.Errorf("unexpected app CSS: %q", css.app)
+	}
+
+	if css := generateBannerCSS(style, UrgencyLow); !strings.Contains(css.box, "solid #b8bb26") {
+		t.Errorf("expected configured low accent: %q", css.box)
+	}
+	// Normal has no configured color, so it keeps the built-in accent
+	if css := generateBannerCSS(style, UrgencyNormal); !strings.Contains(css.box, "solid "+urgencyColors[UrgencyNormal]) {
+		t.Errorf("expected built-in normal accent: %q", css.box)
+	}
+}
+
+func TestGenerateBannerCSSUnset(t *testing.T) {
+	// A config without [notification.daemon.style] renders like the defaults
+	defaults := config.DefaultConfig.Notification.Daemon.Style
+	for urgency := range urgencyColors {
+		got := generateBannerCSS(config.BannerStyleConfig{}, urgency)
+		want := generateBannerCSS(defaults, urgency)
+		if got != want {
+			t.Errorf("urgency %d: expected default CSS %+v, got %+v", urgency, want, got)
+		}
+	}
+
+	hidden := generateBannerCSS(config.BannerStyleConfig{BorderWidth: -1}, UrgencyNormal)
+	if !strings.Contains(hidden.box, "border-left: 0px solid") {
+		t.Errorf("expected a negative border width to hide the accent: %q", hidden.box)
+	}
+}
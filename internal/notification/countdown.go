@@ -0,0 +1,73 @@
+package notification
+
+import "time"
+
+// hoverResumeMin is the least time a banner stays up after the pointer
+// leaves it, so a banner read until its last moment isn't dismissed at once
+const hoverResumeMin = 1500 * time.Millisecond
+
+// dismissCountdown tracks how long a banner has left before auto-dismissal,
+// pausing while it is hovered
+type dismissCountdown struct {
+	remaining time.Duration
+	deadline  time.Time
+	running   bool
+	paused    bool
+	now       func() time.Time
+}
+
+func newDismissCountdown(timeout time.Duration) *dismissCountdown {
+	return &dismissCountdown{remaining: timeout, now: time.Now}
+}
+
+// Start begins counting down and returns the time left
+func (c *dismissCountdown) Start() time.Duration {
+	c.deadline = c.now().Add(c.remaining)
+	c.running = true
+	c.paused = false
+	return c.remaining
+}
+
+// Pause stops the countdown, keeping the time left. It reports whether a
+// running countdown was paused.
+func (c *dismissCountdown) Pause() bool {
+	if !c.running {
+		return false
+	}
+
+	c.remaining = c.deadline.Sub(c.now())
+	if c.remaining < 0 {
+		c.remaining = 0
+	}
+	c.running = false
+	c.paused = true
+	return true
+}
+
+// Resume restarts a paused countdown, extended to at least hoverResumeMin,
+// and returns the time left. ok is false if the countdown wasn't paused.
+func (c *dismissCountdown) Resume() (time.Duration, bool) {
+	if !c.paused {
+		return 0, false
+	}
+	if c.remaining < hoverResumeMin {
+		c.remaining = hoverResumeMin
+	}
+	return c.Start(), true
+}
+
+// Cancel stops the countdown for good; it can't be resumed
+func (c *dismissCountdown) Cancel() {
+	c.running = false
+	c.paused = false
+}
+
+// Running returns whether the countdown is currently running
+func (c *dismissCountdown) Running() bool {
+	return c.running
+}
+
+// Paused returns whether the countdown is paused by a hover
+func (c *dismissCountdown) Paused() bool {
+	return c.paused
+}
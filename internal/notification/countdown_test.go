@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestCountdown(timeout time.Duration) (*dismissCountdown, *time.Time) {
+	now := time.Now()
+	c := newDismissCountdown(timeout)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestDismissCountdownPauseResume(t *testing.T) {
+	c, now := newTestCountdown(5 * time.Second)
+
+	if got := c.Start(); got != 5*time.Second {
+		t.Fatalf("Start() = %v, want 5s", got)
+	}
+
+	// Hover enter after 2s keeps the 3s left
+	*now = now.Add(2 * time.Second)
+	if !c.Pause() {
+		t.Fatal("expected running countdown to pause")
+	}
+	if c.Running() || !c.Paused() {
+		t.Errorf("after pause: running=%v paused=%v", c.Running(), c.Paused())
+	}
+
+	// Time spent hovering doesn't count
+	*now = now.Add(10 * time.Second)
+	left, ok := c.Resume()
+	if !ok || left != 3*time.Second {
+		t.Errorf("Resume() = (%v, %v), want (3s, true)", left, ok)
+	}
+	if !c.Running() || c.Paused() {
+		t.Errorf("after resume: running=%v paused=%v", c.Running(), c.Paused())
+	}
+}
+
+func TestDismissCountdownResumeExtends(t *testing.T) {
+	c, now := newTestCountdown(2 * time.Second)
+	c.Start()
+
+	*now = now.Add(1900 * time.Millisecond)
+	c.Pause()
+
+	left, ok := c.Resume()
+	if !ok || left != hoverResumeMin {
+		t.Errorf("Resume() = (%v, %v), want (%v, true)", left, ok, hoverResumeMin)
+	}
+}
+
+func TestDismissCountdownRepeatedHover(t *testing.T) {
+	c, now := newTestCountdown(5 * time.Second)
+	c.Start()
+
+	*now = now.Add(time.Second)
+	c.Pause()
+	// A second enter while already paused changes nothing
+	if c.Pause() {
+		t.Error("pausing twice should be a no-op")
+	}
+	left, _ := c.Resume()
+	if left != 4*time.Second {
+		t.Errorf("first resume = %v, want 4s", left)
+	}
+
+	*now = now.Add(time.Second)
+	c.Pause()
+	left, _ = c.Resume()
+	if left != 3*time.Second {
+		t.Errorf("second resume = %v, want 3s", left)
+	}
+}
+
+func TestDismissCountdownNotStarted(t *testing.T) {
+	// Banners without a timer (critical or no timeout) never start counting,
+	// so hovering must not schedule a dismissal
+	c, _ := newTestCountdown(5 * time.Second)
+	if c.Pause() {
+		t.Error("countdown that never started should not pause")
+	}
+	if _, ok := c.Resume(); ok {
+		t.Error("countdown that never started should not resume")
+	}
+}
+
+func TestDismissCountdownCancel(t *testing.T) {
+	c, _ := newTestCountdown(5 * time.Second)
+	c.Start()
+	c.Pause()
+	c.Cancel()
+
+	if _, ok := c.Resume(); ok {
+		t.Error("cancelled countdown should not resume")
+	}
+}
@@ -1,8 +1,10 @@
 package notification
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"os/exec"
 	"sync"
 	"time"
 
@@ -11,6 +13,14 @@ import (
 	"github.com/gotk3/gotk3/glib"
 )
 
+var (
+	// ErrNoSuchAction is returned when re-invoking an action a notification doesn't offer
+	ErrNoSuchAction = errors.New("notification has no such action")
+	// ErrAppNotRunning is returned when the app that sent a notification has
+	// left the bus and can't be relaunched
+	ErrAppNotRunning = errors.New("application that sent the notification is no longer running")
+)
+
 type Daemon struct {
 	conn         *dbus.Conn
 	store        *Store
@@ -18,17 +28,20 @@ type Daemon struct {
 	nextID       uint32
 	activeNotifs map[uint32]string
 	config       *config.NotificationConfig
+	limiter      *rateLimiter
 	mu           sync.Mutex
 	running      bool
+	busID        string // the session bus's ID, recorded with each sender
 }
 
 func NewDaemon(store *Store, queue *Queue, cfg *config.NotificationConfig) *Daemon {
 	return &Daemon{
 		store:        store,
 		queue:        queue,
-		nextID:       1,
+		nextID:       store.nextBusID(),
 		activeNotifs: make(map[uint32]string),
 		config:       cfg,
+		limiter:      newRateLimiter(cfg.Daemon.RateLimitBurst, time.Duration(cfg.Daemon.RateLimitInterval)*time.Millisecond),
 		running:      false,
 	}
 }
@@ -64,6 +77,10 @@ func (d *Daemon) Start() error {
 		return fmt.Errorf("name already owned by another process")
 	}
 
+	if err := conn.BusObject().Call("org.freedesktop.DBus.GetId", 0).Store(&d.busID); err != nil {
+		log.Printf("Failed to get the session bus ID, actions from history will relaunch apps: %v", err)
+	}
+
 	d.running = true
 
 	log.Println("Notification daemon started on org.freedesktop.Notifications")
@@ -92,7 +109,24 @@ func (d *Daemon) Stop() error {
 	return nil
 }
 
+// admit stores a notification and reports whether its banner should be shown
+func (d *Daemon) admit(notif *Notification) bool {
+	log.Printf("Adding notification to store...")
+	if err := d.store.AddNotification(notif); err != nil {
+		log.Printf("Failed to add notification to store: %v", err)
+	} else {
+		log.Printf("Successfully added notification to store")
+	}
+
+	if d.store.DoNotDisturb() && notif.Urgency != UrgencyCritical {
+		return false
+	}
+
+	return d.limiter.Allow(notif.AppName, notif.Urgency)
+}
+
 func (d *Daemon) Notify(
+	sender dbus.Sender,
 	appName string,
 	replacesID uint32,
 	appIcon string,
@@ -125,12 +159,7 @@ func (d *Daemon) Notify(
 		}
 	}
 
-	timeout := int(expireTimeout)
-	if timeout == -1 || urgency == UrgencyCritical {
-		timeout = -1
-	} else if timeout == 0 {
-		timeout = 5000
-	}
+	timeout := resolveExpireTimeout(expireTimeout, urgency, d.config.Timeouts)
 
 	actionList := make([]Action, 0)
 	for i := 0; i < len(actions); i += 2 {
@@ -149,6 +178,9 @@ func (d *Daemon) Notify(
 		}
 	}
 
+	imageData := imageDataHint(hints, imageDataHints, appName)
+	iconData := imageDataHint(hints, iconDataHints, appName)
+
 	if replacesID > 0 {
 		if oldNotifID, exists := d.activeNotifs[replacesID]; exists {
 			d.store.RemoveNotification(oldNotifID)
@@ -173,18 +205,21 @@ func (d *Daemon) Notify(
 		Urgency:       urgency,
 		Read:          false,
 		ReplacesID:    replacesID,
+		Bus:           &BusInfo{Sender: string(sender), NotificationID: notifID, BusID: d.busID},
+		ImageData:     imageData,
+		IconData:      iconData,
 	}
 
-	log.Printf("Adding notification to store...")
-	if err := d.store.AddNotification(notif); err != nil {
-		log.Printf("Failed to add notification to store: %v", err)
-	} else {
-		log.Printf("Successfully added notification to store")
-	}
+	showBanner := d.admit(notif)
 
 	d.activeNotifs[notifID] = notificationID
 	log.Printf("Active notifications count: %d", len(d.activeNotifs))
 
+	if !showBanner {
+		log.Printf("Suppressing banner for app=%s (do not disturb or rate limited)", appName)
+		return notifID, nil
+	}
+
 	log.Printf("Queueing notification for display...")
 	glib.IdleAdd(func() {
 		log.Printf("Showing notification banner...")
@@ -199,6 +234,50 @@ func (d *Daemon) Notify(
 	return notifID, nil
 }
 
+// imageDataHint returns the image from the first of keys that holds a valid
+// one, logging any that don't
+func imageDataHint(hints map[string]dbus.Variant, keys []string, appName string) *ImageData {
+	for _, key := range keys {
+		variant, ok := hints[key]
+		if !ok {
+			continue
+		}
+		img, err := parseImageData(variant.Value())
+		if err != nil {
+			log.Printf("Ignoring %s hint from %s: %v", key, appName, err)
+			continue
+		}
+		return img
+	}
+	return nil
+}
+
+// resolveExpireTimeout maps Notify's expire_timeout to a banner timeout in
+// ms, -1 meaning never. Per the spec -1 asks for the server default (the
+// configured timeout for the urgency) and 0 for no expiry. Critical
+// notifications never expire.
+func resolveExpireTimeout(expireTimeout int32, urgency Urgency, timeouts config.NotificationTimeoutsConfig) int {
+	if urgency == UrgencyCritical {
+		return -1
+	}
+
+	switch {
+	case expireTimeout == 0:
+		return -1
+	case expireTimeout > 0:
+		return int(expireTimeout)
+	}
+
+	timeout := timeouts.Normal
+	if urgency == UrgencyLow {
+		timeout = timeouts.Low
+	}
+	if timeout <= 0 {
+		return -1
+	}
+	return timeout
+}
+
 func (d *Daemon) CloseNotification(id uint32) *dbus.Error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -222,7 +301,6 @@ func (d *Daemon) GetCapabilities() ([]string, *dbus.Error) {
 		"body-markup",
 		"icon-static",
 		"persistence",
-		"sound",
 	}
 	return capabilities, nil
 }
@@ -257,6 +335,71 @@ func (d *Daemon) emitActionInvoked(id uint32, actionKey string) {
 	}
 }
 
+// emitActionInvokedTo sends ActionInvoked to a single bus client rather than
+// broadcasting it, so a stale ID can't trigger another app's notification
+func (d *Daemon) emitActionInvokedTo(destination string, id uint32, actionKey string) error {
+	msg := &dbus.Message{
+		Type: dbus.TypeSignal,
+		Headers: map[dbus.HeaderField]dbus.Variant{
+			dbus.FieldPath:        dbus.MakeVariant(dbus.ObjectPath("/org/freedesktop/Notifications")),
+			dbus.FieldInterface:   dbus.MakeVariant("org.freedesktop.Notifications"),
+			dbus.FieldMember:      dbus.MakeVariant("ActionInvoked"),
+			dbus.FieldDestination: dbus.MakeVariant(destination),
+			dbus.FieldSignature:   dbus.MakeVariant(dbus.SignatureOf(id, actionKey)),
+		},
+		Body: []interface{}{id, actionKey},
+	}
+	return d.conn.Send(msg, nil).Err
+}
+
+// senderRunning reports whether a unique bus name is still connected
+func (d *Daemon) senderRunning(sender string) bool {
+	var hasOwner bool
+	err := d.conn.BusObject().Call("org.freedesktop.DBus.NameHasOwner", 0, sender).Store(&hasOwner)
+	return err == nil && hasOwner
+}
+
+// InvokeStoredAction re-invokes an action of a notification from history,
+// which may predate this daemon instance. If the sending app has left the
+// bus, or sent it during an earlier session, it is relaunched from its
+// desktop-entry hint when there is one.
+func (d *Daemon) InvokeStoredAction(notifID, actionKey string) error {
+	notif, ok := d.store.GetNotification(notifID)
+	if !ok {
+		return fmt.Errorf("notification '%s' not found", notifID)
+	}
+	if !notif.HasAction(actionKey) {
+		return ErrNoSuchAction
+	}
+
+	d.mu.Lock()
+	running, busID := d.running, d.busID
+	d.mu.Unlock()
+	if !running || d.conn == nil {
+		return fmt.Errorf("daemon not running")
+	}
+
+	// A sender from an earlier session is gone even if its name is taken
+	if !notif.Bus.sameBus(busID) || !d.senderRunning(notif.Bus.Sender) {
+		entry := notif.Hints["desktop-entry"]
+		if entry == "" {
+			return ErrAppNotRunning
+		}
+		log.Printf("Sender of notification %s is gone, launching %s instead", notifID, entry)
+		if err := exec.Command("gtk-launch", entry).Start(); err != nil {
+			return fmt.Errorf("%w: failed to launch %s: %v", ErrAppNotRunning, entry, err)
+		}
+		d.store.MarkActionInvoked(notifID, actionKey)
+		return nil
+	}
+
+	if err := d.emitActionInvokedTo(notif.Bus.Sender, notif.Bus.NotificationID, actionKey); err != nil {
+		return fmt.Errorf("failed to invoke action: %w", err)
+	}
+	d.store.MarkActionInvoked(notifID, actionKey)
+	return nil
+}
+
 func generateID() string {
 	return fmt.Sprintf("notif-%d-%d", time.Now().UnixNano(), time.Now().Unix())
 }
@@ -0,0 +1,209 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/chess10kp/locus/internal/config"
+	"github.com/godbus/dbus/v5"
+)
+
+func newTestDaemon(t *testing.T) (*Daemon, *Store) {
+	t.Helper()
+	store := newTestStore(t)
+	cfg := config.DefaultConfig.Notification
+	queue := NewQueue(store, 5, 10, 100, 400, 200, CornerTopRight, nil)
+
+	d := NewDaemon(store, queue, &cfg)
+	// Serve calls directly without claiming the bus name
+	d.running = true
+	return d, store
+}
+
+func TestDaemonNotifyAndClose(t *testing.T) {
+	d, store := newTestDaemon(t)
+
+	id, dbusErr := d.Notify(":1.7", "Mail", 0, "mail-unread", "New message", "Hello",
+		[]string{"default", "Open"}, map[string]dbus.Variant{"urgency": dbus.MakeVariant(byte(1))}, -1)
+	if dbusErr != nil {
+		t.Fatalf("Notify() error = %v", dbusErr)
+	}
+	if id == 0 {
+		t.Fatal("Notify() returned id 0")
+	}
+
+	notifs := store.GetNotifications(0)
+	if len(notifs) != 1 {
+		t.Fatalf("expected 1 stored notification, got %d", len(notifs))
+	}
+	notif := notifs[0]
+	if notif.Summary != "New message" || notif.AppName != "Mail" {
+		t.Errorf("stored notification = %+v", notif)
+	}
+	if !notif.HasAction("default") {
+		t.Error("expected the default action to be stored")
+	}
+	if notif.Bus == nil || notif.Bus.Sender != ":1.7" || notif.Bus.NotificationID != id {
+		t.Errorf("bus info = %+v, want sender :1.7 id %d", notif.Bus, id)
+	}
+	if notif.ExpireTimeout != config.DefaultConfig.Notification.Timeouts.Normal {
+		t.Errorf("ExpireTimeout = %d, want server default %d", notif.ExpireTimeout, config.DefaultConfig.Notification.Timeouts.Normal)
+	}
+
+	second, _ := d.Notify(":1.7", "Mail", 0, "", "Another", "", nil, nil, 0)
+	if second == id {
+		t.Errorf("second notification reused id %d", id)
+	}
+
+	if dbusErr := d.CloseNotification(id); dbusErr != nil {
+		t.Fatalf("CloseNotification() error = %v", dbusErr)
+	}
+	if _, ok := store.GetNotification(notif.ID); ok {
+		t.Error("CloseNotification() did not remove the notification")
+	}
+	if got := len(store.GetNotifications(0)); got != 1 {
+		t.Errorf("expected the other notification to remain, got %d", got)
+	}
+
+	// Closing an unknown id is not an error
+	if dbusErr := d.CloseNotification(9999); dbusErr != nil {
+		t.Errorf("CloseNotification(unknown) error = %v", dbusErr)
+	}
+}
+
+func TestDaemonNotifyReplacesID(t *testing.T) {
+	d, store := newTestDaemon(t)
+
+	id, _ := d.Notify(":1.7", "Volume", 0, "", "Volume 40%", "", nil, nil, -1)
+	replaced, _ := d.Notify(":1.7", "Volume", id, "", "Volume 50%", "", nil, nil, -1)
+	if replaced != id {
+		t.Errorf("replacing notification got id %d, want %d", replaced, id)
+	}
+
+	notifs := store.GetNotifications(0)
+	if len(notifs) != 1 || notifs[0].Summary != "Volume 50%" {
+		t.Errorf("expected only the replacement in the store, got %d entries", len(notifs))
+	}
+}
+
+func TestDaemonNotifyNotRunning(t *testing.T) {
+	d, _ := newTestDaemon(t)
+	d.running = false
+
+	if _, dbusErr := d.Notify(":1.7", "App", 0, "", "Summary", "", nil, nil, -1); dbusErr == nil {
+		t.Error("expected Notify to fail while the daemon is stopped")
+	}
+}
+
+func TestResolveExpireTimeout(t *testing.T) {
+	timeouts := config.NotificationTimeoutsConfig{Low: 3000, Normal: 5000, Critical: -1}
+
+	tests := []struct {
+		name    string
+		expire  int32
+		urgency Urgency
+		want    int
+	}{
+		{"server default normal", -1, UrgencyNormal, 5000},
+		{"server default low", -1, UrgencyLow, 3000},
+		{"never expire", 0, UrgencyNormal, -1},
+		{"explicit", 1200, UrgencyLow, 1200},
+		{"critical never expires", 1200, UrgencyCritical, -1},
+		{"critical default", -1, UrgencyCritical, -1},
+	}
+
+	for _, tt := range tests {
+		if got := resolveExpireTimeout(tt.expire, tt.urgency, timeouts); got != tt.want {
+			t.Errorf("%s: resolveExpireTimeout(%d) = %d, want %d", tt.name, tt.expire, got, tt.want)
+		}
+	}
+
+	if got := resolveExpireTimeout(-1, UrgencyNormal, config.NotificationTimeoutsConfig{}); got != -1 {
+		t.Errorf("unset default timeout = %d, want -1 (never)", got)
+	}
+}
+
+func TestDaemonCapabilities(t *testing.T) {
+	d, _ := newTestDaemon(t)
+
+	caps, dbusErr := d.GetCapabilities()
+	if dbusErr != nil {
+		t.Fatalf("GetCapabilities() error = %v", dbusErr)
+	}
+
+	have := make(map[string]bool)
+	for _, c := range caps {
+		have[c] = true
+	}
+	for _, want := range []string{"body", "body-hyperlinks", "body-markup", "actions", "icon-static", "persistence"} {
+		if !have[want] {
+			t.Errorf("missing capability %q in %v", want, caps)
+		}
+	}
+
+	name, vendor, _, spec, _ := d.GetServerInformation()
+	if name == "" || vendor == "" || spec != "1.2" {
+		t.Errorf("GetServerInformation() = %q, %q, spec %q", name, vendor, spec)
+	}
+}
+
+func TestDaemonNotifyImageData(t *testing.T) {
+	d, store := newTestDaemon(t)
+
+	hints := map[string]dbus.Variant{
+		"image_data": dbus.MakeVariant(imageDataValue(1, 1, false, []byte{1, 2, 3})),
+		"image-data": dbus.MakeVariant(imageDataValue(1, 1, true, []byte{1, 2, 3, 4})),
+	}
+	if _, dbusErr := d.Notify(":1.7", "Chat", 0, "chat", "Photo", "", nil, hints, -1); dbusErr != nil {
+		t.Fatalf("Notify() error = %v", dbusErr)
+	}
+
+	notif := store.GetNotifications(0)[0]
+	if notif.ImageData == nil || !notif.ImageData.HasAlpha {
+		t.Fatalf("expected the image-data hint to win over image_data, got %+v", notif.ImageData)
+	}
+	if icon := notificationIcon(notif); icon.kind != iconSourceImageData {
+		t.Errorf("expected the banner to use the image data, got %+v", icon)
+	}
+
+	legacy := map[string]dbus.Variant{"icon_data": dbus.MakeVariant(imageDataValue(1, 1, false, []byte{1, 2, 3}))}
+	d.Notify(":1.7", "Chat", 0, "chat", "Legacy", "", nil, legacy, -1)
+	for _, n := range store.GetNotifications(0) {
+		if n.Summary != "Legacy" {
+			continue
+		}
+		if n.ImageData != nil || n.IconData == nil {
+			t.Errorf("expected icon_data to be kept apart from image-data, got %+v / %+v", n.ImageData, n.IconData)
+		}
+		if icon := notificationIcon(n); icon.kind != iconSourceName || icon.name != "chat" {
+			t.Errorf("expected app_icon to win over icon_data, got %+v", icon)
+		}
+	}
+
+	bad := map[string]dbus.Variant{"image-data": dbus.MakeVariant("not an image")}
+	d.Notify(":1.7", "Chat", 0, "chat", "Broken", "", nil, bad, -1)
+	for _, n := range store.GetNotifications(0) {
+		if n.Summary == "Broken" && n.ImageData != nil {
+			t.Error("expected invalid image data to be ignored")
+		}
+	}
+}
+
+func TestBusInfoSameBus(t *testing.T) {
+	tests := []struct {
+		bus   *BusInfo
+		busID string
+		want  bool
+	}{
+		{&BusInfo{Sender: ":1.42", BusID: "4f2a"}, "4f2a", true},
+		{&BusInfo{Sender: ":1.42", BusID: "4f2a"}, "9c1b", false}, // earlier session
+		{&BusInfo{Sender: ":1.42"}, "4f2a", false},                // recorded without a bus ID
+		{&BusInfo{BusID: "4f2a"}, "4f2a", false},
+		{nil, "4f2a", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bus.sameBus(tt.busID); got != tt.want {
+			t.Errorf("%+v.sameBus(%q) = %v, want %v", tt.bus, tt.busID, got, tt.want)
+		}
+	}
+}
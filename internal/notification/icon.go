@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ImageData is a raw image sent in the image-data hint, laid out like a
+// GdkPixbuf
+type ImageData struct {
+	Width         int
+	Height        int
+	Rowstride     int
+	HasAlpha      bool
+	BitsPerSample int
+	Channels      int
+	Data          []byte
+}
+
+// imageDataHints are the hints that may carry raw image data, newest name
+// first; image_data is from an older spec version
+var imageDataHints = []string{"image-data", "image_data"}
+
+// iconDataHints carry raw image data from the oldest spec version, which
+// ranks it below app_icon
+var iconDataHints = []string{"icon_data"}
+
+// imagePathHints are the hints that may carry an image path or icon name,
+// newest name first
+var imagePathHints = []string{"image-path", "image_path"}
+
+// parseImageData decodes an image-data hint value, the D-Bus struct
+// (iiibiiay)
+func parseImageData(value interface{}) (*ImageData, error) {
+	fields, ok := value.([]interface{})
+	if !ok || len(fields) != 7 {
+		return nil, fmt.Errorf("image data is not an (iiibiiay) struct")
+	}
+
+	var ints [6]int32
+	for i, idx := range []int{0, 1, 2, 4, 5} {
+		v, ok := fields[idx].(int32)
+		if !ok {
+			return nil, fmt.Errorf("image data field %d is not an int32", idx)
+		}
+		ints[i] = v
+	}
+	hasAlpha, ok := fields[3].(bool)
+	if !ok {
+		return nil, fmt.Errorf("image data field 3 is not a bool")
+	}
+	data, ok := fields[6].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("image data field 6 is not a byte array")
+	}
+
+	img := &ImageData{
+		Width:         int(ints[0]),
+		Height:        int(ints[1]),
+		Rowstride:     int(ints[2]),
+		HasAlpha:      hasAlpha,
+		BitsPerSample: int(ints[3]),
+		Channels:      int(ints[4]),
+		Data:          data,
+	}
+	if err := img.validate(); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// validate checks the image is one GdkPixbuf can hold and that Data is
+// large enough for its dimensions
+func (d *ImageData) validate() error {
+	if d.Width <= 0 || d.Height <= 0 {
+		return fmt.Errorf("invalid image size %dx%d", d.Width, d.Height)
+	}
+	if d.BitsPerSample != 8 {
+		return fmt.Errorf("unsupported bits per sample %d", d.BitsPerSample)
+	}
+	channels := 3
+	if d.HasAlpha {
+		channels = 4
+	}
+	if d.Channels != channels {
+		return fmt.Errorf("invalid channel count %d (must be %d when has_alpha is %v)", d.Channels, channels, d.HasAlpha)
+	}
+	rowBytes := d.Width * d.Channels
+	if d.Rowstride < rowBytes {
+		return fmt.Errorf("rowstride %d is shorter than a row of %d bytes", d.Rowstride, rowBytes)
+	}
+	if need := d.Rowstride*(d.Height-1) + rowBytes; len(d.Data) < need {
+		return fmt.Errorf("image data has %d bytes, need %d", len(d.Data), need)
+	}
+	return nil
+}
+
+// iconSourceKind is where a notification's icon comes from
+type iconSourceKind int
+
+const (
+	iconSourceNone iconSourceKind = iota
+	iconSourceImageData
+	iconSourceFile
+	iconSourceName
+)
+
+// iconSource is the icon a banner shows: raw image data, an image file, or
+// a themed icon name
+type iconSource struct {
+	kind  iconSourceKind
+	name  string     // file path or icon name
+	image *ImageData // raw image for iconSourceImageData
+}
+
+// notificationIcon picks a notification's icon by the spec's precedence:
+// image-data, then image-path, then app_icon, then icon_data
+func notificationIcon(n *Notification) iconSource {
+	if n.ImageData != nil {
+		return iconSource{kind: iconSourceImageData, image: n.ImageData}
+	}
+	for _, hint := range imagePathHints {
+		if src := iconFromString(n.Hints[hint]); src.kind != iconSourceNone {
+			return src
+		}
+	}
+	if src := iconFromString(n.AppIcon); src.kind != iconSourceNone {
+		return src
+	}
+	if n.IconData != nil {
+		return iconSource{kind: iconSourceImageData, image: n.IconData}
+	}
+	return iconSource{}
+}
+
+// iconFromString interprets an image-path or app_icon value: a file://
+// URI, an absolute path, or an icon name
+func iconFromString(s string) iconSource {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return iconSource{}
+	case strings.HasPrefix(s, "file://"):
+		u, err := url.Parse(s)
+		if err != nil || u.Path == "" {
+			return iconSource{}
+		}
+		return iconSource{kind: iconSourceFile, name: u.Path}
+	case strings.HasPrefix(s, "/"):
+		return iconSource{kind: iconSourceFile, name: s}
+	default:
+		return iconSource{kind: iconSourceName, name: s}
+	}
+}
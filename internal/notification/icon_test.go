@@ -0,0 +1,72 @@
+package notification
+
+import "testing"
+
+// imageDataValue builds an image-data hint value as godbus decodes it
+func imageDataValue(width, height int32, hasAlpha bool, data []byte) []interface{} {
+	channels := int32(3)
+	if hasAlpha {
+		channels = 4
+	}
+	return []interface{}{width, height, width * channels, hasAlpha, int32(8), channels, data}
+}
+
+func TestParseImageData(t *testing.T) {
+	img, err := parseImageData(imageDataValue(2, 2, true, make([]byte, 16)))
+	if err != nil {
+		t.Fatalf("parseImageData() error = %v", err)
+	}
+	if img.Width != 2 || img.Height != 2 || img.Rowstride != 8 || !img.HasAlpha || img.Channels != 4 {
+		t.Errorf("parseImageData() = %+v", img)
+	}
+
+	invalid := map[string]interface{}{
+		"not a struct":   "image.png",
+		"too few fields": []interface{}{int32(1), int32(1)},
+		"short data":     imageDataValue(2, 2, true, make([]byte, 15)),
+		"wrong channels": []interface{}{int32(1), int32(1), int32(4), false, int32(8), int32(4), make([]byte, 4)},
+		"zero size":      imageDataValue(0, 2, false, nil),
+		"16-bit samples": []interface{}{int32(1), int32(1), int32(6), false, int32(16), int32(3), make([]byte, 6)},
+	}
+	for name, value := range invalid {
+		if _, err := parseImageData(value); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNotificationIconPrecedence(t *testing.T) {
+	img := &ImageData{Width: 1, Height: 1, Rowstride: 3, BitsPerSample: 8, Channels: 3, Data: make([]byte, 3)}
+
+	tests := []struct {
+		name      string
+		notif     Notification
+		wantKind  iconSourceKind
+		wantValue string
+	}{
+		{"nothing", Notification{}, iconSourceNone, ""},
+		{"app icon", Notification{AppIcon: "mail-unread"}, iconSourceName, "mail-unread"},
+		{"app icon path", Notification{AppIcon: "/usr/share/icons/mail.png"}, iconSourceFile, "/usr/share/icons/mail.png"},
+		{"image path beats app icon",
+			Notification{AppIcon: "mail-unread", Hints: map[string]string{"image-path": "file:///tmp/avatar%20me.png"}},
+			iconSourceFile, "/tmp/avatar me.png"},
+		{"image path icon name", Notification{Hints: map[string]string{"image-path": "avatar-default"}}, iconSourceName, "avatar-default"},
+		{"deprecated image_path", Notification{AppIcon: "mail-unread", Hints: map[string]string{"image_path": "/tmp/a.png"}}, iconSourceFile, "/tmp/a.png"},
+		{"image-path beats image_path",
+			Notification{Hints: map[string]string{"image-path": "/tmp/new.png", "image_path": "/tmp/old.png"}},
+			iconSourceFile, "/tmp/new.png"},
+		{"image data beats everything",
+			Notification{AppIcon: "mail-unread", Hints: map[string]string{"image-path": "/tmp/a.png"}, ImageData: img},
+			iconSourceImageData, ""},
+		{"app icon beats icon_data", Notification{AppIcon: "mail-unread", IconData: img}, iconSourceName, "mail-unread"},
+		{"icon_data without app icon", Notification{IconData: img}, iconSourceImageData, ""},
+		{"blank image path falls back", Notification{AppIcon: "mail-unread", Hints: map[string]string{"image-path": " "}}, iconSourceName, "mail-unread"},
+	}
+
+	for _, tc := range tests {
+		got := notificationIcon(&tc.notif)
+		if got.kind != tc.wantKind || got.name != tc.wantValue {
+			t.Errorf("%s: notificationIcon() = %+v, want kind %d %q", tc.name, got, tc.wantKind, tc.wantValue)
+		}
+	}
+}
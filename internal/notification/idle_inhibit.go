@@ -0,0 +1,65 @@
+package notification
+
+import "log"
+
+// idleInhibitor is a held inhibition of the screen going idle
+type idleInhibitor interface {
+	Release()
+}
+
+// idleInhibitTracker holds an idle inhibitor for every visible critical
+// banner, so the screen stays awake until the last one is dismissed
+type idleInhibitTracker struct {
+	enabled    bool
+	inhibitors map[string]idleInhibitor
+}
+
+func newIdleInhibitTracker() *idleInhibitTracker {
+	return &idleInhibitTracker{inhibitors: make(map[string]idleInhibitor)}
+}
+
+// setEnabled turns inhibition on or off, releasing anything held when disabled
+func (t *idleInhibitTracker) setEnabled(enabled bool) {
+	t.enabled = enabled
+	if !enabled {
+		t.releaseAll()
+	}
+}
+
+// bannerShown acquires an inhibitor for a newly shown critical banner
+func (t *idleInhibitTracker) bannerShown(id string, urgency Urgency, acquire func() (idleInhibitor, error)) {
+	if !t.enabled || urgency != UrgencyCritical {
+		return
+	}
+	if _, held := t.inhibitors[id]; held {
+		return
+	}
+
+	inhibitor, err := acquire()
+	if err != nil {
+		log.Printf("Failed to inhibit idle for critical notification %s: %v", id, err)
+		return
+	}
+	t.inhibitors[id] = inhibitor
+}
+
+// bannerClosed releases the inhibitor held for a banner, if any
+func (t *idleInhibitTracker) bannerClosed(id string) {
+	if inhibitor, held := t.inhibitors[id]; held {
+		inhibitor.Release()
+		delete(t.inhibitors, id)
+	}
+}
+
+// releaseAll releases every held inhibitor
+func (t *idleInhibitTracker) releaseAll() {
+	for id, inhibitor := range t.inhibitors {
+		inhibitor.Release()
+		delete(t.inhibitors, id)
+	}
+}
+
+// active reports whether the screen is currently kept awake
+func (t *idleInhibitTracker) active() bool {
+	return len(t.inhibitors) > 0
+}
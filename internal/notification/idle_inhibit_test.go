@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeInhibitor struct {
+	released int
+}
+
+func (f *fakeInhibitor) Release() {
+	f.released++
+}
+
+func TestIdleInhibitTrackerLifecycle(t *testing.T) {
+	tracker := newIdleInhibitTracker()
+	tracker.setEnabled(true)
+
+	var acquired []*fakeInhibitor
+	acquire := func() (idleInhibitor, error) {
+		inhibitor := &fakeInhibitor{}
+		acquired = append(acquired, inhibitor)
+		return inhibitor, nil
+	}
+
+	tracker.bannerShown("normal", UrgencyNormal, acquire)
+	if tracker.active() || len(acquired) != 0 {
+		t.Fatal("non-critical banner should not inhibit idle")
+	}
+
+	tracker.bannerShown("alarm", UrgencyCritical, acquire)
+	tracker.bannerShown("battery", UrgencyCritical, acquire)
+	if !tracker.active() || len(acquired) != 2 {
+		t.Fatalf("expected an inhibitor per critical banner, got %d", len(acquired))
+	}
+
+	// Showing the same banner again must not acquire twice
+	tracker.bannerShown("alarm", UrgencyCritical, acquire)
+	if len(acquired) != 2 {
+		t.Errorf("duplicate show acquired again: %d inhibitors", len(acquired))
+	}
+
+	tracker.bannerClosed("normal")
+	tracker.bannerClosed("alarm")
+	if acquired[0].released != 1 {
+		t.Errorf("closed banner's inhibitor released %d times, want 1", acquired[0].released)
+	}
+	if !tracker.active() {
+		t.Error("inhibition should remain while a critical banner is visible")
+	}
+
+	tracker.bannerClosed("battery")
+	tracker.bannerClosed("battery")
+	if tracker.active() {
+		t.Error("inhibition should end once no critical banner is visible")
+	}
+	if acquired[1].released != 1 {
+		t.Errorf("inhibitor released %d times, want 1", acquired[1].released)
+	}
+}
+
+func TestIdleInhibitTrackerDisabled(t *testing.T) {
+	tracker := newIdleInhibitTracker()
+
+	called := false
+	tracker.bannerShown("alarm", UrgencyCritical, func() (idleInhibitor, error) {
+		called = true
+		return &fakeInhibitor{}, nil
+	})
+	if called || tracker.active() {
+		t.Error("disabled tracker should not acquire inhibitors")
+	}
+}
+
+func TestIdleInhibitTrackerReleaseOnDisable(t *testing.T) {
+	tracker := newIdleInhibitTracker()
+	tracker.setEnabled(true)
+
+	inhibitor := &fakeInhibitor{}
+	tracker.bannerShown("alarm", UrgencyCritical, func() (idleInhibitor, error) {
+		return inhibitor, nil
+	})
+
+	tracker.setEnabled(false)
+	if tracker.active() || inhibitor.released != 1 {
+		t.Errorf("disabling should release held inhibitors (released %d)", inhibitor.released)
+	}
+}
+
+func TestIdleInhibitTrackerAcquireFailure(t *testing.T) {
+	tracker := newIdleInhibitTracker()
+	tracker.setEnabled(true)
+
+	tracker.bannerShown("alarm", UrgencyCritical, func() (idleInhibitor, error) {
+		return nil, errors.New("unsupported")
+	})
+	if tracker.active() {
+		t.Error("failed acquisition should not count as inhibiting")
+	}
+
+	// Closing a banner that never got an inhibitor is harmless
+	tracker.bannerClosed("alarm")
+}
+
+func TestIdleInhibitTrackerReleaseAll(t *testing.T) {
+	tracker := newIdleInhibitTracker()
+	tracker.setEnabled(true)
+
+	inhibitors := []*fakeInhibitor{{}, {}}
+	for i, id := range []string{"a", "b"} {
+		inhibitor := inhibitors[i]
+		tracker.bannerShown(id, UrgencyCritical, func() (idleInhibitor, error) {
+			return inhibitor, nil
+		})
+	}
+
+	tracker.releaseAll()
+	if tracker.active() {
+		t.Error("releaseAll left inhibitors held")
+	}
+	for i, inhibitor := range inhibitors {
+		if inhibitor.released != 1 {
+			t.Errorf("inhibitor %d released %d times, want 1", i, inhibitor.released)
+		}
+	}
+}
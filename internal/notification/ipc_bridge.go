@@ -28,6 +28,7 @@ type IPCResponse struct {
 
 type IPCBridge struct {
 	store      *Store
+	invoke     func(notifID, actionKey string) error
 	socketPath string
 	listener   net.Listener
 	running    bool
@@ -149,6 +150,12 @@ func (b *IPCBridge) handleRequest(request IPCRequest) IPCResponse {
 		return b.handleRemove(request.Params)
 	case "clear_all":
 		return b.handleClearAll()
+	case "get_status":
+		return b.handleGetStatus()
+	case "set_dnd":
+		return b.handleSetDND(request.Params)
+	case "invoke_action":
+		return b.handleInvokeAction(request.Params)
 	default:
 		return IPCResponse{
 			Success: false,
@@ -157,6 +164,51 @@ func (b *IPCBridge) handleRequest(request IPCRequest) IPCResponse {
 	}
 }
 
+// SetActionInvoker sets how invoke_action re-runs a stored notification's action
+func (b *IPCBridge) SetActionInvoker(invoke func(notifID, actionKey string) error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.invoke = invoke
+}
+
+// handleInvokeAction re-invokes an action ("default" unless given) of a
+// notification in history
+func (b *IPCBridge) handleInvokeAction(params map[string]interface{}) IPCResponse {
+	id, ok := params["id"].(string)
+	if !ok {
+		return IPCResponse{
+			Success: false,
+			Error:   "missing id parameter",
+		}
+	}
+
+	actionKey := "default"
+	if key, ok := params["action"].(string); ok && key != "" {
+		actionKey = key
+	}
+
+	b.mu.Lock()
+	invoke := b.invoke
+	b.mu.Unlock()
+	if invoke == nil {
+		return IPCResponse{
+			Success: false,
+			Error:   "actions cannot be invoked without the notification daemon",
+		}
+	}
+
+	if err := invoke(id, actionKey); err != nil {
+		return IPCResponse{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	return IPCResponse{
+		Success: true,
+		Data:    true,
+	}
+}
+
 func (b *IPCBridge) handleGetUnreadCount() IPCResponse {
 	return IPCResponse{
 		Success: true,
@@ -175,19 +227,56 @@ func (b *IPCBridge) handleGetNotifications(params map[string]interface{}) IPCRes
 		appName = appNameParam
 	}
 
+	unread, _ := params["unread"].(bool)
+
+	since, err := parseSinceParam(params["since"])
+	if err != nil {
+		return IPCResponse{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
 	var notifs []*Notification
-	if appName != "" {
-		notifs = b.store.GetNotificationsByApp(appName)
-	} else {
+	switch {
+	case appName != "":
+		notifs = filterNotifications(b.store.GetNotificationsByApp(appName), since, unread)
+	case unread:
+		notifs = filterNotifications(b.store.GetUnread(), since, false)
+	case !since.IsZero():
+		notifs = b.store.GetNotificationsSince(since)
+	default:
 		notifs = b.store.GetNotifications(limit)
 	}
 
+	if limit > 0 && len(notifs) > limit {
+		notifs = notifs[:limit]
+	}
+
 	return IPCResponse{
 		Success: true,
 		Data:    notifs,
 	}
 }
 
+// parseSinceParam accepts an RFC3339 string or unix seconds
+func parseSinceParam(param interface{}) (time.Time, error) {
+	switch v := param.(type) {
+	case nil:
+		return time.Time{}, nil
+	case float64:
+		return time.Unix(int64(v), 0), nil
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid since parameter: %s", v)
+		}
+		return t, nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid since parameter type: %T", param)
+	}
+}
+
 func (b *IPCBridge) handleSearch(params map[string]interface{}) IPCResponse {
 	query := ""
 	if queryParam, ok := params["query"].(string); ok {
@@ -248,6 +337,26 @@ func (b *IPCBridge) handleClearAll() IPCResponse {
 	}
 }
 
+func (b *IPCBridge) handleGetStatus() IPCResponse {
+	return IPCResponse{
+		Success: true,
+		Data:    b.store.Status(),
+	}
+}
+
+func (b *IPCBridge) handleSetDND(params map[string]interface{}) IPCResponse {
+	enabled, ok := params["enabled"].(bool)
+	if !ok {
+		enabled = !b.store.DoNotDisturb()
+	}
+
+	b.store.SetDoNotDisturb(enabled)
+	return IPCResponse{
+		Success: true,
+		Data:    enabled,
+	}
+}
+
 func (b *IPCBridge) sendResponse(conn net.Conn, response IPCResponse) {
 	data, err := json.Marshal(response)
 	if err != nil {
@@ -316,6 +425,29 @@ func GetUnreadCount(socketPath string) (int, error) {
 	return 0, fmt.Errorf("invalid response data type")
 }
 
+func GetStatus(socketPath string) (*NotificationStatus, error) {
+	response, err := QueryNotificationStoreSimple(socketPath, "get_status")
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, fmt.Errorf("failed to get status: %s", response.Error)
+	}
+
+	data, err := json.Marshal(response.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response data: %w", err)
+	}
+
+	var status NotificationStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("invalid response data: %w", err)
+	}
+
+	return &status, nil
+}
+
 type Manager struct {
 	store     *Store
 	queue     *Queue
@@ -327,6 +459,9 @@ type Manager struct {
 	mu        sync.Mutex
 }
 
+// historyPruneInterval is how often expired notifications are removed
+const historyPruneInterval = time.Hour
+
 func NewManager(cfg *config.NotificationConfig, iconCache *launcher.IconCache) (*Manager, error) {
 	// Expand ~ in socket path
 	socketPath := expandPath(cfg.History.PersistPath) + ".sock"
@@ -342,9 +477,17 @@ func NewManager(cfg *config.NotificationConfig, iconCache *launcher.IconCache) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to create notification store: %w", err)
 	}
+	store.SetSaveInterval(cfg.History.SaveEvery())
+	store.SetKeepImportant(cfg.History.KeepImportant)
 
 	corner := Corner(cfg.Daemon.Position)
 	queue := NewQueue(store, cfg.Daemon.MaxBanners, cfg.Daemon.BannerGap, cfg.Daemon.BannerHeight, cfg.Daemon.BannerWidth, cfg.Daemon.AnimationDuration, corner, iconCache)
+	queue.SetOverflowPolicy(OverflowPolicy(cfg.Daemon.OverflowPolicy))
+	queue.SetMaxLifetime(cfg.Daemon.MaxLifetime)
+	queue.SetBannerStyle(cfg.Daemon.Style)
+	queue.SetBannerAnimation(bannerAnimationFor(cfg.Daemon.Animation))
+	queue.SetBannerLayout(bannerLayoutFor(cfg.Daemon))
+	queue.SetIdleInhibitCritical(cfg.Daemon.IdleInhibitCritical)
 
 	m := &Manager{
 		store:     store,
@@ -356,6 +499,7 @@ func NewManager(cfg *config.NotificationConfig, iconCache *launcher.IconCache) (
 
 	m.daemon = NewDaemon(store, queue, cfg)
 	m.ipcBridge = NewIPCBridge(store, socketPath)
+	m.ipcBridge.SetActionInvoker(m.daemon.InvokeStoredAction)
 
 	return m, nil
 }
@@ -380,6 +524,7 @@ func (m *Manager) Start() error {
 	}
 
 	go m.listenStoreEvents()
+	m.store.StartPruning(historyPruneInterval)
 
 	m.running = true
 
@@ -401,6 +546,10 @@ func (m *Manager) Stop() error {
 	m.daemon.Stop()
 	m.ipcBridge.Stop()
 	m.queue.Cleanup()
+	m.store.StopPruning()
+	if err := m.store.Flush(); err != nil {
+		log.Printf("Failed to save notification history: %v", err)
+	}
 
 	log.Println("Notification manager stopped")
 
@@ -408,7 +557,10 @@ func (m *Manager) Stop() error {
 }
 
 func (m *Manager) onBannerClose(notifID string) {
-	m.daemon.emitNotificationClosed(m.getDaemonID(notifID), CloseReasonDismissed)
+	// Banners closed through CloseNotification were already reported
+	if daemonID := m.getDaemonID(notifID); daemonID > 0 {
+		m.daemon.emitNotificationClosed(daemonID, CloseReasonDismissed)
+	}
 }
 
 func (m *Manager) onBannerAction(notifID, actionKey string) {
@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// markupTagPattern matches a single tag such as <b>, </i> or <a href="...">
+var markupTagPattern = regexp.MustCompile(`<(/?)([a-zA-Z]+)([^<>]*)>`)
+
+// markupHrefPattern extracts the href attribute of an <a> tag
+var markupHrefPattern = regexp.MustCompile(`href\s*=\s*("([^"]*)"|'([^']*)')`)
+
+// sanitizeBodyMarkup turns a notification body using the spec's markup
+// subset (b, i, u, a href) into well-formed Pango markup. Other tags are
+// dropped, text is escaped and unbalanced tags are closed or discarded, so
+// the result is always safe to pass to gtk.Label.SetMarkup.
+func sanitizeBodyMarkup(body string) string {
+	var out strings.Builder
+	var open []string
+
+	writeText := func(text string) {
+		out.WriteString(html.EscapeString(html.UnescapeString(text)))
+	}
+
+	last := 0
+	for _, m := range markupTagPattern.FindAllStringSubmatchIndex(body, -1) {
+		writeText(body[last:m[0]])
+		last = m[1]
+
+		closing := m[3] > m[2]
+		name := strings.ToLower(body[m[4]:m[5]])
+		attrs := body[m[6]:m[7]]
+
+		switch name {
+		case "b", "i", "u", "a":
+		default:
+			continue
+		}
+
+		if closing {
+			if len(open) > 0 && open[len(open)-1] == name {
+				open = open[:len(open)-1]
+				out.WriteString("</" + name + ">")
+			}
+			continue
+		}
+
+		if name == "a" {
+			href := ""
+			if hm := markupHrefPattern.FindStringSubmatch(attrs); hm != nil {
+				href = hm[2] + hm[3]
+			}
+			out.WriteString(`<a href="` + html.EscapeString(html.UnescapeString(href)) + `">`)
+		} else {
+			out.WriteString("<" + name + ">")
+		}
+		open = append(open, name)
+	}
+	writeText(body[last:])
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+	return out.String()
+}
@@ -0,0 +1,28 @@
+package notification
+
+import "testing"
+
+func TestSanitizeBodyMarkup(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"plain", "Hello world", "Hello world"},
+		{"supported tags", "<b>bold</b> and <i>italic</i> <u>u</u>", "<b>bold</b> and <i>italic</i> <u>u</u>"},
+		{"link", `see <a href="https://example.com/?a=1&amp;b=2">here</a>`, `see <a href="https://example.com/?a=1&amp;b=2">here</a>`},
+		{"unsupported tags dropped", `<img src="x.png" alt="pic"/>text <span color="red">red</span>`, "text red"},
+		{"text escaped", "1 < 2 && 3 > 2", "1 &lt; 2 &amp;&amp; 3 &gt; 2"},
+		{"entities kept", "Tom &amp; Jerry", "Tom &amp; Jerry"},
+		{"unclosed tag closed", "<b>bold", "<b>bold</b>"},
+		{"stray close dropped", "text</b>", "text"},
+		{"misnested", "<b><i>x</b></i>", "<b><i>x</i></b>"},
+		{"uppercase tags", "<B>loud</B>", "<b>loud</b>"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeBodyMarkup(tt.body); got != tt.want {
+			t.Errorf("%s: sanitizeBodyMarkup(%q) = %q, want %q", tt.name, tt.body, got, tt.want)
+		}
+	}
+}
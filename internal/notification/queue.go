@@ -4,6 +4,7 @@ import (
 	"log"
 	"sync"
 
+	"github.com/chess10kp/locus/internal/config"
 	"github.com/chess10kp/locus/internal/launcher"
 )
 
@@ -17,11 +18,35 @@ type Queue struct {
 	animationDuration int
 	corner            Corner
 	iconCache         *launcher.IconCache
+	overflowPolicy    OverflowPolicy
+	maxLifetime       int
+	bannerStyle       config.BannerStyleConfig
+	bannerAnimation   BannerAnimation
+	bannerLayout      bannerLayout
+	idleInhibit       *idleInhibitTracker
+	pending           []*Notification
 	mu                sync.RWMutex
 	onClose           func(string)
 	onAction          func(string, string)
 }
 
+type OverflowPolicy string
+
+const (
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+	OverflowQueue      OverflowPolicy = "queue"
+)
+
+type overflowAction int
+
+const (
+	overflowShow overflowAction = iota
+	overflowEvict
+	overflowDrop
+	overflowEnqueue
+)
+
 func NewQueue(store *Store, maxBanners, bannerGap, bannerHeight, bannerWidth, animationDuration int, corner Corner, iconCache *launcher.IconCache) *Queue {
 	return &Queue{
 		store:             store,
@@ -33,9 +58,59 @@ func NewQueue(store *Store, maxBanners, bannerGap, bannerHeight, bannerWidth, an
 		animationDuration: animationDuration,
 		corner:            corner,
 		iconCache:         iconCache,
+		overflowPolicy:    OverflowDropOldest,
+		bannerStyle:       config.DefaultConfig.Notification.Daemon.Style,
+		bannerAnimation:   BannerAnimationSlide,
+		bannerLayout:      bannerLayoutFor(config.DefaultConfig.Notification.Daemon),
+		idleInhibit:       newIdleInhibitTracker(),
 	}
 }
 
+func (q *Queue) SetOverflowPolicy(policy OverflowPolicy) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if policy == "" {
+		policy = OverflowDropOldest
+	}
+	q.overflowPolicy = policy
+}
+
+// SetMaxLifetime caps how long non-critical banners stay on screen (ms, 0 disables).
+func (q *Queue) SetMaxLifetime(maxLifetime int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.maxLifetime = maxLifetime
+}
+
+// SetBannerStyle sets the styling of banners shown from now on
+func (q *Queue) SetBannerStyle(style config.BannerStyleConfig) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.bannerStyle = style
+}
+
+// SetBannerAnimation sets how banners shown from now on appear and disappear
+func (q *Queue) SetBannerAnimation(animation BannerAnimation) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.bannerAnimation = animation
+}
+
+// SetBannerLayout sets the icon size and padding of banners shown from now on
+func (q *Queue) SetBannerLayout(layout bannerLayout) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.bannerLayout = layout
+}
+
+// SetIdleInhibitCritical sets whether the screen is kept from going idle
+// while a critical banner is visible
+func (q *Queue) SetIdleInhibitCritical(enabled bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.idleInhibit.setEnabled(enabled)
+}
+
 func (q *Queue) SetCallbacks(onClose func(string), onAction func(string, string)) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -53,13 +128,26 @@ func (q *Queue) ShowNotification(notif *Notification) error {
 		return nil
 	}
 
-	if len(q.banners) >= q.maxBanners {
-		log.Printf("Max banners reached, removing oldest...")
-		q.removeOldestBanner()
+	action, evictID := planOverflow(q.overflowPolicy, q.maxBanners, q.activeNotificationsLocked(), notif)
+	switch action {
+	case overflowDrop:
+		log.Printf("Max banners reached, dropping banner for: %s", notif.ID)
+		return nil
+	case overflowEnqueue:
+		log.Printf("Max banners reached, queueing banner for: %s", notif.ID)
+		q.pending = append(q.pending, notif)
+		return nil
+	case overflowEvict:
+		log.Printf("Max banners reached, removing oldest banner: %s", evictID)
+		q.dismissBanner(evictID)
 	}
 
+	return q.showBannerLocked(notif)
+}
+
+func (q *Queue) showBannerLocked(notif *Notification) error {
 	log.Printf("Creating new banner...")
-	banner, err := NewBanner(notif, q.onBannerClose, q.onBannerAction, q.bannerWidth, q.bannerHeight, q.animationDuration, q.iconCache)
+	banner, err := NewBanner(notif, q.onBannerClose, q.onBannerAction, q.bannerWidth, q.bannerHeight, q.animationDuration, q.bannerAnimation, q.bannerLayout, q.maxLifetime, q.bannerStyle, q.iconCache)
 	if err != nil {
 		log.Printf("Failed to create banner: %v", err)
 		return err
@@ -70,6 +158,7 @@ func (q *Queue) ShowNotification(notif *Notification) error {
 	log.Printf("Calling banner.Show()...")
 	banner.Show()
 	log.Printf("Banner.Show() completed")
+	q.idleInhibit.bannerShown(notif.ID, notif.Urgency, banner.inhibitIdle)
 
 	log.Printf("Repositioning all banners...")
 	q.repositionAllBanners()
@@ -78,42 +167,85 @@ func (q *Queue) ShowNotification(notif *Notification) error {
 	return nil
 }
 
-func (q *Queue) removeOldestBanner() {
-	if len(q.banners) == 0 {
-		return
+// showPendingLocked shows queued notifications while banner slots are free.
+func (q *Queue) showPendingLocked() {
+	for len(q.pending) > 0 && len(q.banners) < q.maxBanners {
+		next := q.pending[0]
+		q.pending = q.pending[1:]
+		if err := q.showBannerLocked(next); err != nil {
+			log.Printf("Failed to show queued banner: %v", err)
+		}
 	}
+}
 
-	oldestID := ""
-	oldestTime := q.banners[q.getFirstKey()].notification.Timestamp
+func (q *Queue) activeNotificationsLocked() []*Notification {
+	active := make([]*Notification, 0, len(q.banners))
+	for _, banner := range q.banners {
+		active = append(active, banner.notification)
+	}
+	return active
+}
 
-	for id, banner := range q.banners {
-		if banner.notification.Timestamp.Before(oldestTime) {
-			oldestTime = banner.notification.Timestamp
-			oldestID = id
+// planOverflow decides what happens to an incoming notification when the
+// maximum number of banners is already on screen. Critical notifications are
+// never dropped: they evict the oldest non-critical banner, or are shown
+// above the limit when every visible banner is critical.
+func planOverflow(policy OverflowPolicy, maxBanners int, active []*Notification, incoming *Notification) (overflowAction, string) {
+	if len(active) < maxBanners {
+		return overflowShow, ""
+	}
+
+	var victim *Notification
+	for _, notif := range active {
+		if notif.Urgency == UrgencyCritical {
+			continue
+		}
+		if victim == nil || notif.Timestamp.Before(victim.Timestamp) {
+			victim = notif
 		}
 	}
 
-	if oldestID != "" {
-		q.dismissBanner(oldestID)
+	if incoming.Urgency == UrgencyCritical {
+		if victim != nil {
+			return overflowEvict, victim.ID
+		}
+		return overflowShow, ""
 	}
-}
 
-func (q *Queue) getFirstKey() string {
-	for k := range q.banners {
-		return k
+	switch policy {
+	case OverflowDropNewest:
+		return overflowDrop, ""
+	case OverflowQueue:
+		return overflowEnqueue, ""
+	default:
+		if victim != nil {
+			return overflowEvict, victim.ID
+		}
+		return overflowDrop, ""
 	}
-	return ""
 }
 
 func (q *Queue) DismissBanner(id string) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	q.removePendingLocked(id)
 	q.dismissBanner(id)
+	q.showPendingLocked()
+}
+
+func (q *Queue) removePendingLocked(id string) {
+	for i, notif := range q.pending {
+		if notif.ID == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return
+		}
+	}
 }
 
 func (q *Queue) dismissBanner(id string) {
 	if banner, exists := q.banners[id]; exists {
 		delete(q.banners, id)
+		q.idleInhibit.bannerClosed(id)
 		banner.Dismiss()
 		q.repositionAllBanners()
 	}
@@ -127,13 +259,15 @@ func (q *Queue) DismissAll() {
 		banner.Dismiss()
 		delete(q.banners, id)
 	}
+	q.idleInhibit.releaseAll()
+	q.pending = nil
 }
 
 func (q *Queue) SetCorner(corner Corner) {
 	q.mu.Lock()
-	q.corner = corner
-	q.mu.Unlock()
+	defer q.mu.Unlock()
 
+	q.corner = corner
 	q.repositionAllBanners()
 }
 
@@ -149,8 +283,10 @@ func (q *Queue) onBannerClose(id string) {
 
 	if _, exists := q.banners[id]; exists {
 		delete(q.banners, id)
+		q.idleInhibit.bannerClosed(id)
 		q.repositionAllBanners()
 	}
+	q.showPendingLocked()
 
 	if q.onClose != nil {
 		q.onClose(id)
@@ -181,10 +317,8 @@ func (q *Queue) onBannerAction(notifID, actionKey string) {
 	}
 }
 
+// repositionAllBanners expects q.mu to be held by the caller.
 func (q *Queue) repositionAllBanners() {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-
 	banners := make([]*Banner, 0, len(q.banners))
 	for _, banner := range q.banners {
 		banners = append(banners, banner)
@@ -237,6 +371,8 @@ func (q *Queue) Cleanup() {
 	}
 
 	q.banners = make(map[string]*Banner)
+	q.idleInhibit.releaseAll()
+	q.pending = nil
 
 	log.Println("Notification queue cleaned up")
 }
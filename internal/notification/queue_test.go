@@ -0,0 +1,212 @@
+package notification
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// simulateBurst feeds notifications through planOverflow the way Queue does and
+// returns the IDs left on screen, dropped and queued.
+func simulateBurst(policy OverflowPolicy, maxBanners int, burst []*Notification) (active, dropped, queued []string) {
+	var onScreen []*Notification
+	for _, notif := range burst {
+		action, evictID := planOverflow(policy, maxBanners, onScreen, notif)
+		switch action {
+		case overflowDrop:
+			dropped = append(dropped, notif.ID)
+			continue
+		case overflowEnqueue:
+			queued = append(queued, notif.ID)
+			continue
+		case overflowEvict:
+			for i, n := range onScreen {
+				if n.ID == evictID {
+					onScreen = append(onScreen[:i], onScreen[i+1:]...)
+					dropped = append(dropped, evictID)
+					break
+				}
+			}
+		}
+		onScreen = append(onScreen, notif)
+	}
+
+	for _, n := range onScreen {
+		active = append(active, n.ID)
+	}
+	return active, dropped, queued
+}
+
+func makeBurst(count int, critical ...int) []*Notification {
+	base := time.Now()
+	burst := make([]*Notification, count)
+	for i := range burst {
+		burst[i] = &Notification{
+			ID:        fmt.Sprintf("n%d", i),
+			Timestamp: base.Add(time.Duration(i) * time.Millisecond),
+			Urgency:   UrgencyNormal,
+		}
+	}
+	for _, i := range critical {
+		burst[i].Urgency = UrgencyCritical
+	}
+	return burst
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOverflowDropOldest(t *testing.T) {
+	active, dropped, queued := simulateBurst(OverflowDropOldest, 3, makeBurst(5))
+
+	if !equalIDs(active, []string{"n2", "n3", "n4"}) {
+		t.Errorf("Expected newest banners on screen, got %v", active)
+	}
+	if !equalIDs(dropped, []string{"n0", "n1"}) {
+		t.Errorf("Expected oldest banners dropped, got %v", dropped)
+	}
+	if len(queued) != 0 {
+		t.Errorf("Expected nothing queued, got %v", queued)
+	}
+}
+
+func TestOverflowDropNewest(t *testing.T) {
+	active, dropped, queued := simulateBurst(OverflowDropNewest, 3, makeBurst(5))
+
+	if !equalIDs(active, []string{"n0", "n1", "n2"}) {
+		t.Errorf("Expected first banners kept, got %v", active)
+	}
+	if !equalIDs(dropped, []string{"n3", "n4"}) {
+		t.Errorf("Expected newest banners dropped, got %v", dropped)
+	}
+	if len(queued) != 0 {
+		t.Errorf("Expected nothing queued, got %v", queued)
+	}
+}
+
+func TestOverflowQueue(t *testing.T) {
+	active, dropped, queued := simulateBurst(OverflowQueue, 3, makeBurst(5))
+
+	if !equalIDs(active, []string{"n0", "n1", "n2"}) {
+		t.Errorf("Expected first banners shown, got %v", active)
+	}
+	if len(dropped) != 0 {
+		t.Errorf("Expected nothing dropped, got %v", dropped)
+	}
+	if !equalIDs(queued, []string{"n3", "n4"}) {
+		t.Errorf("Expected overflow queued in order, got %v", queued)
+	}
+}
+
+func TestOverflowCriticalBypassesDropping(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowDropOldest, OverflowDropNewest, OverflowQueue} {
+		t.Run(string(policy), func(t *testing.T) {
+			// n0 is critical and must survive; n4 is critical and must be shown
+			active, dropped, queued := simulateBurst(policy, 3, makeBurst(5, 0, 4))
+
+			for _, id := range []string{"n0", "n4"} {
+				found := false
+				for _, a := range active {
+					if a == id {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Expected critical %s on screen, got %v", id, active)
+				}
+			}
+			for _, id := range append(dropped, queued...) {
+				if id == "n0" || id == "n4" {
+					t.Errorf("Critical %s was dropped or queued", id)
+				}
+			}
+		})
+	}
+}
+
+func TestOverflowAllCriticalShownAboveLimit(t *testing.T) {
+	active, dropped, _ := simulateBurst(OverflowDropOldest, 2, makeBurst(3, 0, 1, 2))
+
+	if len(active) != 3 || len(dropped) != 0 {
+		t.Errorf("Expected all critical banners shown, active=%v dropped=%v", active, dropped)
+	}
+}
+
+func TestBannerTextWidthChars(t *testing.T) {
+	defaults := bannerLayoutFor(config.DefaultConfig.Notification.Daemon)
+	tests := []struct {
+		width  int
+		layout bannerLayout
+		want   int
+	}{
+		{400, defaults, 40},
+		{800, defaults, 97},
+		{120, defaults, bannerMinTextChars},
+		{0, defaults, bannerMinTextChars},
+		{400, bannerLayout{iconSize: 96, padding: 10}, 33},
+		{400, bannerLayout{iconSize: 48, padding: 0}, 45},
+	}
+
+	for _, tt := range tests {
+		if got := bannerTextWidthChars(tt.width, tt.layout); got != tt.want {
+			t.Errorf("bannerTextWidthChars(%d, %+v) = %d, want %d", tt.width, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestBannerLayoutFor(t *testing.T) {
+	padding := func(px int) *int { return &px }
+	tests := []struct {
+		iconSize int
+		padding  *int
+		want     bannerLayout
+	}{
+		{48, padding(10), bannerLayout{iconSize: 48, padding: 10}},
+		{64, padding(4), bannerLayout{iconSize: 64, padding: 4}},
+		{32, padding(0), bannerLayout{iconSize: 32, padding: 0}},
+		{0, padding(-1), bannerLayout{iconSize: 48, padding: 10}},
+		{0, nil, bannerLayout{iconSize: 48, padding: 10}},
+	}
+
+	for _, tt := range tests {
+		d := config.NotificationDaemonConfig{IconSize: tt.iconSize, Padding: tt.padding}
+		if got := bannerLayoutFor(d); got != tt.want {
+			t.Errorf("bannerLayoutFor(icon_size=%d) = %+v, want %+v", tt.iconSize, got, tt.want)
+		}
+	}
+
+	if got := bannerLayoutFor(config.DefaultConfig.Notification.Daemon); got.iconSize != 48 || got.padding != 10 {
+		t.Errorf("default layout = %+v, want 48px icons and 10px padding", got)
+	}
+}
+
+func TestCapLifetime(t *testing.T) {
+	tests := []struct {
+		timeout, maxLifetime int
+		urgency              Urgency
+		want                 int
+	}{
+		{5000, 0, UrgencyNormal, 5000},
+		{5000, 3000, UrgencyNormal, 3000},
+		{2000, 3000, UrgencyLow, 2000},
+		{-1, 3000, UrgencyNormal, 3000},
+		{-1, 3000, UrgencyCritical, -1},
+	}
+
+	for _, tt := range tests {
+		if got := capLifetime(tt.timeout, tt.maxLifetime, tt.urgency); got != tt.want {
+			t.Errorf("capLifetime(%d, %d, %v) = %d, want %d", tt.timeout, tt.maxLifetime, tt.urgency, got, tt.want)
+		}
+	}
+}
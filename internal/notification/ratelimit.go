@@ -0,0 +1,86 @@
+package notification
+
+import (
+	"sync"
+	"time"
+)
+
+const maxRateLimitBuckets = 256
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter is a per-app token bucket limiting how many banners an app can show
+type rateLimiter struct {
+	burst    int
+	interval time.Duration
+	buckets  map[string]*tokenBucket
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+func newRateLimiter(burst int, interval time.Duration) *rateLimiter {
+	return &rateLimiter{
+		burst:    burst,
+		interval: interval,
+		buckets:  make(map[string]*tokenBucket),
+		now:      time.Now,
+	}
+}
+
+func (r *rateLimiter) enabled() bool {
+	return r != nil && r.burst > 0 && r.interval > 0
+}
+
+// Allow reports whether appName may show another banner, consuming a token
+// if so. Critical notifications are always allowed.
+func (r *rateLimiter) Allow(appName string, urgency Urgency) bool {
+	if !r.enabled() || urgency == UrgencyCritical {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	bucket, ok := r.buckets[appName]
+	if !ok {
+		if len(r.buckets) >= maxRateLimitBuckets {
+			r.pruneLocked(now)
+		}
+		bucket = &tokenBucket{tokens: float64(r.burst), last: now}
+		r.buckets[appName] = bucket
+	}
+
+	r.refillLocked(bucket, now)
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+func (r *rateLimiter) refillLocked(bucket *tokenBucket, now time.Time) {
+	elapsed := now.Sub(bucket.last)
+	if elapsed <= 0 {
+		return
+	}
+	bucket.tokens += float64(elapsed) / float64(r.interval)
+	if bucket.tokens > float64(r.burst) {
+		bucket.tokens = float64(r.burst)
+	}
+	bucket.last = now
+}
+
+// pruneLocked drops buckets that have refilled completely
+func (r *rateLimiter) pruneLocked(now time.Time) {
+	for app, bucket := range r.buckets {
+		r.refillLocked(bucket, now)
+		if bucket.tokens >= float64(r.burst) {
+			delete(r.buckets, app)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+func TestRateLimiterRefill(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(2, time.Second)
+	limiter.now = func() time.Time { return now }
+
+	if !limiter.Allow("spam", UrgencyNormal) || !limiter.Allow("spam", UrgencyNormal) {
+		t.Fatal("Expected burst of 2 to be allowed")
+	}
+	if limiter.Allow("spam", UrgencyNormal) {
+		t.Error("Expected third notification to be limited")
+	}
+	if !limiter.Allow("other", UrgencyNormal) {
+		t.Error("Expected other apps to have their own bucket")
+	}
+	if !limiter.Allow("spam", UrgencyCritical) {
+		t.Error("Expected critical notifications to be exempt")
+	}
+
+	now = now.Add(time.Second)
+	if !limiter.Allow("spam", UrgencyNormal) {
+		t.Error("Expected a token to be regained after the interval")
+	}
+	if limiter.Allow("spam", UrgencyNormal) {
+		t.Error("Expected only one token to be regained")
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := newRateLimiter(0, time.Second)
+	for i := 0; i < 50; i++ {
+		if !limiter.Allow("spam", UrgencyLow) {
+			t.Fatal("Expected disabled limiter to allow everything")
+		}
+	}
+}
+
+func TestDaemonFloodSuppressesBanners(t *testing.T) {
+	store, err := NewStore(1000, 30, t.TempDir()+"/notifications.json")
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	cfg := config.DefaultConfig.Notification
+	cfg.Daemon.RateLimitBurst = 3
+	cfg.Daemon.RateLimitInterval = 60000
+	d := NewDaemon(store, nil, &cfg)
+
+	banners := 0
+	for i := 0; i < 20; i++ {
+		notif := &Notification{ID: fmt.Sprintf("spam-%d", i), AppName: "spammer", Urgency: UrgencyNormal, Timestamp: time.Now()}
+		if d.admit(notif) {
+			banners++
+		}
+	}
+
+	if banners != 3 {
+		t.Errorf("Expected 3 banners from the flood, got %d", banners)
+	}
+	if got := len(store.GetNotifications(0)); got != 20 {
+		t.Errorf("Expected all 20 notifications in history, got %d", got)
+	}
+
+	critical := &Notification{ID: "urgent", AppName: "spammer", Urgency: UrgencyCritical, Timestamp: time.Now()}
+	if !d.admit(critical) {
+		t.Error("Expected critical notification banner despite the flood")
+	}
+}
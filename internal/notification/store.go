@@ -1,10 +1,13 @@
 package notification
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
@@ -16,6 +19,19 @@ type Store struct {
 	maxAgeDays    int
 	persistPath   string
 	eventChan     chan NotificationEvent
+	doNotDisturb  bool
+
+	// saveInterval batches history writes: changes are saved at most this
+	// often. Zero saves on every change.
+	saveInterval time.Duration
+	saveTimer    *time.Timer
+	dirty        bool
+	writeFile    func(path string, data []byte, perm os.FileMode) error
+
+	keepImportant bool             // evict read, non-critical notifications first
+	now           func() time.Time // clock for age pruning
+	pruneStop     chan struct{}
+	pruneDone     chan struct{} // closed once the pruning goroutine exits
 }
 
 func NewStore(maxHistory, maxAgeDays int, persistPath string) (*Store, error) {
@@ -25,6 +41,8 @@ func NewStore(maxHistory, maxAgeDays int, persistPath string) (*Store, error) {
 		maxAgeDays:    maxAgeDays,
 		persistPath:   persistPath,
 		eventChan:     make(chan NotificationEvent, 100),
+		writeFile:     os.WriteFile,
+		now:           time.Now,
 	}
 
 	if err := s.load(); err != nil {
@@ -51,6 +69,7 @@ func (s *Store) AddNotification(notif *Notification) error {
 		NotificationID: notif.ID,
 		UnreadCount:    s.getUnreadCountLocked(),
 	})
+	s.changedLocked()
 
 	return nil
 }
@@ -66,6 +85,7 @@ func (s *Store) RemoveNotification(id string) bool {
 			NotificationID: id,
 			UnreadCount:    s.getUnreadCountLocked(),
 		})
+		s.changedLocked()
 		return true
 	}
 	return false
@@ -83,6 +103,7 @@ func (s *Store) MarkAsRead(id string) bool {
 				NotificationID: id,
 				UnreadCount:    s.getUnreadCountLocked(),
 			})
+			s.changedLocked()
 		}
 		return true
 	}
@@ -106,6 +127,7 @@ func (s *Store) MarkAllAsRead() int {
 			Type:        "unread_count_changed",
 			UnreadCount: s.getUnreadCountLocked(),
 		})
+		s.changedLocked()
 	}
 
 	return count
@@ -122,6 +144,7 @@ func (s *Store) ClearAll() int {
 		Type:        "notifications_cleared",
 		UnreadCount: 0,
 	})
+	s.changedLocked()
 
 	return count
 }
@@ -142,18 +165,62 @@ func (s *Store) GetNotifications(limit int) []*Notification {
 	return notifications
 }
 
-func (s *Store) GetUnreadNotifications() []*Notification {
+// GetNotification returns a stored notification by ID
+func (s *Store) GetNotification(id string) (*Notification, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	unread := make([]*Notification, 0)
+	notif, exists := s.notifications[id]
+	return notif, exists
+}
+
+// MarkActionInvoked records that an action of a stored notification ran
+func (s *Store) MarkActionInvoked(id, actionKey string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notif, exists := s.notifications[id]
+	if !exists {
+		return false
+	}
+	for i := range notif.Actions {
+		if notif.Actions[i].Key == actionKey {
+			notif.Actions[i].Invoked = true
+			s.changedLocked()
+			return true
+		}
+	}
+	return false
+}
+
+// nextBusID returns the D-Bus notification ID to continue from, past every
+// ID in history, so IDs handed out after a restart don't collide with ones
+// apps may still act on
+func (s *Store) nextBusID() uint32 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	next := uint32(1)
 	for _, notif := range s.notifications {
-		if !notif.Read {
-			unread = append(unread, notif)
+		if notif.Bus != nil && notif.Bus.NotificationID >= next {
+			next = notif.Bus.NotificationID + 1
 		}
 	}
+	return next
+}
+
+func (s *Store) GetUnread() []*Notification {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return filterNotifications(s.toSlice(), time.Time{}, true)
+}
 
-	return unread
+func (s *Store) GetNotificationsSince(t time.Time) []*Notification {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return filterNotifications(s.toSlice(), t, false)
 }
 
 func (s *Store) GetNotificationsByApp(appName string) []*Notification {
@@ -199,6 +266,54 @@ func (s *Store) GetUnreadCount() int {
 	return s.getUnreadCountLocked()
 }
 
+func (s *Store) SetDoNotDisturb(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.doNotDisturb == enabled {
+		return
+	}
+	s.doNotDisturb = enabled
+	s.emitEvent(NotificationEvent{
+		Type:        "dnd_changed",
+		UnreadCount: s.getUnreadCountLocked(),
+	})
+}
+
+func (s *Store) DoNotDisturb() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.doNotDisturb
+}
+
+// Status returns aggregate counts and DND state in a single snapshot
+func (s *Store) Status() NotificationStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return computeStatus(s.toSlice(), s.doNotDisturb)
+}
+
+func computeStatus(notifs []*Notification, dnd bool) NotificationStatus {
+	status := NotificationStatus{
+		Total:        len(notifs),
+		PerApp:       make(map[string]int),
+		UnreadPerApp: make(map[string]int),
+		DoNotDisturb: dnd,
+	}
+
+	for _, notif := range notifs {
+		status.PerApp[notif.AppName]++
+		if !notif.Read {
+			status.Unread++
+			status.UnreadPerApp[notif.AppName]++
+		}
+	}
+
+	return status
+}
+
 func (s *Store) getUnreadCountLocked() int {
 	count := 0
 	for _, notif := range s.notifications {
@@ -214,20 +329,79 @@ func (s *Store) Events() <-chan NotificationEvent {
 }
 
 func (s *Store) Close() {
+	s.StopPruning()
 	close(s.eventChan)
 	s.Save()
 }
 
+// SetSaveInterval batches history writes so changes are saved at most once
+// per interval. Zero saves on every change.
+func (s *Store) SetSaveInterval(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.saveInterval = interval
+}
+
+// changedLocked records a change to the history, saving it now or
+// scheduling a batched save
+func (s *Store) changedLocked() {
+	s.dirty = true
+	if s.saveInterval <= 0 {
+		if err := s.saveLocked(); err != nil {
+			log.Printf("Failed to save notification history: %v", err)
+		}
+		return
+	}
+	if s.saveTimer == nil {
+		s.saveTimer = time.AfterFunc(s.saveInterval, s.flushScheduled)
+	}
+}
+
+// flushScheduled runs when a batched save is due
+func (s *Store) flushScheduled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.saveTimer = nil
+	if !s.dirty {
+		return
+	}
+	if err := s.saveLocked(); err != nil {
+		log.Printf("Failed to save notification history: %v", err)
+	}
+}
+
+// Flush saves pending changes immediately
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.saveTimer != nil {
+		s.saveTimer.Stop()
+		s.saveTimer = nil
+	}
+	if !s.dirty {
+		return nil
+	}
+	return s.saveLocked()
+}
+
 func (s *Store) Save() error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.saveTimer != nil {
+		s.saveTimer.Stop()
+		s.saveTimer = nil
+	}
+	return s.saveLocked()
+}
 
-	data := struct {
-		Notifications []*Notification `json:"notifications"`
-		Version       int             `json:"version"`
-	}{
+func (s *Store) saveLocked() error {
+	data := persistedHistory{
 		Notifications: s.toSlice(),
-		Version:       1,
+		Version:       historyVersion,
 	}
 
 	jsonData, err := json.MarshalIndent(data, "", "  ")
@@ -239,13 +413,80 @@ func (s *Store) Save() error {
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
-	if err := os.WriteFile(s.persistPath, jsonData, 0644); err != nil {
+	if err := s.writeFile(s.persistPath, jsonData, 0644); err != nil {
 		return fmt.Errorf("failed to write notification history: %w", err)
 	}
 
+	s.dirty = false
 	return nil
 }
 
+// historyVersion is the current schema version of the persisted history file
+const historyVersion = 1
+
+type persistedHistory struct {
+	Notifications []*Notification `json:"notifications"`
+	Version       int             `json:"version"`
+}
+
+// historyMigrations upgrades notifications persisted at version i to i+1
+var historyMigrations = []func([]*Notification) []*Notification{
+	migrateHistoryV0,
+}
+
+// migrateHistoryV0 drops empty entries and assigns IDs to entries missing one;
+// v0 files were written before IDs and the version field were required
+func migrateHistoryV0(notifs []*Notification) []*Notification {
+	migrated := make([]*Notification, 0, len(notifs))
+	for i, notif := range notifs {
+		if notif == nil {
+			continue
+		}
+		if notif.ID == "" {
+			notif.ID = fmt.Sprintf("migrated-%d-%d", notif.Timestamp.UnixNano(), i)
+		}
+		migrated = append(migrated, notif)
+	}
+	return migrated
+}
+
+// decodeHistory parses a persisted history file, migrating older schema
+// versions. It returns the version the file was written with.
+func decodeHistory(data []byte) ([]*Notification, int, error) {
+	var loaded persistedHistory
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		// v0: a bare array of notifications
+		if err := json.Unmarshal(trimmed, &loaded.Notifications); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal notifications: %w", err)
+		}
+	} else if err := json.Unmarshal(trimmed, &loaded); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal notifications: %w", err)
+	}
+
+	if loaded.Version < 0 || loaded.Version > historyVersion {
+		return nil, loaded.Version, fmt.Errorf("unsupported notification history version %d", loaded.Version)
+	}
+
+	notifs := loaded.Notifications
+	for v := loaded.Version; v < historyVersion; v++ {
+		notifs = historyMigrations[v](notifs)
+	}
+
+	return notifs, loaded.Version, nil
+}
+
+// backupHistory copies the history file aside before it is migrated or discarded
+func (s *Store) backupHistory(data []byte, suffix string) string {
+	backupPath := fmt.Sprintf("%s.%s.bak", s.persistPath, suffix)
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		log.Printf("Failed to back up notification history to %s: %v", backupPath, err)
+		return ""
+	}
+	return backupPath
+}
+
 func (s *Store) load() error {
 	if _, err := os.Stat(s.persistPath); os.IsNotExist(err) {
 		return nil
@@ -256,62 +497,69 @@ func (s *Store) load() error {
 		return fmt.Errorf("failed to read notification history: %w", err)
 	}
 
-	var loaded struct {
-		Notifications []*Notification `json:"notifications"`
-		Version       int             `json:"version"`
+	s.notifications = make(map[string]*Notification)
+
+	notifs, version, err := decodeHistory(data)
+	if err != nil {
+		backupPath := s.backupHistory(data, fmt.Sprintf("corrupt-%d", time.Now().Unix()))
+		log.Printf("Discarding unreadable notification history (%v), backed up to %s", err, backupPath)
+		return nil
 	}
 
-	if err := json.Unmarshal(data, &loaded); err != nil {
-		return fmt.Errorf("failed to unmarshal notifications: %w", err)
+	if version < historyVersion {
+		backupPath := s.backupHistory(data, fmt.Sprintf("v%d", version))
+		log.Printf("Migrated notification history from version %d to %d, backed up to %s", version, historyVersion, backupPath)
 	}
 
-	s.notifications = make(map[string]*Notification)
-	for _, notif := range loaded.Notifications {
+	for _, notif := range notifs {
 		s.notifications[notif.ID] = notif
 	}
 
 	return nil
 }
 
-func (s *Store) evictOldest() {
-	if len(s.notifications) <= s.maxHistory {
-		return
-	}
+// SetKeepImportant makes eviction past maxHistory remove read, non-critical
+// notifications before unread or critical ones
+func (s *Store) SetKeepImportant(keep bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	type timestampedNotif struct {
-		id        string
-		timestamp time.Time
-	}
+	s.keepImportant = keep
+}
 
-	notifs := make([]timestampedNotif, 0, len(s.notifications))
-	for id, notif := range s.notifications {
-		notifs = append(notifs, timestampedNotif{id: id, timestamp: notif.Timestamp})
-	}
+// isImportant reports whether notif should outlive others on eviction
+func isImportant(notif *Notification) bool {
+	return !notif.Read || notif.Urgency == UrgencyCritical
+}
 
-	for i := 0; i < len(notifs); i++ {
-		for j := i + 1; j < len(notifs); j++ {
-			if notifs[i].timestamp.After(notifs[j].timestamp) {
-				notifs[i], notifs[j] = notifs[j], notifs[i]
-			}
-		}
+// evictOldest removes the oldest notifications beyond maxHistory
+func (s *Store) evictOldest() {
+	excess := len(s.notifications) - s.maxHistory
+	if excess <= 0 {
+		return
 	}
 
-	oldestIDs := make([]string, 0)
-	for i := 0; i < len(notifs)-s.maxHistory; i++ {
-		oldestIDs = append(oldestIDs, notifs[i].id)
+	notifs := s.toSlice()
+	sort.Slice(notifs, func(i, j int) bool {
+		return notifs[i].Timestamp.Before(notifs[j].Timestamp)
+	})
+	if s.keepImportant {
+		sort.SliceStable(notifs, func(i, j int) bool {
+			return !isImportant(notifs[i]) && isImportant(notifs[j])
+		})
 	}
 
-	for _, id := range oldestIDs {
-		delete(s.notifications, id)
+	for _, notif := range notifs[:excess] {
+		delete(s.notifications, notif.ID)
 	}
 }
 
+// cleanupExpired removes notifications older than maxAgeDays
 func (s *Store) cleanupExpired() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	now := time.Now()
-	cutoff := now.AddDate(0, 0, -s.maxAgeDays)
+	cutoff := s.now().AddDate(0, 0, -s.maxAgeDays)
 
 	removed := 0
 	for id, notif := range s.notifications {
@@ -321,9 +569,84 @@ func (s *Store) cleanupExpired() int {
 		}
 	}
 
+	if removed > 0 {
+		s.emitEvent(NotificationEvent{
+			Type:        "unread_count_changed",
+			UnreadCount: s.getUnreadCountLocked(),
+		})
+		s.changedLocked()
+	}
+
 	return removed
 }
 
+// StartPruning removes expired notifications every interval until
+// StopPruning or Close
+func (s *Store) StartPruning(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pruneStop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	s.pruneStop = stop
+	s.pruneDone = done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if removed := s.cleanupExpired(); removed > 0 {
+					log.Printf("Pruned %d expired notifications", removed)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopPruning stops the pruning started by StartPruning, waiting for a
+// pass in progress to finish
+func (s *Store) StopPruning() {
+	s.mu.Lock()
+	stop, done := s.pruneStop, s.pruneDone
+	s.pruneStop, s.pruneDone = nil, nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		// A pass in progress needs s.mu, so wait without holding it
+		close(stop)
+		<-done
+	}
+}
+
+// filterNotifications returns notifications at or after since (when non-zero),
+// optionally unread only, sorted newest first
+func filterNotifications(notifs []*Notification, since time.Time, unreadOnly bool) []*Notification {
+	filtered := make([]*Notification, 0, len(notifs))
+	for _, notif := range notifs {
+		if unreadOnly && notif.Read {
+			continue
+		}
+		if !since.IsZero() && notif.Timestamp.Before(since) {
+			continue
+		}
+		filtered = append(filtered, notif)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Timestamp.After(filtered[j].Timestamp)
+	})
+
+	return filtered
+}
+
 func (s *Store) toSlice() []*Notification {
 	notifications := make([]*Notification, 0, len(s.notifications))
 	for _, notif := range s.notifications {
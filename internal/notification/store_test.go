@@ -0,0 +1,465 @@
+package notification
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := NewStore(100, 30, filepath.Join(t.TempDir(), "notifications.json"))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	return store
+}
+
+func TestStoreTimeAndUnreadQueries(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+
+	notifs := []*Notification{
+		{ID: "old-read", Timestamp: now.Add(-48 * time.Hour), Read: true},
+		{ID: "old-unread", Timestamp: now.Add(-48 * time.Hour)},
+		{ID: "recent-read", Timestamp: now.Add(-30 * time.Minute), Read: true},
+		{ID: "recent-unread", Timestamp: now.Add(-10 * time.Minute)},
+		{ID: "newest-unread", Timestamp: now.Add(-1 * time.Minute)},
+	}
+	for _, n := range notifs {
+		store.AddNotification(n)
+	}
+
+	ids := func(list []*Notification) []string {
+		out := make([]string, len(list))
+		for i, n := range list {
+			out[i] = n.ID
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		got      []*Notification
+		expected []string
+	}{
+		{"since hour", store.GetNotificationsSince(now.Add(-time.Hour)), []string{"newest-unread", "recent-unread", "recent-read"}},
+		{"since future", store.GetNotificationsSince(now.Add(time.Hour)), []string{}},
+		{"unread", store.GetUnread(), []string{"newest-unread", "recent-unread", "old-unread"}},
+		{"unread since hour", filterNotifications(store.GetUnread(), now.Add(-time.Hour), false), []string{"newest-unread", "recent-unread"}},
+	}
+
+	for _, tt := range tests {
+		got := ids(tt.got)
+		if len(got) != len(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+				break
+			}
+		}
+	}
+}
+
+func TestParseSinceParam(t *testing.T) {
+	if since, err := parseSinceParam(nil); err != nil || !since.IsZero() {
+		t.Errorf("Expected zero time for missing param, got %v (%v)", since, err)
+	}
+	if since, err := parseSinceParam(float64(1700000000)); err != nil || since.Unix() != 1700000000 {
+		t.Errorf("Expected unix timestamp, got %v (%v)", since, err)
+	}
+	if since, err := parseSinceParam("2024-01-02T03:04:05Z"); err != nil || since.Year() != 2024 {
+		t.Errorf("Expected RFC3339 time, got %v (%v)", since, err)
+	}
+	if _, err := parseSinceParam("yesterday"); err == nil {
+		t.Error("Expected error for invalid since string")
+	}
+}
+
+func TestStoreLoadMigratesV0History(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.json")
+	now := time.Now().UTC().Format(time.RFC3339)
+	v0 := `[
+		{"id": "a", "app_name": "mail", "summary": "Hello", "timestamp": "` + now + `"},
+		{"app_name": "chat", "summary": "No id", "timestamp": "` + now + `"},
+		null
+	]`
+	if err := os.WriteFile(path, []byte(v0), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewStore(100, 30, path)
+	if err != nil {
+		t.Fatalf("NewStore failed on v0 history: %v", err)
+	}
+
+	if got := len(store.GetNotifications(0)); got != 2 {
+		t.Errorf("Expected 2 migrated notifications, got %d", got)
+	}
+	for _, notif := range store.GetNotifications(0) {
+		if notif.ID == "" {
+			t.Error("Expected migrated notification to have an ID")
+		}
+	}
+
+	backup, err := os.ReadFile(path + ".v0.bak")
+	if err != nil {
+		t.Fatalf("Expected v0 backup: %v", err)
+	}
+	if string(backup) != v0 {
+		t.Error("Expected backup to contain the original file")
+	}
+
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if _, version, err := decodeHistory(data); err != nil || version != historyVersion {
+		t.Errorf("Expected saved history at version %d, got %d (%v)", historyVersion, version, err)
+	}
+}
+
+func TestStoreLoadBacksUpUnreadableHistory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notifications.json")
+
+	for _, content := range []string{`{not json`, `{"version": 99, "notifications": []}`} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		store, err := NewStore(100, 30, path)
+		if err != nil {
+			t.Fatalf("Expected unreadable history to be discarded, got error: %v", err)
+		}
+		if got := store.GetUnreadCount(); got != 0 {
+			t.Errorf("Expected empty store, got %d unread", got)
+		}
+
+		backups, _ := filepath.Glob(filepath.Join(dir, "notifications.json.corrupt-*.bak"))
+		if len(backups) == 0 {
+			t.Errorf("Expected a backup for %q", content)
+		}
+		for _, b := range backups {
+			os.Remove(b)
+		}
+	}
+}
+
+func TestComputeStatus(t *testing.T) {
+	notifs := []*Notification{
+		{ID: "1", AppName: "mail"},
+		{ID: "2", AppName: "mail", Read: true},
+		{ID: "3", AppName: "chat"},
+		{ID: "4", AppName: "chat"},
+		{ID: "5", AppName: "updates", Read: true},
+	}
+
+	status := computeStatus(notifs, true)
+
+	if status.Total != 5 || status.Unread != 3 || !status.DoNotDisturb {
+		t.Errorf("Unexpected totals: %+v", status)
+	}
+	expectedPerApp := map[string]int{"mail": 2, "chat": 2, "updates": 1}
+	for app, count := range expectedPerApp {
+		if status.PerApp[app] != count {
+			t.Errorf("Expected %d notifications for %s, got %d", count, app, status.PerApp[app])
+		}
+	}
+	expectedUnread := map[string]int{"mail": 1, "chat": 2}
+	for app, count := range expectedUnread {
+		if status.UnreadPerApp[app] != count {
+			t.Errorf("Expected %d unread for %s, got %d", count, app, status.UnreadPerApp[app])
+		}
+	}
+	if _, ok := status.UnreadPerApp["updates"]; ok {
+		t.Error("Expected no unread entry for fully read app")
+	}
+
+	empty := computeStatus(nil, false)
+	if empty.Total != 0 || empty.Unread != 0 || empty.DoNotDisturb {
+		t.Errorf("Unexpected empty status: %+v", empty)
+	}
+}
+
+func TestStorePersistsActionsAndBusInfo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.json")
+	store, err := NewStore(100, 30, path)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	store.AddNotification(&Notification{
+		ID:        "with-actions",
+		AppName:   "Mail",
+		Summary:   "New message",
+		Timestamp: time.Now(),
+		Actions: []Action{
+			{Key: "default", Label: "Open"},
+			{Key: "archive", Label: "Archive"},
+		},
+		Hints: map[string]string{"desktop-entry": "org.example.Mail"},
+		Bus:   &BusInfo{Sender: ":1.42", NotificationID: 17, BusID: "4f2a"},
+	})
+	store.AddNotification(&Notification{ID: "legacy", Timestamp: time.Now()})
+
+	if !store.MarkActionInvoked("with-actions", "archive") {
+		t.Fatal("expected archive action to be marked invoked")
+	}
+	if store.MarkActionInvoked("with-actions", "reply") {
+		t.Error("unknown action should not be marked invoked")
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, err := NewStore(100, 30, path)
+	if err != nil {
+		t.Fatalf("reloading store failed: %v", err)
+	}
+
+	notif, ok := reloaded.GetNotification("with-actions")
+	if !ok {
+		t.Fatal("notification missing after reload")
+	}
+	if len(notif.Actions) != 2 {
+		t.Fatalf("expected 2 actions after reload, got %+v", notif.Actions)
+	}
+	if notif.Actions[0] != (Action{Key: "default", Label: "Open"}) {
+		t.Errorf("default action = %+v", notif.Actions[0])
+	}
+	if notif.Actions[1] != (Action{Key: "archive", Label: "Archive", Invoked: true}) {
+		t.Errorf("archive action = %+v", notif.Actions[1])
+	}
+	if notif.Bus == nil || *notif.Bus != (BusInfo{Sender: ":1.42", NotificationID: 17, BusID: "4f2a"}) {
+		t.Errorf("bus info = %+v, want sender :1.42 id 17", notif.Bus)
+	}
+	if notif.Hints["desktop-entry"] != "org.example.Mail" {
+		t.Errorf("desktop-entry hint = %q", notif.Hints["desktop-entry"])
+	}
+	if !notif.HasAction("default") || notif.HasAction("reply") {
+		t.Error("HasAction does not reflect the stored actions")
+	}
+
+	legacy, ok := reloaded.GetNotification("legacy")
+	if !ok || legacy.Bus != nil {
+		t.Errorf("notification without bus info should reload without it, got %+v", legacy)
+	}
+
+	// IDs handed out after a restart continue past the stored ones
+	if next := reloaded.nextBusID(); next != 18 {
+		t.Errorf("nextBusID() = %d, want 18", next)
+	}
+}
+
+// countWrites makes store count its history writes
+func countWrites(store *Store) *int32 {
+	var writes int32
+	store.writeFile = func(path string, data []byte, perm os.FileMode) error {
+		atomic.AddInt32(&writes, 1)
+		return os.WriteFile(path, data, perm)
+	}
+	return &writes
+}
+
+func TestStoreBatchesSaves(t *testing.T) {
+	store := newTestStore(t)
+	writes := countWrites(store)
+	store.SetSaveInterval(50 * time.Millisecond)
+
+	for i := 0; i < 20; i++ {
+		store.AddNotification(&Notification{ID: fmt.Sprintf("n%d", i), Timestamp: time.Now()})
+	}
+	store.MarkAllAsRead()
+	if got := atomic.LoadInt32(writes); got != 0 {
+		t.Fatalf("Expected no writes before the interval, got %d", got)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(writes) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(writes); got != 1 {
+		t.Errorf("Expected the changes to be saved in one write, got %d", got)
+	}
+
+	reloaded, err := NewStore(100, 30, store.persistPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(reloaded.GetNotifications(0)); got != 20 {
+		t.Errorf("Expected 20 saved notifications, got %d", got)
+	}
+}
+
+func TestStoreSavesEveryChangeWithoutInterval(t *testing.T) {
+	store := newTestStore(t)
+	writes := countWrites(store)
+
+	store.AddNotification(&Notification{ID: "a", Timestamp: time.Now()})
+	store.MarkAsRead("a")
+	store.MarkAsRead("a")
+	if got := atomic.LoadInt32(writes); got != 2 {
+		t.Errorf("Expected a write per change, got %d", got)
+	}
+}
+
+func TestStoreFlushWritesPendingChanges(t *testing.T) {
+	store := newTestStore(t)
+	writes := countWrites(store)
+	store.SetSaveInterval(time.Hour)
+
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(writes); got != 0 {
+		t.Errorf("Expected no write without changes, got %d", got)
+	}
+
+	store.AddNotification(&Notification{ID: "pending", Timestamp: time.Now()})
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(writes); got != 1 {
+		t.Errorf("Expected Flush to write pending changes once, got %d", got)
+	}
+
+	reloaded, err := NewStore(100, 30, store.persistPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reloaded.GetNotification("pending"); !ok {
+		t.Error("Expected the flushed notification to be saved")
+	}
+}
+
+func TestStoreCapsHistory(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	add := func(store *Store, id string, minute int, read bool, urgency Urgency) {
+		store.AddNotification(&Notification{ID: id, Timestamp: base.Add(time.Duration(minute) * time.Minute), Read: read, Urgency: urgency})
+	}
+	ids := func(store *Store) []string {
+		var got []string
+		for _, notif := range filterNotifications(store.GetNotifications(0), time.Time{}, false) {
+			got = append(got, notif.ID)
+		}
+		return got
+	}
+
+	fifo := newTestStore(t)
+	fifo.maxHistory = 3
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		add(fifo, id, i, false, UrgencyNormal)
+	}
+	if got := fmt.Sprint(ids(fifo)); got != "[e d c]" {
+		t.Errorf("Expected the newest three kept, got %s", got)
+	}
+
+	keep := newTestStore(t)
+	keep.maxHistory = 3
+	keep.SetKeepImportant(true)
+	add(keep, "unread", 0, false, UrgencyNormal)
+	add(keep, "critical", 1, true, UrgencyCritical)
+	add(keep, "read-old", 2, true, UrgencyNormal)
+	add(keep, "read-new", 3, true, UrgencyLow)
+	add(keep, "newest", 4, true, UrgencyNormal)
+	if got := fmt.Sprint(ids(keep)); got != "[newest critical unread]" {
+		t.Errorf("Expected read notifications evicted first, got %s", got)
+	}
+
+	// With only important notifications left, the oldest go
+	add(keep, "unread-2", 5, false, UrgencyNormal)
+	add(keep, "unread-3", 6, false, UrgencyNormal)
+	if got := fmt.Sprint(ids(keep)); got != "[unread-3 unread-2 critical]" {
+		t.Errorf("Expected the oldest important notification evicted, got %s", got)
+	}
+}
+
+func TestStorePrunesExpired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := newTestStore(t)
+	store.maxAgeDays = 30
+	store.now = func() time.Time { return now }
+
+	store.AddNotification(&Notification{ID: "fresh", Timestamp: now.AddDate(0, 0, -29)})
+	store.AddNotification(&Notification{ID: "stale", Timestamp: now.AddDate(0, 0, -31)})
+
+	if removed := store.cleanupExpired(); removed != 1 {
+		t.Errorf("Expected 1 expired notification removed, got %d", removed)
+	}
+	if _, ok := store.GetNotification("stale"); ok {
+		t.Error("Expected the stale notification to be pruned")
+	}
+
+	now = now.AddDate(0, 0, 2)
+	store.cleanupExpired()
+	if _, ok := store.GetNotification("fresh"); ok {
+		t.Error("Expected the notification to be pruned once it ages out")
+	}
+}
+
+func TestStoreCloseWaitsForPruning(t *testing.T) {
+	now := time.Now()
+	store := newTestStore(t)
+	store.AddNotification(&Notification{ID: "stale", Timestamp: now.AddDate(0, 0, -31)})
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	store.now = func() time.Time {
+		select {
+		case entered <- struct{}{}:
+			<-release
+		default:
+		}
+		return now
+	}
+	store.StartPruning(time.Millisecond)
+	<-entered
+
+	closed := make(chan struct{})
+	go func() {
+		store.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Expected Close to wait for the pruning pass in progress")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// The pass emits an event for the pruned notification, which must not
+	// hit a closed channel
+	close(release)
+	<-closed
+}
+
+func TestStorePrunesExpiredOnLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.json")
+	store, err := NewStore(100, 30, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.AddNotification(&Notification{ID: "fresh", Timestamp: time.Now()})
+	store.AddNotification(&Notification{ID: "stale", Timestamp: time.Now().AddDate(0, 0, -31)})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewStore(100, 30, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reloaded.GetNotification("stale"); ok {
+		t.Error("Expected the stale notification to be pruned on load")
+	}
+	if _, ok := reloaded.GetNotification("fresh"); !ok {
+		t.Error("Expected the fresh notification to survive loading")
+	}
+}
@@ -45,6 +45,34 @@ type Notification struct {
 	Urgency       Urgency           `json:"urgency"`
 	Read          bool              `json:"read"`
 	ReplacesID    uint32            `json:"replaces_id,omitempty"`
+	Bus           *BusInfo          `json:"bus,omitempty"`
+	ImageData     *ImageData        `json:"-"` // from the image-data hint, not persisted
+	IconData      *ImageData        `json:"-"` // from the legacy icon_data hint, not persisted
+}
+
+// BusInfo records the D-Bus client that sent a notification so its actions
+// can still be invoked from history after the daemon restarts
+type BusInfo struct {
+	Sender         string `json:"sender"`           // unique bus name, e.g. ":1.42"
+	NotificationID uint32 `json:"notification_id"`  // ID returned from Notify
+	BusID          string `json:"bus_id,omitempty"` // ID of the bus Sender was on
+}
+
+// sameBus reports whether the sender was on the bus with ID busID. Unique
+// names are only unique within one bus: a new session reuses them for
+// unrelated clients.
+func (b *BusInfo) sameBus(busID string) bool {
+	return b != nil && b.Sender != "" && b.BusID != "" && b.BusID == busID
+}
+
+// HasAction reports whether the notification offers the given action key
+func (n *Notification) HasAction(key string) bool {
+	for _, action := range n.Actions {
+		if action.Key == key {
+			return true
+		}
+	}
+	return false
 }
 
 type Action struct {
@@ -68,6 +96,14 @@ type NotificationEvent struct {
 	UnreadCount    int    `json:"unread_count"`
 }
 
+type NotificationStatus struct {
+	Total        int            `json:"total"`
+	Unread       int            `json:"unread"`
+	PerApp       map[string]int `json:"per_app"`
+	UnreadPerApp map[string]int `json:"unread_per_app"`
+	DoNotDisturb bool           `json:"dnd"`
+}
+
 type BannerPosition struct {
 	Corner Corner
 	X      int
@@ -0,0 +1,73 @@
+package statusbar
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Default reconnect backoff for socket listeners
+const (
+	DefaultBackoffBase   = time.Second
+	DefaultBackoffMax    = time.Minute
+	DefaultBackoffFactor = 2.0
+	DefaultBackoffJitter = 0.2
+)
+
+// Backoff produces exponentially growing delays, capped at Max, with up to
+// Jitter (a fraction of the delay) randomly taken off each one
+type Backoff struct {
+	Base    time.Duration
+	Max     time.Duration
+	Factor  float64
+	Jitter  float64
+	attempt int
+	rand    func() float64
+}
+
+// NewBackoff creates a backoff with the given base, cap and growth factor
+// and the default jitter
+func NewBackoff(base, max time.Duration, factor float64) *Backoff {
+	return &Backoff{
+		Base:   base,
+		Max:    max,
+		Factor: factor,
+		Jitter: DefaultBackoffJitter,
+		rand:   rand.Float64,
+	}
+}
+
+// Delay returns the delay before the given attempt (0-based), without jitter
+func (b *Backoff) Delay(attempt int) time.Duration {
+	factor := b.Factor
+	if factor < 1 {
+		factor = 1
+	}
+
+	delay := float64(b.Base) * math.Pow(factor, float64(attempt))
+	if b.Max > 0 && delay > float64(b.Max) {
+		return b.Max
+	}
+	return time.Duration(delay)
+}
+
+// Next returns the jittered delay for the next attempt and advances
+func (b *Backoff) Next() time.Duration {
+	delay := b.Delay(b.attempt)
+	b.attempt++
+
+	if b.Jitter > 0 && b.rand != nil {
+		delay -= time.Duration(float64(delay) * b.Jitter * b.rand())
+	}
+	return delay
+}
+
+// Attempt returns how many delays have been handed out since the last reset
+func (b *Backoff) Attempt() int {
+	return b.attempt
+}
+
+// Reset starts the sequence over from Base
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
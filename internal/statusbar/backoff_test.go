@@ -0,0 +1,81 @@
+package statusbar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffSequence(t *testing.T) {
+	b := NewBackoff(100*time.Millisecond, time.Second, 2)
+	b.Jitter = 0
+
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, w := range want {
+		if got := b.Next(); got != w {
+			t.Errorf("attempt %d: Next() = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestBackoffFactor(t *testing.T) {
+	b := NewBackoff(time.Second, time.Minute, 3)
+	if got := b.Delay(2); got != 9*time.Second {
+		t.Errorf("Delay(2) = %v, want 9s", got)
+	}
+
+	// Factors below 1 never shrink the delay
+	b = NewBackoff(time.Second, time.Minute, 0.5)
+	if got := b.Delay(3); got != time.Second {
+		t.Errorf("Delay(3) with factor 0.5 = %v, want 1s", got)
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	b := NewBackoff(time.Second, 10*time.Second, 2)
+	b.Jitter = 0.5
+
+	b.rand = func() float64 { return 0 }
+	if got := b.Next(); got != time.Second {
+		t.Errorf("no jitter drawn: Next() = %v, want 1s", got)
+	}
+
+	b.rand = func() float64 { return 1 }
+	if got := b.Next(); got != time.Second {
+		t.Errorf("full jitter: Next() = %v, want 1s (half of 2s)", got)
+	}
+
+	// Jitter only ever shortens delays, so the cap holds
+	b.rand = func() float64 { return 0.999 }
+	for i := 0; i < 10; i++ {
+		if got := b.Next(); got > 10*time.Second || got <= 0 {
+			t.Errorf("attempt %d: Next() = %v, want within (0, 10s]", b.Attempt(), got)
+		}
+	}
+}
+
+func TestBackoffReset(t *testing.T) {
+	b := NewBackoff(time.Second, time.Minute, 2)
+	b.Jitter = 0
+
+	b.Next()
+	b.Next()
+	b.Next()
+	if b.Attempt() != 3 {
+		t.Fatalf("Attempt() = %d, want 3", b.Attempt())
+	}
+
+	b.Reset()
+	if b.Attempt() != 0 {
+		t.Errorf("Attempt() after Reset = %d, want 0", b.Attempt())
+	}
+	if got := b.Next(); got != time.Second {
+		t.Errorf("Next() after Reset = %v, want base 1s", got)
+	}
+}
@@ -0,0 +1,226 @@
+package statusbar
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// MissingDependencyClass is added to a module's widget while a command it
+// runs is not installed
+const MissingDependencyClass = "module-missing"
+
+// DependencyCheck reports which of the commands a module runs are missing,
+// so the module can say so instead of showing misleading output
+type DependencyCheck struct {
+	commands []string
+	lookPath func(file string) (string, error)
+}
+
+// NewDependencyCheck checks the given commands, ignoring empty ones
+func NewDependencyCheck(commands ...string) *DependencyCheck {
+	d := &DependencyCheck{lookPath: exec.LookPath}
+	for _, command := range commands {
+		if command != "" {
+			d.commands = append(d.commands, command)
+		}
+	}
+	return d
+}
+
+// SetLookPath replaces the PATH lookup, for tests
+func (d *DependencyCheck) SetLookPath(lookPath func(file string) (string, error)) {
+	d.lookPath = lookPath
+}
+
+// Commands returns the commands being checked
+func (d *DependencyCheck) Commands() []string {
+	if d == nil {
+		return nil
+	}
+	return d.commands
+}
+
+// Missing returns the commands that are not on PATH. A nil check has none.
+func (d *DependencyCheck) Missing() []string {
+	if d == nil {
+		return nil
+	}
+
+	var missing []string
+	for _, command := range d.commands {
+		if _, err := d.lookPath(command); err != nil {
+			missing = append(missing, command)
+		}
+	}
+	return missing
+}
+
+// MissingLabel is the text a module shows for missing commands
+func MissingLabel(missing []string) string {
+	return "missing: " + strings.Join(missing, ", ")
+}
+
+// ShellCommandDependency returns the program a shell command line starts
+// with, skipping leading variable assignments, or "" if there is none
+func ShellCommandDependency(command string) string {
+	for _, field := range strings.Fields(command) {
+		if name, _, ok := strings.Cut(field, "="); ok && name != "" && !strings.ContainsAny(name, "/'\"$") {
+			continue
+		}
+		if strings.ContainsAny(field, "$`'\"(|;&<>") {
+			return ""
+		}
+		return field
+	}
+	return ""
+}
+
+// SetMissingState toggles MissingDependencyClass on widget
+func SetMissingState(widget *gtk.Widget, missing bool) {
+	ctx, err := widget.GetStyleContext()
+	if err != nil {
+		return
+	}
+	if missing {
+		ctx.AddClass(MissingDependencyClass)
+	} else {
+		ctx.RemoveClass(MissingDependencyClass)
+	}
+}
+
+// SetDependencies sets the commands the module runs, which it checks with
+// DependenciesMissing before each read. Empty commands are ignored.
+func (m *BaseModule) SetDependencies(commands ...string) {
+	m.deps = NewDependencyCheck(commands...)
+}
+
+// SetDependencyLookPath replaces the PATH lookup of the dependency check,
+// for tests
+func (m *BaseModule) SetDependencyLookPath(lookPath func(file string) (string, error)) {
+	m.deps.SetLookPath(lookPath)
+}
+
+// DependencyCommands returns the commands given to SetDependencies
+func (m *BaseModule) DependencyCommands() []string {
+	return m.deps.Commands()
+}
+
+// DependenciesMissing checks the module's commands again, reporting whether
+// any is not installed; the module then skips reading its value
+func (m *BaseModule) DependenciesMissing() bool {
+	m.missing = m.deps.Missing()
+	return len(m.missing) > 0
+}
+
+// MissingText returns the text shown instead of a value while commands are
+// missing, or false if none are
+func (m *BaseModule) MissingText() (string, bool) {
+	if len(m.missing) == 0 {
+		return "", false
+	}
+	return MissingLabel(m.missing), true
+}
+
+// ShowMissingState toggles MissingDependencyClass on widget to match the
+// last DependenciesMissing check
+func (m *BaseModule) ShowMissingState(widget *gtk.Widget) {
+	SetMissingState(widget, len(m.missing) > 0)
+}
+
+// DependencyPolicy decides what happens to a module whose dependencies are
+// missing when it is created
+type DependencyPolicy string
+
+const (
+	// DependencyPolicyPlaceholder shows a "missing: ..." label in its place
+	DependencyPolicyPlaceholder DependencyPolicy = "placeholder"
+	// DependencyPolicySkip leaves the module out of the bar
+	DependencyPolicySkip DependencyPolicy = "skip"
+	// DependencyPolicyLoad loads the module anyway
+	DependencyPolicyLoad DependencyPolicy = "load"
+)
+
+// ErrMissingDependency is returned (wrapped) for modules skipped because a
+// dependency is missing
+var ErrMissingDependency = errors.New("missing dependency")
+
+// dependencyReporter is implemented by modules whose dependencies follow
+// their configuration, such as a configurable command
+type dependencyReporter interface {
+	Dependencies() []string
+}
+
+// moduleDependencies returns what module needs: its own report when it has
+// one, otherwise what its factory declares
+func moduleDependencies(factory ModuleFactory, module Module) []string {
+	if reporter, ok := module.(dependencyReporter); ok {
+		return reporter.Dependencies()
+	}
+	return factory.Dependencies()
+}
+
+// applyDependencyPolicy returns the module to register in place of module,
+// which needs the missing commands
+func applyDependencyPolicy(policy DependencyPolicy, module Module, missing []string) (Module, error) {
+	switch policy {
+	case DependencyPolicySkip:
+		module.Cleanup()
+		return nil, fmt.Errorf("%w: %s", ErrMissingDependency, strings.Join(missing, ", "))
+	case DependencyPolicyLoad:
+		return module, nil
+	default:
+		module.Cleanup()
+		return NewMissingDependencyModule(module.Name(), missing), nil
+	}
+}
+
+// MissingDependencyModule stands in for a module whose dependencies are
+// missing, showing which commands to install
+type MissingDependencyModule struct {
+	*BaseModule
+	missing []string
+}
+
+// NewMissingDependencyModule creates a placeholder for the named module
+func NewMissingDependencyModule(name string, missing []string) *MissingDependencyModule {
+	m := &MissingDependencyModule{
+		BaseModule: NewBaseModule(name, UpdateModeStatic),
+		missing:    missing,
+	}
+	m.SetCSSClasses([]string{name + "-module", MissingDependencyClass})
+	m.initialized = true
+	return m
+}
+
+// Missing returns the commands the replaced module needs
+func (m *MissingDependencyModule) Missing() []string {
+	return m.missing
+}
+
+// CurrentValue returns the placeholder text
+func (m *MissingDependencyModule) CurrentValue() string {
+	return MissingLabel(m.missing)
+}
+
+// CreateWidget creates the placeholder label
+func (m *MissingDependencyModule) CreateWidget() (gtk.IWidget, error) {
+	label, err := gtk.LabelNew(m.CurrentValue())
+	if err != nil {
+		return nil, err
+	}
+
+	helper := &WidgetHelper{}
+	if err := helper.ApplyStylesToWidget(label, m.GetStyles(), m.GetCSSClasses()); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// UpdateWidget does nothing; the placeholder never changes
+func (m *MissingDependencyModule) UpdateWidget(widget gtk.IWidget) error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package statusbar
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func fakeLookPath(installed ...string) func(string) (string, error) {
+	return func(file string) (string, error) {
+		for _, name := range installed {
+			if name == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", errors.New("executable file not found in $PATH")
+	}
+}
+
+func TestDependencyCheckMissing(t *testing.T) {
+	check := NewDependencyCheck("nmcli", "", "bluetoothctl", "mpc")
+	check.SetLookPath(fakeLookPath("nmcli"))
+
+	want := []string{"bluetoothctl", "mpc"}
+	if got := check.Missing(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Missing() = %v, want %v", got, want)
+	}
+	if got := MissingLabel(want); got != "missing: bluetoothctl, mpc" {
+		t.Errorf("MissingLabel() = %q", got)
+	}
+
+	check.SetLookPath(fakeLookPath("nmcli", "bluetoothctl", "mpc"))
+	if got := check.Missing(); got != nil {
+		t.Errorf("Missing() with everything installed = %v, want none", got)
+	}
+
+	var none *DependencyCheck
+	if got := none.Missing(); got != nil {
+		t.Errorf("nil check Missing() = %v, want none", got)
+	}
+}
+
+func TestShellCommandDependency(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"mpstat 1 1 | awk 'NR==4 {print 100 - $NF}'", "mpstat"},
+		{"  nmcli -t -f TYPE con show --active", "nmcli"},
+		{"LC_ALL=C df -h", "df"},
+		{"/usr/local/bin/status --json", "/usr/local/bin/status"},
+		{"$HOME/bin/status", ""},
+		{"(cat /proc/loadavg)", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ShellCommandDependency(tt.command); got != tt.want {
+			t.Errorf("ShellCommandDependency(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
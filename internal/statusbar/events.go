@@ -67,14 +67,17 @@ func (l *BaseEventListener) Cleanup() {
 	l.Stop()
 }
 
-// SocketEventListener handles socket-based events (e.g., sway, hyprland sockets)
+// SocketEventListener handles socket-based events (e.g., sway, hyprland sockets).
+// Hyprland-style sockets are read raw; with subscribe events set, the socket
+// speaks i3-ipc and the handler receives each event's JSON payload.
 type SocketEventListener struct {
 	*BaseEventListener
-	socketPath     string
-	socket         net.Conn
-	eventHandler   func(event string)
-	reconnectDelay time.Duration
-	maxRetries     int
+	socketPath   string
+	socket       net.Conn
+	eventHandler func(event string)
+	subscribe    []string
+	backoff      *Backoff
+	maxRetries   int
 }
 
 // NewSocketEventListener creates a new socket event listener
@@ -82,7 +85,7 @@ func NewSocketEventListener(socketPath string) *SocketEventListener {
 	return &SocketEventListener{
 		BaseEventListener: NewBaseEventListener(),
 		socketPath:        socketPath,
-		reconnectDelay:    5 * time.Second,
+		backoff:           NewBackoff(DefaultBackoffBase, DefaultBackoffMax, DefaultBackoffFactor),
 		maxRetries:        10,
 	}
 }
@@ -92,6 +95,17 @@ func (l *SocketEventListener) SetEventHandler(handler func(event string)) {
 	l.eventHandler = handler
 }
 
+// SetBackoff sets the reconnect delay base, cap and growth factor
+func (l *SocketEventListener) SetBackoff(base, max time.Duration, factor float64) {
+	l.backoff = NewBackoff(base, max, factor)
+}
+
+// SetSubscribeEvents switches the listener to i3-ipc framing and subscribes
+// to events (e.g. "workspace", "window") on every connect
+func (l *SocketEventListener) SetSubscribeEvents(events []string) {
+	l.subscribe = events
+}
+
 // ConnectToSocket connects to the Unix socket
 func (l *SocketEventListener) ConnectToSocket() error {
 	if l.socketPath == "" {
@@ -103,6 +117,13 @@ func (l *SocketEventListener) ConnectToSocket() error {
 		return fmt.Errorf("failed to connect to socket %s: %w", l.socketPath, err)
 	}
 
+	if len(l.subscribe) > 0 {
+		if err := subscribeI3IPC(conn, l.subscribe); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to subscribe on socket %s: %w", l.socketPath, err)
+		}
+	}
+
 	l.socket = conn
 	log.Printf("Connected to socket: %s", l.socketPath)
 	return nil
@@ -137,26 +158,52 @@ func (l *SocketEventListener) listen(callback func()) {
 			log.Printf("Socket listener stopped")
 			return
 		default:
-			n, err := l.socket.Read(buffer)
+			event, err := l.readEvent(buffer)
 			if err != nil {
+				if l.ctx.Err() != nil {
+					return
+				}
 				log.Printf("Socket read error: %v", err)
 
-				if l.shouldReconnect() {
-					l.reconnect(callback)
+				if l.shouldReconnect() && l.reconnect() {
 					continue
 				}
 
 				return
 			}
 
-			if n > 0 {
-				event := string(buffer[:n])
+			// The connection has proven healthy, so a later drop starts
+			// backing off from the base delay again
+			l.backoff.Reset()
+
+			if event != "" {
 				l.handleEvent(event, callback)
 			}
 		}
 	}
 }
 
+// readEvent reads the next event: an i3-ipc event payload when subscribed,
+// otherwise whatever the socket has available. Replies are skipped.
+func (l *SocketEventListener) readEvent(buffer []byte) (string, error) {
+	if len(l.subscribe) == 0 {
+		n, err := l.socket.Read(buffer)
+		if err != nil {
+			return "", err
+		}
+		return string(buffer[:n]), nil
+	}
+
+	msg, err := ReadI3IPCMessage(l.socket)
+	if err != nil {
+		return "", err
+	}
+	if !msg.IsEvent() {
+		return "", nil
+	}
+	return string(msg.Payload), nil
+}
+
 // handleEvent handles an incoming event
 func (l *SocketEventListener) handleEvent(event string, callback func()) {
 	if l.eventHandler != nil {
@@ -175,23 +222,31 @@ func (l *SocketEventListener) shouldReconnect() bool {
 	return l.maxRetries > 0
 }
 
-// reconnect attempts to reconnect to the socket
-func (l *SocketEventListener) reconnect(callback func()) {
+// reconnect attempts to reconnect to the socket with exponential backoff,
+// re-subscribing in i3-ipc mode, and reports whether it succeeded. The
+// backoff only resets once the new connection delivers data, so a socket
+// that accepts and immediately drops connections keeps backing off.
+func (l *SocketEventListener) reconnect() bool {
+	if l.socket != nil {
+		l.socket.Close()
+	}
+
 	for i := 0; i < l.maxRetries; i++ {
 		select {
 		case <-l.ctx.Done():
-			return
-		case <-time.After(l.reconnectDelay):
+			return false
+		case <-time.After(l.backoff.Next()):
 			log.Printf("Attempting to reconnect to socket (attempt %d/%d)...", i+1, l.maxRetries)
 
 			if err := l.ConnectToSocket(); err == nil {
 				log.Printf("Successfully reconnected to socket")
-				return
+				return true
 			}
 		}
 	}
 
 	log.Printf("Failed to reconnect after %d attempts", l.maxRetries)
+	return false
 }
 
 // Cleanup cleans up resources
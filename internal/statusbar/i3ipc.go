@@ -0,0 +1,112 @@
+package statusbar
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// i3-ipc framing used by sway and i3: the "i3-ipc" magic string, a 32-bit
+// payload length and a 32-bit message type (both in native byte order, little
+// endian on every platform sway and i3 run on), followed by the payload
+const (
+	i3IPCMagic      = "i3-ipc"
+	i3IPCHeaderSize = len(i3IPCMagic) + 8
+
+	// i3IPCMaxPayload guards against allocating for a corrupt length
+	i3IPCMaxPayload = 16 << 20
+
+	// I3IPCSubscribe is the message type of the subscribe request
+	I3IPCSubscribe uint32 = 2
+
+	// i3IPCEventBit is set in the type of event messages
+	i3IPCEventBit uint32 = 1 << 31
+)
+
+// I3IPCMessage is a decoded i3-ipc message
+type I3IPCMessage struct {
+	Type    uint32
+	Payload []byte
+}
+
+// IsEvent reports whether the message is an event rather than a reply
+func (m I3IPCMessage) IsEvent() bool {
+	return m.Type&i3IPCEventBit != 0
+}
+
+// EventType returns the event type with the event bit cleared
+func (m I3IPCMessage) EventType() uint32 {
+	return m.Type &^ i3IPCEventBit
+}
+
+// EncodeI3IPCMessage frames a payload as an i3-ipc message
+func EncodeI3IPCMessage(msgType uint32, payload []byte) []byte {
+	buf := make([]byte, i3IPCHeaderSize+len(payload))
+	copy(buf, i3IPCMagic)
+	binary.LittleEndian.PutUint32(buf[len(i3IPCMagic):], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[len(i3IPCMagic)+4:], msgType)
+	copy(buf[i3IPCHeaderSize:], payload)
+	return buf
+}
+
+// ReadI3IPCMessage reads one framed i3-ipc message from r
+func ReadI3IPCMessage(r io.Reader) (I3IPCMessage, error) {
+	header := make([]byte, i3IPCHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return I3IPCMessage{}, err
+	}
+	if string(header[:len(i3IPCMagic)]) != i3IPCMagic {
+		return I3IPCMessage{}, fmt.Errorf("invalid i3-ipc magic %q", header[:len(i3IPCMagic)])
+	}
+
+	length := binary.LittleEndian.Uint32(header[len(i3IPCMagic):])
+	if length > i3IPCMaxPayload {
+		return I3IPCMessage{}, fmt.Errorf("i3-ipc payload too large: %d bytes", length)
+	}
+
+	msg := I3IPCMessage{
+		Type:    binary.LittleEndian.Uint32(header[len(i3IPCMagic)+4:]),
+		Payload: make([]byte, length),
+	}
+	if _, err := io.ReadFull(r, msg.Payload); err != nil {
+		return I3IPCMessage{}, fmt.Errorf("failed to read i3-ipc payload: %w", err)
+	}
+	return msg, nil
+}
+
+// subscribeI3IPC sends a subscribe request for events on rw and waits for
+// the reply, skipping any events that arrive first
+func subscribeI3IPC(rw io.ReadWriter, events []string) error {
+	payload, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to encode subscription: %w", err)
+	}
+	if _, err := rw.Write(EncodeI3IPCMessage(I3IPCSubscribe, payload)); err != nil {
+		return fmt.Errorf("failed to send subscription: %w", err)
+	}
+
+	for {
+		msg, err := ReadI3IPCMessage(rw)
+		if err != nil {
+			return fmt.Errorf("failed to read subscription reply: %w", err)
+		}
+		if msg.IsEvent() {
+			continue
+		}
+		if msg.Type != I3IPCSubscribe {
+			return fmt.Errorf("unexpected i3-ipc reply type %d", msg.Type)
+		}
+
+		var reply struct {
+			Success bool `json:"success"`
+		}
+		if err := json.Unmarshal(msg.Payload, &reply); err != nil {
+			return fmt.Errorf("failed to parse subscription reply: %w", err)
+		}
+		if !reply.Success {
+			return fmt.Errorf("subscription to %v was rejected", events)
+		}
+		return nil
+	}
+}
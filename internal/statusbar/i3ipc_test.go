@@ -0,0 +1,126 @@
+package statusbar
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+)
+
+func TestReadI3IPCMessage(t *testing.T) {
+	// A workspace event as sent by sway: magic, length 24, type 0x80000000
+	payload := `{"change":"focus","x":1}`
+	frame := append([]byte("i3-ipc"), 0x18, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80)
+	frame = append(frame, payload...)
+
+	msg, err := ReadI3IPCMessage(bytes.NewReader(frame))
+	if err != nil {
+		t.Fatalf("ReadI3IPCMessage() error = %v", err)
+	}
+	if !msg.IsEvent() {
+		t.Errorf("expected an event, got type %#x", msg.Type)
+	}
+	if msg.EventType() != 0 {
+		t.Errorf("EventType() = %d, want 0 (workspace)", msg.EventType())
+	}
+	if string(msg.Payload) != payload {
+		t.Errorf("Payload = %q, want %q", msg.Payload, payload)
+	}
+
+	var event struct {
+		Change string `json:"change"`
+	}
+	if err := json.Unmarshal(msg.Payload, &event); err != nil || event.Change != "focus" {
+		t.Errorf("payload did not parse as JSON: %v (change=%q)", err, event.Change)
+	}
+}
+
+func TestReadI3IPCMessageSequence(t *testing.T) {
+	var stream bytes.Buffer
+	stream.Write(EncodeI3IPCMessage(I3IPCSubscribe, []byte(`{"success":true}`)))
+	stream.Write(EncodeI3IPCMessage(i3IPCEventBit|3, []byte(`{"change":"new"}`)))
+
+	first, err := ReadI3IPCMessage(&stream)
+	if err != nil {
+		t.Fatalf("first message: %v", err)
+	}
+	if first.IsEvent() || first.Type != I3IPCSubscribe {
+		t.Errorf("first message type = %#x, want subscribe reply", first.Type)
+	}
+
+	second, err := ReadI3IPCMessage(&stream)
+	if err != nil {
+		t.Fatalf("second message: %v", err)
+	}
+	if !second.IsEvent() || second.EventType() != 3 {
+		t.Errorf("second message type = %#x, want window event", second.Type)
+	}
+	if string(second.Payload) != `{"change":"new"}` {
+		t.Errorf("second payload = %q", second.Payload)
+	}
+}
+
+func TestReadI3IPCMessageErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame []byte
+	}{
+		{"bad magic", append([]byte("i3-xyz"), make([]byte, 8)...)},
+		{"short header", []byte("i3-ipc\x01")},
+		{"truncated payload", EncodeI3IPCMessage(1, []byte(`{"a":1}`))[:i3IPCHeaderSize+3]},
+		{"oversized", append([]byte("i3-ipc"), 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		if _, err := ReadI3IPCMessage(bytes.NewReader(tt.frame)); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestSubscribeI3IPC(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	requests := make(chan I3IPCMessage, 1)
+	go func() {
+		defer server.Close()
+		msg, err := ReadI3IPCMessage(server)
+		if err != nil {
+			return
+		}
+		requests <- msg
+		// An event racing the reply must be skipped
+		server.Write(EncodeI3IPCMessage(i3IPCEventBit, []byte(`{"change":"focus"}`)))
+		server.Write(EncodeI3IPCMessage(I3IPCSubscribe, []byte(`{"success":true}`)))
+	}()
+
+	if err := subscribeI3IPC(client, []string{"workspace", "window"}); err != nil {
+		t.Fatalf("subscribeI3IPC() error = %v", err)
+	}
+
+	msg := <-requests
+	if msg.Type != I3IPCSubscribe {
+		t.Errorf("request type = %d, want %d", msg.Type, I3IPCSubscribe)
+	}
+	if string(msg.Payload) != `["workspace","window"]` {
+		t.Errorf("request payload = %q", msg.Payload)
+	}
+}
+
+func TestSubscribeI3IPCRejected(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	go func() {
+		defer server.Close()
+		if _, err := ReadI3IPCMessage(server); err != nil {
+			return
+		}
+		server.Write(EncodeI3IPCMessage(I3IPCSubscribe, []byte(`{"success":false}`)))
+	}()
+
+	if err := subscribeI3IPC(client, []string{"bogus"}); err == nil {
+		t.Error("expected rejected subscription to fail")
+	}
+}
@@ -1,8 +1,10 @@
 package statusbar
 
 import (
+	"strings"
 	"time"
 
+	"github.com/chess10kp/locus/internal/command"
 	"github.com/gotk3/gotk3/gtk"
 )
 
@@ -55,6 +57,10 @@ type Module interface {
 	HandlesIPC() bool
 	HandleIPC(message string) bool
 
+	// Scripting - the module's current value for query_module IPC, or ""
+	// to fall back to the widget's text
+	CurrentValue() string
+
 	// Styling
 	GetStyles() string
 	GetCSSClasses() []string
@@ -71,6 +77,9 @@ type BaseModule struct {
 	config       map[string]interface{}
 	clickHandler func(widget gtk.IWidget) bool
 	ipcHandler   func(message string) bool
+	commands     command.Runner
+	deps         *DependencyCheck
+	missing      []string // commands not installed, shown instead of a value
 }
 
 // NewBaseModule creates a new base module with defaults
@@ -85,6 +94,7 @@ func NewBaseModule(name string, updateMode UpdateMode) *BaseModule {
 		config:       make(map[string]interface{}),
 		clickHandler: nil,
 		ipcHandler:   nil,
+		commands:     command.Runner{Timeout: command.DefaultTimeout},
 	}
 }
 
@@ -138,6 +148,10 @@ func (m *BaseModule) Initialize(config map[string]interface{}) error {
 		m.interval = interval
 	}
 
+	if timeout, ok := config[CommandTimeoutKey].(time.Duration); ok {
+		m.commands = command.Runner{Timeout: timeout}
+	}
+
 	if styles, ok := config["styles"].(string); ok {
 		m.styles = styles
 	}
@@ -208,14 +222,64 @@ func (m *BaseModule) Cleanup() error {
 	return nil
 }
 
+// Commands returns the runner for the module's external commands, which
+// kills any that outlive the configured command timeout
+func (m *BaseModule) Commands() command.Runner {
+	return m.commands
+}
+
 // IsInitialized returns whether the module has been initialized
 func (m *BaseModule) IsInitialized() bool {
 	return m.initialized
 }
 
+// CurrentValue returns the module's value (base implementation returns "")
+func (m *BaseModule) CurrentValue() string {
+	return ""
+}
+
 // WidgetHelper provides helper functions for widget styling
 type WidgetHelper struct{}
 
+// WidgetText returns the text shown by a label or button, looking through
+// bins such as event boxes and joining the texts of box children. Call it on
+// the GTK main thread.
+func (h *WidgetHelper) WidgetText(widget gtk.IWidget) string {
+	switch w := widget.(type) {
+	case *gtk.Label:
+		text, _ := w.GetText()
+		return text
+	case *gtk.Button:
+		text, _ := w.GetLabel()
+		return text
+	case *gtk.EventBox:
+		return h.binText(&w.Bin)
+	case *gtk.Box:
+		var parts []string
+		w.GetChildren().Foreach(func(item interface{}) {
+			child, ok := item.(*gtk.Widget)
+			if !ok {
+				return
+			}
+			if cast, err := child.Cast(); err == nil {
+				if text := h.WidgetText(cast); text != "" {
+					parts = append(parts, text)
+				}
+			}
+		})
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
+
+func (h *WidgetHelper) binText(bin *gtk.Bin) string {
+	child, err := bin.GetChild()
+	if err != nil || child == nil {
+		return ""
+	}
+	return h.WidgetText(child)
+}
+
 // ApplyStylesToWidget applies CSS styles and classes to a widget
 func (h *WidgetHelper) ApplyStylesToWidget(widget gtk.IWidget, styles string, classes []string) error {
 	if widget == nil {
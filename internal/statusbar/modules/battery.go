@@ -161,6 +161,11 @@ func (m *BatteryModule) GetPercentage() int {
 	return m.percentage
 }
 
+// CurrentValue returns the battery percentage
+func (m *BatteryModule) CurrentValue() string {
+	return strconv.Itoa(m.percentage)
+}
+
 // IsCharging returns whether battery is charging
 func (m *BatteryModule) IsCharging() bool {
 	return m.isCharging
@@ -4,11 +4,11 @@ import (
 	"encoding/json"
 	"log"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/gotk3/gotk3/gtk"
+	"github.com/chess10kp/locus/internal/command"
 	"github.com/chess10kp/locus/internal/statusbar"
 )
 
@@ -18,7 +18,7 @@ type BindingModeResult struct {
 }
 
 // getBindingModeFromWM gets the current binding mode from the window manager
-func getBindingModeFromWM() (string, error) {
+func getBindingModeFromWM(runner command.Runner) (string, error) {
 	env := os.Environ()
 	for i, e := range env {
 		if strings.HasPrefix(e, "LD_PRELOAD=") {
@@ -27,9 +27,7 @@ func getBindingModeFromWM() (string, error) {
 		}
 	}
 
-	cmd := exec.Command("scrollmsg", "-t", "get_binding_state")
-	cmd.Env = env
-	output, err := cmd.Output()
+	output, err := runner.OutputEnv(env, "scrollmsg", "-t", "get_binding_state")
 	if err == nil {
 		var result BindingModeResult
 		if err := json.Unmarshal(output, &result); err == nil {
@@ -37,9 +35,7 @@ func getBindingModeFromWM() (string, error) {
 		}
 	}
 
-	cmd = exec.Command("swaymsg", "-t", "get_binding_mode")
-	cmd.Env = env
-	output, err = cmd.Output()
+	output, err = runner.OutputEnv(env, "swaymsg", "-t", "get_binding_mode")
 	if err != nil {
 		return "", err
 	}
@@ -102,7 +98,7 @@ func (m *BindingModeModule) UpdateWidget(widget gtk.IWidget) error {
 		return nil
 	}
 
-	mode, err := getBindingModeFromWM()
+	mode, err := getBindingModeFromWM(m.Commands())
 	if err != nil {
 		log.Printf("Failed to get binding mode: %v", err)
 		label.SetText("")
@@ -1,11 +1,13 @@
 package modules
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/gotk3/gotk3/gtk"
+	"github.com/chess10kp/locus/internal/command"
 	"github.com/chess10kp/locus/internal/statusbar"
 )
 
@@ -97,6 +99,7 @@ func (m *BluetoothModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readBluetoothStatus()
 	formatted := m.formatBluetooth()
 	button.SetLabel(formatted)
+	m.ShowMissingState(button.ToWidget())
 
 	// Update CSS classes for color
 	if ctx, err := button.ToWidget().GetStyleContext(); err == nil {
@@ -122,6 +125,8 @@ func (m *BluetoothModule) Initialize(config map[string]interface{}) error {
 		m.showIcon = showIcon
 	}
 
+	m.SetDependencies("bluetoothctl")
+
 	m.SetCSSClasses([]string{"bluetooth-module", "bluetooth-button"})
 
 	m.SetClickHandler(func(widget gtk.IWidget) bool {
@@ -135,11 +140,13 @@ func (m *BluetoothModule) Initialize(config map[string]interface{}) error {
 
 // readBluetoothStatus reads bluetooth status from system
 func (m *BluetoothModule) readBluetoothStatus() {
+	if m.DependenciesMissing() {
+		return
+	}
+
 	// Check if powered on
-	if cmd := exec.Command("bluetoothctl", "show"); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			m.isPowered = strings.Contains(string(output), "Powered: yes")
-		}
+	if output, err := m.Commands().Output(context.Background(), "bluetoothctl", "show"); err == nil {
+		m.isPowered = strings.Contains(string(output), "Powered: yes")
 	}
 
 	if !m.isPowered {
@@ -149,30 +156,26 @@ func (m *BluetoothModule) readBluetoothStatus() {
 
 	// Get devices
 	m.devices = []BluetoothDevice{}
-	if cmd := exec.Command("bluetoothctl", "devices"); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			lines := strings.Split(string(output), "\n")
-			for _, line := range lines {
-				if strings.HasPrefix(line, "Device ") {
-					parts := strings.Fields(line)
-					if len(parts) >= 3 {
-						mac := parts[1]
-						name := strings.Join(parts[2:], " ")
-
-						// Check if connected
-						connected := false
-						if infoCmd := exec.Command("bluetoothctl", "info", mac); infoCmd != nil {
-							if infoOutput, err := infoCmd.Output(); err == nil {
-								connected = strings.Contains(string(infoOutput), "Connected: yes")
-							}
-						}
-
-						m.devices = append(m.devices, BluetoothDevice{
-							MAC:       mac,
-							Name:      name,
-							Connected: connected,
-						})
+	if output, err := m.Commands().Output(context.Background(), "bluetoothctl", "devices"); err == nil {
+		lines := strings.Split(string(output), "\n")
+		for _, line := range lines {
+			if strings.HasPrefix(line, "Device ") {
+				parts := strings.Fields(line)
+				if len(parts) >= 3 {
+					mac := parts[1]
+					name := strings.Join(parts[2:], " ")
+
+					// Check if connected
+					connected := false
+					if infoOutput, err := m.Commands().Output(context.Background(), "bluetoothctl", "info", mac); err == nil {
+						connected = strings.Contains(string(infoOutput), "Connected: yes")
 					}
+
+					m.devices = append(m.devices, BluetoothDevice{
+						MAC:       mac,
+						Name:      name,
+						Connected: connected,
+					})
 				}
 			}
 		}
@@ -181,6 +184,10 @@ func (m *BluetoothModule) readBluetoothStatus() {
 
 // formatBluetooth formats bluetooth status for display
 func (m *BluetoothModule) formatBluetooth() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	var builder strings.Builder
 
 	if m.showIcon {
@@ -320,23 +327,24 @@ func (m *BluetoothModule) updateDeviceMenu() {
 
 // toggleBluetoothPower toggles bluetooth power
 func (m *BluetoothModule) toggleBluetoothPower() {
-	var cmd *exec.Cmd
+	state := "on"
 	if m.isPowered {
-		cmd = exec.Command("bluetoothctl", "power", "off")
-	} else {
-		cmd = exec.Command("bluetoothctl", "power", "on")
+		state = "off"
 	}
-	cmd.Run()
+	m.Commands().Run("bluetoothctl", "power", state)
 	m.readBluetoothStatus()
 	if m.widget != nil {
 		m.widget.SetLabel(m.formatBluetooth())
 	}
 }
 
+// bluetoothConnectTimeout bounds connecting to or disconnecting from a
+// device, which can take longer than the configured command timeout while
+// bluetoothctl pairs with it or retries
+const bluetoothConnectTimeout = 30 * time.Second
+
 // toggleDeviceConnection toggles connection to a device
 func (m *BluetoothModule) toggleDeviceConnection(mac string) {
-	var cmd *exec.Cmd
-
 	// Find device
 	var device *BluetoothDevice
 	for i := range m.devices {
@@ -350,12 +358,12 @@ func (m *BluetoothModule) toggleDeviceConnection(mac string) {
 		return
 	}
 
+	action := "connect"
 	if device.Connected {
-		cmd = exec.Command("bluetoothctl", "disconnect", mac)
-	} else {
-		cmd = exec.Command("bluetoothctl", "connect", mac)
+		action = "disconnect"
 	}
-	cmd.Run()
+	connect := command.Runner{Timeout: bluetoothConnectTimeout}
+	connect.Run("bluetoothctl", action, mac)
 	m.readBluetoothStatus()
 	if m.widget != nil {
 		m.widget.SetLabel(m.formatBluetooth())
@@ -408,7 +416,7 @@ func (f *BluetoothModuleFactory) DefaultConfig() map[string]interface{} {
 
 // Dependencies returns module dependencies
 func (f *BluetoothModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"bluetoothctl"}
 }
 
 func init() {
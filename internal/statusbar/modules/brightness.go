@@ -2,20 +2,30 @@ package modules
 
 import (
 	"fmt"
+	"log"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/chess10kp/locus/internal/statusbar"
+	"github.com/gotk3/gotk3/glib"
 	"github.com/gotk3/gotk3/gtk"
 )
 
+const backlightRoot = "/sys/class/backlight"
+
 // BrightnessModule displays screen brightness level
 type BrightnessModule struct {
 	*statusbar.BaseModule
-	widget     *gtk.Label
+	widget     *gtk.EventBox
+	label      *gtk.Label
 	command    string
 	device     string
+	sysfsRoot  string
+	step       int
 	showIcon   bool
 	current    int
 	maximum    int
@@ -29,6 +39,8 @@ func NewBrightnessModule() *BrightnessModule {
 		widget:     nil,
 		command:    "brightnessctl -m",
 		device:     "",
+		sysfsRoot:  backlightRoot,
+		step:       5,
 		showIcon:   true,
 		current:    0,
 		maximum:    0,
@@ -36,40 +48,51 @@ func NewBrightnessModule() *BrightnessModule {
 	}
 }
 
-// CreateWidget creates a brightness label widget
+// CreateWidget creates a brightness label that adjusts brightness on scroll
 func (m *BrightnessModule) CreateWidget() (gtk.IWidget, error) {
+	eventBox, err := gtk.EventBoxNew()
+	if err != nil {
+		return nil, err
+	}
+
 	label, err := gtk.LabelNew(m.formatBrightness())
 	if err != nil {
 		return nil, err
 	}
+	eventBox.Add(label)
 
-	m.widget = label
+	m.widget = eventBox
+	m.label = label
+
+	connectScroll(eventBox, func(delta int) {
+		go func() {
+			m.adjust(delta)
+			glib.IdleAdd(func() {
+				m.UpdateWidget(m.widget)
+			})
+		}()
+	})
 
 	helper := &statusbar.WidgetHelper{}
-	if err := helper.ApplyStylesToWidget(label, m.GetStyles(), m.GetCSSClasses()); err != nil {
+	if err := helper.ApplyStylesToWidget(eventBox, m.GetStyles(), m.GetCSSClasses()); err != nil {
 		return nil, err
 	}
 
-	return label, nil
+	return eventBox, nil
 }
 
 // UpdateWidget updates brightness widget
 func (m *BrightnessModule) UpdateWidget(widget gtk.IWidget) error {
-	if widget == nil {
-		return nil
-	}
-
-	label, ok := widget.(*gtk.Label)
-	if !ok {
+	if widget == nil || m.label == nil {
 		return nil
 	}
 
 	m.readBrightness()
 	formatted := m.formatBrightness()
-	label.SetText(formatted)
+	m.label.SetText(formatted)
 
 	// Update CSS classes for color
-	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
+	if ctx, err := m.label.ToWidget().GetStyleContext(); err == nil {
 		ctx.RemoveClass("brightness-night")
 		if m.percentage < 50 {
 			ctx.AddClass("brightness-night")
@@ -96,6 +119,13 @@ func (m *BrightnessModule) Initialize(config map[string]interface{}) error {
 		}
 	}
 
+	// TOML properties decode as int64
+	if step, ok := config["step"].(int); ok && step > 0 {
+		m.step = step
+	} else if step, ok := config["step"].(int64); ok && step > 0 {
+		m.step = int(step)
+	}
+
 	if showIcon, ok := config["show_icon"].(bool); ok {
 		m.showIcon = showIcon
 	}
@@ -107,10 +137,25 @@ func (m *BrightnessModule) Initialize(config map[string]interface{}) error {
 	return nil
 }
 
-// readBrightness reads brightness from system
+// readBrightness reads brightness from sysfs, falling back to the
+// configured command when no backlight device is available
 func (m *BrightnessModule) readBrightness() {
-	cmd := exec.Command("sh", "-c", m.command)
-	output, err := cmd.Output()
+	if device, err := selectBacklightDevice(m.sysfsRoot, m.device); err == nil {
+		current, max, err := readBacklight(filepath.Join(m.sysfsRoot, device))
+		if err == nil {
+			m.current = current
+			m.maximum = max
+			m.percentage = brightnessPercent(current, max)
+			return
+		}
+	}
+
+	m.readBrightnessCommand()
+}
+
+// readBrightnessCommand reads brightness using the configured command
+func (m *BrightnessModule) readBrightnessCommand() {
+	output, err := m.Commands().Shell(m.command)
 	if err != nil {
 		m.current = 0
 		m.maximum = 0
@@ -135,6 +180,126 @@ func (m *BrightnessModule) readBrightness() {
 	}
 }
 
+// adjust changes brightness by one step in the given direction
+func (m *BrightnessModule) adjust(delta int) {
+	tool := ""
+	for _, candidate := range []string{"brightnessctl", "light"} {
+		if _, err := exec.LookPath(candidate); err == nil {
+			tool = candidate
+			break
+		}
+	}
+	if tool == "" {
+		log.Printf("brightness: no brightness control command found")
+		return
+	}
+
+	device, _ := selectBacklightDevice(m.sysfsRoot, m.device)
+	args := brightnessAdjustArgs(tool, device, delta > 0, m.step)
+	if err := m.Commands().Run(args[0], args[1:]...); err != nil {
+		log.Printf("brightness: %s failed: %v", tool, err)
+	}
+}
+
+// brightnessAdjustArgs builds the brightnessctl/light invocation for one step
+func brightnessAdjustArgs(tool, device string, up bool, step int) []string {
+	if tool == "light" {
+		flag := "-U"
+		if up {
+			flag = "-A"
+		}
+		args := []string{"light"}
+		if device != "" {
+			args = append(args, "-s", "sysfs/backlight/"+device)
+		}
+		return append(args, flag, strconv.Itoa(step))
+	}
+
+	change := fmt.Sprintf("%d%%-", step)
+	if up {
+		change = fmt.Sprintf("+%d%%", step)
+	}
+	args := []string{"brightnessctl", "-q"}
+	if device != "" {
+		args = append(args, "-d", device)
+	}
+	return append(args, "set", change)
+}
+
+// selectBacklightDevice returns the preferred device when it exists under
+// root, otherwise the device with the highest max_brightness (raw firmware
+// interfaces like intel_backlight have finer steps than acpi_video*)
+func selectBacklightDevice(root, preferred string) (string, error) {
+	if preferred != "" {
+		if _, err := os.Stat(filepath.Join(root, preferred, "brightness")); err == nil {
+			return preferred, nil
+		}
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	best, bestMax := "", -1
+	for _, name := range names {
+		_, max, err := readBacklight(filepath.Join(root, name))
+		if err != nil {
+			continue
+		}
+		if max > bestMax {
+			best, bestMax = name, max
+		}
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("no backlight device in %s", root)
+	}
+	return best, nil
+}
+
+// readBacklight reads brightness and max_brightness from a backlight dir
+func readBacklight(dir string) (int, int, error) {
+	read := func(name string) (int, error) {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(strings.TrimSpace(string(data)))
+	}
+
+	current, err := read("brightness")
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err := read("max_brightness")
+	if err != nil {
+		return 0, 0, err
+	}
+	return current, max, nil
+}
+
+// brightnessPercent converts a raw brightness value into a 0-100 percentage
+func brightnessPercent(current, max int) float64 {
+	if max <= 0 {
+		return 0
+	}
+	percent := float64(current) / float64(max) * 100
+	if percent < 0 {
+		return 0
+	}
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
+
 // formatBrightness formats brightness for display
 func (m *BrightnessModule) formatBrightness() string {
 	var builder strings.Builder
@@ -202,12 +367,25 @@ func (f *BrightnessModuleFactory) DefaultConfig() map[string]interface{} {
 	return map[string]interface{}{
 		"command":     "brightnessctl -m",
 		"device":      "",
+		"step":        5,
 		"show_icon":   true,
 		"interval":    "5s",
 		"css_classes": []string{"brightness-module"},
 	}
 }
 
+// brightnessProperties are the checked brightness properties
+var brightnessProperties = statusbar.PropertyRules{
+	"device":    {Kind: statusbar.PropertyString},
+	"step":      {Kind: statusbar.PropertyInt, Min: 1, Max: 100},
+	"show_icon": {Kind: statusbar.PropertyBool},
+}
+
+// ValidateConfig checks the types and ranges of the brightness properties
+func (f *BrightnessModuleFactory) ValidateConfig(cfg map[string]interface{}) error {
+	return brightnessProperties.Validate(cfg)
+}
+
 // Dependencies returns module dependencies
 func (f *BrightnessModuleFactory) Dependencies() []string {
 	return []string{}
@@ -0,0 +1,82 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeBacklight(t *testing.T, root, name string, current, max string) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "brightness"), []byte(current+"\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "max_brightness"), []byte(max+"\n"), 0644)
+}
+
+func TestBrightnessPercent(t *testing.T) {
+	cases := []struct {
+		current, max int
+		want         float64
+	}{
+		{current: 0, max: 100, want: 0},
+		{current: 480, max: 960, want: 50},
+		{current: 19393, max: 19393, want: 100},
+		{current: 10, max: 0, want: 0},
+		{current: 200, max: 100, want: 100},
+	}
+
+	for _, tc := range cases {
+		if got := brightnessPercent(tc.current, tc.max); got != tc.want {
+			t.Errorf("brightnessPercent(%d, %d) = %v, want %v", tc.current, tc.max, got, tc.want)
+		}
+	}
+}
+
+func TestSelectBacklightDevice(t *testing.T) {
+	root := t.TempDir()
+	writeBacklight(t, root, "acpi_video0", "5", "10")
+	writeBacklight(t, root, "intel_backlight", "9000", "19393")
+	os.MkdirAll(filepath.Join(root, "broken"), 0755)
+
+	device, err := selectBacklightDevice(root, "")
+	if err != nil || device != "intel_backlight" {
+		t.Errorf("Expected intel_backlight to be picked automatically, got %q (%v)", device, err)
+	}
+
+	device, err = selectBacklightDevice(root, "acpi_video0")
+	if err != nil || device != "acpi_video0" {
+		t.Errorf("Expected configured device, got %q (%v)", device, err)
+	}
+
+	device, err = selectBacklightDevice(root, "missing")
+	if err != nil || device != "intel_backlight" {
+		t.Errorf("Expected fallback for missing configured device, got %q (%v)", device, err)
+	}
+
+	if _, err := selectBacklightDevice(t.TempDir(), ""); err == nil {
+		t.Error("Expected error when no backlight device exists")
+	}
+}
+
+func TestBrightnessAdjustArgs(t *testing.T) {
+	cases := []struct {
+		tool, device string
+		up           bool
+		want         []string
+	}{
+		{"brightnessctl", "", true, []string{"brightnessctl", "-q", "set", "+5%"}},
+		{"brightnessctl", "intel_backlight", false, []string{"brightnessctl", "-q", "-d", "intel_backlight", "set", "5%-"}},
+		{"light", "", true, []string{"light", "-A", "5"}},
+		{"light", "intel_backlight", false, []string{"light", "-s", "sysfs/backlight/intel_backlight", "-U", "5"}},
+	}
+
+	for _, tc := range cases {
+		if got := brightnessAdjustArgs(tc.tool, tc.device, tc.up, 5); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("brightnessAdjustArgs(%q, %q, %v) = %v, want %v", tc.tool, tc.device, tc.up, got, tc.want)
+		}
+	}
+}
@@ -1,8 +1,8 @@
 package modules
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -65,6 +65,7 @@ func (m *CpuModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readCpuUsage()
 	formatted := m.formatCpu()
 	label.SetText(formatted)
+	m.ShowMissingState(label.ToWidget())
 
 	// Update CSS classes for color
 	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
@@ -101,6 +102,8 @@ func (m *CpuModule) Initialize(config map[string]interface{}) error {
 		}
 	}
 
+	m.SetDependencies(statusbar.ShellCommandDependency(m.command))
+
 	m.SetCSSClasses([]string{"cpu-module"})
 
 	m.readCpuUsage()
@@ -110,8 +113,11 @@ func (m *CpuModule) Initialize(config map[string]interface{}) error {
 
 // readCpuUsage reads CPU usage from system
 func (m *CpuModule) readCpuUsage() {
-	cmd := exec.Command("sh", "-c", m.command)
-	output, err := cmd.Output()
+	if m.DependenciesMissing() {
+		return
+	}
+
+	output, err := m.Commands().Shell(m.command)
 	if err != nil {
 		m.usage = 0.0
 		return
@@ -129,8 +135,7 @@ func (m *CpuModule) readCpuUsage() {
 
 // getCpuCoreCount gets the number of CPU cores
 func (m *CpuModule) getCpuCoreCount() int {
-	cmd := exec.Command("nproc")
-	output, err := cmd.Output()
+	output, err := m.Commands().Output(context.Background(), "nproc")
 	if err != nil {
 		return 0
 	}
@@ -146,6 +151,10 @@ func (m *CpuModule) getCpuCoreCount() int {
 
 // formatCpu formats CPU usage for display
 func (m *CpuModule) formatCpu() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	var builder strings.Builder
 
 	if m.showIcon {
@@ -189,6 +198,11 @@ func (m *CpuModule) Cleanup() error {
 	return m.BaseModule.Cleanup()
 }
 
+// Dependencies returns the commands the configured module runs
+func (m *CpuModule) Dependencies() []string {
+	return m.DependencyCommands()
+}
+
 // CpuModuleFactory is a factory for creating CpuModule instances
 type CpuModuleFactory struct{}
 
@@ -219,7 +233,7 @@ func (f *CpuModuleFactory) DefaultConfig() map[string]interface{} {
 
 // Dependencies returns module dependencies
 func (f *CpuModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"mpstat"}
 }
 
 func init() {
@@ -0,0 +1,59 @@
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chess10kp/locus/internal/statusbar"
+)
+
+func notInstalled(file string) (string, error) {
+	return "", errors.New("executable file not found in $PATH")
+}
+
+func TestModulesShowMissingDependency(t *testing.T) {
+	bluetooth := NewBluetoothModule()
+	bluetooth.SetDependencies("bluetoothctl")
+	bluetooth.SetDependencyLookPath(notInstalled)
+	bluetooth.readBluetoothStatus()
+	if got := bluetooth.formatBluetooth(); got != "missing: bluetoothctl" {
+		t.Errorf("bluetooth without bluetoothctl = %q, want the missing state", got)
+	}
+
+	wifi := NewWifiModule()
+	wifi.SetDependencies(statusbar.ShellCommandDependency(wifi.command))
+	wifi.SetDependencyLookPath(notInstalled)
+	wifi.readWifiStatus()
+	if got := wifi.formatWifi(); got != "missing: nmcli" {
+		t.Errorf("wifi without nmcli = %q, want the missing state", got)
+	}
+
+	keyboard := NewKeyboardModule()
+	keyboard.SetDependencies("setxkbmap", "xset")
+	keyboard.SetDependencyLookPath(func(file string) (string, error) {
+		if file == "xset" {
+			return notInstalled(file)
+		}
+		return "/usr/bin/" + file, nil
+	})
+	keyboard.readKeyboardStatus()
+	if got := keyboard.formatKeyboard(); got != "missing: xset" {
+		t.Errorf("keyboard without xset = %q, want the missing state", got)
+	}
+}
+
+func TestModulesClearMissingDependency(t *testing.T) {
+	memory := NewMemoryModule()
+	memory.SetDependencies("free")
+	memory.SetDependencyLookPath(notInstalled)
+	memory.readMemoryUsage()
+	if _, missing := memory.MissingText(); !missing {
+		t.Fatal("Expected the missing state without free")
+	}
+
+	memory.SetDependencyLookPath(func(file string) (string, error) { return "/bin/true", nil })
+	memory.readMemoryUsage()
+	if text, missing := memory.MissingText(); missing {
+		t.Errorf("Expected the missing state to clear once free is installed, got %q", text)
+	}
+}
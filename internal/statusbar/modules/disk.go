@@ -2,7 +2,6 @@ package modules
 
 import (
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -65,6 +64,7 @@ func (m *DiskModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readDiskUsage()
 	formatted := m.formatDisk()
 	label.SetText(formatted)
+	m.ShowMissingState(label.ToWidget())
 
 	// Update CSS classes for color
 	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
@@ -113,6 +113,8 @@ func (m *DiskModule) Initialize(config map[string]interface{}) error {
 		}
 	}
 
+	m.SetDependencies(statusbar.ShellCommandDependency(m.command))
+
 	m.SetCSSClasses([]string{"disk-module"})
 
 	m.readDiskUsage()
@@ -122,8 +124,11 @@ func (m *DiskModule) Initialize(config map[string]interface{}) error {
 
 // readDiskUsage reads disk usage from system
 func (m *DiskModule) readDiskUsage() {
-	cmd := exec.Command("sh", "-c", m.command)
-	output, err := cmd.Output()
+	if m.DependenciesMissing() {
+		return
+	}
+
+	output, err := m.Commands().Shell(m.command)
 	if err != nil {
 		return
 	}
@@ -154,6 +159,10 @@ func (m *DiskModule) readDiskUsage() {
 
 // formatDisk formats disk usage for display
 func (m *DiskModule) formatDisk() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	var builder strings.Builder
 
 	if m.showIcon {
@@ -221,6 +230,11 @@ func (m *DiskModule) Cleanup() error {
 	return m.BaseModule.Cleanup()
 }
 
+// Dependencies returns the commands the configured module runs
+func (m *DiskModule) Dependencies() []string {
+	return m.DependencyCommands()
+}
+
 // DiskModuleFactory is a factory for creating DiskModule instances
 type DiskModuleFactory struct{}
 
@@ -252,7 +266,7 @@ func (f *DiskModuleFactory) DefaultConfig() map[string]interface{} {
 
 // Dependencies returns module dependencies
 func (f *DiskModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"df"}
 }
 
 func init() {
@@ -1,14 +1,15 @@
 package modules
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"os/exec"
 	"strings"
 	"time"
 
-	"github.com/gotk3/gotk3/gtk"
 	"github.com/chess10kp/locus/internal/statusbar"
+	"github.com/gotk3/gotk3/gtk"
 )
 
 // EmacsClockInfo represents clock information from Emacs
@@ -17,9 +18,8 @@ type EmacsClockInfo struct {
 	Time string `json:"time"`
 }
 
-// getEmacsClockInfo gets the current Emacs org-mode clock information
-func getEmacsClockInfo() (*EmacsClockInfo, error) {
-	emacsScript := `
+// defaultEmacsClockEval prints the active org clock as JSON, or null
+const defaultEmacsClockEval = `
 (let ((inhibit-message t)
       (message-log-max nil))
   (with-temp-message ""
@@ -40,24 +40,47 @@ func getEmacsClockInfo() (*EmacsClockInfo, error) {
       (princ "null"))))
 `
 
-	cmd := exec.Command("emacsclient", "--quiet", "-e", emacsScript)
+// emacsClientTimeout bounds how long a busy Emacs can stall an update
+const emacsClientTimeout = 2 * time.Second
+
+// getEmacsClockInfo evaluates elisp in the running Emacs and parses the
+// clock it reports
+func getEmacsClockInfo(eval string) (*EmacsClockInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), emacsClientTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "emacsclient", "--quiet", "-e", eval)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
 	}
 
-	outputStr := strings.TrimSpace(string(output))
+	return parseEmacsClockOutput(string(output))
+}
+
+// parseEmacsClockOutput parses emacsclient output. JSON objects give a task
+// and time; any other non-empty string (e.g. a mode-line string from custom
+// elisp) becomes the task. nil/null/empty mean no active clock.
+func parseEmacsClockOutput(output string) (*EmacsClockInfo, error) {
+	outputStr := strings.TrimSpace(output)
 
-	if outputStr == "null" || outputStr == "" {
+	if outputStr == "null" || outputStr == "nil" || outputStr == "" {
 		return nil, nil
 	}
 
-	if strings.HasPrefix(outputStr, `"`) && strings.HasSuffix(outputStr, `"`) {
+	if strings.HasPrefix(outputStr, `"`) && strings.HasSuffix(outputStr, `"`) && len(outputStr) >= 2 {
 		unquoted, err := unescapeJSONString(outputStr[1 : len(outputStr)-1])
 		if err != nil {
 			return nil, err
 		}
-		outputStr = unquoted
+		outputStr = strings.TrimSpace(unquoted)
+		if outputStr == "" {
+			return nil, nil
+		}
+	}
+
+	if !strings.HasPrefix(outputStr, "{") {
+		return &EmacsClockInfo{Task: outputStr}, nil
 	}
 
 	var info EmacsClockInfo
@@ -72,6 +95,18 @@ func getEmacsClockInfo() (*EmacsClockInfo, error) {
 	return &info, nil
 }
 
+// formatEmacsClock returns the label text and whether the module should be
+// shown; with no clock it falls back to fallbackText, hiding when empty
+func formatEmacsClock(info *EmacsClockInfo, fallbackText string) (string, bool) {
+	if info == nil || info.Task == "" {
+		return fallbackText, fallbackText != ""
+	}
+	if info.Time != "" {
+		return "org: " + info.Task + ": " + info.Time, true
+	}
+	return "org: " + info.Task, true
+}
+
 // unescapeJSONString unescapes a JSON string literal
 func unescapeJSONString(s string) (string, error) {
 	var unescaped string
@@ -87,6 +122,8 @@ type EmacsClockModule struct {
 	widget       *gtk.Label
 	clockInfo    *EmacsClockInfo
 	fallbackText string
+	eval         string
+	lastErr      string
 	interval     time.Duration
 }
 
@@ -97,6 +134,7 @@ func NewEmacsClockModule() *EmacsClockModule {
 		widget:       nil,
 		clockInfo:    nil,
 		fallbackText: "",
+		eval:         defaultEmacsClockEval,
 		interval:     10 * time.Second,
 	}
 }
@@ -109,6 +147,8 @@ func (m *EmacsClockModule) CreateWidget() (gtk.IWidget, error) {
 	}
 
 	m.widget = label
+	label.SetNoShowAll(true)
+	label.SetVisible(m.fallbackText != "")
 
 	helper := &statusbar.WidgetHelper{}
 	if err := helper.ApplyStylesToWidget(label, m.GetStyles(), m.GetCSSClasses()); err != nil {
@@ -129,24 +169,23 @@ func (m *EmacsClockModule) UpdateWidget(widget gtk.IWidget) error {
 		return nil
 	}
 
-	info, err := getEmacsClockInfo()
+	// Emacs not running is the common case, so only log when the error changes
+	info, err := getEmacsClockInfo(m.eval)
 	if err != nil {
-		log.Printf("Failed to get Emacs clock info: %v", err)
-		label.SetText(m.fallbackText)
-		return nil
+		if err.Error() != m.lastErr {
+			log.Printf("Emacs clock unavailable: %v", err)
+			m.lastErr = err.Error()
+		}
+		info = nil
+	} else {
+		m.lastErr = ""
 	}
 
 	m.clockInfo = info
 
-	if info != nil && info.Task != "" {
-		if info.Time != "" {
-			label.SetText("org: " + info.Task + ": " + info.Time)
-		} else {
-			label.SetText("org: " + info.Task)
-		}
-	} else {
-		label.SetText(m.fallbackText)
-	}
+	text, visible := formatEmacsClock(info, m.fallbackText)
+	label.SetText(text)
+	label.SetVisible(visible)
 
 	return nil
 }
@@ -161,6 +200,10 @@ func (m *EmacsClockModule) Initialize(config map[string]interface{}) error {
 		m.fallbackText = fallbackText
 	}
 
+	if eval, ok := config["eval"].(string); ok && strings.TrimSpace(eval) != "" {
+		m.eval = eval
+	}
+
 	if interval, ok := config["interval"].(string); ok {
 		if duration, err := time.ParseDuration(interval); err == nil {
 			m.interval = duration
@@ -213,6 +256,17 @@ func (f *EmacsClockModuleFactory) DefaultConfig() map[string]interface{} {
 	}
 }
 
+// emacsClockProperties are the checked emacs clock properties
+var emacsClockProperties = statusbar.PropertyRules{
+	"eval":          {Kind: statusbar.PropertyString, NonEmpty: true},
+	"fallback_text": {Kind: statusbar.PropertyString},
+}
+
+// ValidateConfig checks the types and ranges of the emacs clock properties
+func (f *EmacsClockModuleFactory) ValidateConfig(cfg map[string]interface{}) error {
+	return emacsClockProperties.Validate(cfg)
+}
+
 // Dependencies returns module dependencies
 func (f *EmacsClockModuleFactory) Dependencies() []string {
 	return []string{}
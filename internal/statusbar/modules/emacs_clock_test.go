@@ -0,0 +1,57 @@
+package modules
+
+import "testing"
+
+func TestParseEmacsClockOutput(t *testing.T) {
+	cases := []struct {
+		name   string
+		output string
+		want   *EmacsClockInfo
+	}{
+		{"null", "null\n", nil},
+		{"nil", "nil", nil},
+		{"empty", "", nil},
+		{"json", `{"task":"Write report","time":"0:42"}`, &EmacsClockInfo{Task: "Write report", Time: "0:42"}},
+		{"quoted json", `"{\"task\":\"Review\",\"time\":\"1:05\"}"`, &EmacsClockInfo{Task: "Review", Time: "1:05"}},
+		{"empty json", `{"task":"","time":""}`, nil},
+		{"mode-line string", `"[0:12] (Inbox)"`, &EmacsClockInfo{Task: "[0:12] (Inbox)"}},
+		{"empty string", `""`, nil},
+	}
+
+	for _, tc := range cases {
+		got, err := parseEmacsClockOutput(tc.output)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+			t.Errorf("%s: got %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+
+	if _, err := parseEmacsClockOutput(`{"task":`); err == nil {
+		t.Error("Expected error for truncated JSON")
+	}
+}
+
+func TestFormatEmacsClock(t *testing.T) {
+	text, visible := formatEmacsClock(nil, "")
+	if visible || text != "" {
+		t.Errorf("Expected hidden module with no clock and no fallback, got %q visible=%v", text, visible)
+	}
+
+	text, visible = formatEmacsClock(nil, "no clock")
+	if !visible || text != "no clock" {
+		t.Errorf("Expected fallback text, got %q visible=%v", text, visible)
+	}
+
+	text, visible = formatEmacsClock(&EmacsClockInfo{Task: "Write report", Time: "0:42"}, "")
+	if !visible || text != "org: Write report: 0:42" {
+		t.Errorf("Unexpected clock text %q visible=%v", text, visible)
+	}
+
+	text, _ = formatEmacsClock(&EmacsClockInfo{Task: "Inbox"}, "")
+	if text != "org: Inbox" {
+		t.Errorf("Unexpected clock text without time %q", text)
+	}
+}
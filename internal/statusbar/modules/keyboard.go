@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -70,6 +69,7 @@ func (m *KeyboardModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readKeyboardStatus()
 	formatted := m.formatKeyboard()
 	label.SetText(formatted)
+	m.ShowMissingState(label.ToWidget())
 
 	// Update CSS classes for color
 	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
@@ -112,6 +112,8 @@ func (m *KeyboardModule) Initialize(config map[string]interface{}) error {
 		m.showLocks = showLocks
 	}
 
+	m.SetDependencies(statusbar.ShellCommandDependency(m.layoutCmd), statusbar.ShellCommandDependency(m.locksCmd))
+
 	m.SetCSSClasses([]string{"keyboard-module"})
 
 	m.readKeyboardStatus()
@@ -121,21 +123,21 @@ func (m *KeyboardModule) Initialize(config map[string]interface{}) error {
 
 // readKeyboardStatus reads keyboard status from system
 func (m *KeyboardModule) readKeyboardStatus() {
+	if m.DependenciesMissing() {
+		return
+	}
+
 	// Get layout
-	if cmd := exec.Command("sh", "-c", m.layoutCmd); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			m.layout = strings.TrimSpace(string(output))
-		}
+	if output, err := m.Commands().Shell(m.layoutCmd); err == nil {
+		m.layout = strings.TrimSpace(string(output))
 	}
 
 	// Get lock states
-	if cmd := exec.Command("sh", "-c", m.locksCmd); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			if maskStr := strings.TrimSpace(string(output)); maskStr != "" {
-				if mask, err := strconv.Atoi(maskStr); err == nil {
-					m.capsLock = (mask & 1) != 0 // CAPS lock is bit 0
-					m.numLock = (mask & 2) != 0  // NUM lock is bit 1
-				}
+	if output, err := m.Commands().Shell(m.locksCmd); err == nil {
+		if maskStr := strings.TrimSpace(string(output)); maskStr != "" {
+			if mask, err := strconv.Atoi(maskStr); err == nil {
+				m.capsLock = (mask & 1) != 0 // CAPS lock is bit 0
+				m.numLock = (mask & 2) != 0  // NUM lock is bit 1
 			}
 		}
 	}
@@ -143,6 +145,10 @@ func (m *KeyboardModule) readKeyboardStatus() {
 
 // formatKeyboard formats keyboard status for display
 func (m *KeyboardModule) formatKeyboard() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	var builder strings.Builder
 
 	if m.showIcon {
@@ -198,6 +204,11 @@ func (m *KeyboardModule) Cleanup() error {
 	return m.BaseModule.Cleanup()
 }
 
+// Dependencies returns the commands the configured module runs
+func (m *KeyboardModule) Dependencies() []string {
+	return m.DependencyCommands()
+}
+
 // KeyboardModuleFactory is a factory for creating KeyboardModule instances
 type KeyboardModuleFactory struct{}
 
@@ -230,7 +241,7 @@ func (f *KeyboardModuleFactory) DefaultConfig() map[string]interface{} {
 
 // Dependencies returns module dependencies
 func (f *KeyboardModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"setxkbmap", "xset"}
 }
 
 func init() {
@@ -1,8 +1,8 @@
 package modules
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -67,6 +67,7 @@ func (m *MemoryModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readMemoryUsage()
 	formatted := m.formatMemory()
 	label.SetText(formatted)
+	m.ShowMissingState(label.ToWidget())
 
 	// Update CSS classes for color
 	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
@@ -100,6 +101,8 @@ func (m *MemoryModule) Initialize(config map[string]interface{}) error {
 		m.showDetails = showDetails
 	}
 
+	m.SetDependencies("free")
+
 	m.SetCSSClasses([]string{"memory-module"})
 
 	m.readMemoryUsage()
@@ -109,9 +112,12 @@ func (m *MemoryModule) Initialize(config map[string]interface{}) error {
 
 // readMemoryUsage reads memory usage from system
 func (m *MemoryModule) readMemoryUsage() {
+	if m.DependenciesMissing() {
+		return
+	}
+
 	// Get detailed usage
-	cmd := exec.Command("free", "-b") // bytes for accurate calculation
-	output, err := cmd.Output()
+	output, err := m.Commands().Output(context.Background(), "free", "-b") // bytes for accurate calculation
 	if err != nil {
 		m.used = 0.0
 		m.total = 0.0
@@ -141,6 +147,10 @@ func (m *MemoryModule) readMemoryUsage() {
 
 // formatMemory formats memory usage for display
 func (m *MemoryModule) formatMemory() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	var builder strings.Builder
 
 	if m.showIcon {
@@ -224,7 +234,7 @@ func (f *MemoryModuleFactory) DefaultConfig() map[string]interface{} {
 
 // Dependencies returns module dependencies
 func (f *MemoryModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"free"}
 }
 
 func init() {
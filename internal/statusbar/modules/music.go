@@ -2,7 +2,6 @@ package modules
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/gotk3/gotk3/gtk"
@@ -76,6 +75,7 @@ func (m *MusicModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readMusicStatus()
 	formatted := m.formatMusic()
 	label.SetText(formatted)
+	m.ShowMissingState(label.ToWidget())
 
 	// Update CSS classes for color
 	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
@@ -102,8 +102,11 @@ func (m *MusicModule) Initialize(config map[string]interface{}) error {
 		m.host = host
 	}
 
+	// TOML properties decode as int64
 	if port, ok := config["port"].(int); ok {
 		m.port = port
+	} else if port, ok := config["port"].(int64); ok {
+		m.port = int(port)
 	}
 
 	if showIcon, ok := config["show_icon"].(bool); ok {
@@ -116,6 +119,8 @@ func (m *MusicModule) Initialize(config map[string]interface{}) error {
 
 	if maxLength, ok := config["max_length"].(int); ok {
 		m.maxLength = maxLength
+	} else if maxLength, ok := config["max_length"].(int64); ok {
+		m.maxLength = int(maxLength)
 	}
 
 	// Build commands with host/port
@@ -123,6 +128,8 @@ func (m *MusicModule) Initialize(config map[string]interface{}) error {
 	m.currentCmd = fmt.Sprintf("mpc %s current", hostPort)
 	m.statusCmd = fmt.Sprintf("mpc %s status", hostPort)
 
+	m.SetDependencies("mpc")
+
 	m.SetCSSClasses([]string{"music-module"})
 
 	m.readMusicStatus()
@@ -132,46 +139,46 @@ func (m *MusicModule) Initialize(config map[string]interface{}) error {
 
 // readMusicStatus reads music status from MPD
 func (m *MusicModule) readMusicStatus() {
+	if m.DependenciesMissing() {
+		return
+	}
+
 	// Get current song
-	if cmd := exec.Command("sh", "-c", m.currentCmd); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			current := strings.TrimSpace(string(output))
-			if current != "" {
-				// Parse artist - title
-				parts := strings.SplitN(current, " - ", 2)
-				if len(parts) >= 2 {
-					m.artist = strings.TrimSpace(parts[0])
-					m.title = strings.TrimSpace(parts[1])
-				} else {
-					m.artist = ""
-					m.title = current
-				}
+	if output, err := m.Commands().Shell(m.currentCmd); err == nil {
+		current := strings.TrimSpace(string(output))
+		if current != "" {
+			// Parse artist - title
+			parts := strings.SplitN(current, " - ", 2)
+			if len(parts) >= 2 {
+				m.artist = strings.TrimSpace(parts[0])
+				m.title = strings.TrimSpace(parts[1])
 			} else {
 				m.artist = ""
-				m.title = ""
+				m.title = current
 			}
+		} else {
+			m.artist = ""
+			m.title = ""
 		}
 	}
 
 	// Get playback status
-	if cmd := exec.Command("sh", "-c", m.statusCmd); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			lines := strings.Split(string(output), "\n")
-			if len(lines) > 1 {
-				statusLine := strings.TrimSpace(lines[1])
-				if strings.Contains(statusLine, "[playing]") {
-					m.playbackStatus = "playing"
-					m.isPlaying = true
-				} else if strings.Contains(statusLine, "[paused]") {
-					m.playbackStatus = "paused"
-					m.isPlaying = false
-				} else if strings.Contains(statusLine, "[stopped]") {
-					m.playbackStatus = "stopped"
-					m.isPlaying = false
-				} else {
-					m.playbackStatus = ""
-					m.isPlaying = false
-				}
+	if output, err := m.Commands().Shell(m.statusCmd); err == nil {
+		lines := strings.Split(string(output), "\n")
+		if len(lines) > 1 {
+			statusLine := strings.TrimSpace(lines[1])
+			if strings.Contains(statusLine, "[playing]") {
+				m.playbackStatus = "playing"
+				m.isPlaying = true
+			} else if strings.Contains(statusLine, "[paused]") {
+				m.playbackStatus = "paused"
+				m.isPlaying = false
+			} else if strings.Contains(statusLine, "[stopped]") {
+				m.playbackStatus = "stopped"
+				m.isPlaying = false
+			} else {
+				m.playbackStatus = ""
+				m.isPlaying = false
 			}
 		}
 	}
@@ -179,6 +186,10 @@ func (m *MusicModule) readMusicStatus() {
 
 // formatMusic formats music status for display
 func (m *MusicModule) formatMusic() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	if m.title == "" {
 		return ""
 	}
@@ -284,9 +295,23 @@ func (f *MusicModuleFactory) DefaultConfig() map[string]interface{} {
 	}
 }
 
+// musicProperties are the checked music properties
+var musicProperties = statusbar.PropertyRules{
+	"host":        {Kind: statusbar.PropertyString, NonEmpty: true},
+	"port":        {Kind: statusbar.PropertyInt, Min: 1, Max: 65535},
+	"max_length":  {Kind: statusbar.PropertyInt, Min: 1, Max: 1000},
+	"show_icon":   {Kind: statusbar.PropertyBool},
+	"show_status": {Kind: statusbar.PropertyBool},
+}
+
+// ValidateConfig checks the types and ranges of the music properties
+func (f *MusicModuleFactory) ValidateConfig(cfg map[string]interface{}) error {
+	return musicProperties.Validate(cfg)
+}
+
 // Dependencies returns module dependencies
 func (f *MusicModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"mpc"}
 }
 
 func init() {
@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"os/exec"
 	"strings"
 
 	"github.com/gotk3/gotk3/gtk"
@@ -67,6 +66,7 @@ func (m *NetworkModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readNetworkStatus()
 	formatted := m.formatNetwork()
 	label.SetText(formatted)
+	m.ShowMissingState(label.ToWidget())
 
 	// Update CSS classes for color
 	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
@@ -107,6 +107,8 @@ func (m *NetworkModule) Initialize(config map[string]interface{}) error {
 		m.showVpn = showVpn
 	}
 
+	m.SetDependencies(statusbar.ShellCommandDependency(m.command))
+
 	m.SetCSSClasses([]string{"network-module"})
 
 	m.readNetworkStatus()
@@ -116,8 +118,11 @@ func (m *NetworkModule) Initialize(config map[string]interface{}) error {
 
 // readNetworkStatus reads network status from system
 func (m *NetworkModule) readNetworkStatus() {
-	cmd := exec.Command("sh", "-c", m.command)
-	output, err := cmd.Output()
+	if m.DependenciesMissing() {
+		return
+	}
+
+	output, err := m.Commands().Shell(m.command)
 	if err != nil {
 		m.hasEthernet = false
 		m.hasWifi = false
@@ -145,6 +150,10 @@ func (m *NetworkModule) readNetworkStatus() {
 
 // formatNetwork formats network status for display
 func (m *NetworkModule) formatNetwork() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	var builder strings.Builder
 
 	if m.showIcon {
@@ -216,6 +225,11 @@ func (m *NetworkModule) Cleanup() error {
 	return m.BaseModule.Cleanup()
 }
 
+// Dependencies returns the commands the configured module runs
+func (m *NetworkModule) Dependencies() []string {
+	return m.DependencyCommands()
+}
+
 // NetworkModuleFactory is a factory for creating NetworkModule instances
 type NetworkModuleFactory struct{}
 
@@ -247,7 +261,7 @@ func (f *NetworkModuleFactory) DefaultConfig() map[string]interface{} {
 
 // Dependencies returns module dependencies
 func (f *NetworkModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"nmcli"}
 }
 
 func init() {
@@ -16,6 +16,8 @@ type NotificationModule struct {
 	count        int
 	icon         string
 	iconFull     string
+	iconDND      string
+	dnd          bool
 	socketPath   string
 	updateTicker *time.Ticker
 	running      bool
@@ -30,6 +32,7 @@ func NewNotificationModule(cfg *config.Config) *NotificationModule {
 		count:        0,
 		icon:         "N",
 		iconFull:     "N",
+		iconDND:      "DND",
 		socketPath:   socketPath,
 		updateTicker: time.NewTicker(5 * time.Second),
 		running:      false,
@@ -71,9 +74,7 @@ func (m *NotificationModule) UpdateWidget(widget gtk.IWidget) error {
 		return nil
 	}
 
-	count := m.fetchUnreadCount()
-	if count != m.count {
-		m.count = count
+	if m.refreshStatus() {
 		button.SetLabel(m.formatNotification())
 	}
 
@@ -93,6 +94,10 @@ func (m *NotificationModule) Initialize(config map[string]interface{}) error {
 		m.iconFull = iconFull
 	}
 
+	if iconDND, ok := config["icon_dnd"].(string); ok {
+		m.iconDND = iconDND
+	}
+
 	if socketPath, ok := config["socket_path"].(string); ok {
 		m.socketPath = socketPath
 	}
@@ -133,35 +138,47 @@ func (m *NotificationModule) updateLoop(callback func()) {
 		select {
 		case <-m.updateTicker.C:
 			glib.IdleAdd(func() {
-				if m.widget != nil {
-					count := m.fetchUnreadCount()
-					if count != m.count {
-						m.count = count
-						m.widget.SetLabel(m.formatNotification())
-					}
+				if m.widget != nil && m.refreshStatus() {
+					m.widget.SetLabel(m.formatNotification())
 				}
 			})
 		}
 	}
 }
 
-func (m *NotificationModule) fetchUnreadCount() int {
-	count, err := notification.GetUnreadCount(m.socketPath)
-	if err != nil {
-		return 0
+// refreshStatus fetches unread count and DND state in one round trip and
+// reports whether either changed
+func (m *NotificationModule) refreshStatus() bool {
+	count, dnd := 0, false
+	if status, err := notification.GetStatus(m.socketPath); err == nil {
+		count, dnd = status.Unread, status.DoNotDisturb
 	}
-	return count
+
+	changed := count != m.count || dnd != m.dnd
+	m.count, m.dnd = count, dnd
+	return changed
 }
+
 func (m *NotificationModule) formatNotification() string {
-	if m.count > 0 {
-		if m.count > 99 {
-			return m.iconFull + " 99+"
+	if m.dnd {
+		if m.count > 0 {
+			return m.iconDND + " " + formatCount(m.count)
 		}
-		return m.iconFull + " " + intToString(m.count)
+		return m.iconDND
+	}
+	if m.count > 0 {
+		return m.iconFull + " " + formatCount(m.count)
 	}
 	return ""
 }
 
+func formatCount(n int) string {
+	if n > 99 {
+		return "99+"
+	}
+	return intToString(n)
+}
+
 func intToString(n int) string {
 	if n < 0 {
 		return "0"
@@ -211,6 +228,7 @@ func (f *NotificationModuleFactory) DefaultConfig() map[string]interface{} {
 	return map[string]interface{}{
 		"icon":        "N",
 		"icon_full":   "N",
+		"icon_dnd":    "DND",
 		"css_classes": []string{"notification-module", "notification-button"},
 	}
 }
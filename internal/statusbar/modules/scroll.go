@@ -0,0 +1,27 @@
+package modules
+
+import (
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// connectScroll calls fn with +1 for scroll up and -1 for scroll down
+func connectScroll(box *gtk.EventBox, fn func(delta int)) {
+	box.AddEvents(int(gdk.SCROLL_MASK | gdk.SMOOTH_SCROLL_MASK))
+	box.Connect("scroll-event", func(_ *gtk.EventBox, event *gdk.Event) bool {
+		scroll := gdk.EventScrollNewFromEvent(event)
+		switch scroll.Direction() {
+		case gdk.SCROLL_UP:
+			fn(1)
+		case gdk.SCROLL_DOWN:
+			fn(-1)
+		case gdk.SCROLL_SMOOTH:
+			if dy := scroll.DeltaY(); dy < 0 {
+				fn(1)
+			} else if dy > 0 {
+				fn(-1)
+			}
+		}
+		return true
+	})
+}
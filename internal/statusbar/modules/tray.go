@@ -0,0 +1,348 @@
+package modules
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/chess10kp/locus/internal/statusbar"
+	"github.com/godbus/dbus/v5"
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+const defaultTrayIconSize = 16
+
+// TrayModule shows StatusNotifierItem tray icons, acting as a
+// StatusNotifierHost for the session's StatusNotifierWatcher. Clicking an
+// icon activates its item.
+type TrayModule struct {
+	*statusbar.BaseModule
+	box      *gtk.Box
+	iconSize int
+	spacing  int
+	items    trayItems
+	conn     *dbus.Conn
+	signals  chan *dbus.Signal
+	done     chan struct{} // closed once the signal goroutine exits
+}
+
+// NewTrayModule creates a new tray module
+func NewTrayModule() *TrayModule {
+	return &TrayModule{
+		BaseModule: statusbar.NewBaseModule("tray", statusbar.UpdateModeStatic),
+		iconSize:   defaultTrayIconSize,
+		spacing:    4,
+	}
+}
+
+// CreateWidget creates the box holding tray icons and starts watching for
+// items
+func (m *TrayModule) CreateWidget() (gtk.IWidget, error) {
+	box, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, m.spacing)
+	if err != nil {
+		return nil, err
+	}
+	m.box = box
+
+	helper := &statusbar.WidgetHelper{}
+	if err := helper.ApplyStylesToWidget(box, m.GetStyles(), m.GetCSSClasses()); err != nil {
+		return nil, err
+	}
+
+	if err := m.startHost(); err != nil {
+		log.Printf("tray: %v", err)
+	}
+
+	return box, nil
+}
+
+// UpdateWidget rebuilds the tray icons
+func (m *TrayModule) UpdateWidget(widget gtk.IWidget) error {
+	if m.box == nil {
+		return nil
+	}
+
+	m.box.GetChildren().Foreach(func(child interface{}) {
+		if widget, ok := child.(*gtk.Widget); ok {
+			m.box.Remove(widget)
+		}
+	})
+
+	items := m.items.List()
+	for _, item := range items {
+		icon, err := m.createIcon(item)
+		if err != nil {
+			log.Printf("tray: failed to create icon for %s: %v", item.ID, err)
+			continue
+		}
+		m.box.PackStart(icon, false, false, 0)
+	}
+	m.box.ShowAll()
+	m.box.SetVisible(len(items) > 0)
+
+	return nil
+}
+
+// createIcon builds the clickable icon of one item
+func (m *TrayModule) createIcon(item trayItem) (*gtk.EventBox, error) {
+	eventBox, err := gtk.EventBoxNew()
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := gtk.ImageNew()
+	if err != nil {
+		return nil, err
+	}
+	if width, height, rgba, ok := trayPixmapRGBA(item.Pixmaps, m.iconSize); ok && item.IconName == "" {
+		pixbuf, err := gdk.PixbufNewFromBytes(rgba, gdk.COLORSPACE_RGB, true, 8, width, height, width*4)
+		if err == nil {
+			if scaled, err := pixbuf.ScaleSimple(m.iconSize, m.iconSize, gdk.INTERP_BILINEAR); err == nil {
+				image.SetFromPixbuf(scaled)
+			}
+		}
+	} else {
+		iconName := item.IconName
+		if iconName == "" {
+			iconName = "image-missing"
+		}
+		image.SetFromIconName(iconName, gtk.ICON_SIZE_MENU)
+		image.SetPixelSize(m.iconSize)
+	}
+	eventBox.Add(image)
+
+	if item.Title != "" {
+		eventBox.SetTooltipText(item.Title)
+	}
+
+	eventBox.Connect("button-press-event", func(_ *gtk.EventBox, event *gdk.Event) bool {
+		button := gdk.EventButtonNewFromEvent(event)
+		method := "Activate"
+		switch button.Button() {
+		case gdk.BUTTON_PRIMARY:
+		case gdk.BUTTON_MIDDLE:
+			method = "SecondaryActivate"
+		default:
+			return false
+		}
+		x, y := int32(button.XRoot()), int32(button.YRoot())
+		go m.callItem(item, method, x, y)
+		return true
+	})
+
+	return eventBox, nil
+}
+
+// callItem calls Activate or SecondaryActivate on item
+func (m *TrayModule) callItem(item trayItem, method string, x, y int32) {
+	if m.conn == nil {
+		return
+	}
+	call := m.conn.Object(item.Service, item.Path).Call(trayItemInterface+"."+method, 0, x, y)
+	if call.Err != nil {
+		log.Printf("tray: %s on %s failed: %v", method, item.ID, call.Err)
+	}
+}
+
+// startHost registers as a StatusNotifierHost and follows item
+// registrations
+func (m *TrayModule) startHost() error {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+
+	hostName := fmt.Sprintf("org.kde.StatusNotifierHost-%d", os.Getpid())
+	if _, err := conn.RequestName(hostName, dbus.NameFlagDoNotQueue); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to request %s: %w", hostName, err)
+	}
+
+	watcher := conn.Object(trayWatcherName, trayWatcherPath)
+	if call := watcher.Call(trayWatcherName+".RegisterStatusNotifierHost", 0, hostName); call.Err != nil {
+		conn.Close()
+		return fmt.Errorf("no StatusNotifierWatcher available: %w", call.Err)
+	}
+
+	if err := conn.AddMatchSignal(dbus.WithMatchInterface(trayWatcherName), dbus.WithMatchObjectPath(trayWatcherPath)); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to watch tray registrations: %w", err)
+	}
+	if err := conn.AddMatchSignal(dbus.WithMatchInterface(trayItemInterface)); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to watch tray items: %w", err)
+	}
+
+	m.conn = conn
+	m.signals = make(chan *dbus.Signal, 16)
+	m.done = make(chan struct{})
+	conn.Signal(m.signals)
+
+	go func() {
+		defer close(m.done)
+		if err := m.items.Sync(dbusTrayWatcher{conn: conn}); err != nil {
+			log.Printf("tray: %v", err)
+		}
+		for _, item := range m.items.List() {
+			m.refreshItem(item.ID)
+		}
+		m.scheduleRebuild()
+
+		for signal := range m.signals {
+			m.handleSignal(signal)
+		}
+	}()
+
+	return nil
+}
+
+// handleSignal applies a watcher or item signal and rebuilds the icons
+func (m *TrayModule) handleSignal(signal *dbus.Signal) {
+	switch signal.Name {
+	case trayWatcherName + ".StatusNotifierItemRegistered":
+		if id, ok := trayItemSignalID(signal); ok && m.items.Add(id) {
+			m.refreshItem(id)
+		}
+	case trayWatcherName + ".StatusNotifierItemUnregistered":
+		if id, ok := trayItemSignalID(signal); !ok || !m.items.Remove(id) {
+			return
+		}
+	case trayItemInterface + ".NewIcon", trayItemInterface + ".NewTitle":
+		// Items signal from their unique name, which may not be the name
+		// they registered with; until an item's owner is known, refresh
+		// them all
+		ids := m.items.SignalledBy(signal.Sender, signal.Path)
+		if len(ids) == 0 {
+			for _, item := range m.items.List() {
+				ids = append(ids, item.ID)
+			}
+		}
+		for _, id := range ids {
+			m.refreshItem(id)
+		}
+	default:
+		return
+	}
+	m.scheduleRebuild()
+}
+
+// trayItemSignalID returns the item ID carried by a watcher signal
+func trayItemSignalID(signal *dbus.Signal) (string, bool) {
+	if len(signal.Body) == 0 {
+		return "", false
+	}
+	id, ok := signal.Body[0].(string)
+	return id, ok
+}
+
+// refreshItem reloads the icon and title of the item registered as id
+func (m *TrayModule) refreshItem(id string) {
+	service, path := parseTrayItemID(id)
+	loaded := trayItem{ID: id, Service: service, Path: path}
+	if err := loadTrayItemProperties(m.conn, &loaded); err != nil {
+		log.Printf("tray: %v", err)
+		return
+	}
+	m.items.Update(id, func(item *trayItem) {
+		item.Owner = loaded.Owner
+		item.IconName = loaded.IconName
+		item.Pixmaps = loaded.Pixmaps
+		item.Title = loaded.Title
+	})
+}
+
+func (m *TrayModule) scheduleRebuild() {
+	glib.IdleAdd(func() {
+		m.UpdateWidget(m.box)
+	})
+}
+
+// Initialize initializes the module with configuration
+func (m *TrayModule) Initialize(config map[string]interface{}) error {
+	if err := m.BaseModule.Initialize(config); err != nil {
+		return err
+	}
+
+	// TOML properties decode as int64
+	if size, ok := config["icon_size"].(int); ok && size > 0 {
+		m.iconSize = size
+	} else if size, ok := config["icon_size"].(int64); ok && size > 0 {
+		m.iconSize = int(size)
+	}
+
+	if spacing, ok := config["spacing"].(int); ok && spacing >= 0 {
+		m.spacing = spacing
+	} else if spacing, ok := config["spacing"].(int64); ok && spacing >= 0 {
+		m.spacing = int(spacing)
+	}
+
+	m.SetCSSClasses([]string{"tray-module"})
+
+	return nil
+}
+
+// Cleanup closes the session bus connection once the signal goroutine,
+// which uses it, has stopped
+func (m *TrayModule) Cleanup() error {
+	if m.conn != nil {
+		m.conn.RemoveSignal(m.signals)
+		close(m.signals)
+		// Closing first makes calls in flight fail instead of blocking
+		m.conn.Close()
+		<-m.done
+		m.conn = nil
+	}
+	return m.BaseModule.Cleanup()
+}
+
+// TrayModuleFactory is a factory for creating TrayModule instances
+type TrayModuleFactory struct{}
+
+// CreateModule creates a new TrayModule instance
+func (f *TrayModuleFactory) CreateModule(config map[string]interface{}) (statusbar.Module, error) {
+	module := NewTrayModule()
+	if err := module.Initialize(config); err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+// ModuleName returns module name
+func (f *TrayModuleFactory) ModuleName() string {
+	return "tray"
+}
+
+// DefaultConfig returns default configuration
+func (f *TrayModuleFactory) DefaultConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"icon_size":   defaultTrayIconSize,
+		"spacing":     4,
+		"css_classes": []string{"tray-module"},
+	}
+}
+
+// trayProperties are the checked tray properties
+var trayProperties = statusbar.PropertyRules{
+	"icon_size": {Kind: statusbar.PropertyInt, Min: 8, Max: 256},
+	"spacing":   {Kind: statusbar.PropertyInt, Min: 0, Max: 100},
+}
+
+// ValidateConfig checks the types and ranges of the tray properties
+func (f *TrayModuleFactory) ValidateConfig(cfg map[string]interface{}) error {
+	return trayProperties.Validate(cfg)
+}
+
+// Dependencies returns module dependencies
+func (f *TrayModuleFactory) Dependencies() []string {
+	return []string{}
+}
+
+func init() {
+	registry := statusbar.DefaultRegistry()
+	factory := &TrayModuleFactory{}
+	if err := registry.RegisterFactory(factory); err != nil {
+		panic(err)
+	}
+}
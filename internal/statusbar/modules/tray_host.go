@@ -0,0 +1,244 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	trayWatcherName     = "org.kde.StatusNotifierWatcher"
+	trayWatcherPath     = "/StatusNotifierWatcher"
+	trayItemInterface   = "org.kde.StatusNotifierItem"
+	trayDefaultItemPath = "/StatusNotifierItem"
+
+	// trayCallTimeout bounds each call to an item, so one that hangs can't
+	// stall the tray
+	trayCallTimeout = 2 * time.Second
+)
+
+// trayPixmap is one entry of an item's IconPixmap: ARGB32 pixels in network
+// byte order
+type trayPixmap struct {
+	Width  int32
+	Height int32
+	Data   []byte
+}
+
+// trayItem is a StatusNotifierItem known to the tray
+type trayItem struct {
+	ID       string // as registered with the watcher
+	Service  string
+	Owner    string // unique bus name its signals come from, once known
+	Path     dbus.ObjectPath
+	IconName string
+	Pixmaps  []trayPixmap
+	Title    string
+}
+
+// parseTrayItemID splits a watcher item ID into the item's bus name and
+// object path. Items register either a bare bus name, served at the
+// default path, or a bus name followed by the path.
+func parseTrayItemID(id string) (service string, path dbus.ObjectPath) {
+	if i := strings.Index(id, "/"); i > 0 {
+		return id[:i], dbus.ObjectPath(id[i:])
+	}
+	return id, trayDefaultItemPath
+}
+
+// trayWatcher lists the items registered with the StatusNotifierWatcher
+type trayWatcher interface {
+	RegisteredItems() ([]string, error)
+}
+
+// trayItems keeps the registered items in registration order
+type trayItems struct {
+	mu    sync.Mutex
+	items []*trayItem
+}
+
+// Add records a newly registered item and reports whether it was new
+func (t *trayItems) Add(id string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.indexLocked(id) >= 0 {
+		return false
+	}
+	service, path := parseTrayItemID(id)
+	t.items = append(t.items, &trayItem{ID: id, Service: service, Path: path})
+	return true
+}
+
+// Remove forgets an unregistered item and reports whether it was known
+func (t *trayItems) Remove(id string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	i := t.indexLocked(id)
+	if i < 0 {
+		return false
+	}
+	t.items = append(t.items[:i], t.items[i+1:]...)
+	return true
+}
+
+// Sync replaces the items with those the watcher has registered, keeping
+// what is already known about items that stay
+func (t *trayItems) Sync(w trayWatcher) error {
+	ids, err := w.RegisteredItems()
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	known := make(map[string]*trayItem, len(t.items))
+	for _, item := range t.items {
+		known[item.ID] = item
+	}
+	items := make([]*trayItem, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		item, ok := known[id]
+		if !ok {
+			service, path := parseTrayItemID(id)
+			item = &trayItem{ID: id, Service: service, Path: path}
+		}
+		items = append(items, item)
+	}
+	t.items = items
+	t.mu.Unlock()
+	return nil
+}
+
+// Update applies fn to the item registered as id, if any
+func (t *trayItems) Update(id string, fn func(item *trayItem)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i := t.indexLocked(id); i >= 0 {
+		fn(t.items[i])
+	}
+}
+
+// List returns copies of the items in registration order
+func (t *trayItems) List() []trayItem {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	list := make([]trayItem, len(t.items))
+	for i, item := range t.items {
+		list[i] = *item
+	}
+	return list
+}
+
+// SignalledBy returns the IDs of the items at path owned by sender, the
+// unique bus name a signal came from
+func (t *trayItems) SignalledBy(sender string, path dbus.ObjectPath) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var ids []string
+	for _, item := range t.items {
+		if item.Owner == sender && item.Path == path {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+func (t *trayItems) indexLocked(id string) int {
+	for i, item := range t.items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// trayPixmapRGBA picks the smallest pixmap at least size pixels wide (or
+// the largest there is) and converts it to RGBA
+func trayPixmapRGBA(pixmaps []trayPixmap, size int) (width, height int, rgba []byte, ok bool) {
+	var best *trayPixmap
+	for i := range pixmaps {
+		p := &pixmaps[i]
+		if p.Width <= 0 || p.Height <= 0 || len(p.Data) < int(p.Width*p.Height*4) {
+			continue
+		}
+		switch {
+		case best == nil:
+			best = p
+		case int(best.Width) < size && p.Width > best.Width:
+			best = p
+		case int(p.Width) >= size && p.Width < best.Width:
+			best = p
+		}
+	}
+	if best == nil {
+		return 0, 0, nil, false
+	}
+
+	n := int(best.Width * best.Height)
+	rgba = make([]byte, n*4)
+	for i := 0; i < n; i++ {
+		a, r, g, b := best.Data[i*4], best.Data[i*4+1], best.Data[i*4+2], best.Data[i*4+3]
+		rgba[i*4], rgba[i*4+1], rgba[i*4+2], rgba[i*4+3] = r, g, b, a
+	}
+	return int(best.Width), int(best.Height), rgba, true
+}
+
+// dbusTrayWatcher reads registered items from the session bus watcher
+type dbusTrayWatcher struct {
+	conn *dbus.Conn
+}
+
+// RegisteredItems returns the watcher's RegisteredStatusNotifierItems
+func (w dbusTrayWatcher) RegisteredItems() ([]string, error) {
+	v, err := w.conn.Object(trayWatcherName, trayWatcherPath).GetProperty(trayWatcherName + ".RegisteredStatusNotifierItems")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tray items: %w", err)
+	}
+	ids, ok := v.Value().([]string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected RegisteredStatusNotifierItems type %T", v.Value())
+	}
+	return ids, nil
+}
+
+// loadTrayItemProperties reads the owner, icon and title of item from the
+// bus, giving up on an item that doesn't answer within trayCallTimeout
+func loadTrayItemProperties(conn *dbus.Conn, item *trayItem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), trayCallTimeout)
+	defer cancel()
+
+	item.Owner = item.Service
+	if !strings.HasPrefix(item.Service, ":") {
+		call := conn.BusObject().CallWithContext(ctx, "org.freedesktop.DBus.GetNameOwner", 0, item.Service)
+		if err := call.Store(&item.Owner); err != nil {
+			return fmt.Errorf("failed to find the owner of %s: %w", item.Service, err)
+		}
+	}
+
+	var props map[string]dbus.Variant
+	call := conn.Object(item.Service, item.Path).CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, trayItemInterface)
+	if err := call.Store(&props); err != nil {
+		return fmt.Errorf("failed to read %s: %w", item.ID, err)
+	}
+	if v, ok := props["IconName"]; ok {
+		item.IconName, _ = v.Value().(string)
+	}
+	if v, ok := props["IconPixmap"]; ok {
+		var pixmaps []trayPixmap
+		if err := v.Store(&pixmaps); err == nil {
+			item.Pixmaps = pixmaps
+		}
+	}
+	if v, ok := props["Title"]; ok {
+		item.Title, _ = v.Value().(string)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package modules
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// fakeTrayWatcher stands in for the StatusNotifierWatcher on the bus
+type fakeTrayWatcher struct {
+	ids []string
+	err error
+}
+
+func (w *fakeTrayWatcher) RegisteredItems() ([]string, error) {
+	return w.ids, w.err
+}
+
+func trayItemIDs(items *trayItems) []string {
+	var ids []string
+	for _, item := range items.List() {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestParseTrayItemID(t *testing.T) {
+	cases := []struct {
+		id          string
+		wantService string
+		wantPath    dbus.ObjectPath
+	}{
+		{"org.kde.StatusNotifierItem-1234-1", "org.kde.StatusNotifierItem-1234-1", "/StatusNotifierItem"},
+		{":1.52/org/ayatana/NotificationItem/nm_applet", ":1.52", "/org/ayatana/NotificationItem/nm_applet"},
+		{":1.7/StatusNotifierItem", ":1.7", "/StatusNotifierItem"},
+	}
+
+	for _, tc := range cases {
+		service, path := parseTrayItemID(tc.id)
+		if service != tc.wantService || path != tc.wantPath {
+			t.Errorf("parseTrayItemID(%q) = %q, %q, want %q, %q", tc.id, service, path, tc.wantService, tc.wantPath)
+		}
+	}
+}
+
+func TestTrayItemsRegistration(t *testing.T) {
+	var items trayItems
+
+	if !items.Add(":1.10/StatusNotifierItem") || !items.Add("org.example.Tray") {
+		t.Fatal("Add of new items reported no change")
+	}
+	if items.Add("org.example.Tray") {
+		t.Error("Add of a known item reported a change")
+	}
+	if got, want := trayItemIDs(&items), []string{":1.10/StatusNotifierItem", "org.example.Tray"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+
+	items.Update("org.example.Tray", func(item *trayItem) { item.IconName = "network-wireless" })
+	if got := items.List()[1].IconName; got != "network-wireless" {
+		t.Errorf("IconName after Update = %q, want network-wireless", got)
+	}
+
+	if !items.Remove(":1.10/StatusNotifierItem") {
+		t.Error("Remove of a known item reported no change")
+	}
+	if items.Remove(":1.10/StatusNotifierItem") {
+		t.Error("Remove of an unknown item reported a change")
+	}
+	if got, want := trayItemIDs(&items), []string{"org.example.Tray"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items after Remove = %v, want %v", got, want)
+	}
+}
+
+func TestTrayItemsSignalledBy(t *testing.T) {
+	var items trayItems
+	items.Add("org.example.Tray")
+	items.Add(":1.9/org/ayatana/NotificationItem/nm")
+	items.Add("org.example.Unloaded")
+	items.Update("org.example.Tray", func(item *trayItem) { item.Owner = ":1.4" })
+	items.Update(":1.9/org/ayatana/NotificationItem/nm", func(item *trayItem) { item.Owner = ":1.9" })
+
+	if got, want := items.SignalledBy(":1.4", "/StatusNotifierItem"), []string{"org.example.Tray"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SignalledBy(:1.4) = %v, want %v", got, want)
+	}
+	if got, want := items.SignalledBy(":1.9", "/org/ayatana/NotificationItem/nm"), []string{":1.9/org/ayatana/NotificationItem/nm"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SignalledBy(:1.9) = %v, want %v", got, want)
+	}
+	if got := items.SignalledBy(":1.9", "/StatusNotifierItem"); len(got) != 0 {
+		t.Errorf("SignalledBy with another path = %v, want none", got)
+	}
+	if got := items.SignalledBy(":1.20", "/StatusNotifierItem"); len(got) != 0 {
+		t.Errorf("SignalledBy with an unknown sender = %v, want none", got)
+	}
+}
+
+func TestTrayItemsSync(t *testing.T) {
+	var items trayItems
+	items.Add("org.example.Kept")
+	items.Add("org.example.Gone")
+	items.Update("org.example.Kept", func(item *trayItem) { item.Title = "Kept" })
+
+	watcher := &fakeTrayWatcher{ids: []string{"org.example.Kept", ":1.9/Item", "org.example.Kept"}}
+	if err := items.Sync(watcher); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got, want := trayItemIDs(&items), []string{"org.example.Kept", ":1.9/Item"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items after Sync = %v, want %v", got, want)
+	}
+	if got := items.List()[0].Title; got != "Kept" {
+		t.Errorf("Title of a kept item = %q, want it preserved", got)
+	}
+	if got := items.List()[1].Path; got != "/Item" {
+		t.Errorf("Path of a synced item = %q, want /Item", got)
+	}
+
+	watcher.err = errors.New("no watcher")
+	if err := items.Sync(watcher); err == nil {
+		t.Error("Sync with a failing watcher returned no error")
+	}
+	if got := len(items.List()); got != 2 {
+		t.Errorf("failed Sync changed the items to %d", got)
+	}
+}
+
+func TestTrayPixmapRGBA(t *testing.T) {
+	small := trayPixmap{Width: 1, Height: 1, Data: []byte{0xff, 0x10, 0x20, 0x30}}
+	large := trayPixmap{Width: 2, Height: 2, Data: make([]byte, 16)}
+	broken := trayPixmap{Width: 4, Height: 4, Data: []byte{1, 2, 3}}
+
+	width, height, rgba, ok := trayPixmapRGBA([]trayPixmap{small, large, broken}, 1)
+	if !ok || width != 1 || height != 1 {
+		t.Fatalf("picked %dx%d (ok=%v), want the 1x1 pixmap", width, height, ok)
+	}
+	if want := []byte{0x10, 0x20, 0x30, 0xff}; !reflect.DeepEqual(rgba, want) {
+		t.Errorf("rgba = %v, want %v", rgba, want)
+	}
+
+	if width, _, _, _ := trayPixmapRGBA([]trayPixmap{small, large}, 16); width != 2 {
+		t.Errorf("picked width %d for a larger size, want the largest (2)", width)
+	}
+	if _, _, _, ok := trayPixmapRGBA([]trayPixmap{broken}, 16); ok {
+		t.Error("picked a pixmap with too little data")
+	}
+}
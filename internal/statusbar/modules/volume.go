@@ -1,24 +1,37 @@
 package modules
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"log"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/gotk3/gotk3/gtk"
 	"github.com/chess10kp/locus/internal/statusbar"
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
 )
 
+// volumeBackends lists supported mixers in detection order
+var volumeBackends = []string{"pamixer", "pactl", "amixer"}
+
 // VolumeModule displays system volume status
 type VolumeModule struct {
 	*statusbar.BaseModule
-	widget    *gtk.Label
+	widget    *gtk.EventBox
+	label     *gtk.Label
+	backend   string
 	volumeCmd string
 	muteCmd   string
+	step      int
 	showIcon  bool
 	volume    int
 	isMuted   bool
+	cancel    context.CancelFunc
 }
 
 // NewVolumeModule creates a new volume module
@@ -26,48 +39,63 @@ func NewVolumeModule() *VolumeModule {
 	return &VolumeModule{
 		BaseModule: statusbar.NewBaseModule("volume", statusbar.UpdateModePeriodic),
 		widget:     nil,
-		volumeCmd:  "pamixer --get-volume",
-		muteCmd:    "pamixer --get-mute",
+		backend:    "auto",
+		step:       5,
 		showIcon:   true,
 		volume:     50,
 		isMuted:    false,
 	}
 }
 
-// CreateWidget creates a volume label widget
+// CreateWidget creates a volume label that adjusts volume on scroll and
+// toggles mute on click
 func (m *VolumeModule) CreateWidget() (gtk.IWidget, error) {
+	eventBox, err := gtk.EventBoxNew()
+	if err != nil {
+		return nil, err
+	}
+
 	label, err := gtk.LabelNew(m.formatVolume())
 	if err != nil {
 		return nil, err
 	}
+	eventBox.Add(label)
+
+	m.widget = eventBox
+	m.label = label
 
-	m.widget = label
+	connectScroll(eventBox, func(delta int) {
+		m.runAsync(volumeAdjustArgs(m.resolveBackend(), delta > 0, m.step))
+	})
+	eventBox.Connect("button-press-event", func(_ *gtk.EventBox, event *gdk.Event) bool {
+		if gdk.EventButtonNewFromEvent(event).Button() == gdk.BUTTON_PRIMARY {
+			m.runAsync(volumeMuteArgs(m.resolveBackend()))
+		}
+		return true
+	})
 
 	helper := &statusbar.WidgetHelper{}
-	if err := helper.ApplyStylesToWidget(label, m.GetStyles(), m.GetCSSClasses()); err != nil {
+	if err := helper.ApplyStylesToWidget(eventBox, m.GetStyles(), m.GetCSSClasses()); err != nil {
 		return nil, err
 	}
 
-	return label, nil
+	m.watchEvents()
+
+	return eventBox, nil
 }
 
 // UpdateWidget updates volume widget
 func (m *VolumeModule) UpdateWidget(widget gtk.IWidget) error {
-	if widget == nil {
-		return nil
-	}
-
-	label, ok := widget.(*gtk.Label)
-	if !ok {
+	if widget == nil || m.label == nil {
 		return nil
 	}
 
 	m.readVolumeStatus()
 	formatted := m.formatVolume()
-	label.SetText(formatted)
+	m.label.SetText(formatted)
 
 	// Update CSS classes for color
-	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
+	if ctx, err := m.label.ToWidget().GetStyleContext(); err == nil {
 		ctx.RemoveClass("volume-muted")
 		if m.isMuted {
 			ctx.AddClass("volume-muted")
@@ -83,6 +111,11 @@ func (m *VolumeModule) Initialize(config map[string]interface{}) error {
 		return err
 	}
 
+	if backend, ok := config["backend"].(string); ok && backend != "" {
+		m.backend = backend
+	}
+
+	// Explicit commands override the detected backend
 	if volumeCmd, ok := config["volume_cmd"].(string); ok {
 		m.volumeCmd = volumeCmd
 	}
@@ -91,6 +124,13 @@ func (m *VolumeModule) Initialize(config map[string]interface{}) error {
 		m.muteCmd = muteCmd
 	}
 
+	// TOML properties decode as int64
+	if step, ok := config["step"].(int); ok && step > 0 {
+		m.step = step
+	} else if step, ok := config["step"].(int64); ok && step > 0 {
+		m.step = int(step)
+	}
+
 	if showIcon, ok := config["show_icon"].(bool); ok {
 		m.showIcon = showIcon
 	}
@@ -102,24 +142,229 @@ func (m *VolumeModule) Initialize(config map[string]interface{}) error {
 	return nil
 }
 
+// resolveBackend returns the configured backend, detecting the first
+// installed mixer for "auto"
+func (m *VolumeModule) resolveBackend() string {
+	if m.backend != "auto" {
+		return m.backend
+	}
+	for _, backend := range volumeBackends {
+		if _, err := exec.LookPath(backend); err == nil {
+			m.backend = backend
+			return backend
+		}
+	}
+	return ""
+}
+
 // readVolumeStatus reads volume status from system
 func (m *VolumeModule) readVolumeStatus() {
-	// Get volume
-	if cmd := exec.Command("sh", "-c", m.volumeCmd); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			if vol, err := strconv.Atoi(strings.TrimSpace(string(output))); err == nil {
-				m.volume = vol
+	if m.volumeCmd != "" {
+		m.readVolumeCommands()
+		return
+	}
+
+	// pamixer reports false/zero results through its exit status, so the
+	// output is used even when the command "fails"
+	output := func(args ...string) string {
+		out, _ := m.Commands().Output(context.Background(), args[0], args[1:]...)
+		return string(out)
+	}
+
+	var volume int
+	var muted bool
+	var err error
+
+	switch m.resolveBackend() {
+	case "pamixer":
+		volume, muted, err = parsePamixerVolume(output("pamixer", "--get-volume"), output("pamixer", "--get-mute"))
+	case "pactl":
+		volume, muted, err = parsePactlVolume(output("pactl", "get-sink-volume", "@DEFAULT_SINK@"), output("pactl", "get-sink-mute", "@DEFAULT_SINK@"))
+	case "amixer":
+		volume, muted, err = parseAmixerVolume(output("amixer", "get", "Master"))
+	default:
+		return
+	}
+
+	if err != nil {
+		return
+	}
+	m.volume = volume
+	m.isMuted = muted
+}
+
+// readVolumeCommands reads volume using the configured shell commands
+func (m *VolumeModule) readVolumeCommands() {
+	if output, err := m.Commands().Shell(m.volumeCmd); err == nil {
+		if vol, err := strconv.Atoi(strings.TrimSpace(string(output))); err == nil {
+			m.volume = vol
+		}
+	}
+
+	if m.muteCmd != "" {
+		if output, err := m.Commands().Shell(m.muteCmd); err == nil {
+			m.isMuted = parseMuteState(string(output))
+		}
+	}
+}
+
+// runAsync runs a mixer command off the main loop and refreshes afterwards
+func (m *VolumeModule) runAsync(args []string) {
+	if len(args) == 0 {
+		return
+	}
+	go func() {
+		if err := m.Commands().Run(args[0], args[1:]...); err != nil {
+			log.Printf("volume: %s failed: %v", args[0], err)
+		}
+		glib.IdleAdd(func() {
+			m.UpdateWidget(m.widget)
+		})
+	}()
+}
+
+// watchEvents refreshes on PulseAudio/PipeWire sink events when pactl is
+// available; polling covers everything else
+func (m *VolumeModule) watchEvents() {
+	if _, err := exec.LookPath("pactl"); err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	go func() {
+		cmd := exec.CommandContext(ctx, "pactl", "subscribe")
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return
+		}
+		if err := cmd.Start(); err != nil {
+			return
+		}
+		defer cmd.Wait()
+
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			if isSinkEvent(scanner.Text()) {
+				glib.IdleAdd(func() {
+					m.UpdateWidget(m.widget)
+				})
 			}
 		}
+	}()
+}
+
+// isSinkEvent reports whether a `pactl subscribe` line concerns a sink or
+// the server (default sink switches)
+func isSinkEvent(line string) bool {
+	return strings.Contains(line, "'change' on sink") || strings.Contains(line, "on server")
+}
+
+// volumeAdjustArgs builds the command raising or lowering volume by step
+func volumeAdjustArgs(backend string, up bool, step int) []string {
+	switch backend {
+	case "pamixer":
+		if up {
+			return []string{"pamixer", "--increase", strconv.Itoa(step)}
+		}
+		return []string{"pamixer", "--decrease", strconv.Itoa(step)}
+	case "pactl":
+		sign := "-"
+		if up {
+			sign = "+"
+		}
+		return []string{"pactl", "set-sink-volume", "@DEFAULT_SINK@", fmt.Sprintf("%s%d%%", sign, step)}
+	case "amixer":
+		sign := "-"
+		if up {
+			sign = "+"
+		}
+		return []string{"amixer", "-q", "set", "Master", fmt.Sprintf("%d%%%s", step, sign)}
 	}
+	return nil
+}
 
-	// Get mute status
-	if cmd := exec.Command("sh", "-c", m.muteCmd); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			muteStr := strings.TrimSpace(string(output))
-			m.isMuted = muteStr == "true" || muteStr == "1"
+// volumeMuteArgs builds the command toggling mute
+func volumeMuteArgs(backend string) []string {
+	switch backend {
+	case "pamixer":
+		return []string{"pamixer", "--toggle-mute"}
+	case "pactl":
+		return []string{"pactl", "set-sink-mute", "@DEFAULT_SINK@", "toggle"}
+	case "amixer":
+		return []string{"amixer", "-q", "set", "Master", "toggle"}
+	}
+	return nil
+}
+
+var (
+	percentPattern     = regexp.MustCompile(`(\d+)%`)
+	amixerStatePattern = regexp.MustCompile(`\[(on|off)\]`)
+)
+
+// parseMuteState interprets mute output from pamixer ("true"), pactl
+// ("Mute: yes") or plain 1/0
+func parseMuteState(output string) bool {
+	s := strings.ToLower(strings.TrimSpace(output))
+	s = strings.TrimSpace(strings.TrimPrefix(s, "mute:"))
+	return s == "true" || s == "yes" || s == "1"
+}
+
+// parsePamixerVolume parses `pamixer --get-volume` and `--get-mute`
+func parsePamixerVolume(volumeOut, muteOut string) (int, bool, error) {
+	volume, err := strconv.Atoi(strings.TrimSpace(volumeOut))
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid pamixer volume %q", volumeOut)
+	}
+	return volume, parseMuteState(muteOut), nil
+}
+
+// parsePactlVolume parses `pactl get-sink-volume` (averaging channels)
+// and `pactl get-sink-mute`
+func parsePactlVolume(volumeOut, muteOut string) (int, bool, error) {
+	line := volumeOut
+	if i := strings.Index(line, "\n"); i >= 0 {
+		line = line[:i] // second line is balance
+	}
+
+	matches := percentPattern.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return 0, false, fmt.Errorf("no volume in pactl output %q", volumeOut)
+	}
+
+	total := 0
+	for _, match := range matches {
+		v, _ := strconv.Atoi(match[1])
+		total += v
+	}
+	return (total + len(matches)/2) / len(matches), parseMuteState(muteOut), nil
+}
+
+// parseAmixerVolume parses `amixer get Master`, averaging the playback
+// channels; any channel reporting [off] counts as muted
+func parseAmixerVolume(output string) (int, bool, error) {
+	total, count, muted := 0, 0, false
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.Contains(line, "Playback") || !strings.Contains(line, "[") {
+			continue
+		}
+		match := percentPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		v, _ := strconv.Atoi(match[1])
+		total += v
+		count++
+		if state := amixerStatePattern.FindStringSubmatch(line); state != nil && state[1] == "off" {
+			muted = true
 		}
 	}
+
+	if count == 0 {
+		return 0, false, fmt.Errorf("no playback channels in amixer output")
+	}
+	return (total + count/2) / count, muted, nil
 }
 
 // formatVolume formats volume status for display
@@ -173,6 +418,9 @@ func (m *VolumeModule) IsMuted() bool {
 
 // Cleanup cleans up resources
 func (m *VolumeModule) Cleanup() error {
+	if m.cancel != nil {
+		m.cancel()
+	}
 	return m.BaseModule.Cleanup()
 }
 
@@ -196,14 +444,28 @@ func (f *VolumeModuleFactory) ModuleName() string {
 // DefaultConfig returns default configuration
 func (f *VolumeModuleFactory) DefaultConfig() map[string]interface{} {
 	return map[string]interface{}{
-		"volume_cmd":  "pamixer --get-volume",
-		"mute_cmd":    "pamixer --get-mute",
+		"backend":     "auto",
+		"step":        5,
 		"show_icon":   true,
 		"interval":    "10s",
 		"css_classes": []string{"volume-module"},
 	}
 }
 
+// volumeProperties are the checked volume properties
+var volumeProperties = statusbar.PropertyRules{
+	"backend":    {Kind: statusbar.PropertyString, OneOf: []string{"auto", "pamixer", "pactl", "amixer"}},
+	"volume_cmd": {Kind: statusbar.PropertyString},
+	"mute_cmd":   {Kind: statusbar.PropertyString},
+	"step":       {Kind: statusbar.PropertyInt, Min: 1, Max: 100},
+	"show_icon":  {Kind: statusbar.PropertyBool},
+}
+
+// ValidateConfig checks the types and ranges of the volume properties
+func (f *VolumeModuleFactory) ValidateConfig(cfg map[string]interface{}) error {
+	return volumeProperties.Validate(cfg)
+}
+
 // Dependencies returns module dependencies
 func (f *VolumeModuleFactory) Dependencies() []string {
 	return []string{}
@@ -0,0 +1,105 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePamixerVolume(t *testing.T) {
+	volume, muted, err := parsePamixerVolume("42\n", "false\n")
+	if err != nil || volume != 42 || muted {
+		t.Errorf("Expected 42%% unmuted, got %d muted=%v err=%v", volume, muted, err)
+	}
+
+	volume, muted, err = parsePamixerVolume("0\n", "true\n")
+	if err != nil || volume != 0 || !muted {
+		t.Errorf("Expected 0%% muted, got %d muted=%v err=%v", volume, muted, err)
+	}
+
+	if _, _, err := parsePamixerVolume("", ""); err == nil {
+		t.Error("Expected error for empty pamixer output")
+	}
+}
+
+func TestParsePactlVolume(t *testing.T) {
+	volumeOut := "Volume: front-left: 32768 /  50% / -18.06 dB,   front-right: 34078 /  52% / -17.04 dB\n" +
+		"        balance 0.04\n"
+
+	volume, muted, err := parsePactlVolume(volumeOut, "Mute: no\n")
+	if err != nil || volume != 51 || muted {
+		t.Errorf("Expected averaged 51%% unmuted, got %d muted=%v err=%v", volume, muted, err)
+	}
+
+	_, muted, _ = parsePactlVolume(volumeOut, "Mute: yes\n")
+	if !muted {
+		t.Error("Expected 'Mute: yes' to be muted")
+	}
+
+	if _, _, err := parsePactlVolume("Failed to get sink volume", ""); err == nil {
+		t.Error("Expected error for pactl output without a volume")
+	}
+}
+
+func TestParseAmixerVolume(t *testing.T) {
+	stereo := `Simple mixer control 'Master',0
+  Capabilities: pvolume pswitch pswitch-joined
+  Playback channels: Front Left - Front Right
+  Limits: Playback 0 - 65536
+  Mono:
+  Front Left: Playback 39322 [60%] [on]
+  Front Right: Playback 39322 [60%] [on]
+`
+	volume, muted, err := parseAmixerVolume(stereo)
+	if err != nil || volume != 60 || muted {
+		t.Errorf("Expected 60%% unmuted, got %d muted=%v err=%v", volume, muted, err)
+	}
+
+	mono := `Simple mixer control 'Master',0
+  Mono: Playback 55 [86%] [-12.75dB] [off]
+`
+	volume, muted, err = parseAmixerVolume(mono)
+	if err != nil || volume != 86 || !muted {
+		t.Errorf("Expected 86%% muted, got %d muted=%v err=%v", volume, muted, err)
+	}
+
+	if _, _, err := parseAmixerVolume("amixer: Unable to find simple control 'Master',0\n"); err == nil {
+		t.Error("Expected error for amixer output without channels")
+	}
+}
+
+func TestParseMuteState(t *testing.T) {
+	for _, s := range []string{"true", "yes", "Mute: yes", "1\n"} {
+		if !parseMuteState(s) {
+			t.Errorf("Expected %q to be muted", s)
+		}
+	}
+	for _, s := range []string{"false", "no", "Mute: no", "0", ""} {
+		if parseMuteState(s) {
+			t.Errorf("Expected %q to be unmuted", s)
+		}
+	}
+}
+
+func TestVolumeCommandArgs(t *testing.T) {
+	if got := volumeAdjustArgs("pactl", true, 5); !reflect.DeepEqual(got, []string{"pactl", "set-sink-volume", "@DEFAULT_SINK@", "+5%"}) {
+		t.Errorf("Unexpected pactl args: %v", got)
+	}
+	if got := volumeAdjustArgs("amixer", false, 3); !reflect.DeepEqual(got, []string{"amixer", "-q", "set", "Master", "3%-"}) {
+		t.Errorf("Unexpected amixer args: %v", got)
+	}
+	if got := volumeMuteArgs("pamixer"); !reflect.DeepEqual(got, []string{"pamixer", "--toggle-mute"}) {
+		t.Errorf("Unexpected pamixer mute args: %v", got)
+	}
+	if got := volumeAdjustArgs("", true, 5); got != nil {
+		t.Errorf("Expected no command without a backend, got %v", got)
+	}
+}
+
+func TestIsSinkEvent(t *testing.T) {
+	if !isSinkEvent("Event 'change' on sink #57") {
+		t.Error("Expected sink change to trigger refresh")
+	}
+	if isSinkEvent("Event 'change' on source-output #12") {
+		t.Error("Expected source-output events to be ignored")
+	}
+}
@@ -2,7 +2,6 @@ package modules
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/gotk3/gotk3/gtk"
@@ -72,6 +71,7 @@ func (m *WeatherModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readWeather()
 	formatted := m.formatWeather()
 	label.SetText(formatted)
+	m.ShowMissingState(label.ToWidget())
 
 	return nil
 }
@@ -106,6 +106,8 @@ func (m *WeatherModule) Initialize(config map[string]interface{}) error {
 	url := fmt.Sprintf("%s/%s?format=%s", m.service, m.location, m.format)
 	m.command = fmt.Sprintf("curl -s \"%s\"", url)
 
+	m.SetDependencies("curl")
+
 	m.SetCSSClasses([]string{"weather-module"})
 
 	m.readWeather()
@@ -115,8 +117,11 @@ func (m *WeatherModule) Initialize(config map[string]interface{}) error {
 
 // readWeather reads weather from service
 func (m *WeatherModule) readWeather() {
-	cmd := exec.Command("sh", "-c", m.command)
-	output, err := cmd.Output()
+	if m.DependenciesMissing() {
+		return
+	}
+
+	output, err := m.Commands().Shell(m.command)
 	if err != nil {
 		m.condition = ""
 		m.temperature = ""
@@ -148,6 +153,10 @@ func (m *WeatherModule) readWeather() {
 
 // formatWeather formats weather for display
 func (m *WeatherModule) formatWeather() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	if m.condition == "" && m.temperature == "" {
 		return ""
 	}
@@ -254,9 +263,24 @@ func (f *WeatherModuleFactory) DefaultConfig() map[string]interface{} {
 	}
 }
 
+// weatherProperties are the checked weather properties. An empty location
+// locates by IP.
+var weatherProperties = statusbar.PropertyRules{
+	"service":      {Kind: statusbar.PropertyString, NonEmpty: true},
+	"location":     {Kind: statusbar.PropertyString},
+	"format":       {Kind: statusbar.PropertyString, NonEmpty: true},
+	"show_icon":    {Kind: statusbar.PropertyBool},
+	"show_details": {Kind: statusbar.PropertyBool},
+}
+
+// ValidateConfig checks the types and ranges of the weather properties
+func (f *WeatherModuleFactory) ValidateConfig(cfg map[string]interface{}) error {
+	return weatherProperties.Validate(cfg)
+}
+
 // Dependencies returns module dependencies
 func (f *WeatherModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"curl"}
 }
 
 func init() {
@@ -0,0 +1,14 @@
+package modules
+
+import "testing"
+
+func TestWeatherValidateConfig(t *testing.T) {
+	f := &WeatherModuleFactory{}
+
+	if err := f.ValidateConfig(f.DefaultConfig()); err != nil {
+		t.Errorf("ValidateConfig(DefaultConfig()) = %v, want the defaults accepted", err)
+	}
+	if err := f.ValidateConfig(map[string]interface{}{"location": 42}); err == nil {
+		t.Error("ValidateConfig() = nil, want a non-string location rejected")
+	}
+}
@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -68,6 +67,7 @@ func (m *WifiModule) UpdateWidget(widget gtk.IWidget) error {
 	m.readWifiStatus()
 	formatted := m.formatWifi()
 	label.SetText(formatted)
+	m.ShowMissingState(label.ToWidget())
 
 	// Update CSS classes for color
 	if ctx, err := label.ToWidget().GetStyleContext(); err == nil {
@@ -102,6 +102,8 @@ func (m *WifiModule) Initialize(config map[string]interface{}) error {
 		m.showSignal = showSignal
 	}
 
+	m.SetDependencies(statusbar.ShellCommandDependency(m.command))
+
 	m.SetCSSClasses([]string{"wifi-module"})
 
 	m.readWifiStatus()
@@ -111,8 +113,11 @@ func (m *WifiModule) Initialize(config map[string]interface{}) error {
 
 // readWifiStatus reads WiFi status from system
 func (m *WifiModule) readWifiStatus() {
-	cmd := exec.Command("sh", "-c", m.command)
-	output, err := cmd.Output()
+	if m.DependenciesMissing() {
+		return
+	}
+
+	output, err := m.Commands().Shell(m.command)
 	if err != nil {
 		m.isConnected = false
 		m.ssid = ""
@@ -150,6 +155,10 @@ func (m *WifiModule) readWifiStatus() {
 
 // formatWifi formats WiFi status for display
 func (m *WifiModule) formatWifi() string {
+	if text, missing := m.MissingText(); missing {
+		return text
+	}
+
 	var builder strings.Builder
 
 	if m.showIcon {
@@ -232,6 +241,11 @@ func (m *WifiModule) Cleanup() error {
 	return m.BaseModule.Cleanup()
 }
 
+// Dependencies returns the commands the configured module runs
+func (m *WifiModule) Dependencies() []string {
+	return m.DependencyCommands()
+}
+
 // WifiModuleFactory is a factory for creating WifiModule instances
 type WifiModuleFactory struct{}
 
@@ -263,7 +277,7 @@ func (f *WifiModuleFactory) DefaultConfig() map[string]interface{} {
 
 // Dependencies returns module dependencies
 func (f *WifiModuleFactory) Dependencies() []string {
-	return []string{}
+	return []string{"nmcli"}
 }
 
 func init() {
@@ -0,0 +1,257 @@
+package modules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/chess10kp/locus/internal/statusbar"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+const defaultWindowTitleMaxLength = 60
+
+// swayWindowEvent is the part of a sway "window" or "workspace" event the
+// window title module reads
+type swayWindowEvent struct {
+	Change    string `json:"change"`
+	Container *struct {
+		Name    string `json:"name"`
+		Focused bool   `json:"focused"`
+	} `json:"container"`
+	Current *struct {
+		Nodes         []json.RawMessage `json:"nodes"`
+		FloatingNodes []json.RawMessage `json:"floating_nodes"`
+	} `json:"current"`
+}
+
+// parseSwayWindowEvent returns the focused window title after a sway event
+// payload. ok is false when the event doesn't change it.
+func parseSwayWindowEvent(payload string) (title string, ok bool) {
+	var event swayWindowEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return "", false
+	}
+
+	switch {
+	case event.Container != nil:
+		switch event.Change {
+		case "focus":
+			return event.Container.Name, true
+		case "title":
+			if event.Container.Focused {
+				return event.Container.Name, true
+			}
+		case "close":
+			if event.Container.Focused {
+				return "", true
+			}
+		}
+	case event.Current != nil:
+		// Focusing an empty workspace leaves no window focused
+		if event.Change == "focus" && len(event.Current.Nodes) == 0 && len(event.Current.FloatingNodes) == 0 {
+			return "", true
+		}
+	}
+	return "", false
+}
+
+// parseHyprlandWindowEvent returns the focused window title after a chunk
+// read from Hyprland's event socket, using its last activewindow line. ok is
+// false when the chunk has none.
+func parseHyprlandWindowEvent(chunk string) (title string, ok bool) {
+	for _, line := range strings.Split(chunk, "\n") {
+		data, found := strings.CutPrefix(line, "activewindow>>")
+		if !found {
+			continue
+		}
+		// class,title; the title itself may contain commas
+		_, title, _ = strings.Cut(data, ",")
+		ok = true
+	}
+	return title, ok
+}
+
+// truncateTitle shortens title to maxLength characters with an ellipsis
+func truncateTitle(title string, maxLength int) string {
+	runes := []rune(title)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return title
+	}
+	if maxLength == 1 {
+		return "…"
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
+
+// hyprlandEventSocket returns the path of Hyprland's event socket
+func hyprlandEventSocket(signature string) string {
+	path := filepath.Join(os.Getenv("XDG_RUNTIME_DIR"), "hypr", signature, ".socket2.sock")
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	// Hyprland before 0.40 kept its sockets under /tmp
+	return filepath.Join("/tmp", "hypr", signature, ".socket2.sock")
+}
+
+// WindowTitleModule displays the title of the focused window, following
+// sway or Hyprland focus events
+type WindowTitleModule struct {
+	*statusbar.BaseModule
+	widget    *gtk.Label
+	maxLength int
+	title     string
+	mu        sync.Mutex
+}
+
+// NewWindowTitleModule creates a new window title module
+func NewWindowTitleModule() *WindowTitleModule {
+	return &WindowTitleModule{
+		BaseModule: statusbar.NewBaseModule("window_title", statusbar.UpdateModeEventDriven),
+		maxLength:  defaultWindowTitleMaxLength,
+	}
+}
+
+// CreateWidget creates a window title label widget
+func (m *WindowTitleModule) CreateWidget() (gtk.IWidget, error) {
+	label, err := gtk.LabelNew("")
+	if err != nil {
+		return nil, err
+	}
+
+	m.widget = label
+
+	helper := &statusbar.WidgetHelper{}
+	if err := helper.ApplyStylesToWidget(label, m.GetStyles(), m.GetCSSClasses()); err != nil {
+		return nil, err
+	}
+
+	label.SetVisible(false)
+
+	return label, nil
+}
+
+// UpdateWidget shows the last focused window title, hiding the label when
+// no window is focused
+func (m *WindowTitleModule) UpdateWidget(widget gtk.IWidget) error {
+	label, ok := widget.(*gtk.Label)
+	if !ok || label == nil {
+		return nil
+	}
+
+	title := m.CurrentValue()
+	label.SetText(truncateTitle(title, m.maxLength))
+	label.SetTooltipText(title)
+	label.SetVisible(title != "")
+
+	return nil
+}
+
+// Initialize initializes the module with configuration
+func (m *WindowTitleModule) Initialize(config map[string]interface{}) error {
+	if err := m.BaseModule.Initialize(config); err != nil {
+		return err
+	}
+
+	// TOML properties decode as int64
+	if maxLength, ok := config["max_length"].(int); ok && maxLength > 0 {
+		m.maxLength = maxLength
+	} else if maxLength, ok := config["max_length"].(int64); ok && maxLength > 0 {
+		m.maxLength = int(maxLength)
+	}
+
+	m.SetCSSClasses([]string{"window-title-module"})
+
+	return nil
+}
+
+// SetupEventListeners subscribes to focus events of the running window
+// manager
+func (m *WindowTitleModule) SetupEventListeners() ([]statusbar.EventListener, error) {
+	if signature := os.Getenv("HYPRLAND_INSTANCE_SIGNATURE"); signature != "" {
+		listener := statusbar.NewSocketEventListener(hyprlandEventSocket(signature))
+		listener.SetEventHandler(func(event string) {
+			if title, ok := parseHyprlandWindowEvent(event); ok {
+				m.setTitle(title)
+			}
+		})
+		return []statusbar.EventListener{listener}, nil
+	}
+
+	socket := os.Getenv("SWAYSOCK")
+	if socket == "" {
+		return nil, fmt.Errorf("neither SWAYSOCK nor HYPRLAND_INSTANCE_SIGNATURE is set")
+	}
+	listener := statusbar.NewSocketEventListener(socket)
+	listener.SetSubscribeEvents([]string{"window", "workspace"})
+	listener.SetEventHandler(func(event string) {
+		if title, ok := parseSwayWindowEvent(event); ok {
+			m.setTitle(title)
+		}
+	})
+	return []statusbar.EventListener{listener}, nil
+}
+
+func (m *WindowTitleModule) setTitle(title string) {
+	m.mu.Lock()
+	m.title = title
+	m.mu.Unlock()
+}
+
+// CurrentValue returns the focused window title
+func (m *WindowTitleModule) CurrentValue() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.title
+}
+
+// WindowTitleModuleFactory is a factory for creating WindowTitleModule instances
+type WindowTitleModuleFactory struct{}
+
+// CreateModule creates a new WindowTitleModule instance
+func (f *WindowTitleModuleFactory) CreateModule(config map[string]interface{}) (statusbar.Module, error) {
+	module := NewWindowTitleModule()
+	if err := module.Initialize(config); err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+// ModuleName returns module name
+func (f *WindowTitleModuleFactory) ModuleName() string {
+	return "window_title"
+}
+
+// DefaultConfig returns default configuration
+func (f *WindowTitleModuleFactory) DefaultConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"max_length":  defaultWindowTitleMaxLength,
+		"css_classes": []string{"window-title-module"},
+	}
+}
+
+// windowTitleProperties are the checked window title properties
+var windowTitleProperties = statusbar.PropertyRules{
+	"max_length": {Kind: statusbar.PropertyInt, Min: 1, Max: 1000},
+}
+
+// ValidateConfig checks the types and ranges of the window title properties
+func (f *WindowTitleModuleFactory) ValidateConfig(cfg map[string]interface{}) error {
+	return windowTitleProperties.Validate(cfg)
+}
+
+// Dependencies returns module dependencies
+func (f *WindowTitleModuleFactory) Dependencies() []string {
+	return []string{}
+}
+
+func init() {
+	registry := statusbar.DefaultRegistry()
+	factory := &WindowTitleModuleFactory{}
+	if err := registry.RegisterFactory(factory); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,71 @@
+package modules
+
+import "testing"
+
+func TestParseSwayWindowEvent(t *testing.T) {
+	cases := []struct {
+		name    string
+		payload string
+		want    string
+		wantOK  bool
+	}{
+		{"focus", `{"change":"focus","container":{"name":"Firefox","focused":true}}`, "Firefox", true},
+		{"focused title change", `{"change":"title","container":{"name":"New tab","focused":true}}`, "New tab", true},
+		{"background title change", `{"change":"title","container":{"name":"Other","focused":false}}`, "", false},
+		{"focused close", `{"change":"close","container":{"name":"Firefox","focused":true}}`, "", true},
+		{"new window", `{"change":"new","container":{"name":"foot","focused":false}}`, "", false},
+		{"empty workspace", `{"change":"focus","current":{"name":"3","nodes":[],"floating_nodes":[]}}`, "", true},
+		{"occupied workspace", `{"change":"focus","current":{"name":"2","nodes":[{"name":"foot"}]}}`, "", false},
+		{"invalid", `{"change":`, "", false},
+	}
+
+	for _, tc := range cases {
+		got, ok := parseSwayWindowEvent(tc.payload)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("%s: got %q, %v, want %q, %v", tc.name, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestParseHyprlandWindowEvent(t *testing.T) {
+	cases := []struct {
+		name   string
+		chunk  string
+		want   string
+		wantOK bool
+	}{
+		{"active window", "activewindow>>firefox,Mozilla Firefox\n", "Mozilla Firefox", true},
+		{"comma in title", "activewindow>>foot,vim a,b.txt\n", "vim a,b.txt", true},
+		{"no window", "activewindow>>,\n", "", true},
+		{"last line wins", "activewindow>>foot,one\nworkspace>>2\nactivewindow>>foot,two\n", "two", true},
+		{"other events", "workspace>>2\nfocusedmon>>DP-1,2\n", "", false},
+		{"v2 event only", "activewindowv2>>5a3c\n", "", false},
+	}
+
+	for _, tc := range cases {
+		got, ok := parseHyprlandWindowEvent(tc.chunk)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("%s: got %q, %v, want %q, %v", tc.name, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestTruncateTitle(t *testing.T) {
+	cases := []struct {
+		title     string
+		maxLength int
+		want      string
+	}{
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"a longer window title", 8, "a longe…"},
+		{"ünïcödé title", 5, "ünïc…"},
+		{"anything", 0, "anything"},
+	}
+
+	for _, tc := range cases {
+		if got := truncateTitle(tc.title, tc.maxLength); got != tc.want {
+			t.Errorf("truncateTitle(%q, %d) = %q, want %q", tc.title, tc.maxLength, got, tc.want)
+		}
+	}
+}
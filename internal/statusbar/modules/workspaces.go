@@ -5,11 +5,11 @@ import (
 	"encoding/json"
 	"log"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/gotk3/gotk3/gtk"
 	"github.com/joshuarubin/go-sway"
+	"github.com/chess10kp/locus/internal/command"
 	"github.com/chess10kp/locus/internal/statusbar"
 )
 
@@ -23,7 +23,7 @@ type Workspace struct {
 }
 
 // getWorkspacesFromSway gets workspaces from sway IPC
-func getWorkspacesFromSway() ([]Workspace, error) {
+func getWorkspacesFromSway(runner command.Runner) ([]Workspace, error) {
 	// Try using go-sway library first
 	ctx := context.Background()
 	client, err := sway.New(ctx)
@@ -54,9 +54,7 @@ func getWorkspacesFromSway() ([]Workspace, error) {
 		}
 	}
 
-	cmd := exec.Command("swaymsg", "-t", "get_workspaces")
-	cmd.Env = env
-	output, err := cmd.Output()
+	output, err := runner.OutputEnv(env, "swaymsg", "-t", "get_workspaces")
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +121,7 @@ func (m *WorkspacesModule) UpdateWidget(widget gtk.IWidget) error {
 	}
 
 	// Poll workspaces from sway
-	workspaces, err := getWorkspacesFromSway()
+	workspaces, err := getWorkspacesFromSway(m.Commands())
 	if err != nil {
 		log.Printf("Failed to get workspaces from sway: %v", err)
 		// Keep existing workspaces if polling fails
@@ -192,6 +190,14 @@ func (m *WorkspacesModule) SetFocusedIndex(index int) {
 	}
 }
 
+// CurrentValue returns the focused workspace name
+func (m *WorkspacesModule) CurrentValue() string {
+	if m.focusedIndex >= 0 && m.focusedIndex < len(m.workspaces) {
+		return m.workspaces[m.focusedIndex]
+	}
+	return ""
+}
+
 // WorkspacesModuleFactory is a factory for creating WorkspacesModule instances
 type WorkspacesModuleFactory struct{}
 
@@ -0,0 +1,114 @@
+package statusbar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chess10kp/locus/internal/config"
+)
+
+// PropertyKind is the type a module property must have
+type PropertyKind int
+
+const (
+	PropertyString PropertyKind = iota
+	PropertyBool
+	PropertyInt
+)
+
+func (k PropertyKind) String() string {
+	switch k {
+	case PropertyBool:
+		return "a boolean"
+	case PropertyInt:
+		return "an integer"
+	default:
+		return "a string"
+	}
+}
+
+// PropertyRule describes one checked module property
+type PropertyRule struct {
+	Kind PropertyKind
+	// NonEmpty rejects a blank string
+	NonEmpty bool
+	// Min and Max bound an integer
+	Min, Max int
+	// OneOf lists the allowed strings, if set
+	OneOf []string
+}
+
+// PropertyRules maps the checked properties of a module to their rules.
+// Factories declare theirs next to the factory and check them from
+// ValidateConfig.
+type PropertyRules map[string]PropertyRule
+
+// Validate checks props against the rules. It returns a
+// *config.ValidationError listing every bad property; properties without a
+// rule are not checked.
+func (r PropertyRules) Validate(props map[string]interface{}) error {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		if _, ok := r[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var problems []error
+	for _, key := range keys {
+		if err := r[key].check(props[key]); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &config.ValidationError{Problems: problems}
+}
+
+// check reports why value doesn't satisfy the rule, if it doesn't
+func (r PropertyRule) check(value interface{}) error {
+	switch r.Kind {
+	case PropertyString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%v must be %s", value, r.Kind)
+		}
+		if r.NonEmpty && strings.TrimSpace(s) == "" {
+			return fmt.Errorf("must not be empty")
+		}
+		if len(r.OneOf) > 0 && !containsString(r.OneOf, s) {
+			return fmt.Errorf("%s (must be one of: %s)", s, strings.Join(r.OneOf, ", "))
+		}
+	case PropertyBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%v must be %s", value, r.Kind)
+		}
+	case PropertyInt:
+		// TOML properties decode as int64
+		var n int64
+		switch v := value.(type) {
+		case int:
+			n = int64(v)
+		case int64:
+			n = v
+		default:
+			return fmt.Errorf("%v must be %s", value, r.Kind)
+		}
+		if n < int64(r.Min) || n > int64(r.Max) {
+			return fmt.Errorf("%d (must be %d-%d)", n, r.Min, r.Max)
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package statusbar
+
+import "testing"
+
+func TestPropertyRulesValidate(t *testing.T) {
+	rules := PropertyRules{
+		"location":  {Kind: PropertyString},
+		"format":    {Kind: PropertyString, NonEmpty: true},
+		"show_icon": {Kind: PropertyBool},
+		"icon_size": {Kind: PropertyInt, Min: 8, Max: 256},
+		"backend":   {Kind: PropertyString, OneOf: []string{"auto", "pactl"}},
+	}
+
+	tests := []struct {
+		props   map[string]interface{}
+		wantErr string
+	}{
+		{map[string]interface{}{"location": "", "show_icon": true}, ""},
+		{map[string]interface{}{"icon_size": int64(24), "anything": 1}, ""},
+		{map[string]interface{}{"format": " "}, "format: must not be empty"},
+		{map[string]interface{}{"location": 42}, "location: 42 must be a string"},
+		{map[string]interface{}{"icon_size": int64(1000)}, "icon_size: 1000 (must be 8-256)"},
+		{map[string]interface{}{"icon_size": "wide"}, "icon_size: wide must be an integer"},
+		{map[string]interface{}{"backend": "oss"}, "backend: oss (must be one of: auto, pactl)"},
+		{map[string]interface{}{"show_icon": "yes", "location": 1}, "2 problems: location: 1 must be a string; show_icon: yes must be a boolean"},
+	}
+
+	for _, tt := range tests {
+		err := rules.Validate(tt.props)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", tt.props, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.wantErr {
+			t.Errorf("%v: expected error %q, got %v", tt.props, tt.wantErr, err)
+		}
+	}
+}
@@ -3,8 +3,12 @@ package statusbar
 import (
 	"fmt"
 	"log"
+	"os/exec"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/chess10kp/locus/internal/command"
 	"github.com/gotk3/gotk3/glib"
 	"github.com/gotk3/gotk3/gtk"
 )
@@ -24,6 +28,16 @@ type ModuleFactory interface {
 	Dependencies() []string
 }
 
+// CommandTimeoutKey is the module config key holding the time.Duration
+// that bounds the module's external commands
+const CommandTimeoutKey = "command_timeout"
+
+// ConfigValidator is implemented by factories that check a module's
+// configuration before creating it
+type ConfigValidator interface {
+	ValidateConfig(config map[string]interface{}) error
+}
+
 // ModuleRegistry manages module factories and instances
 type ModuleRegistry struct {
 	factories    map[string]ModuleFactory
@@ -32,6 +46,15 @@ type ModuleRegistry struct {
 	mu           sync.RWMutex
 	widgetHelper *WidgetHelper
 	initialized  bool
+
+	dependencyPolicy DependencyPolicy
+	lookPath         func(file string) (string, error)
+	commandTimeout   time.Duration
+
+	// reconnect backoff applied to modules' socket listeners
+	reconnectBase   time.Duration
+	reconnectMax    time.Duration
+	reconnectFactor float64
 }
 
 // NewModuleRegistry creates a new module registry
@@ -42,6 +65,14 @@ func NewModuleRegistry() *ModuleRegistry {
 		listeners:    make(map[string][]EventListener),
 		widgetHelper: &WidgetHelper{},
 		initialized:  false,
+
+		dependencyPolicy: DependencyPolicyPlaceholder,
+		lookPath:         exec.LookPath,
+		commandTimeout:   command.DefaultTimeout,
+
+		reconnectBase:   DefaultBackoffBase,
+		reconnectMax:    DefaultBackoffMax,
+		reconnectFactor: DefaultBackoffFactor,
 	}
 }
 
@@ -77,6 +108,20 @@ func (r *ModuleRegistry) UnregisterFactory(name string) error {
 	return nil
 }
 
+// ValidateModuleConfig checks config with the factory for name, if that
+// factory validates its config. Names without a registered factory, such
+// as layout spacers, aren't checked.
+func (r *ModuleRegistry) ValidateModuleConfig(name string, config map[string]interface{}) error {
+	r.mu.RLock()
+	factory, exists := r.factories[name]
+	r.mu.RUnlock()
+
+	if validator, ok := factory.(ConfigValidator); exists && ok {
+		return validator.ValidateConfig(config)
+	}
+	return nil
+}
+
 // CreateModule creates a module instance by name with the given configuration
 func (r *ModuleRegistry) CreateModule(name string, config map[string]interface{}) (Module, error) {
 	r.mu.RLock()
@@ -87,14 +132,88 @@ func (r *ModuleRegistry) CreateModule(name string, config map[string]interface{}
 		return nil, fmt.Errorf("no factory registered for module '%s'", name)
 	}
 
+	if validator, ok := factory.(ConfigValidator); ok {
+		if err := validator.ValidateConfig(config); err != nil {
+			return nil, fmt.Errorf("invalid config for module '%s': %w", name, err)
+		}
+	}
+
+	r.mu.RLock()
+	commandTimeout := r.commandTimeout
+	r.mu.RUnlock()
+	if _, ok := config[CommandTimeoutKey]; !ok {
+		withTimeout := make(map[string]interface{}, len(config)+1)
+		for key, value := range config {
+			withTimeout[key] = value
+		}
+		withTimeout[CommandTimeoutKey] = commandTimeout
+		config = withTimeout
+	}
+
 	module, err := factory.CreateModule(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create module '%s': %w", name, err)
 	}
 
+	r.mu.RLock()
+	policy, lookPath := r.dependencyPolicy, r.lookPath
+	r.mu.RUnlock()
+
+	check := NewDependencyCheck(moduleDependencies(factory, module)...)
+	check.SetLookPath(lookPath)
+	if missing := check.Missing(); len(missing) > 0 {
+		log.Printf("Warning: module '%s' is missing %s (policy: %s)", name, strings.Join(missing, ", "), policy)
+		module, err = applyDependencyPolicy(policy, module, missing)
+		if err != nil {
+			return nil, fmt.Errorf("module '%s' skipped: %w", name, err)
+		}
+	}
+
 	return module, nil
 }
 
+// SetCommandTimeout sets how long the external commands of modules created
+// from now on may run. Modules see it in their config as CommandTimeoutKey.
+func (r *ModuleRegistry) SetCommandTimeout(timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commandTimeout = timeout
+}
+
+// SetReconnectBackoff sets the backoff of socket listeners set up from now
+// on. Zero values keep the defaults.
+func (r *ModuleRegistry) SetReconnectBackoff(base, max time.Duration, factor float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reconnectBase, r.reconnectMax, r.reconnectFactor = DefaultBackoffBase, DefaultBackoffMax, DefaultBackoffFactor
+	if base > 0 {
+		r.reconnectBase = base
+	}
+	if max > 0 {
+		r.reconnectMax = max
+	}
+	if factor > 0 {
+		r.reconnectFactor = factor
+	}
+}
+
+// SetDependencyPolicy sets how modules with missing dependencies are created
+func (r *ModuleRegistry) SetDependencyPolicy(policy DependencyPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if policy == "" {
+		policy = DependencyPolicyPlaceholder
+	}
+	r.dependencyPolicy = policy
+}
+
+// SetLookPath replaces the PATH lookup used for dependency checks, for tests
+func (r *ModuleRegistry) SetLookPath(lookPath func(file string) (string, error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookPath = lookPath
+}
+
 // CreateModuleWithDefaults creates a module instance with default configuration
 func (r *ModuleRegistry) CreateModuleWithDefaults(name string) (Module, error) {
 	r.mu.RLock()
@@ -251,6 +370,12 @@ func (r *ModuleRegistry) SetupModuleEventListeners(name string) error {
 		return fmt.Errorf("failed to setup event listeners for module '%s': %w", name, err)
 	}
 
+	for _, listener := range listeners {
+		if socket, ok := listener.(*SocketEventListener); ok {
+			socket.SetBackoff(r.reconnectBase, r.reconnectMax, r.reconnectFactor)
+		}
+	}
+
 	if listeners != nil && len(listeners) > 0 {
 		r.listeners[name] = listeners
 		log.Printf("Set up %d event listeners for module '%s'", len(listeners), name)
@@ -306,6 +431,21 @@ func (r *ModuleRegistry) StopModuleListeners(name string) error {
 	return nil
 }
 
+// ModuleListenersRunning reports whether all of a module's event listeners
+// are running. Modules without listeners report true.
+func (r *ModuleRegistry) ModuleListenersRunning(name string) bool {
+	r.mu.RLock()
+	listeners := r.listeners[name]
+	r.mu.RUnlock()
+
+	for _, listener := range listeners {
+		if !listener.IsRunning() {
+			return false
+		}
+	}
+	return true
+}
+
 // CreateWidgetForModule creates a GTK widget for a module
 func (r *ModuleRegistry) CreateWidgetForModule(name string) (gtk.IWidget, error) {
 	r.mu.RLock()
@@ -348,6 +488,11 @@ func (r *ModuleRegistry) UpdateModuleWidget(name string, widget gtk.IWidget) err
 	errChan := make(chan error, 1)
 
 	glib.IdleAdd(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errChan <- fmt.Errorf("panic updating module '%s': %v", name, r)
+			}
+		}()
 		err := module.UpdateWidget(widget)
 		errChan <- err
 	})
@@ -0,0 +1,210 @@
+package statusbar
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// fakeModule records whether it was cleaned up
+type fakeModule struct {
+	*BaseModule
+	deps    []string
+	cleaned bool
+}
+
+func (m *fakeModule) CreateWidget() (gtk.IWidget, error)    { return nil, nil }
+func (m *fakeModule) UpdateWidget(widget gtk.IWidget) error { return nil }
+func (m *fakeModule) Cleanup() error {
+	m.cleaned = true
+	return m.BaseModule.Cleanup()
+}
+
+// fakeFactory creates fakeModules declaring deps
+type fakeFactory struct {
+	name    string
+	deps    []string
+	created *fakeModule
+}
+
+func (f *fakeFactory) CreateModule(config map[string]interface{}) (Module, error) {
+	f.created = &fakeModule{BaseModule: NewBaseModule(f.name, UpdateModePeriodic)}
+	return f.created, f.created.Initialize(config)
+}
+func (f *fakeFactory) ModuleName() string                    { return f.name }
+func (f *fakeFactory) DefaultConfig() map[string]interface{} { return map[string]interface{}{} }
+func (f *fakeFactory) Dependencies() []string                { return f.deps }
+
+func newDependencyRegistry(policy DependencyPolicy, installed ...string) *ModuleRegistry {
+	r := NewModuleRegistry()
+	r.SetDependencyPolicy(policy)
+	r.SetLookPath(fakeLookPath(installed...))
+	return r
+}
+
+func TestCreateModuleMissingDependencyPlaceholder(t *testing.T) {
+	r := newDependencyRegistry("", "nmcli")
+	factory := &fakeFactory{name: "bluetooth", deps: []string{"bluetoothctl"}}
+	r.RegisterFactory(factory)
+
+	module, err := r.CreateModule("bluetooth", nil)
+	if err != nil {
+		t.Fatalf("CreateModule() error = %v, want a placeholder", err)
+	}
+	placeholder, ok := module.(*MissingDependencyModule)
+	if !ok {
+		t.Fatalf("CreateModule() = %T, want *MissingDependencyModule", module)
+	}
+	if placeholder.Name() != "bluetooth" || placeholder.CurrentValue() != "missing: bluetoothctl" {
+		t.Errorf("placeholder = %q showing %q", placeholder.Name(), placeholder.CurrentValue())
+	}
+	if !reflect.DeepEqual(placeholder.GetCSSClasses(), []string{"bluetooth-module", MissingDependencyClass}) {
+		t.Errorf("placeholder classes = %v", placeholder.GetCSSClasses())
+	}
+	if !factory.created.cleaned {
+		t.Error("Expected the replaced module to be cleaned up")
+	}
+
+	if err := r.RegisterModule(module); err != nil {
+		t.Errorf("RegisterModule(placeholder) error = %v", err)
+	}
+}
+
+func TestCreateModuleMissingDependencySkip(t *testing.T) {
+	r := newDependencyRegistry(DependencyPolicySkip)
+	r.RegisterFactory(&fakeFactory{name: "music", deps: []string{"mpc"}})
+
+	module, err := r.CreateModule("music", nil)
+	if !errors.Is(err, ErrMissingDependency) {
+		t.Errorf("CreateModule() error = %v, want ErrMissingDependency", err)
+	}
+	if module != nil {
+		t.Errorf("CreateModule() = %T, want no module", module)
+	}
+}
+
+func TestCreateModuleMissingDependencyLoad(t *testing.T) {
+	r := newDependencyRegistry(DependencyPolicyLoad)
+	factory := &fakeFactory{name: "weather", deps: []string{"curl"}}
+	r.RegisterFactory(factory)
+
+	module, err := r.CreateModule("weather", nil)
+	if err != nil || module != factory.created {
+		t.Errorf("CreateModule() = %T, %v; want the module itself", module, err)
+	}
+}
+
+func TestCreateModuleDependenciesPresent(t *testing.T) {
+	r := newDependencyRegistry(DependencyPolicySkip, "free")
+	factory := &fakeFactory{name: "memory", deps: []string{"free"}}
+	r.RegisterFactory(factory)
+
+	if module, err := r.CreateModule("memory", nil); err != nil || module != factory.created {
+		t.Errorf("CreateModule() = %T, %v; want the module itself", module, err)
+	}
+}
+
+// reportingModule needs whatever its configuration says
+type reportingModule struct {
+	fakeModule
+}
+
+func (m *reportingModule) Dependencies() []string { return m.deps }
+
+type reportingFactory struct {
+	fakeFactory
+	moduleDeps []string
+}
+
+func (f *reportingFactory) CreateModule(config map[string]interface{}) (Module, error) {
+	return &reportingModule{fakeModule{BaseModule: NewBaseModule(f.name, UpdateModePeriodic), deps: f.moduleDeps}}, nil
+}
+
+func TestCreateModuleUsesModuleDependencies(t *testing.T) {
+	r := newDependencyRegistry(DependencyPolicySkip, "top")
+	// The default command needs mpstat, but the configured one runs top
+	r.RegisterFactory(&reportingFactory{fakeFactory: fakeFactory{name: "cpu", deps: []string{"mpstat"}}, moduleDeps: []string{"top"}})
+
+	if _, err := r.CreateModule("cpu", nil); err != nil {
+		t.Errorf("CreateModule() error = %v, want the module's own dependencies checked", err)
+	}
+}
+
+// validatingFactory rejects a non-string location
+type validatingFactory struct {
+	fakeFactory
+}
+
+func (f *validatingFactory) ValidateConfig(config map[string]interface{}) error {
+	if _, ok := config["location"].(string); !ok {
+		return errors.New("location must be a string")
+	}
+	return nil
+}
+
+func TestCreateModuleValidatesConfig(t *testing.T) {
+	r := newDependencyRegistry(DependencyPolicyLoad)
+	factory := &validatingFactory{fakeFactory{name: "weather"}}
+	r.RegisterFactory(factory)
+
+	if _, err := r.CreateModule("weather", map[string]interface{}{"location": 42}); err == nil {
+		t.Error("CreateModule() error = nil, want the invalid config rejected")
+	}
+	if factory.created != nil {
+		t.Error("Expected no module created from an invalid config")
+	}
+
+	if _, err := r.CreateModule("weather", map[string]interface{}{"location": "Oslo"}); err != nil {
+		t.Errorf("CreateModule() error = %v, want a valid config accepted", err)
+	}
+}
+
+func TestCreateModuleSetsCommandTimeout(t *testing.T) {
+	r := newDependencyRegistry(DependencyPolicyLoad)
+	r.SetCommandTimeout(time.Second)
+	factory := &fakeFactory{name: "cpu"}
+	r.RegisterFactory(factory)
+
+	config := map[string]interface{}{"interval": "2s"}
+	if _, err := r.CreateModule("cpu", config); err != nil {
+		t.Fatalf("CreateModule() error = %v", err)
+	}
+	if got := factory.created.Commands().Timeout; got != time.Second {
+		t.Errorf("Commands().Timeout = %v, want the registry's timeout", got)
+	}
+	if _, ok := config[CommandTimeoutKey]; ok {
+		t.Error("Expected the caller's config left unchanged")
+	}
+}
+
+// socketModule listens on a socket that is never connected to
+type socketModule struct {
+	fakeModule
+	listener *SocketEventListener
+}
+
+func (m *socketModule) SetupEventListeners() ([]EventListener, error) {
+	m.listener = NewSocketEventListener("/nonexistent.sock")
+	return []EventListener{m.listener}, nil
+}
+
+func TestSetupModuleEventListenersAppliesReconnectBackoff(t *testing.T) {
+	r := NewModuleRegistry()
+	r.SetReconnectBackoff(500*time.Millisecond, 0, 3)
+
+	module := &socketModule{fakeModule: fakeModule{BaseModule: NewBaseModule("window_title", UpdateModeEventDriven)}}
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetupModuleEventListeners("window_title"); err != nil {
+		t.Fatal(err)
+	}
+
+	b := module.listener.backoff
+	if b.Base != 500*time.Millisecond || b.Max != DefaultBackoffMax || b.Factor != 3 {
+		t.Errorf("backoff = %v/%v/x%v, want 500ms/%v/x3 with the unset max defaulted", b.Base, b.Max, b.Factor, DefaultBackoffMax)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gotk3/gotk3/glib"
 	"github.com/gotk3/gotk3/gtk"
 )
 
@@ -17,6 +18,7 @@ type ModuleUpdateInfo struct {
 	Timer     *time.Timer
 	Listeners []EventListener
 	Active    bool
+	stop      chan struct{}
 }
 
 // UpdateScheduler manages module updates based on their update modes
@@ -30,6 +32,7 @@ type UpdateScheduler struct {
 	widgetMap      map[string]gtk.IWidget
 	running        bool
 	callbacks      map[string]func()
+	watchdog       *Watchdog
 }
 
 // NewUpdateScheduler creates a new update scheduler
@@ -43,6 +46,7 @@ func NewUpdateScheduler(registry *ModuleRegistry) *UpdateScheduler {
 		widgetMap: make(map[string]gtk.IWidget),
 		running:   false,
 		callbacks: make(map[string]func()),
+		watchdog:  NewWatchdog(DefaultWatchdogThreshold, DefaultWatchdogMaxRestarts, DefaultBackoffBase, DefaultBackoffMax),
 	}
 }
 
@@ -76,6 +80,7 @@ func (s *UpdateScheduler) Stop() {
 
 	s.cancel()
 	s.running = false
+	s.watchdog.Stop()
 
 	if s.periodicTicker != nil {
 		s.periodicTicker.Stop()
@@ -132,32 +137,40 @@ func (s *UpdateScheduler) schedulePeriodic(name string, info *ModuleUpdateInfo)
 		interval = time.Second
 	}
 
-	info.Timer = time.NewTimer(interval)
+	timer := time.NewTimer(interval)
+	stop := make(chan struct{})
+	info.Timer = timer
+	info.stop = stop
 	info.Active = true
 
 	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("Recovered from panic in periodic update for '%s': %v", name, r)
-			}
-		}()
-
 		for {
 			select {
 			case <-s.ctx.Done():
 				return
-			case <-info.Timer.C:
-				s.updateModule(name)
-
-				if info.Timer == nil {
-					return
-				}
-				info.Timer.Reset(interval)
+			case <-stop:
+				return
+			case <-timer.C:
+				s.updatePeriodic(name)
+				timer.Reset(interval)
 			}
 		}
 	}()
 }
 
+// updatePeriodic runs a periodic update, reporting panics to the watchdog
+// instead of ending the module's update loop
+func (s *UpdateScheduler) updatePeriodic(name string) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recovered from panic in periodic update for '%s': %v", name, r)
+			go s.reportUpdate(name, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	s.updateModule(name)
+}
+
 // scheduleEventDriven schedules an event-driven module update
 func (s *UpdateScheduler) scheduleEventDriven(name string, info *ModuleUpdateInfo) {
 	callback := func() {
@@ -189,10 +202,25 @@ func (s *UpdateScheduler) UnscheduleModule(name string) {
 		return
 	}
 
+	s.stopModule(name, info)
+	s.watchdog.Forget(name)
+
+	delete(s.updates, name)
+	delete(s.widgetMap, name)
+
+	log.Printf("Unscheduled module '%s'", name)
+}
+
+// stopModule stops a module's timer and listeners
+func (s *UpdateScheduler) stopModule(name string, info *ModuleUpdateInfo) {
 	if info.Timer != nil {
 		info.Timer.Stop()
 		info.Timer = nil
 	}
+	if info.stop != nil {
+		close(info.stop)
+		info.stop = nil
+	}
 
 	if len(info.Listeners) > 0 {
 		for _, listener := range info.Listeners {
@@ -206,10 +234,113 @@ func (s *UpdateScheduler) UnscheduleModule(name string) {
 	}
 
 	info.Active = false
-	delete(s.updates, name)
-	delete(s.widgetMap, name)
+}
 
-	log.Printf("Unscheduled module '%s'", name)
+// restartModule stops a module and schedules it again, recreating its event
+// listeners. Called by the watchdog.
+func (s *UpdateScheduler) restartModule(name string) {
+	s.mu.Lock()
+	info, exists := s.updates[name]
+	if !exists || !s.running {
+		s.mu.Unlock()
+		return
+	}
+
+	log.Printf("[WATCHDOG] Restarting module '%s'", name)
+	s.stopModule(name, info)
+	switch info.Module.UpdateMode() {
+	case UpdateModePeriodic:
+		s.schedulePeriodic(name, info)
+	case UpdateModeEventDriven:
+		s.scheduleEventDriven(name, info)
+	default:
+		info.Active = true
+	}
+	s.mu.Unlock()
+
+	s.refreshAfterRestart(name)
+}
+
+// refreshAfterRestart updates a restarted module's widget right away
+func (s *UpdateScheduler) refreshAfterRestart(name string) {
+	s.mu.RLock()
+	widget, ok := s.widgetMap[name]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	s.reportRefresh(name, s.registry.UpdateModuleWidget(name, widget))
+}
+
+// reportRefresh feeds the result of a restart's own refresh to the
+// watchdog. Only failures count: a module has recovered once its restarted
+// listener or ticker updates it, so one that fails on every event still
+// runs out of restarts.
+func (s *UpdateScheduler) reportRefresh(name string, err error) {
+	if err != nil {
+		s.reportUpdate(name, err)
+	}
+}
+
+// reportUpdate feeds an update result to the watchdog and toggles the
+// module widget's "degraded" class as it fails and recovers
+func (s *UpdateScheduler) reportUpdate(name string, err error) {
+	s.mu.RLock()
+	widget := s.widgetMap[name]
+	s.mu.RUnlock()
+
+	if err == nil {
+		if s.watchdog.ReportSuccess(name) {
+			log.Printf("[WATCHDOG] Module '%s' recovered", name)
+			markDegraded(widget, false)
+		}
+		return
+	}
+
+	if s.watchdog.ReportFailure(name, err, func() { s.restartModule(name) }) {
+		markDegraded(widget, true)
+	}
+}
+
+// checkListeners reports event-driven modules whose listeners have died
+func (s *UpdateScheduler) checkListeners() {
+	s.mu.RLock()
+	var dead []string
+	for name := range s.callbacks {
+		if !s.registry.ModuleListenersRunning(name) {
+			dead = append(dead, name)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, name := range dead {
+		s.reportUpdate(name, fmt.Errorf("event listener stopped"))
+	}
+}
+
+// IsDegraded returns whether the watchdog considers a module failing
+func (s *UpdateScheduler) IsDegraded(name string) bool {
+	return s.watchdog.IsDegraded(name)
+}
+
+// markDegraded adds or removes the "degraded" CSS class on a module widget
+func markDegraded(widget gtk.IWidget, degraded bool) {
+	if widget == nil {
+		return
+	}
+
+	glib.IdleAdd(func() {
+		ctx, err := widget.ToWidget().GetStyleContext()
+		if err != nil {
+			return
+		}
+		if degraded {
+			ctx.AddClass("degraded")
+		} else {
+			ctx.RemoveClass("degraded")
+		}
+	})
 }
 
 // UpdateModule immediately updates a module
@@ -227,7 +358,9 @@ func (s *UpdateScheduler) updateModule(name string) error {
 		return fmt.Errorf("widget not found for module '%s'", name)
 	}
 
-	return s.registry.UpdateModuleWidget(name, widget)
+	err := s.registry.UpdateModuleWidget(name, widget)
+	go s.reportUpdate(name, err)
+	return err
 }
 
 // UpdateAll updates all scheduled modules
@@ -389,6 +522,7 @@ func (s *UpdateScheduler) run() {
 			return
 		case <-s.periodicTicker.C:
 			s.UpdateModulesByMode(UpdateModePeriodic)
+			s.checkListeners()
 		}
 	}
 }
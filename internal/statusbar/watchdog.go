@@ -0,0 +1,167 @@
+package statusbar
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Watchdog defaults: modules are restarted after this many consecutive
+// failures, at most this many times in a row
+const (
+	DefaultWatchdogThreshold   = 3
+	DefaultWatchdogMaxRestarts = 5
+)
+
+// moduleHealth tracks a module's consecutive failures and restarts
+type moduleHealth struct {
+	failures int
+	restarts int
+	degraded bool
+	gaveUp   bool
+	timer    *time.Timer
+	backoff  *Backoff
+}
+
+// Watchdog tracks consecutive module failures and restarts failing modules
+// after a backoff, giving up after maxRestarts restarts without a success
+type Watchdog struct {
+	threshold   int
+	maxRestarts int
+	base        time.Duration
+	max         time.Duration
+	health      map[string]*moduleHealth
+	mu          sync.Mutex
+}
+
+// NewWatchdog creates a watchdog restarting modules after threshold
+// consecutive failures, waiting from base up to max between restarts
+func NewWatchdog(threshold, maxRestarts int, base, max time.Duration) *Watchdog {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &Watchdog{
+		threshold:   threshold,
+		maxRestarts: maxRestarts,
+		base:        base,
+		max:         max,
+		health:      make(map[string]*moduleHealth),
+	}
+}
+
+func (w *Watchdog) get(name string) *moduleHealth {
+	h, ok := w.health[name]
+	if !ok {
+		h = &moduleHealth{backoff: NewBackoff(w.base, w.max, DefaultBackoffFactor)}
+		w.health[name] = h
+	}
+	return h
+}
+
+// ReportSuccess clears a module's failures and restart count. It returns
+// true if the module was degraded and has now recovered.
+func (w *Watchdog) ReportSuccess(name string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	h, ok := w.health[name]
+	if !ok {
+		return false
+	}
+
+	recovered := h.degraded
+	h.failures = 0
+	h.restarts = 0
+	h.degraded = false
+	h.gaveUp = false
+	h.backoff.Reset()
+	return recovered
+}
+
+// ReportFailure records a failed update or dead listener. Once failures
+// reach the threshold the module is degraded and restart is called after a
+// backoff, unless a restart is already pending or the cap is reached. It
+// returns true when the module has just become degraded.
+func (w *Watchdog) ReportFailure(name string, err error, restart func()) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	h := w.get(name)
+	h.failures++
+	log.Printf("[WATCHDOG] Module '%s' failed (%d in a row): %v", name, h.failures, err)
+
+	if h.failures < w.threshold {
+		return false
+	}
+
+	newlyDegraded := !h.degraded
+	h.degraded = true
+
+	if h.timer != nil || h.gaveUp {
+		return newlyDegraded
+	}
+	if h.restarts >= w.maxRestarts {
+		h.gaveUp = true
+		log.Printf("[WATCHDOG] Giving up on module '%s' after %d restarts", name, h.restarts)
+		return newlyDegraded
+	}
+
+	h.restarts++
+	h.failures = 0
+	delay := h.backoff.Next()
+	log.Printf("[WATCHDOG] Restarting module '%s' in %v (restart %d/%d)", name, delay, h.restarts, w.maxRestarts)
+
+	h.timer = time.AfterFunc(delay, func() {
+		w.mu.Lock()
+		h.timer = nil
+		w.mu.Unlock()
+		restart()
+	})
+
+	return newlyDegraded
+}
+
+// IsDegraded returns whether a module is currently failing
+func (w *Watchdog) IsDegraded(name string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	h, ok := w.health[name]
+	return ok && h.degraded
+}
+
+// Restarts returns how many times a module has been restarted since it last
+// updated successfully
+func (w *Watchdog) Restarts(name string) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if h, ok := w.health[name]; ok {
+		return h.restarts
+	}
+	return 0
+}
+
+// Forget cancels any pending restart and drops a module's state
+func (w *Watchdog) Forget(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if h, ok := w.health[name]; ok && h.timer != nil {
+		h.timer.Stop()
+	}
+	delete(w.health, name)
+}
+
+// Stop cancels all pending restarts
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, h := range w.health {
+		if h.timer != nil {
+			h.timer.Stop()
+			h.timer = nil
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package statusbar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWatchdogRestartsAfterRepeatedFailures(t *testing.T) {
+	w := NewWatchdog(3, 5, time.Millisecond, 10*time.Millisecond)
+	defer w.Stop()
+
+	restarted := make(chan struct{}, 1)
+	restart := func() { restarted <- struct{}{} }
+	errUpdate := errors.New("update failed")
+
+	if w.ReportFailure("battery", errUpdate, restart) {
+		t.Error("one failure should not degrade the module")
+	}
+	w.ReportFailure("battery", errUpdate, restart)
+	if w.IsDegraded("battery") {
+		t.Error("module degraded before reaching the threshold")
+	}
+
+	if !w.ReportFailure("battery", errUpdate, restart) {
+		t.Error("third failure should degrade the module")
+	}
+	if !w.IsDegraded("battery") {
+		t.Error("expected module to be degraded")
+	}
+
+	select {
+	case <-restarted:
+	case <-time.After(time.Second):
+		t.Fatal("module was not restarted")
+	}
+	if w.Restarts("battery") != 1 {
+		t.Errorf("Restarts() = %d, want 1", w.Restarts("battery"))
+	}
+}
+
+func TestWatchdogCapsRestarts(t *testing.T) {
+	w := NewWatchdog(1, 2, time.Millisecond, time.Millisecond)
+	defer w.Stop()
+
+	restarts := make(chan struct{}, 10)
+	restart := func() { restarts <- struct{}{} }
+
+	for i := 0; i < 2; i++ {
+		w.ReportFailure("wifi", errors.New("listener died"), restart)
+		select {
+		case <-restarts:
+		case <-time.After(time.Second):
+			t.Fatalf("restart %d did not happen", i+1)
+		}
+	}
+
+	// The cap is reached, so further failures don't restart again
+	for i := 0; i < 3; i++ {
+		w.ReportFailure("wifi", errors.New("listener died"), restart)
+	}
+	select {
+	case <-restarts:
+		t.Error("module restarted past the cap")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if !w.IsDegraded("wifi") {
+		t.Error("module that was given up on should stay degraded")
+	}
+}
+
+func TestWatchdogSuccessResets(t *testing.T) {
+	w := NewWatchdog(2, 1, time.Millisecond, time.Millisecond)
+	defer w.Stop()
+
+	restarted := make(chan struct{}, 2)
+	restart := func() { restarted <- struct{}{} }
+
+	w.ReportFailure("cpu", errors.New("failed"), restart)
+	if w.ReportSuccess("cpu") {
+		t.Error("module that never degraded should not report recovery")
+	}
+
+	// The earlier failure was cleared, so one more doesn't reach the threshold
+	w.ReportFailure("cpu", errors.New("failed"), restart)
+	if w.IsDegraded("cpu") {
+		t.Error("success should reset consecutive failures")
+	}
+
+	w.ReportFailure("cpu", errors.New("failed"), restart)
+	<-restarted
+	if !w.ReportSuccess("cpu") {
+		t.Error("degraded module should report recovery on success")
+	}
+	if w.IsDegraded("cpu") || w.Restarts("cpu") != 0 {
+		t.Errorf("after recovery: degraded=%v restarts=%d", w.IsDegraded("cpu"), w.Restarts("cpu"))
+	}
+
+	// The restart budget is available again after recovering
+	w.ReportFailure("cpu", errors.New("failed"), restart)
+	w.ReportFailure("cpu", errors.New("failed"), restart)
+	select {
+	case <-restarted:
+	case <-time.After(time.Second):
+		t.Fatal("module was not restarted after recovering")
+	}
+}
+
+func TestWatchdogForgetCancelsRestart(t *testing.T) {
+	w := NewWatchdog(1, 5, 50*time.Millisecond, 50*time.Millisecond)
+
+	restarted := make(chan struct{}, 1)
+	w.ReportFailure("music", errors.New("failed"), func() { restarted <- struct{}{} })
+	w.Forget("music")
+
+	select {
+	case <-restarted:
+		t.Error("forgotten module was restarted")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if w.IsDegraded("music") {
+		t.Error("forgotten module should not be degraded")
+	}
+}
+
+func TestSchedulerGivesUpOnCrashLoop(t *testing.T) {
+	s := NewUpdateScheduler(NewModuleRegistry())
+	s.watchdog = NewWatchdog(1, 2, time.Millisecond, time.Millisecond)
+	defer s.watchdog.Stop()
+
+	// The module refreshes fine after each restart but crashes on the next
+	// event, so the restart budget runs out
+	for i := 0; i < 4; i++ {
+		s.reportUpdate("wifi", errors.New("panic: crashed on event"))
+		time.Sleep(20 * time.Millisecond)
+		s.reportRefresh("wifi", nil)
+	}
+	if got := s.watchdog.Restarts("wifi"); got != 2 {
+		t.Errorf("Restarts() = %d, want the cap of 2", got)
+	}
+	if !s.IsDegraded("wifi") {
+		t.Error("crash-looping module should stay degraded")
+	}
+
+	// An update from the running module shows it recovered
+	s.reportUpdate("wifi", nil)
+	if s.IsDegraded("wifi") || s.watchdog.Restarts("wifi") != 0 {
+		t.Errorf("after recovery: degraded=%v restarts=%d", s.IsDegraded("wifi"), s.watchdog.Restarts("wifi"))
+	}
+}
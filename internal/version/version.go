@@ -0,0 +1,74 @@
+// Package version holds the locus build version, set at link time with
+// -ldflags "-X github.com/chess10kp/locus/internal/version.Version=..."
+// (likewise Commit and Date)
+package version
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// Version is the locus release the binary was built from
+var Version = "dev"
+
+// Commit is the revision the binary was built from. Builds from a git
+// checkout fall back to the revision Go records.
+var Commit = ""
+
+// Date is when the binary was built
+var Date = ""
+
+// Info describes the build of the running binary
+type Info struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+	Date    string `json:"date,omitempty"`
+}
+
+// Get returns the build info, taking the commit and date Go recorded from
+// version control when they weren't set at link time
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, Date: Date}
+	if info.Commit != "" && info.Date != "" {
+		return info
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.Date == "" {
+				info.Date = setting.Value
+			}
+		}
+	}
+	return info
+}
+
+// String formats the build info as "locus <version> (commit <commit>,
+// built <date>)", leaving out what is unknown
+func (i Info) String() string {
+	var details []string
+	if i.Commit != "" {
+		commit := i.Commit
+		if len(commit) > 12 {
+			commit = commit[:12]
+		}
+		details = append(details, "commit "+commit)
+	}
+	if i.Date != "" {
+		details = append(details, "built "+i.Date)
+	}
+	if len(details) == 0 {
+		return "locus " + i.Version
+	}
+	return fmt.Sprintf("locus %s (%s)", i.Version, strings.Join(details, ", "))
+}
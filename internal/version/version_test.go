@@ -0,0 +1,33 @@
+package version
+
+import "testing"
+
+func TestGetUsesLinkTimeValues(t *testing.T) {
+	defer func(v, c, d string) { Version, Commit, Date = v, c, d }(Version, Commit, Date)
+	Version, Commit, Date = "1.4.0", "0123456789abcdef0123", "2026-10-01T12:00:00Z"
+
+	info := Get()
+	if info != (Info{Version: "1.4.0", Commit: "0123456789abcdef0123", Date: "2026-10-01T12:00:00Z"}) {
+		t.Errorf("unexpected build info %+v", info)
+	}
+	if want := "locus 1.4.0 (commit 0123456789ab, built 2026-10-01T12:00:00Z)"; info.String() != want {
+		t.Errorf("String() = %q, want %q", info.String(), want)
+	}
+}
+
+func TestInfoString(t *testing.T) {
+	tests := []struct {
+		info Info
+		want string
+	}{
+		{Info{Version: "dev"}, "locus dev"},
+		{Info{Version: "dev", Commit: "abc123"}, "locus dev (commit abc123)"},
+		{Info{Version: "1.0.0", Date: "2026-01-02"}, "locus 1.0.0 (built 2026-01-02)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.info.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.info, got, tt.want)
+		}
+	}
+}